@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: logaid/v1/logaid.proto
+
+// The gRPC API served by 'logaid serve' on GRPC_ADDR. Requests must send
+// API_TOKEN as the "authorization: Bearer <token>" metadata
+
+package logaidv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LogAid_Suggest_FullMethodName       = "/logaid.v1.LogAid/Suggest"
+	LogAid_StreamSuggest_FullMethodName = "/logaid.v1.LogAid/StreamSuggest"
+	LogAid_History_FullMethodName       = "/logaid.v1.LogAid/History"
+)
+
+// LogAidClient is the client API for LogAid service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LogAidClient interface {
+	// Suggest returns the ranked fixes for a failed command
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
+	// StreamSuggest reports what the pipeline is doing, then sends each fix as
+	// it is ranked and finally the complete response
+	StreamSuggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SuggestEvent], error)
+	// History returns the suggestion history, newest first
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+}
+
+type logAidClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLogAidClient(cc grpc.ClientConnInterface) LogAidClient {
+	return &logAidClient{cc}
+}
+
+func (c *logAidClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, LogAid_Suggest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logAidClient) StreamSuggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SuggestEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LogAid_ServiceDesc.Streams[0], LogAid_StreamSuggest_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SuggestRequest, SuggestEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LogAid_StreamSuggestClient = grpc.ServerStreamingClient[SuggestEvent]
+
+func (c *logAidClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, LogAid_History_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LogAidServer is the server API for LogAid service.
+// All implementations must embed UnimplementedLogAidServer
+// for forward compatibility.
+type LogAidServer interface {
+	// Suggest returns the ranked fixes for a failed command
+	Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error)
+	// StreamSuggest reports what the pipeline is doing, then sends each fix as
+	// it is ranked and finally the complete response
+	StreamSuggest(*SuggestRequest, grpc.ServerStreamingServer[SuggestEvent]) error
+	// History returns the suggestion history, newest first
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	mustEmbedUnimplementedLogAidServer()
+}
+
+// UnimplementedLogAidServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLogAidServer struct{}
+
+func (UnimplementedLogAidServer) Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
+func (UnimplementedLogAidServer) StreamSuggest(*SuggestRequest, grpc.ServerStreamingServer[SuggestEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSuggest not implemented")
+}
+func (UnimplementedLogAidServer) History(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedLogAidServer) mustEmbedUnimplementedLogAidServer() {}
+func (UnimplementedLogAidServer) testEmbeddedByValue()                {}
+
+// UnsafeLogAidServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LogAidServer will
+// result in compilation errors.
+type UnsafeLogAidServer interface {
+	mustEmbedUnimplementedLogAidServer()
+}
+
+func RegisterLogAidServer(s grpc.ServiceRegistrar, srv LogAidServer) {
+	// If the following call pancis, it indicates UnimplementedLogAidServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LogAid_ServiceDesc, srv)
+}
+
+func _LogAid_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogAidServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogAid_Suggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogAidServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogAid_StreamSuggest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SuggestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LogAidServer).StreamSuggest(m, &grpc.GenericServerStream[SuggestRequest, SuggestEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LogAid_StreamSuggestServer = grpc.ServerStreamingServer[SuggestEvent]
+
+func _LogAid_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogAidServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogAid_History_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogAidServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LogAid_ServiceDesc is the grpc.ServiceDesc for LogAid service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LogAid_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "logaid.v1.LogAid",
+	HandlerType: (*LogAidServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Suggest",
+			Handler:    _LogAid_Suggest_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _LogAid_History_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSuggest",
+			Handler:       _LogAid_StreamSuggest_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "logaid/v1/logaid.proto",
+}
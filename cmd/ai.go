@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var aiTestProvider string
+
+var aiCmd = &cobra.Command{
+	Use:   "ai",
+	Short: "Manage AI providers",
+}
+
+var aiTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Check AI provider connectivity and latency",
+	Long: `Validate the API key of each configured AI provider, list the models it offers
+and measure the round-trip latency of a small request. Exits non-zero if any
+provider fails.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !testAIProviders() {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	aiTestCmd.Flags().StringVarP(&aiTestProvider, "provider", "p", "", "Only test this provider")
+	aiCmd.AddCommand(aiTestCmd)
+}
+
+func testAIProviders() bool {
+	providers := providersToTest()
+	passed := true
+
+	for _, provider := range providers {
+		client := ai.NewAIClientFor(provider)
+		if client == nil {
+			fmt.Printf("%-30s FAIL  not configured (check AI_PROVIDER and the API key)\n", provider)
+			passed = false
+			continue
+		}
+
+		result := ai.Check(context.Background(), client)
+		label := fmt.Sprintf("%s (%s)", result.Provider, result.Model)
+		if !result.Passed() {
+			fmt.Printf("%-30s FAIL  %v\n", label, result.Err)
+			passed = false
+			continue
+		}
+
+		fmt.Printf("%-30s PASS  %d models, latency %s\n", label, len(result.Models), result.Latency.Round(time.Millisecond))
+		if result.Warning != "" {
+			logger.Warn(result.Warning)
+		}
+	}
+
+	return passed
+}
+
+// providersToTest returns the provider selected with --provider, or the
+// configured provider plus every other provider that has an API key
+func providersToTest() []string {
+	if aiTestProvider != "" {
+		return []string{aiTestProvider}
+	}
+
+	configured := ai.ConfiguredProvider()
+	providers := []string{configured}
+	for _, provider := range ai.Providers {
+		if provider != configured && hasAPIKey(provider) {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+func hasAPIKey(provider string) bool {
+	cfg := config.Current()
+	if cfg == nil {
+		return false
+	}
+	switch provider {
+	case "gemini":
+		return cfg.GeminiAPIKey != ""
+	case "openai":
+		return cfg.OpenAIAPIKey != ""
+	case "llama":
+		return cfg.LlamaModelPath != "" && ai.LlamaSupported()
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/audit"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditLimit  int
+	auditVerify bool
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Review the commands LogAid executed on your behalf",
+	Long: `Show the audit log of every fix LogAid executed: when, as which user, the
+failed command, the fix and where it came from, its exit code and how it was
+confirmed (accepted at the prompt or auto-confirmed).
+
+The log (EXEC_AUDIT_LOG_FILE) is append-only and each record holds the hash of
+the previous one. --verify checks the chain and exits with an error when a
+record was modified, inserted or removed.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !showAudit() {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	auditCmd.Flags().IntVarP(&auditLimit, "limit", "n", 20, "Number of records to show, 0 for all")
+	auditCmd.Flags().BoolVar(&auditVerify, "verify", false, "Check that the log has not been tampered with")
+}
+
+func showAudit() bool {
+	path := config.Current().ExecAuditLogFile
+	if path == "" {
+		logger.Error("The audit log is disabled (EXEC_AUDIT_LOG_FILE is empty)")
+		return false
+	}
+	records, err := audit.Read(path)
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+
+	if auditVerify {
+		checked, err := audit.Verify(records)
+		if jsonOutput {
+			result := map[string]interface{}{"path": path, "records": checked, "valid": err == nil}
+			if err != nil {
+				result["error"] = err.Error()
+			}
+			printJSON(result)
+			return err == nil
+		}
+		if err != nil {
+			logger.Error(fmt.Sprintf("Audit log %s has been tampered with: %v", path, err))
+			return false
+		}
+		logger.Success(fmt.Sprintf("Audit log intact: %d records verified (%s)", checked, path))
+		return true
+	}
+
+	if auditLimit > 0 && len(records) > auditLimit {
+		records = records[len(records)-auditLimit:]
+	}
+	if jsonOutput {
+		printJSON(records)
+		return true
+	}
+	if len(records) == 0 {
+		logger.Info("No commands executed yet")
+		return true
+	}
+	fmt.Printf("%-17s %-10s %-16s %-15s %4s  %s\n", "TIME", "USER", "SOURCE", "CONFIRMATION", "EXIT", "COMMAND")
+	for _, r := range records {
+		fmt.Printf("%-17s %-10s %-16s %-15s %4d  %s\n", r.Time.Local().Format("2006-01-02 15:04"), r.User, r.Source, r.Confirmation, r.ExitCode, r.Command)
+	}
+	return true
+}
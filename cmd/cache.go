@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/cache"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cachePlugin    string
+	cacheOlderThan string
+	cacheMaxSize   string
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and clean the suggestion and registry lookup caches",
+	Long: `LogAid caches AI suggestions by error signature (CACHE_SUGGESTIONS) and the
+answers of package registries such as npm, PyPI and Docker Hub. These commands
+show what is cached and how often it is used, and remove entries.`,
+}
+
+var cacheShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "List cached entries",
+	Run: func(cmd *cobra.Command, args []string) {
+		showCache()
+	},
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache sizes and hit rates",
+	Run: func(cmd *cobra.Command, args []string) {
+		showCacheStats()
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached entries, optionally by plugin or age",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !clearCache() {
+			exit(1)
+		}
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Drop the least recently used entries until each cache fits a size",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pruneCache() {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	cacheShowCmd.Flags().StringVar(&cachePlugin, "plugin", "", "Only show entries of this plugin or tool")
+	cacheClearCmd.Flags().StringVar(&cachePlugin, "plugin", "", "Only remove entries of this plugin or tool")
+	cacheClearCmd.Flags().StringVar(&cacheOlderThan, "older-than", "", "Only remove entries unused for this long, e.g. 12h or 7d")
+	cachePruneCmd.Flags().StringVar(&cacheMaxSize, "max-size", "", "Size limit per cache, e.g. 512KB or 5MB")
+	cachePruneCmd.MarkFlagRequired("max-size")
+
+	cacheCmd.AddCommand(cacheShowCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+}
+
+func showCache() {
+	if suggestions := cache.NewFromConfig(); suggestions != nil {
+		entries, err := suggestions.Entries()
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to read suggestion cache: %v", err))
+		}
+		fmt.Printf("Suggestions (%s)\n", suggestions.Path())
+		fmt.Printf("%-12s %5s %-17s %s\n", "TOOL", "HITS", "LAST USED", "SUGGESTION")
+		for _, entry := range entries {
+			if cachePlugin != "" && entry.Tool() != cachePlugin && entry.Source != cachePlugin {
+				continue
+			}
+			fmt.Printf("%-12s %5d %-17s %s\n", entry.Tool(), entry.Hits, entry.LastUsed.Format("2006-01-02 15:04"), entry.Suggestion)
+		}
+		fmt.Println()
+	}
+
+	lookups, err := plugins.LookupCacheEntries()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read lookup cache: %v", err))
+	}
+	fmt.Println("Registry lookups")
+	fmt.Printf("%-12s %5s %-17s %s\n", "PLUGIN", "HITS", "FETCHED", "URL")
+	for _, lookup := range lookups {
+		if cachePlugin != "" && lookup.Plugin != cachePlugin {
+			continue
+		}
+		fmt.Printf("%-12s %5d %-17s %s\n", lookup.Plugin, lookup.Hits, lookup.Fetched.Format("2006-01-02 15:04"), lookup.Key)
+	}
+}
+
+func showCacheStats() {
+	fmt.Printf("%-18s %8s %9s %9s %-17s %s\n", "CACHE", "ENTRIES", "SIZE", "HIT RATE", "OLDEST", "BY PLUGIN")
+	if suggestions := cache.NewFromConfig(); suggestions != nil {
+		stats, err := suggestions.Stats()
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to read suggestion cache: %v", err))
+		} else {
+			printCacheStats("suggestions", stats)
+		}
+	}
+	stats, err := plugins.LookupCacheStats()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read lookup cache: %v", err))
+		return
+	}
+	printCacheStats("registry lookups", stats)
+}
+
+func printCacheStats(name string, stats cache.Stats) {
+	oldest := "-"
+	if !stats.Oldest.IsZero() {
+		oldest = stats.Oldest.Format("2006-01-02 15:04")
+	}
+	counts := make([]string, 0, len(stats.Plugins))
+	for plugin, count := range stats.Plugins {
+		counts = append(counts, fmt.Sprintf("%s:%d", plugin, count))
+	}
+	sort.Strings(counts)
+	fmt.Printf("%-18s %8d %9s %8.1f%% %-17s %s\n", name, stats.Entries, cache.FormatSize(stats.Size), stats.HitRate()*100, oldest, strings.Join(counts, " "))
+}
+
+func clearCache() bool {
+	filter := cache.Filter{Plugin: cachePlugin}
+	if cacheOlderThan != "" {
+		age, err := parseAge(cacheOlderThan)
+		if err != nil {
+			logger.Error(err.Error())
+			return false
+		}
+		filter.OlderThan = age
+	}
+
+	ok := true
+	if suggestions := cache.NewFromConfig(); suggestions != nil {
+		removed, err := suggestions.Clear(filter)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to clear suggestion cache: %v", err))
+			ok = false
+		} else {
+			logger.Success(fmt.Sprintf("Removed %d cached suggestions", removed))
+		}
+	}
+	removed, err := plugins.ClearLookupCache(filter)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to clear lookup cache: %v", err))
+		return false
+	}
+	logger.Success(fmt.Sprintf("Removed %d cached registry lookups", removed))
+	return ok
+}
+
+func pruneCache() bool {
+	maxSize, err := cache.ParseSize(cacheMaxSize)
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+
+	ok := true
+	if suggestions := cache.NewFromConfig(); suggestions != nil {
+		removed, err := suggestions.Prune(maxSize)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to prune suggestion cache: %v", err))
+			ok = false
+		} else {
+			logger.Success(fmt.Sprintf("Dropped %d cached suggestions", removed))
+		}
+	}
+	removed, err := plugins.PruneLookupCache(maxSize)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to prune lookup cache: %v", err))
+		return false
+	}
+	logger.Success(fmt.Sprintf("Dropped %d cached registry lookups", removed))
+	return ok
+}
+
+// parseAge parses a duration that may also be given in days, e.g. 7d
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	age, err := time.ParseDuration(s)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age %q, use e.g. 12h or 7d", s)
+	}
+	return age, nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/ayushsharma-1/LogAid/internal/ci"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var ciStrict bool
+
+var ciCmd = &cobra.Command{
+	Use:   "ci -- <command> [args...]",
+	Short: "Run a CI step and annotate its failure with a fix",
+	Long: `Run a step of a CI job, such as a build or deploy script, and when it fails
+print a GitHub Actions error annotation with the error and the suggested fix,
+and add them to the job summary ($GITHUB_STEP_SUMMARY). An error seen in the
+output of a step that succeeded is annotated as a warning.
+
+LogAid never waits for input here: fixes are only suggested unless --yes,
+ASSUME_YES or AUTO_CONFIRM is set. With --strict nothing but the step itself
+is ever executed, whatever the configuration says. The exit code is the
+step's, or 0 when a fix was applied and worked.`,
+	Example: `  # .github/workflows/deploy.yml
+  - run: logaid ci --strict -- ./scripts/deploy.sh production`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runCI(args)
+	},
+}
+
+func init() {
+	// Flags after the command belong to it
+	ciCmd.Flags().SetInterspersed(false)
+	ciCmd.Flags().BoolVar(&ciStrict, "strict", false, "Never execute fixes, even with --yes, ASSUME_YES or AUTO_CONFIRM")
+}
+
+func runCI(args []string) {
+	cfg := config.Current()
+	overrides := map[string]bool{}
+	switch {
+	case ciStrict:
+		overrides["ASSUME_YES"], overrides["AUTO_CONFIRM"], overrides["ASSUME_NO"] = false, false, true
+	case cfg == nil || (!cfg.AssumeYes && !cfg.AutoConfirm):
+		// There is nobody to ask
+		overrides["ASSUME_NO"] = true
+	}
+	for key, value := range overrides {
+		if err := config.Override(key, value); err != nil {
+			logger.Error(fmt.Sprintf("Failed to enter CI mode: %v", err))
+			exit(1)
+		}
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = os.Environ()
+
+	report, err := newEngine().Run(cmd, nil)
+	if annotation := ci.Annotation(report); annotation != "" {
+		fmt.Println(annotation)
+	}
+	if err := ci.WriteSummary(ci.Summary(report)); err != nil {
+		logger.Warn(err.Error())
+	}
+	if err != nil {
+		if report.ExitCode > 0 {
+			exit(report.ExitCode)
+		}
+		exit(1)
+	}
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/ayushsharma-1/LogAid/internal/config"
 	"github.com/ayushsharma-1/LogAid/internal/logger"
@@ -32,26 +36,90 @@ var configInitCmd = &cobra.Command{
 	},
 }
 
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a configuration key",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !getConfigValue(args[0]) {
+			exit(1)
+		}
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration key in config.yaml",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !setConfigValue(args[0], args[1]) {
+			exit(1)
+		}
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration key from config.yaml",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !unsetConfigValue(args[0]) {
+			exit(1)
+		}
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration for mistakes",
+	Long: `Check the type and allowed values of every setting, that the log, cache,
+history and plugins paths are writable and that no conflicting options are set.
+Each problem names the key and how to fix it. Exits non-zero on errors.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !validateConfig() {
+			exit(1)
+		}
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open config.yaml in $EDITOR",
+	Long: `Open ~/.logaid/config.yaml in $VISUAL or $EDITOR. The file is validated after
+you save it; if it has errors you can edit it again or discard the changes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !editConfig() {
+			exit(1)
+		}
+	},
+}
+
 func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configValidateCmd)
 }
 
 func showConfig() {
-	if config.AppConfig == nil {
+	cfg := config.Current()
+	if cfg == nil {
 		logger.Error("Configuration not initialized")
 		return
 	}
 
 	fmt.Println("LogAid Configuration:")
-	fmt.Printf("AI Provider: %s\n", config.AppConfig.AIProvider)
-	fmt.Printf("Log Level: %s\n", config.AppConfig.LogLevel)
-	fmt.Printf("Log File: %s\n", config.AppConfig.LogFile)
-	fmt.Printf("Plugins Directory: %s\n", config.AppConfig.PluginsDir)
-	fmt.Printf("Enabled Plugins: %s\n", config.AppConfig.EnablePlugins)
-	fmt.Printf("Enable Colors: %t\n", config.AppConfig.EnableColors)
-	fmt.Printf("Auto Confirm: %t\n", config.AppConfig.AutoConfirm)
-	fmt.Printf("History File: %s\n", config.AppConfig.HistoryFile)
+	fmt.Printf("AI Provider: %s\n", cfg.AIProvider)
+	fmt.Printf("Log Level: %s\n", cfg.LogLevel)
+	fmt.Printf("Log File: %s\n", cfg.LogFile)
+	fmt.Printf("Plugins Directory: %s\n", cfg.PluginsDir)
+	fmt.Printf("Enabled Plugins: %s\n", cfg.EnablePlugins)
+	fmt.Printf("Enable Colors: %t\n", cfg.EnableColors)
+	fmt.Printf("Auto Confirm: %t\n", cfg.AutoConfirm)
+	fmt.Printf("History File: %s\n", cfg.HistoryFile)
 }
 
 func initConfig() {
@@ -97,3 +165,141 @@ func initConfig() {
 	logger.Info(fmt.Sprintf("Configuration directory: %s", configDir))
 	logger.Info(fmt.Sprintf("Edit %s to configure your API keys", envFile))
 }
+
+func getConfigValue(key string) bool {
+	value, err := config.Get(key)
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+	fmt.Println(value)
+	return true
+}
+
+func setConfigValue(key, value string) bool {
+	path := config.FilePath()
+	if err := config.SetInFile(path, key, value); err != nil {
+		logger.Error(fmt.Sprintf("Failed to set %s: %v", key, err))
+		return false
+	}
+	logger.Success(fmt.Sprintf("Set %s in %s", config.NormalizeKey(key), path))
+	warnEnvOverride(key)
+	return true
+}
+
+func unsetConfigValue(key string) bool {
+	path := config.FilePath()
+	if err := config.UnsetInFile(path, key); err != nil {
+		logger.Error(fmt.Sprintf("Failed to unset %s: %v", key, err))
+		return false
+	}
+	logger.Success(fmt.Sprintf("Removed %s from %s", config.NormalizeKey(key), path))
+	warnEnvOverride(key)
+	return true
+}
+
+// warnEnvOverride tells the user when the environment or .env, which take
+// precedence over config.yaml, also sets key
+func warnEnvOverride(key string) {
+	key = config.NormalizeKey(key)
+	if _, ok := os.LookupEnv(key); ok {
+		logger.Warn(fmt.Sprintf("%s is also set in the environment or %s, which takes precedence over config.yaml", key, filepath.Join(config.Dir(), ".env")))
+	}
+}
+
+func editConfig() bool {
+	path := config.FilePath()
+	original, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error(fmt.Sprintf("Failed to read %s: %v", path, err))
+		return false
+	}
+	existed := err == nil
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		if err := runEditor(path); err != nil {
+			logger.Error(fmt.Sprintf("Failed to run editor: %v", err))
+			return false
+		}
+
+		problems, err := config.ValidateFile(path)
+		if err == nil && len(problems.Errors()) == 0 {
+			reportProblems(problems)
+			logger.Success(fmt.Sprintf("Saved %s", path))
+			return true
+		}
+		if err != nil {
+			logger.Error(err.Error())
+		}
+		reportProblems(problems)
+
+		logger.Info("Edit again? [Y/n]: ")
+		input, _ := reader.ReadString('\n')
+		if answer := strings.ToLower(strings.TrimSpace(input)); answer == "n" || answer == "no" {
+			break
+		}
+	}
+
+	// Put back the file as it was before editing
+	if existed {
+		err = os.WriteFile(path, original, 0644)
+	} else {
+		err = os.Remove(path)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error(fmt.Sprintf("Failed to restore %s: %v", path, err))
+		return false
+	}
+	logger.Warn("Changes discarded")
+	return false
+}
+
+func validateConfig() bool {
+	problems := config.Validate()
+	if len(problems) == 0 {
+		logger.Success("Configuration is valid")
+		return true
+	}
+	reportProblems(problems)
+	return len(problems.Errors()) == 0
+}
+
+// reportProblems prints configuration problems with their fixes
+func reportProblems(problems config.ValidationErrors) {
+	for _, problem := range problems {
+		if problem.Warning {
+			logger.Warn(fmt.Sprintf("%s: %s", problem.Key, problem.Problem))
+		} else {
+			logger.Error(fmt.Sprintf("%s: %s", problem.Key, problem.Problem))
+		}
+		if problem.Fix != "" {
+			fmt.Printf("  → %s\n", problem.Fix)
+		}
+	}
+}
+
+// runEditor opens path in $VISUAL or $EDITOR, which may include arguments
+// such as "code --wait"
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	args := append(strings.Fields(editor), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
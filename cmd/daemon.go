@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/daemon"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Serve suggestions to every terminal from one process",
+	Long: `Run LogAid in the foreground as a daemon listening on DAEMON_SOCKET. While it
+runs, 'logaid exec' asks it for suggestions instead of loading the plugins,
+caches and AI client itself, so they are loaded once and shared by every
+terminal. Start it from your shell profile, e.g. 'logaid daemon &', or run
+'logaid daemon install' to have systemd start it at login.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runDaemon()
+	},
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the daemon is running",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		showDaemonStatus()
+	},
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running daemon",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		stopDaemon()
+	},
+}
+
+var daemonInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Start the daemon at login as a systemd user service",
+	Long: `Write a systemd user unit, logaid.service, that runs this binary as the
+daemon, then enable and start it. The unit fixes the current DAEMON_SOCKET
+and LOG_FILE, so the service listens where 'logaid exec' looks for it; run
+install again after changing them. Manage it like any other service with
+'systemctl --user' and read its output with 'journalctl --user -u logaid'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		installDaemonService(daemonNoStart)
+	},
+}
+
+var daemonUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Stop the daemon service and remove its unit",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		uninstallDaemonService()
+	},
+}
+
+var daemonNoStart bool
+
+func init() {
+	daemonInstallCmd.Flags().BoolVar(&daemonNoStart, "no-start", false, "enable the service for the next login without starting it now")
+	daemonCmd.AddCommand(daemonStatusCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonInstallCmd)
+	daemonCmd.AddCommand(daemonUninstallCmd)
+}
+
+// newEngine returns an engine that uses the daemon when its socket exists,
+// falling back to loading the plugins when it does not answer
+func newEngine() *engine.Engine {
+	path := daemon.SocketPath()
+	if path != "" {
+		if _, err := os.Stat(path); err == nil {
+			logger.Debug(fmt.Sprintf("Using daemon at %s", path))
+			return engine.NewRemote(daemon.NewClient(path))
+		}
+	}
+	return engine.New()
+}
+
+func runDaemon() {
+	path := daemon.SocketPath()
+	if path == "" {
+		logger.Error("The daemon is disabled (DAEMON_SOCKET is empty)")
+		exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	toggleDebugOnHangup(ctx)
+
+	eng := engine.New()
+	if err := eng.Watch(ctx); err != nil {
+		logger.Warn(fmt.Sprintf("Not watching plugins: %v", err))
+	}
+	if err := daemon.NewServer(eng, path).Serve(ctx); err != nil {
+		logger.Error(fmt.Sprintf("Daemon failed: %v", err))
+		exit(1)
+	}
+}
+
+func showDaemonStatus() {
+	path := daemon.SocketPath()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	status, err := daemon.NewClient(path).Status(ctx)
+	if path == "" || err != nil {
+		logger.Info("The daemon is not running")
+		exit(1)
+	}
+
+	if jsonOutput {
+		printJSON(status)
+		return
+	}
+	fmt.Printf("Socket:   %s\n", path)
+	fmt.Printf("PID:      %d\n", status.PID)
+	fmt.Printf("Uptime:   %s\n", time.Since(status.Started).Round(time.Second))
+	fmt.Printf("Plugins:  %d\n", status.Plugins)
+	fmt.Printf("Requests: %d\n", status.Requests)
+	fmt.Printf("Logging:  %s\n", status.LogLevel)
+}
+
+func stopDaemon() {
+	path := daemon.SocketPath()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if path == "" {
+		logger.Info("The daemon is not running")
+		return
+	}
+	if err := daemon.NewClient(path).Stop(ctx); err != nil {
+		logger.Error(fmt.Sprintf("Failed to stop daemon: %v", err))
+		exit(1)
+	}
+	logger.Success("Daemon stopped")
+}
+
+func installDaemonService(noStart bool) {
+	cfg := config.Current()
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		logger.Error("systemd is not available; start 'logaid daemon &' from your shell profile instead")
+		exit(1)
+	}
+	executable, err := os.Executable()
+	if err == nil {
+		executable, err = filepath.EvalSymlinks(executable)
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to find the logaid binary: %v", err))
+		exit(1)
+	}
+	dir, err := daemon.ServiceDir()
+	if err != nil {
+		logger.Error(err.Error())
+		exit(1)
+	}
+
+	socket := daemon.SocketPath()
+	unit, err := daemon.ServiceUnit(executable, socket, cfg.LogFile)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Cannot install the service: %v", err))
+		exit(1)
+	}
+	path, err := daemon.InstallService(dir, unit)
+	if err != nil {
+		logger.Error(err.Error())
+		exit(1)
+	}
+	logger.Info(fmt.Sprintf("Wrote %s", path))
+
+	enable := []string{"enable", daemon.ServiceName}
+	if !noStart {
+		// A daemon started by hand holds the socket the service needs
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if daemon.NewClient(socket).Stop(ctx) == nil {
+			logger.Info("Stopped the daemon running outside systemd")
+		}
+		enable = []string{"enable", "--now", daemon.ServiceName}
+	}
+	for _, args := range [][]string{{"daemon-reload"}, enable} {
+		if err := systemctl(args...); err != nil {
+			logger.Error(err.Error())
+			exit(1)
+		}
+	}
+
+	if noStart {
+		logger.Success("Daemon service installed; it starts at your next login")
+	} else {
+		logger.Success(fmt.Sprintf("Daemon service installed and listening on %s", socket))
+	}
+	fmt.Printf("Logs:   journalctl --user -u %s and %s\n", strings.TrimSuffix(daemon.ServiceName, ".service"), cfg.LogFile)
+	fmt.Printf("Manage: systemctl --user status|restart|stop %s\n", daemon.ServiceName)
+}
+
+func uninstallDaemonService() {
+	dir, err := daemon.ServiceDir()
+	if err != nil {
+		logger.Error(err.Error())
+		exit(1)
+	}
+	if !daemon.ServiceInstalled(dir) {
+		logger.Info("The daemon service is not installed")
+		return
+	}
+
+	if err := systemctl("disable", "--now", daemon.ServiceName); err != nil {
+		logger.Warn(err.Error())
+	}
+	if _, err := daemon.UninstallService(dir); err != nil {
+		logger.Error(err.Error())
+		exit(1)
+	}
+	if err := systemctl("daemon-reload"); err != nil {
+		logger.Warn(err.Error())
+	}
+	logger.Success("Daemon service removed")
+}
+
+// systemctl runs systemctl on the user's service manager
+func systemctl(args ...string) error {
+	out, err := exec.Command("systemctl", append([]string{"--user"}, args...)...).CombinedOutput()
+	if err != nil {
+		message := strings.TrimSpace(string(out))
+		if message == "" {
+			message = err.Error()
+		}
+		return fmt.Errorf("systemctl --user %s failed: %s", strings.Join(args, " "), message)
+	}
+	return nil
+}
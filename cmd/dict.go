@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var dictContext string
+
+var dictCmd = &cobra.Command{
+	Use:   "dict",
+	Short: "Manage the personal typo dictionary",
+	Long: `Every correction you accept, such as "git checout" -> "git checkout", is
+remembered per word in the personal model (~/.logaid/model.json). The next
+time you make the same typo it is corrected instantly, without plugins or the
+AI. These commands list and remove learned entries.`,
+}
+
+var dictListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List learned corrections",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !listDict() {
+			exit(1)
+		}
+	},
+}
+
+var dictRemoveCmd = &cobra.Command{
+	Use:   "remove <typo>...",
+	Short: "Forget learned corrections of a typo",
+	Long: `Forget what a typo was corrected to. Without --context the typo is removed
+from every command it was learned for; "commands" is the context of command
+names themselves.`,
+	Example: `  logaid dict remove checout
+  logaid dict remove --context git stauts`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !removeDict(args, cmd.Flags().Changed("context")) {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	dictRemoveCmd.Flags().StringVar(&dictContext, "context", "", `Only remove corrections learned after this command, e.g. "git"`)
+	dictCmd.AddCommand(dictListCmd)
+	dictCmd.AddCommand(dictRemoveCmd)
+}
+
+// dictStore returns the personal model, or nil when it is disabled
+func dictStore() *model.Store {
+	store := model.NewFromConfig()
+	if store == nil {
+		logger.Error("The personal typo dictionary is disabled (PERSONAL_MODEL=false)")
+	}
+	return store
+}
+
+func listDict() bool {
+	store := dictStore()
+	if store == nil {
+		return false
+	}
+	fixes, err := store.Fixes()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load personal model: %v", err))
+		return false
+	}
+
+	if jsonOutput {
+		if fixes == nil {
+			fixes = []model.Fix{}
+		}
+		printJSON(fixes)
+		return true
+	}
+	if len(fixes) == 0 {
+		logger.Info("No corrections learned yet")
+		return true
+	}
+	fmt.Printf("%-16s %-20s %-20s %s\n", "CONTEXT", "TYPO", "CORRECTION", "USES")
+	for _, fix := range fixes {
+		fmt.Printf("%-16s %-20s %-20s %d\n", contextLabel(fix.Context), fix.From, fix.To, fix.Count)
+	}
+	return true
+}
+
+func removeDict(typos []string, inContext bool) bool {
+	store := dictStore()
+	if store == nil {
+		return false
+	}
+	var contexts []string
+	if inContext {
+		context := dictContext
+		if context == "commands" {
+			context = ""
+		}
+		contexts = append(contexts, context)
+	}
+
+	ok := true
+	for _, typo := range typos {
+		removed, err := store.RemoveFix(typo, contexts...)
+		if err != nil {
+			logger.Error(err.Error())
+			return false
+		}
+		if removed == 0 {
+			logger.Warn(fmt.Sprintf("No learned correction for %q", typo))
+			ok = false
+			continue
+		}
+		logger.Success(fmt.Sprintf("Forgot %d correction(s) of %q", removed, typo))
+	}
+	return ok
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/doctor"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the LogAid environment",
+	Long: `Check the configuration, AI provider key and reachability, PTY support, shell
+hook, plugins and the log, cache and history directories, and explain how to
+fix each problem found. Exits non-zero if any check fails.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !runDoctor() {
+			exit(1)
+		}
+	},
+}
+
+func runDoctor() bool {
+	results := doctor.New().Run(context.Background())
+	for _, result := range results {
+		line := fmt.Sprintf("%-14s %s", result.Name, result.Detail)
+		switch result.Status {
+		case doctor.StatusOK:
+			logger.Success(line)
+		case doctor.StatusWarn:
+			logger.Warn(line)
+		default:
+			logger.Error(line)
+		}
+		if result.Fix != "" {
+			fmt.Printf("  → %s\n", result.Fix)
+		}
+	}
+	return !doctor.Failed(results)
+}
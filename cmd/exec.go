@@ -1,27 +1,51 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/container"
 	"github.com/ayushsharma-1/LogAid/internal/engine"
 	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/session"
 	"github.com/spf13/cobra"
 )
 
+var (
+	execRecord    bool
+	execContainer string
+)
+
 var execCmd = &cobra.Command{
 	Use:   "exec [command]",
 	Short: "Execute a command with LogAid monitoring",
 	Long: `Execute a command with LogAid monitoring. LogAid will intercept the command output
-and provide AI-powered suggestions if errors are detected.`,
+and provide AI-powered suggestions if errors are detected.
+
+With --container the command and any fix run inside a running Docker or
+Podman container (CONTAINER_RUNTIME), given by name, ID or Compose service.
+Fixes are adapted to it: sudo is dropped where it is missing or not needed,
+packages are installed with the container's package manager, and systemctl
+is not suggested when systemd is not running there.`,
+	Example: `  logaid exec apt install ngnix
+  logaid exec --container web -- apt install ngnix`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		executeCommand(args)
 	},
 }
 
+func init() {
+	// Flags after the command belong to it, e.g. apt install -y
+	execCmd.Flags().SetInterspersed(false)
+	execCmd.Flags().BoolVar(&execRecord, "record", false, "Record the session for 'logaid replay' (RECORD_SESSIONS)")
+	execCmd.Flags().StringVar(&execContainer, "container", "", "Run in this container or Compose service with docker or podman exec")
+}
+
 func executeCommand(args []string) {
 	// Join arguments back into a single command string for parsing
 	cmdStr := strings.Join(args, " ")
@@ -31,7 +55,7 @@ func executeCommand(args []string) {
 	parts := strings.Fields(cmdStr)
 	if len(parts) == 0 {
 		logger.Error("No command provided")
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Create command
@@ -46,9 +70,84 @@ func executeCommand(args []string) {
 	cmd.Env = os.Environ()
 	cmd.Stdin = os.Stdin
 
+	toggleDebugOnHangup(context.Background())
+
+	var eng *engine.Engine
+	if execContainer != "" {
+		env, err := container.Open(context.Background(), execContainer)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to use container: %v", err))
+			exit(1)
+		}
+		logger.Info(fmt.Sprintf("Running in %s container %s (%s)", env.Runtime, env.Name, describeContainer(env)))
+		// The daemon cannot describe the container to the AI
+		eng = engine.New()
+		eng.SetContainer(env)
+	} else {
+		eng = newEngine()
+	}
+	recorder := startRecording(eng, cmdStr)
+
 	// Execute with monitoring
-	if err := engine.ExecuteWithMonitoring(cmd); err != nil {
+	if jsonOutput {
+		// The command's output would corrupt the JSON on stdout
+		report, err := eng.Run(cmd, os.Stderr)
+		saveRecording(recorder)
+		printJSON(report)
+		if err != nil {
+			exit(1)
+		}
+		return
+	}
+	_, err := eng.Run(cmd, nil)
+	saveRecording(recorder)
+	if err != nil {
 		logger.Error(fmt.Sprintf("Command execution failed: %v", err))
-		os.Exit(1)
+		exit(1)
+	}
+}
+
+// describeContainer summarizes what was detected in a container
+func describeContainer(env *container.Env) string {
+	var details []string
+	if env.OS != "" {
+		details = append(details, env.OS)
+	}
+	if env.PackageManager != "" {
+		details = append(details, env.PackageManager)
+	}
+	if env.Root {
+		details = append(details, "root")
+	}
+	if !env.Systemd {
+		details = append(details, "no systemd")
+	}
+	return strings.Join(details, ", ")
+}
+
+// startRecording records the session with --record or RECORD_SESSIONS
+func startRecording(eng *engine.Engine, command string) *session.Recorder {
+	cfg := config.Current()
+	if !execRecord && (cfg == nil || !cfg.RecordSessions) {
+		return nil
+	}
+	if session.Dir() == "" {
+		logger.Warn("Not recording: SESSIONS_DIR is empty")
+		return nil
+	}
+	recorder := session.NewRecorder(command)
+	eng.SetRecorder(recorder)
+	return recorder
+}
+
+// saveRecording writes a recorded session to SESSIONS_DIR
+func saveRecording(recorder *session.Recorder) {
+	if recorder == nil {
+		return
+	}
+	if _, err := recorder.Save(session.Dir()); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to save session: %v", err))
+		return
 	}
+	logger.Info(fmt.Sprintf("Session recorded, replay it with: logaid replay %s", recorder.Session().ID))
 }
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var explainRefresh bool
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explain the last suggestion",
+	Long: `Show why the last failed command went wrong and why LogAid's suggestion fixes
+it. The explanation is generated once and stored with the history entry.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		explainLastSuggestion()
+	},
+}
+
+func init() {
+	explainCmd.Flags().BoolVar(&explainRefresh, "refresh", false, "Ask the AI again instead of using the stored explanation")
+}
+
+func explainLastSuggestion() {
+	store := history.NewFromConfig()
+	if store == nil {
+		logger.Error("History is disabled (HISTORY_FILE is empty)")
+		return
+	}
+
+	entry, err := store.Last()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read history: %v", err))
+		return
+	}
+	if entry == nil {
+		logger.Info("No suggestions in history yet")
+		return
+	}
+
+	if entry.Explanation == "" || explainRefresh {
+		explanation, err := ai.Explain(context.Background(), entry.Command, entry.Output, entry.Suggestion)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to get explanation: %v", err))
+			return
+		}
+		entry.Explanation = explanation
+
+		err = store.Update(entry.ID, func(e *history.Entry) {
+			e.Explanation = explanation
+		})
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Failed to store explanation: %v", err))
+		}
+	}
+
+	if jsonOutput {
+		printJSON(entry)
+		return
+	}
+
+	fmt.Printf("Command:    %s\n", entry.Command)
+	fmt.Printf("Suggestion: %s (%s, from %s)\n", entry.Suggestion, entry.Status, entry.Source)
+	fmt.Println()
+	fmt.Println(entry.Explanation)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/feedback"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	feedbackNote string
+	feedbackID   int64
+)
+
+var feedbackCmd = &cobra.Command{
+	Use:   "feedback helpful|harmful",
+	Short: "Rate the last suggestion",
+	Long: `Mark the last suggestion (or the history entry with the given --id, as
+shown by 'logaid explain --json') as helpful or harmful, with an optional note. The rating is stored with the
+history entry; suggestions rated harmful are only offered again when nothing
+else is found.
+
+With FEEDBACK_UPLOAD=true the rating is also sent to FEEDBACK_ENDPOINT,
+anonymized: only the rating, the suggestion's source, the names of the
+programs involved and the LogAid version. Commands' arguments, output and the
+note never leave the machine.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{history.FeedbackHelpful, history.FeedbackHarmful},
+	Run: func(cmd *cobra.Command, args []string) {
+		if !rateSuggestion(args[0]) {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	feedbackCmd.Flags().StringVar(&feedbackNote, "note", "", "Why the suggestion helped or not")
+	feedbackCmd.Flags().Int64Var(&feedbackID, "id", 0, "ID of the history entry to rate instead of the last one")
+}
+
+func rateSuggestion(rating string) bool {
+	if rating != history.FeedbackHelpful && rating != history.FeedbackHarmful {
+		logger.Error(fmt.Sprintf("Unknown rating %q, use helpful or harmful", rating))
+		return false
+	}
+	store := history.NewFromConfig()
+	if store == nil {
+		logger.Error("History is disabled (HISTORY_FILE is empty)")
+		return false
+	}
+
+	var entry *history.Entry
+	var err error
+	if feedbackID != 0 {
+		entry, err = store.Get(feedbackID)
+	} else {
+		entry, err = store.Last()
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read history: %v", err))
+		return false
+	}
+	if entry == nil && feedbackID != 0 {
+		logger.Error(fmt.Sprintf("No history entry with ID %d", feedbackID))
+		return false
+	}
+	if entry == nil {
+		logger.Info("No suggestion to rate")
+		return false
+	}
+
+	err = store.Update(entry.ID, func(e *history.Entry) {
+		e.Feedback = rating
+		e.Note = feedbackNote
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to store feedback: %v", err))
+		return false
+	}
+	entry.Feedback = rating
+	entry.Note = feedbackNote
+
+	if feedback.Enabled() {
+		report := feedback.NewReport(*entry, version)
+		if err := feedback.Upload(context.Background(), nil, config.Current().FeedbackEndpoint, report); err != nil {
+			logger.Warn(fmt.Sprintf("Feedback saved locally but not uploaded: %v", err))
+		}
+	}
+
+	if jsonOutput {
+		printJSON(entry)
+		return true
+	}
+	logger.Success(fmt.Sprintf("Rated %q as %s", entry.Suggestion, rating))
+	return true
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+var (
+	genDocsDir    string
+	genDocsFormat string
+)
+
+var genDocsCmd = &cobra.Command{
+	Use:    "gen-docs",
+	Short:  "Generate man pages and markdown docs for every command",
+	Hidden: true,
+	Long: `Generate a man page (section 1) and a markdown page for every command from
+the same definitions 'logaid --help' uses, so packaged docs stay in sync
+with the flags. Man pages go to <dir>/man and markdown to <dir>/markdown.
+Set SOURCE_DATE_EPOCH for reproducible dates.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := generateDocs(genDocsDir, genDocsFormat); err != nil {
+			logger.Error(fmt.Sprintf("Failed to generate docs: %v", err))
+			exit(1)
+		}
+		logger.Success(fmt.Sprintf("Docs written to %s", genDocsDir))
+	},
+}
+
+func init() {
+	genDocsCmd.Flags().StringVar(&genDocsDir, "dir", "docs", "Directory to write the docs to")
+	genDocsCmd.Flags().StringVar(&genDocsFormat, "format", "all", "What to generate: man, markdown or all")
+}
+
+// generateDocs writes the man pages and/or markdown pages for rootCmd
+func generateDocs(dir, format string) error {
+	if format != "man" && format != "markdown" && format != "all" {
+		return fmt.Errorf("unknown format %q, use man, markdown or all", format)
+	}
+	// The generation date would change every file on each run
+	rootCmd.DisableAutoGenTag = true
+
+	if format != "markdown" {
+		manDir := filepath.Join(dir, "man")
+		if err := os.MkdirAll(manDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", manDir, err)
+		}
+		header := &doc.GenManHeader{
+			Title:   "LOGAID",
+			Section: "1",
+			Source:  "LogAid " + version,
+			Manual:  "LogAid Manual",
+		}
+		if err := doc.GenManTree(rootCmd, header, manDir); err != nil {
+			return fmt.Errorf("failed to generate man pages: %w", err)
+		}
+	}
+	if format != "man" {
+		markdownDir := filepath.Join(dir, "markdown")
+		if err := os.MkdirAll(markdownDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", markdownDir, err)
+		}
+		if err := doc.GenMarkdownTree(rootCmd, markdownDir); err != nil {
+			return fmt.Errorf("failed to generate markdown: %w", err)
+		}
+	}
+	return nil
+}
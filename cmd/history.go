@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historySearch     string
+	historyFailedOnly bool
+	historySince      string
+	historyLimit      int
+	historyOlderThan  string
+	historyFormat     string
+	historyOutput     string
+	historySession    string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Search the suggestion history",
+	Long: `List the failed commands LogAid saw and what it suggested, newest last.
+
+Each entry records the working directory, the exit code of the failed command,
+the plugin or AI provider and model that made the suggestion, how long finding
+it took and the outcome: proposed, applied, failed or rejected. Repeats of the
+same failure with the same suggestion in a session are kept as one entry
+with a count.
+
+  --search       entries whose command, output, suggestion or source contains
+                 the text, e.g. apt
+  --failed-only  entries whose fix failed
+  --since        a duration such as 30m, 2h or 7d, or a date (2006-01-02)
+  --session      all entries of one shell session, in the order they
+                 happened: last (the most recently active), current, or an
+                 ID from 'logaid history sessions'
+
+With --json the matching entries are printed with all their fields.`,
+	Example: `  logaid history --search "apt" --failed-only --since 7d
+  logaid history --session last`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if historySession != "" && !cmd.Flags().Changed("limit") {
+			// The whole story of the session
+			historyLimit = 0
+		}
+		if !showHistory() {
+			exit(1)
+		}
+	},
+}
+
+var historySessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List the shell sessions in the history",
+	Long: `List the shell sessions failures were recorded in, the most recently active
+last. A session is the terminal LogAid ran in; set LOGAID_SESSION to name
+sessions yourself, e.g. one per tmux pane or CI job. Show the entries of one
+with 'logaid history --session <id>'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !listShellSessions() {
+			exit(1)
+		}
+	},
+}
+
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old entries and collapse repeated failures",
+	Long: `Remove the entries last seen before --older-than, collapse repeats of the same
+failure and suggestion into one entry, and trim the history to
+MAX_HISTORY_ENTRIES.`,
+	Example: `  logaid history prune --older-than 30d`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pruneHistory() {
+			exit(1)
+		}
+	},
+}
+
+var historyExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the history as CSV, JSON or Markdown",
+	Long: `Write the history for a retrospective or a spreadsheet: csv has one row per
+entry with every field, json the entries as an array and md a summary of
+outcomes and sources followed by a table. --since, --search and --failed-only
+select entries as for 'logaid history'. Secrets, the home directory and the
+user and host names are redacted.`,
+	Example: `  logaid history export --format md --since 7d -o retro.md
+  logaid history export --format csv --since 2026-10-01 > failures.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !exportHistory() {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	historyExportCmd.Flags().StringVar(&historyFormat, "format", "csv", "Export format: csv, json or md")
+	historyExportCmd.Flags().StringVarP(&historyOutput, "output", "o", "", "Write to a file instead of stdout")
+	historyExportCmd.Flags().StringVar(&historySince, "since", "", "Only entries newer than a duration (2h, 7d) or a date")
+	historyExportCmd.Flags().StringVar(&historySearch, "search", "", "Only entries containing this text")
+	historyExportCmd.Flags().BoolVar(&historyFailedOnly, "failed-only", false, "Only entries whose fix failed")
+	historyExportCmd.Flags().StringVar(&historySession, "session", "", "Only entries of a shell session: last, current or an ID")
+	historyCmd.AddCommand(historyExportCmd)
+
+	historyPruneCmd.Flags().StringVar(&historyOlderThan, "older-than", "", "Remove entries last seen longer ago than this, e.g. 30d")
+	historyCmd.AddCommand(historyPruneCmd)
+	historyCmd.AddCommand(historySessionsCmd)
+
+	historyCmd.Flags().StringVar(&historySearch, "search", "", "Only entries containing this text")
+	historyCmd.Flags().BoolVar(&historyFailedOnly, "failed-only", false, "Only entries whose fix failed")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only entries newer than a duration (2h, 7d) or a date")
+	historyCmd.Flags().StringVar(&historySession, "session", "", "Only entries of a shell session: last, current or an ID")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Number of entries to show, 0 for all")
+}
+
+func showHistory() bool {
+	entries, ok := searchHistory()
+	if !ok {
+		return false
+	}
+	if historyLimit > 0 && len(entries) > historyLimit {
+		entries = entries[len(entries)-historyLimit:]
+	}
+
+	if jsonOutput {
+		if entries == nil {
+			entries = []history.Entry{}
+		}
+		printJSON(entries)
+		return true
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "No matching history entries")
+		return true
+	}
+	fmt.Printf("%-17s %-9s %4s %4s %-16s %8s  %s\n", "TIME", "STATUS", "EXIT", "SEEN", "SOURCE", "LATENCY", "COMMAND -> SUGGESTION")
+	for _, entry := range entries {
+		source := entry.Source
+		if entry.Model != "" {
+			source = entry.Provider + "/" + entry.Model
+		}
+		latency := "-"
+		if entry.LatencyMS > 0 {
+			latency = (time.Duration(entry.LatencyMS) * time.Millisecond).String()
+		}
+		fmt.Printf("%-17s %-9s %4d %4d %-16s %8s  %s -> %s\n", entry.Time.Local().Format("2006-01-02 15:04"), entry.Status, entry.ExitCode, entry.Occurrences(), source, latency, entry.Command, entry.Suggestion)
+	}
+	return true
+}
+
+// searchHistory returns the entries selected by --search, --failed-only,
+// --since and --session
+func searchHistory() ([]history.Entry, bool) {
+	store := history.NewFromConfig()
+	if store == nil {
+		logger.Error("The history is disabled (HISTORY_FILE is empty)")
+		return nil, false
+	}
+	filter := history.Filter{Search: historySearch, FailedOnly: historyFailedOnly}
+	if historySince != "" {
+		since, err := parseSince(historySince, time.Now())
+		if err != nil {
+			logger.Error(fmt.Sprintf("Invalid --since %q, use e.g. 2h, 7d or 2006-01-02", historySince))
+			return nil, false
+		}
+		filter.Since = since
+	}
+	switch historySession {
+	case "last":
+		last, err := store.LastSession()
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to read history: %v", err))
+			return nil, false
+		}
+		if last == "" {
+			logger.Error("No sessions recorded yet")
+			return nil, false
+		}
+		filter.Session = last
+	case "current":
+		filter.Session = history.CurrentSession()
+	default:
+		filter.Session = historySession
+	}
+	entries, err := store.Search(filter)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read history: %v", err))
+		return nil, false
+	}
+	return entries, true
+}
+
+func exportHistory() bool {
+	if !validFormat(historyFormat) {
+		logger.Error(fmt.Sprintf("Unknown format %q, use one of %s", historyFormat, strings.Join(history.ExportFormats, ", ")))
+		return false
+	}
+	entries, ok := searchHistory()
+	if !ok {
+		return false
+	}
+	entries = history.Redact(entries)
+
+	if historyOutput == "" {
+		if err := history.Export(os.Stdout, entries, historyFormat); err != nil {
+			logger.Error(fmt.Sprintf("Failed to export history: %v", err))
+			return false
+		}
+		return true
+	}
+	file, err := os.OpenFile(historyOutput, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create %s: %v", historyOutput, err))
+		return false
+	}
+	if err := history.Export(file, entries, historyFormat); err != nil {
+		file.Close()
+		logger.Error(fmt.Sprintf("Failed to export history: %v", err))
+		return false
+	}
+	if err := file.Close(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to write %s: %v", historyOutput, err))
+		return false
+	}
+	logger.Success(fmt.Sprintf("Exported %d history entries to %s", len(entries), historyOutput))
+	return true
+}
+
+// validFormat reports whether format is one of history.ExportFormats
+func validFormat(format string) bool {
+	for _, known := range history.ExportFormats {
+		if format == known {
+			return true
+		}
+	}
+	return false
+}
+
+func pruneHistory() bool {
+	store := history.NewFromConfig()
+	if store == nil {
+		logger.Error("The history is disabled (HISTORY_FILE is empty)")
+		return false
+	}
+	var cutoff time.Time
+	if historyOlderThan != "" {
+		age, err := parseAge(historyOlderThan)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Invalid --older-than %q, use e.g. 12h or 30d", historyOlderThan))
+			return false
+		}
+		cutoff = time.Now().Add(-age)
+	}
+	removed, err := store.Prune(cutoff)
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+	if jsonOutput {
+		printJSON(map[string]interface{}{"path": store.Path(), "removed": removed})
+		return true
+	}
+	logger.Success(fmt.Sprintf("Removed %d history entries from %s", removed, store.Path()))
+	return true
+}
+
+func listShellSessions() bool {
+	store := history.NewFromConfig()
+	if store == nil {
+		logger.Error("The history is disabled (HISTORY_FILE is empty)")
+		return false
+	}
+	sessions, err := store.Sessions()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read history: %v", err))
+		return false
+	}
+
+	if jsonOutput {
+		if sessions == nil {
+			sessions = []history.Session{}
+		}
+		printJSON(sessions)
+		return true
+	}
+	if len(sessions) == 0 {
+		fmt.Fprintln(os.Stderr, "No sessions recorded yet")
+		return true
+	}
+	current := history.CurrentSession()
+	fmt.Printf("%-12s %-17s %-17s %7s %8s %5s\n", "SESSION", "START", "END", "ENTRIES", "FAILURES", "FIXED")
+	for _, session := range sessions {
+		id := session.ID
+		if id == current {
+			id += "*"
+		}
+		fmt.Printf("%-12s %-17s %-17s %7d %8d %5d\n", id, session.Start.Local().Format("2006-01-02 15:04"), session.End.Local().Format("2006-01-02 15:04"), session.Entries, session.Failures, session.Fixed)
+	}
+	return true
+}
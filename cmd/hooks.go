@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/hooks"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Explain failed git hooks",
+	Long: `Wrap the git hooks of the current repository so that when one fails, such as
+a pre-commit linter or a pre-push test run, LogAid reads its output and prints
+the suggested fix right in the git output. The hook itself still runs as
+before and decides whether the commit or push goes ahead; LogAid never asks
+anything or executes a fix from a hook.
+
+The existing hook is kept as <hook>` + hooks.OriginalSuffix + ` and put back by
+'logaid hooks uninstall'.`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install [hook]...",
+	Short: "Wrap git hooks (pre-commit and pre-push by default)",
+	Example: `  logaid hooks install
+  logaid hooks install commit-msg`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !changeHooks(args, true) {
+			exit(1)
+		}
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall [hook]...",
+	Short: "Remove the wrappers and restore the original hooks (all by default)",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !changeHooks(args, false) {
+			exit(1)
+		}
+	},
+}
+
+var hooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show which git hooks are wrapped",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !listHooks() {
+			exit(1)
+		}
+	},
+}
+
+var hooksRunCmd = &cobra.Command{
+	Use:    "run <hook> [args...]",
+	Short:  "Run the original hook and explain its failure (called by the wrapper)",
+	Hidden: true,
+	Args:   cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runHook(args[0], args[1:])
+	},
+}
+
+func init() {
+	hooksRunCmd.Flags().SetInterspersed(false)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksListCmd)
+	hooksCmd.AddCommand(hooksRunCmd)
+}
+
+// changeHooks installs or uninstalls the named hooks. Without names it
+// installs the default hooks or uninstalls every wrapped one
+func changeHooks(names []string, install bool) bool {
+	for _, name := range names {
+		if err := hooks.Check(name); err != nil {
+			logger.Error(err.Error())
+			return false
+		}
+	}
+	dir, err := hooks.Dir()
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+	explicit := len(names) > 0
+	if !explicit {
+		names = hooks.Default
+		if !install {
+			names = hooks.Supported
+		}
+	}
+
+	statuses := []hooks.Status{}
+	for _, name := range names {
+		if !install && !hooks.Get(dir, name).Installed {
+			if explicit {
+				logger.Info(fmt.Sprintf("%s is not wrapped by LogAid", name))
+			}
+			continue
+		}
+		change := hooks.Uninstall
+		if install {
+			change = hooks.Install
+		}
+		status, err := change(dir, name)
+		if err != nil {
+			logger.Error(err.Error())
+			return false
+		}
+		statuses = append(statuses, status)
+	}
+	if jsonOutput {
+		printJSON(statuses)
+		return true
+	}
+
+	for _, status := range statuses {
+		switch {
+		case install && status.Original:
+			logger.Success(fmt.Sprintf("Wrapped %s (the original is kept as %s%s)", status.Name, status.Name, hooks.OriginalSuffix))
+		case install:
+			logger.Success(fmt.Sprintf("Installed %s", status.Name))
+		case status.Original:
+			logger.Success(fmt.Sprintf("Restored the original %s", status.Name))
+		default:
+			logger.Success(fmt.Sprintf("Removed %s", status.Name))
+		}
+	}
+	return true
+}
+
+func listHooks() bool {
+	dir, err := hooks.Dir()
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+	var statuses []hooks.Status
+	for _, name := range hooks.Supported {
+		statuses = append(statuses, hooks.Get(dir, name))
+	}
+	if jsonOutput {
+		printJSON(statuses)
+		return true
+	}
+
+	fmt.Printf("Hooks in %s:\n", dir)
+	for _, status := range statuses {
+		state := "-"
+		switch {
+		case status.Installed && status.Original:
+			state = "wrapped"
+		case status.Installed:
+			state = "installed (no hook of its own)"
+		}
+		fmt.Printf("  %-20s %s\n", status.Name, state)
+	}
+	return true
+}
+
+// runHook runs the original hook with its arguments and standard input and
+// suggests a fix when it fails, exiting with the hook's exit code
+func runHook(name string, args []string) {
+	dir, err := hooks.Dir()
+	if err != nil {
+		logger.Error(err.Error())
+		exit(1)
+	}
+	original := hooks.Original(dir, name)
+	if _, err := os.Stat(original); err != nil {
+		return
+	}
+
+	// There is nobody to ask, and a hook must not change the repository
+	for key, value := range map[string]bool{"ASSUME_YES": false, "AUTO_CONFIRM": false, "ASSUME_NO": true} {
+		if err := config.Override(key, value); err != nil {
+			logger.Debug(fmt.Sprintf("Failed to apply hook mode: %v", err))
+		}
+	}
+
+	cmd := exec.Command(original, args...)
+	cmd.Env = os.Environ()
+	cmd.Stdin = os.Stdin // pre-push reads the refs being pushed
+	report, err := newEngine().Run(cmd, nil)
+	if err != nil {
+		if report.ExitCode > 0 {
+			exit(report.ExitCode)
+		}
+		exit(1)
+	}
+}
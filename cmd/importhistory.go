@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var importHistoryCmd = &cobra.Command{
+	Use:   "import-history <file>...",
+	Short: "Learn the commands you use from a shell history file",
+	Long: `Read a bash, zsh or fish history file into the personal typo model, so typos
+are corrected to the tools, subcommands, branches and packages you actually
+use from the start, e.g. with a history copied from another machine. The
+format is detected, and timestamps are read from bash HISTTIMEFORMAT
+comments, zsh extended history and fish history.
+
+Commands retyped right after a typo, such as "gti status" followed by
+"git status", are learned as corrections; see them with 'logaid dict list'.
+Importing the same file again replaces what was learned from it.`,
+	Example: `  logaid import-history ~/.bash_history
+  logaid import-history ~/.zsh_history ~/.local/share/fish/fish_history`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !importHistory(args) {
+			exit(1)
+		}
+	},
+}
+
+func importHistory(paths []string) bool {
+	store := dictStore()
+	if store == nil {
+		return false
+	}
+
+	var imports []*model.Import
+	for _, path := range paths {
+		imported, err := store.Import(path)
+		if err != nil {
+			logger.Error(err.Error())
+			return false
+		}
+		imports = append(imports, imported)
+	}
+	if jsonOutput {
+		printJSON(imports)
+		return true
+	}
+
+	for _, imported := range imports {
+		span := ""
+		if !imported.First.IsZero() {
+			span = fmt.Sprintf(", %s to %s", imported.First.Local().Format("2006-01-02"), imported.Last.Local().Format("2006-01-02"))
+		}
+		logger.Success(fmt.Sprintf("Imported %d commands from %s (%s%s)", imported.Commands, imported.Path, imported.Format, span))
+
+		var tools []string
+		for _, usage := range imported.Tools {
+			tools = append(tools, fmt.Sprintf("%s (%d)", usage.Word, usage.Count))
+		}
+		if len(tools) > 0 {
+			fmt.Printf("  Most used: %s\n", strings.Join(tools, ", "))
+		}
+		if len(imported.Fixes) > 0 {
+			fmt.Printf("  Learned %d correction(s):\n", len(imported.Fixes))
+		}
+		for _, fix := range imported.Fixes {
+			fmt.Printf("    [%s] %s -> %s (%d)\n", contextLabel(fix.Context), fix.From, fix.To, fix.Count)
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/daemon"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var logLevelCmd = &cobra.Command{
+	Use:   "log-level [debug|info|warn|error]",
+	Short: "Show or change the log level of the running daemon",
+	Long: `Change the log level of the running daemon without restarting it, e.g. to
+capture debug traces while reproducing a problem, then set it back. Without an
+argument the current level is shown. The level lasts until the daemon
+restarts or LOG_LEVEL changes in the configuration.
+
+Other long-running LogAid processes (logaid exec, watch, serve and the daemon)
+switch to debug logging when they receive SIGHUP, and back to LOG_LEVEL on the
+next one:
+
+  kill -HUP <pid>`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: config.LogLevels,
+	Run: func(cmd *cobra.Command, args []string) {
+		level := ""
+		if len(args) == 1 {
+			level = args[0]
+		}
+		if !setDaemonLogLevel(level) {
+			exit(1)
+		}
+	},
+}
+
+func setDaemonLogLevel(level string) bool {
+	path := daemon.SocketPath()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := daemon.NewClient(path)
+	if _, err := client.Status(ctx); path == "" || err != nil {
+		logger.Error("The daemon is not running; send SIGHUP to toggle debug logging in other LogAid processes")
+		return false
+	}
+	current, err := client.SetLogLevel(ctx, level)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to set the daemon's log level: %v", err))
+		return false
+	}
+
+	if jsonOutput {
+		printJSON(map[string]string{"log_level": current})
+	} else if level == "" {
+		fmt.Println(current)
+	} else {
+		logger.Success(fmt.Sprintf("Daemon log level set to %s", current))
+	}
+	return true
+}
+
+// toggleDebugOnHangup switches between debug logging and LOG_LEVEL each time
+// the process receives SIGHUP, until ctx is done
+func toggleDebugOnHangup(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hangup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hangup:
+				level := logger.ToggleDebug(config.Current().LogLevel)
+				logger.Info(fmt.Sprintf("Received SIGHUP, log level set to %s", level))
+			}
+		}
+	}()
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsLevel  string
+	logsSince  string
+	logsPlugin string
+	logsGrep   string
+	logsLimit  int
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Query LogAid's own log",
+	Long: `Show messages from LogAid's log file (LOG_FILE) without having to find or
+parse it, newest last.
+
+  --level    lowest level shown: debug, info, warn or error
+  --since    a duration such as 30m, 2h or 7d, or a date (2006-01-02) or time
+             (2006-01-02T15:04:05)
+  --plugin   messages that name the plugin, e.g. git
+  --grep     messages matching a regular expression
+
+With --json the matching entries are printed as a JSON array.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !queryLogs() {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	logsCmd.Flags().StringVar(&logsLevel, "level", "", "Lowest level shown: debug, info, warn or error")
+	logsCmd.Flags().StringVar(&logsSince, "since", "", "Only messages newer than a duration (2h, 7d) or a date")
+	logsCmd.Flags().StringVar(&logsPlugin, "plugin", "", "Only messages that name this plugin")
+	logsCmd.Flags().StringVar(&logsGrep, "grep", "", "Only messages matching this regular expression")
+	logsCmd.Flags().IntVarP(&logsLimit, "limit", "n", 100, "Number of messages to show, 0 for all")
+}
+
+func queryLogs() bool {
+	filter, ok := logsFilter()
+	if !ok {
+		return false
+	}
+	path := logger.Path()
+	if path == "" {
+		path = config.Current().LogFile
+	}
+	entries, err := logger.Query(path, filter)
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+	if logsLimit > 0 && len(entries) > logsLimit {
+		entries = entries[len(entries)-logsLimit:]
+	}
+
+	if jsonOutput {
+		if entries == nil {
+			entries = []logger.Entry{}
+		}
+		printJSON(entries)
+		return true
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "No matching messages in %s\n", path)
+		return true
+	}
+	for _, entry := range entries {
+		level := fmt.Sprintf("%-7s", strings.ToUpper(entry.Level))
+		fmt.Printf("%s %s %s\n", entry.Time.Format("2006-01-02 15:04:05"), levelColor(entry.Level).Sprint(level), entry.Message)
+	}
+	return true
+}
+
+// logsFilter builds the filter from the flags
+func logsFilter() (logger.Filter, bool) {
+	filter := logger.Filter{Level: strings.ToLower(logsLevel), Plugin: logsPlugin}
+	if filter.Level != "" && !knownLevel(filter.Level) {
+		logger.Error(fmt.Sprintf("Unknown level %q, use one of %s", logsLevel, strings.Join(config.LogLevels, ", ")))
+		return filter, false
+	}
+	if logsSince != "" {
+		since, err := parseSince(logsSince, time.Now())
+		if err != nil {
+			logger.Error(fmt.Sprintf("Invalid --since %q, use e.g. 2h, 7d or 2006-01-02", logsSince))
+			return filter, false
+		}
+		filter.Since = since
+	}
+	if logsGrep != "" {
+		pattern, err := regexp.Compile(logsGrep)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Invalid --grep pattern: %v", err))
+			return filter, false
+		}
+		filter.Grep = pattern
+	}
+	return filter, true
+}
+
+// knownLevel reports whether level is one of the LOG_LEVEL values
+func knownLevel(level string) bool {
+	for _, known := range config.LogLevels {
+		if level == known {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSince reads --since: an age such as 2h or 7d before now, or a local
+// date or time
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if age, err := parseAge(s); err == nil {
+		return now.Add(-age), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// levelColor returns the color messages of level are shown in
+func levelColor(level string) *color.Color {
+	switch level {
+	case "debug":
+		return logger.DebugColor
+	case "warn":
+		return logger.WarnColor
+	case "error":
+		return logger.ErrorColor
+	case "success":
+		return logger.SuccessColor
+	}
+	return logger.InfoColor
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/lsp"
+	"github.com/spf13/cobra"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Serve fixes to editors as a language server over stdio",
+	Long: `Run a Language Server Protocol server on stdin and stdout for editor
+extensions such as a VS Code or Neovim plugin. Open a terminal buffer as a
+document and every failed command in it is reported as a diagnostic on its
+first error line; the code actions for it run a suggested fix or dismiss it.
+
+A command starts at a prompt line ("user@host:~$ npm start", "# apt install
+nginx", "❯ git pul") and its output runs to the next prompt. Running a fix
+(` + lsp.CommandApplyFix + `) returns {"command": "..."} for the editor to send to the
+terminal; configuration changes are made by LogAid. Messages are logged to
+stderr.`,
+	Example: `  -- Neovim
+  vim.lsp.start({ name = "logaid", cmd = { "logaid", "lsp" } })`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serveLSP()
+	},
+}
+
+func init() {
+	// Language clients such as vscode-languageclient pass --stdio
+	lspCmd.Flags().Bool("stdio", true, "Talk over stdin and stdout (the only transport)")
+}
+
+func serveLSP() {
+	// stdout carries the protocol
+	logger.SetConsole(os.Stderr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	eng := engine.New()
+	if err := eng.Watch(ctx); err != nil {
+		logger.Debug(fmt.Sprintf("Not watching plugins: %v", err))
+	}
+	if err := lsp.NewServer(eng, version).Serve(ctx, os.Stdin, os.Stdout); err != nil {
+		logger.Error(fmt.Sprintf("Language server failed: %v", err))
+		exit(1)
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve LogAid as Model Context Protocol tools over stdio",
+	Long: `Run an MCP server on stdin and stdout so AI agents such as Claude Desktop or
+IDE copilots can call LogAid's tools:
+
+  suggest_fix     ranked fixes for a failed command
+  analyze_error   the error lines in some output and the plugins that know them
+  list_plugins    the tools whose errors LogAid can fix
+
+Register it with your agent as the command 'logaid mcp'. Messages are
+logged to stderr.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serveMCP()
+	},
+}
+
+func serveMCP() {
+	// stdout carries the protocol
+	logger.SetConsole(os.Stderr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	eng := engine.New()
+	if err := eng.Watch(ctx); err != nil {
+		logger.Debug(fmt.Sprintf("Not watching plugins: %v", err))
+	}
+	if err := mcp.NewServer(eng, version).Serve(ctx, os.Stdin, os.Stdout); err != nil {
+		logger.Error(fmt.Sprintf("MCP server failed: %v", err))
+		exit(1)
+	}
+}
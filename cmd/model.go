@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var modelShowLimit int
+
+var modelCmd = &cobra.Command{
+	Use:   "model",
+	Short: "Inspect the personal typo model",
+	Long: `LogAid learns which commands, branches and packages you actually use from
+your shell history and accepted fixes, and prefers them when correcting typos.`,
+}
+
+var modelShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show learned corrections and most used words",
+	Run: func(cmd *cobra.Command, args []string) {
+		showModel()
+	},
+}
+
+var modelRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Rescan shell history now",
+	Run: func(cmd *cobra.Command, args []string) {
+		refreshModel()
+	},
+}
+
+func init() {
+	modelShowCmd.Flags().IntVarP(&modelShowLimit, "limit", "n", 5, "Words to show per context")
+	modelCmd.AddCommand(modelShowCmd)
+	modelCmd.AddCommand(modelRefreshCmd)
+}
+
+func showModel() {
+	store := model.NewFromConfig()
+	if store == nil {
+		logger.Warn("Personal model is disabled (PERSONAL_MODEL=false)")
+		return
+	}
+
+	m, err := store.Load()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load personal model: %v", err))
+		return
+	}
+
+	fmt.Printf("Personal model: %s\n", store.Path())
+	if !m.HistoryUpdated.IsZero() {
+		fmt.Printf("History scanned: %s\n", m.HistoryUpdated.Format("2006-01-02 15:04"))
+	}
+
+	fmt.Println("\nAccepted corrections:")
+	if len(m.Fixes) == 0 {
+		fmt.Println("  (none yet)")
+	}
+	for _, fix := range m.Fixes {
+		fmt.Printf("  [%s] %s -> %s (%d)\n", contextLabel(fix.Context), fix.From, fix.To, fix.Count)
+	}
+
+	fmt.Println("\nMost used:")
+	current := "\x00"
+	for _, usage := range m.Top(modelShowLimit) {
+		if usage.Context != current {
+			current = usage.Context
+			fmt.Printf("  %s:\n", contextLabel(current))
+		}
+		fmt.Printf("    %-30s %d\n", usage.Word, usage.Count)
+	}
+}
+
+func refreshModel() {
+	store := model.NewFromConfig()
+	if store == nil {
+		logger.Warn("Personal model is disabled (PERSONAL_MODEL=false)")
+		return
+	}
+
+	if _, err := store.Refresh(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to refresh personal model: %v", err))
+		return
+	}
+	logger.Success(fmt.Sprintf("Personal model updated: %s", store.Path()))
+}
+
+func contextLabel(context string) string {
+	if context == "" {
+		return "commands"
+	}
+	return context
+}
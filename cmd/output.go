@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+var (
+	// jsonOutput makes exec, explain, stats and watch print JSON on stdout instead
+	// of text; messages go to stderr
+	jsonOutput bool
+
+	// Non-interactive mode: --yes, --no and --quiet
+	assumeYes bool
+	assumeNo  bool
+	quiet     bool
+)
+
+// applyOutputFlags lets --yes, --no and --quiet override ASSUME_YES,
+// ASSUME_NO, QUIET and AUTO_CONFIRM from the environment and config files
+func applyOutputFlags() {
+	overrides := map[string]bool{}
+	switch {
+	case assumeYes:
+		overrides["ASSUME_YES"], overrides["ASSUME_NO"] = true, false
+	case assumeNo:
+		overrides["ASSUME_YES"], overrides["ASSUME_NO"] = false, true
+	}
+	if quiet {
+		overrides["QUIET"] = true
+	}
+	for key, value := range overrides {
+		if err := config.Override(key, value); err != nil {
+			logger.Debug(fmt.Sprintf("Failed to apply flag: %v", err))
+		}
+	}
+
+	if cfg := config.Current(); cfg != nil {
+		logger.SetQuiet(cfg.Quiet)
+		applyTheme()
+	}
+}
+
+// applyTheme colors messages with THEME and the COLOR_* keys
+func applyTheme() {
+	cfg := config.Current()
+	logger.SetColors(cfg.EnableColors)
+	t, err := cfg.ColorTheme()
+	if err == nil {
+		err = logger.SetTheme(t)
+	}
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to apply theme: %v", err))
+	}
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		logger.Error(fmt.Sprintf("Failed to encode JSON: %v", err))
+	}
+}
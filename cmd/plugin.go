@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Install plugins and rule packs from the registry",
+	Long: `Fetch rule packs, scripts and plugin binaries listed in PLUGIN_REGISTRY into
+PLUGINS_DIR. Every file is checked against the SHA-256 checksum in the
+registry index before it is installed.`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List plugins available in the registry",
+	Run: func(cmd *cobra.Command, args []string) {
+		listPlugins()
+	},
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <name>...",
+	Short: "Install plugins from the registry",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !installPlugins(args) {
+			exit(1)
+		}
+	},
+}
+
+var pluginUpdateCmd = &cobra.Command{
+	Use:   "update [name]...",
+	Short: "Update installed plugins to the registry version",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !updatePlugins(args) {
+			exit(1)
+		}
+	},
+}
+
+var pluginRemoveCmd = &cobra.Command{
+	Use:   "remove <name>...",
+	Short: "Remove plugins installed from the registry",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !removePlugins(args) {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInstallCmd)
+	pluginCmd.AddCommand(pluginUpdateCmd)
+	pluginCmd.AddCommand(pluginRemoveCmd)
+}
+
+func listPlugins() {
+	reg, err := registry.NewFromConfig()
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	index, err := reg.Index(context.Background())
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read plugin registry: %v", err))
+		return
+	}
+	installed, err := reg.Installed()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read installed plugins: %v", err))
+		return
+	}
+
+	for _, entry := range index.Plugins {
+		status := ""
+		if current, ok := installed[entry.Name]; ok {
+			status = "installed"
+			if current.Version != entry.Version {
+				status = fmt.Sprintf("installed %s, update available", current.Version)
+			}
+		}
+		fmt.Printf("%-20s %-10s %-45s %s\n", entry.Name, entry.Version, entry.Description, status)
+	}
+}
+
+func installPlugins(names []string) bool {
+	reg, err := registry.NewFromConfig()
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+
+	ok := true
+	for _, name := range names {
+		entry, err := reg.Install(context.Background(), name)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to install %s: %v", name, err))
+			ok = false
+			continue
+		}
+		logger.Success(fmt.Sprintf("Installed %s %s into %s", entry.Name, entry.Version, reg.Dir))
+	}
+	return ok
+}
+
+func updatePlugins(names []string) bool {
+	reg, err := registry.NewFromConfig()
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+
+	updated, err := reg.Update(context.Background(), names)
+	for _, entry := range updated {
+		logger.Success(fmt.Sprintf("Updated %s to %s", entry.Name, entry.Version))
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to update plugins: %v", err))
+		return false
+	}
+	if len(updated) == 0 {
+		logger.Info("All plugins are up to date")
+	}
+	return true
+}
+
+func removePlugins(names []string) bool {
+	reg, err := registry.NewFromConfig()
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+
+	ok := true
+	for _, name := range names {
+		if err := reg.Remove(name); err != nil {
+			logger.Error(fmt.Sprintf("Failed to remove %s: %v", name, err))
+			ok = false
+			continue
+		}
+		logger.Success(fmt.Sprintf("Removed %s", name))
+	}
+	return ok
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/privacy"
+	"github.com/spf13/cobra"
+)
+
+var privacyExecAudit bool
+
+var privacyCmd = &cobra.Command{
+	Use:   "privacy",
+	Short: "Delete the commands and output LogAid stores",
+	Long: `LogAid keeps the commands it saw and their output in the history, the
+suggestion and registry caches, the log file, recorded sessions, the AI audit
+log and the outcomes and corrections it learned. Set RETENTION_DAYS to delete
+what is older than that automatically (checked once a day), or wipe it all
+with 'logaid privacy purge'.`,
+}
+
+var privacyPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete the history, caches, logs and everything learned from them",
+	Long: `Delete every file LogAid stored commands or their output in. The log file is
+emptied rather than removed. The exec audit log is kept unless --exec-audit is
+given, since it is the record of what LogAid ran on your behalf. Asks for
+confirmation unless --yes is given.`,
+	Example: `  logaid privacy purge
+  logaid privacy purge --yes --exec-audit`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !purgePrivacy() {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	privacyPurgeCmd.Flags().BoolVar(&privacyExecAudit, "exec-audit", false, "Also delete the exec audit log")
+	privacyCmd.AddCommand(privacyPurgeCmd)
+}
+
+func purgePrivacy() bool {
+	if !assumeYes {
+		if assumeNo {
+			logger.Warn("Not purging without confirmation (--no)")
+			return false
+		}
+		fmt.Fprintln(os.Stderr, "This deletes:")
+		for _, target := range privacy.Targets(privacyExecAudit) {
+			fmt.Fprintf(os.Stderr, "  %-22s %s\n", target.Name, target.Path)
+		}
+		fmt.Fprint(os.Stderr, "Continue? [y/N]: ")
+		input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if answer := strings.ToLower(strings.TrimSpace(input)); answer != "y" && answer != "yes" {
+			logger.Info("Nothing deleted")
+			return true
+		}
+	}
+
+	targets, err := privacy.Purge(privacyExecAudit)
+	if jsonOutput {
+		printJSON(targets)
+	}
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+	if jsonOutput {
+		return true
+	}
+	removed := 0
+	for _, target := range targets {
+		if target.Removed {
+			logger.Success(fmt.Sprintf("Deleted %s (%s)", target.Name, target.Path))
+			removed++
+		}
+	}
+	if removed == 0 {
+		logger.Info("Nothing stored, nothing to delete")
+	}
+	return true
+}
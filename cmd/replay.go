@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/session"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replaySpeed   float64
+	replayInstant bool
+	replayStep    bool
+	replayMaxIdle time.Duration
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay [session]",
+	Short: "Replay a recorded exec session",
+	Long: `Show a session recorded with 'logaid exec --record' (or RECORD_SESSIONS)
+again: the command's output with its original timing, LogAid's messages, and
+markers for the error detected, the suggestion, the decision and the fix.
+Without a session, list the recorded ones. 'last' replays the newest.
+
+Session files in SESSIONS_DIR are plain JSON and can be attached to bug
+reports or shared with teammates; replay one with its path.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			listSessions()
+			return
+		}
+		if !replaySession(args[0]) {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 1, "Playback speed, e.g. 2 for twice as fast")
+	replayCmd.Flags().BoolVar(&replayInstant, "instant", false, "Show everything at once, without the original timing")
+	replayCmd.Flags().BoolVar(&replayStep, "step", false, "Pause at each step LogAid took until Enter is pressed")
+	replayCmd.Flags().DurationVar(&replayMaxIdle, "max-idle", 2*time.Second, "Shorten longer pauses to this")
+}
+
+func listSessions() {
+	sessions, err := session.List(session.Dir())
+	if err != nil {
+		logger.Error(err.Error())
+		exit(1)
+	}
+	if jsonOutput {
+		printJSON(sessions)
+		return
+	}
+	if len(sessions) == 0 {
+		logger.Info("No recorded sessions yet; record one with 'logaid exec --record <command>'")
+		return
+	}
+	for _, s := range sessions {
+		outcome := ""
+		if event := s.Find(session.EventDecision); event != nil {
+			outcome = event.Data
+		}
+		fmt.Printf("%-28s %s %6.1fs  %-14s %s\n", s.ID, s.Started.Format("2006-01-02 15:04"), s.Duration, outcome, s.Command)
+	}
+}
+
+func replaySession(id string) bool {
+	dir := session.Dir()
+	if id == "last" {
+		sessions, err := session.List(dir)
+		if err != nil || len(sessions) == 0 {
+			logger.Error("No recorded sessions yet")
+			return false
+		}
+		id = sessions[0].ID
+	}
+	s, err := session.Load(dir, id)
+	if err != nil {
+		logger.Error(err.Error())
+		return false
+	}
+	if jsonOutput {
+		printJSON(s)
+		return true
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	player := &session.Player{Out: os.Stdout, Speed: replaySpeed, MaxIdle: replayMaxIdle}
+	if replayInstant {
+		player.Speed = 0
+	}
+	if replayStep {
+		input := bufio.NewReader(os.Stdin)
+		player.Step = func(event session.Event) error {
+			fmt.Fprint(os.Stderr, "[Enter to continue, q to quit] ")
+			answer, err := input.ReadString('\n')
+			if err != nil || strings.TrimSpace(answer) == "q" {
+				return errStopReplay
+			}
+			return nil
+		}
+	}
+
+	color.New(color.Bold).Printf("$ %s", s.Command)
+	fmt.Printf("  (recorded %s)\n", s.Started.Format("2006-01-02 15:04:05"))
+	err = player.Play(ctx, s)
+	if err != nil && !errors.Is(err, errStopReplay) && ctx.Err() == nil {
+		logger.Error(fmt.Sprintf("Failed to replay session: %v", err))
+		return false
+	}
+	return true
+}
+
+// errStopReplay ends a replay the user quit
+var errStopReplay = errors.New("replay stopped")
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/report"
+	"github.com/ayushsharma-1/LogAid/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportID      int64
+	reportSession string
+	reportFormat  string
+	reportOutput  string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a shareable report of the last failure",
+	Long: `Write a report of a failed command — the command, its output, the environment
+and the fixes LogAid suggested with their outcome — ready to paste into a
+GitHub issue or send to a teammate.
+
+The report describes the last suggestion in the history, the history entry
+given with --id, or a session recorded with 'logaid exec --record' given with
+--session (which also includes the exit code and output of the whole run).
+
+Secrets are scrubbed automatically: API keys and tokens, passwords in URLs,
+flags and assignments, private keys, and your home directory, user name and
+host name. Review the report before sharing it.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !writeReport() {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.Flags().Int64Var(&reportID, "id", 0, "ID of the history entry to report instead of the last one")
+	reportCmd.Flags().StringVar(&reportSession, "session", "", "Report a recorded session (ID, path or 'last') instead")
+	reportCmd.Flags().StringVar(&reportFormat, "format", "markdown", "Report format: markdown or html")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Write the report to a file instead of stdout")
+}
+
+func writeReport() bool {
+	if reportFormat != "markdown" && reportFormat != "html" {
+		logger.Error(fmt.Sprintf("Unknown format %q, use markdown or html", reportFormat))
+		return false
+	}
+
+	r, ok := loadReport()
+	if !ok {
+		return false
+	}
+	r.AddEnvironment(version)
+	r.Redact()
+
+	if jsonOutput {
+		printJSON(r)
+		return true
+	}
+
+	text := r.Markdown()
+	if reportFormat == "html" {
+		var err error
+		if text, err = r.HTML(); err != nil {
+			logger.Error(err.Error())
+			return false
+		}
+	}
+
+	if reportOutput == "" {
+		fmt.Print(text)
+		return true
+	}
+	if err := os.WriteFile(reportOutput, []byte(text), 0600); err != nil {
+		logger.Error(fmt.Sprintf("Failed to write report: %v", err))
+		return false
+	}
+	logger.Success(fmt.Sprintf("Report written to %s", reportOutput))
+	return true
+}
+
+// loadReport describes the failure chosen with --session or --id, or the last
+// one in the history
+func loadReport() (*report.Report, bool) {
+	if reportSession != "" {
+		dir := session.Dir()
+		id := reportSession
+		if id == "last" {
+			sessions, err := session.List(dir)
+			if err != nil || len(sessions) == 0 {
+				logger.Error("No recorded sessions yet")
+				return nil, false
+			}
+			id = sessions[0].ID
+		}
+		s, err := session.Load(dir, id)
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, false
+		}
+		return report.FromSession(s), true
+	}
+
+	store := history.NewFromConfig()
+	if store == nil {
+		logger.Error("History is disabled (HISTORY_FILE is empty)")
+		return nil, false
+	}
+	entries, err := store.List(0)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read history: %v", err))
+		return nil, false
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if reportID == 0 || entries[i].ID == reportID {
+			return report.FromHistory(entries[i], entries), true
+		}
+	}
+	if reportID != 0 {
+		logger.Error(fmt.Sprintf("No history entry with ID %d", reportID))
+	} else {
+		logger.Info("No failures in history yet")
+	}
+	return nil, false
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/configedit"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var revertCmd = &cobra.Command{
+	Use:   "revert",
+	Short: "Revert the last configuration file change applied by LogAid",
+	Long: `Restore the configuration file (e.g. ~/.npmrc or ~/.gitconfig) changed by the
+most recently applied LogAid suggestion from its backup.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		revertConfigEdit()
+	},
+}
+
+func revertConfigEdit() {
+	path, err := configedit.RevertLast()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to revert: %v", err))
+		return
+	}
+
+	logger.Success(fmt.Sprintf("Restored %s", path))
+}
@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 
 	"github.com/ayushsharma-1/LogAid/internal/config"
 	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/privacy"
+	"github.com/ayushsharma-1/LogAid/internal/telemetry"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +19,16 @@ var rootCmd = &cobra.Command{
 	Long: `LogAid is a CLI-first AI assistant that intercepts shell commands and error logs 
 in real time, identifies mistakes (typos, wrong package names, syntax errors, etc.), 
 and suggests or auto-applies corrections with user confirmation.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		applyOutputFlags()
+		if jsonOutput {
+			// Keep stdout for the JSON document
+			logger.SetConsole(os.Stderr)
+		}
+		if err := privacy.Enforce(); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to apply RETENTION_DAYS: %v", err))
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		showLogo()
 		startInteractiveShell()
@@ -23,21 +36,93 @@ and suggests or auto-applies corrections with user confirmation.`,
 }
 
 func Execute() error {
+	if err := telemetry.Init(context.Background(), version); err != nil {
+		logger.Warn(fmt.Sprintf("Not exporting telemetry: %v", err))
+	}
+	defer shutdownTelemetry()
 	return rootCmd.Execute()
 }
 
+// shutdownTelemetry exports the remaining spans and metrics
+func shutdownTelemetry() {
+	if err := telemetry.Shutdown(context.Background()); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to export telemetry: %v", err))
+	}
+}
+
+// exit exits with code after writing out the buffered log and telemetry,
+// which os.Exit would drop
+func exit(code int) {
+	shutdownTelemetry()
+	logger.Flush()
+	os.Exit(code)
+}
+
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print JSON instead of text (exec, explain, stats, watch)")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Execute suggestions without asking (ASSUME_YES)")
+	rootCmd.PersistentFlags().BoolVar(&assumeNo, "no", false, "Only show suggestions, never execute them (ASSUME_NO)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Hide informational messages and the logo (QUIET)")
+	rootCmd.MarkFlagsMutuallyExclusive("yes", "no")
+
 	rootCmd.AddCommand(execCmd)
+	rootCmd.AddCommand(ciCmd)
+	rootCmd.AddCommand(hooksCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(revertCmd)
+	rootCmd.AddCommand(modelCmd)
+	rootCmd.AddCommand(dictCmd)
+	rootCmd.AddCommand(importHistoryCmd)
+	rootCmd.AddCommand(aiCmd)
+	rootCmd.AddCommand(explainCmd)
+	rootCmd.AddCommand(feedbackCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(privacyCmd)
+	rootCmd.AddCommand(pluginCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(tuiCmd)
+	rootCmd.AddCommand(daemonCmd)
+	rootCmd.AddCommand(logLevelCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(mcpCmd)
+	rootCmd.AddCommand(lspCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(genDocsCmd)
+}
+
+// ToleratesInvalidConfig reports whether the command args select can run
+// with an invalid configuration: the config commands and doctor, which are
+// how it gets fixed
+func ToleratesInvalidConfig(args []string) bool {
+	command, _, err := rootCmd.Find(args)
+	if err != nil {
+		return false
+	}
+	for ; command != nil; command = command.Parent() {
+		if command == configCmd || command == doctorCmd {
+			return true
+		}
+	}
+	return false
 }
 
 func showLogo() {
+	cfg := config.Current()
+	if logger.Quiet() {
+		return
+	}
 	logoFile := "assets/logo.txt"
 	if _, err := os.Stat(logoFile); err == nil {
 		content, err := ioutil.ReadFile(logoFile)
 		if err == nil {
-			if config.AppConfig != nil && config.AppConfig.EnableColors {
+			if cfg != nil && cfg.EnableColors {
 				logger.InfoColor.Println(string(content))
 			} else {
 				fmt.Println(string(content))
@@ -55,7 +140,7 @@ func showLogo() {
              |___/              
        LogAid CLI Companion      
 `
-	if config.AppConfig != nil && config.AppConfig.EnableColors {
+	if cfg != nil && cfg.EnableColors {
 		logger.InfoColor.Println(logo)
 	} else {
 		fmt.Println(logo)
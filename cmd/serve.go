@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/api"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr     string
+	serveGRPCAddr string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve suggestions over HTTP and gRPC APIs",
+	Long: `Expose LogAid's suggestion pipeline over HTTP on API_ADDR:
+
+  POST /v1/suggest   {"command": ..., "output": ..., "context": ...}
+                     returns the ranked suggestions for a failed command
+  GET  /v1/history   the suggestion history, newest first (?limit=N)
+  GET  /v1/health    whether the server is up; needs no token
+
+and over gRPC on GRPC_ADDR, with the Suggest, StreamSuggest and History
+calls defined in api/logaid/v1/logaid.proto.
+
+Requests must send API_TOKEN as 'Authorization: Bearer <token>'. Without a
+token the server only listens on localhost.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serveAPI()
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "", "HTTP address to listen on (default API_ADDR)")
+	serveCmd.Flags().StringVar(&serveGRPCAddr, "grpc-addr", "", "gRPC address to listen on (default GRPC_ADDR)")
+}
+
+func serveAPI() {
+	cfg := config.Current()
+	addr, grpcAddr, token := "127.0.0.1:8765", "", ""
+	if cfg != nil {
+		addr, grpcAddr, token = cfg.APIAddr, cfg.GRPCAddr, cfg.APIToken
+	}
+	if serveAddr != "" {
+		addr = serveAddr
+	}
+	if serveGRPCAddr != "" {
+		grpcAddr = serveGRPCAddr
+	}
+	for _, a := range []string{addr, grpcAddr} {
+		if a != "" && token == "" && !api.IsLoopback(a) {
+			logger.Error(fmt.Sprintf("Refusing to serve on %s without API_TOKEN; set one or listen on localhost", a))
+			exit(1)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	toggleDebugOnHangup(ctx)
+
+	eng := engine.New()
+	if err := eng.Watch(ctx); err != nil {
+		logger.Warn(fmt.Sprintf("Not watching plugins: %v", err))
+	}
+	apiServer := api.NewServer(eng, history.NewFromConfig(), token)
+
+	if grpcAddr != "" {
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to listen on %s: %v", grpcAddr, err))
+			exit(1)
+		}
+		grpcServer := apiServer.NewGRPC()
+		go func() {
+			<-ctx.Done()
+			grpcServer.GracefulStop()
+		}()
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Error(fmt.Sprintf("gRPC server failed: %v", err))
+				stop()
+			}
+		}()
+		logger.Info(fmt.Sprintf("LogAid gRPC API listening on %s", grpcAddr))
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           apiServer.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		server.Shutdown(shutdown)
+	}()
+
+	logger.Info(fmt.Sprintf("LogAid API listening on http://%s", addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error(fmt.Sprintf("API server failed: %v", err))
+		exit(1)
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/metrics"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsPlugins bool
+	statsReset   bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how suggestions and plugins perform",
+	Long: `Summarize the suggestion history by source and outcome. With --plugins, show
+how often each plugin matched, how often its suggestions were accepted and
+worked, and how long its calls take.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		switch {
+		case statsReset:
+			resetPluginStats()
+		case statsPlugins:
+			showPluginStats()
+		default:
+			showSuggestionStats()
+		}
+	},
+}
+
+// suggestionStats is the history summary printed by 'logaid --json stats'
+type suggestionStats struct {
+	Total   int                    `json:"total"`
+	Since   time.Time              `json:"since"`
+	Sources map[string]sourceStats `json:"sources"`
+}
+
+// sourceStats counts the outcomes of one source's suggestions
+type sourceStats struct {
+	Applied  int `json:"applied"`
+	Failed   int `json:"failed"`
+	Rejected int `json:"rejected"`
+	Pending  int `json:"pending"`
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsPlugins, "plugins", false, "Show per-plugin metrics")
+	statsCmd.Flags().BoolVar(&statsReset, "reset", false, "Delete the recorded plugin metrics")
+}
+
+func showSuggestionStats() {
+	store := history.NewFromConfig()
+	if store == nil {
+		logger.Error("History is disabled (HISTORY_FILE is empty)")
+		return
+	}
+
+	entries, err := store.List(0)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read history: %v", err))
+		return
+	}
+	if len(entries) == 0 {
+		logger.Info("No suggestions in history yet")
+		return
+	}
+
+	counts := map[string]map[string]int{}
+	for _, entry := range entries {
+		if counts[entry.Source] == nil {
+			counts[entry.Source] = map[string]int{}
+		}
+		counts[entry.Source][entry.Status]++
+	}
+	sources := make([]string, 0, len(counts))
+	for source := range counts {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	if jsonOutput {
+		summary := suggestionStats{Total: len(entries), Since: entries[0].Time, Sources: map[string]sourceStats{}}
+		for source, c := range counts {
+			summary.Sources[source] = sourceStats{
+				Applied: c[history.StatusApplied], Failed: c[history.StatusFailed],
+				Rejected: c[history.StatusRejected], Pending: c[history.StatusProposed],
+			}
+		}
+		printJSON(summary)
+		return
+	}
+
+	fmt.Printf("%d suggestions since %s\n\n", len(entries), entries[0].Time.Format("2006-01-02"))
+	fmt.Printf("%-20s %8s %8s %8s %8s\n", "SOURCE", "APPLIED", "FAILED", "REJECTED", "PENDING")
+	for _, source := range sources {
+		c := counts[source]
+		fmt.Printf("%-20s %8d %8d %8d %8d\n", source, c[history.StatusApplied], c[history.StatusFailed], c[history.StatusRejected], c[history.StatusProposed])
+	}
+}
+
+func showPluginStats() {
+	store := metrics.NewFromConfig()
+	if store == nil {
+		logger.Warn("Plugin metrics are disabled (PLUGIN_METRICS=false)")
+		return
+	}
+
+	all, since, err := store.Load()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to read plugin metrics: %v", err))
+		return
+	}
+	if len(all) == 0 {
+		logger.Info("No plugin metrics recorded yet")
+		return
+	}
+
+	if jsonOutput {
+		printJSON(struct {
+			Since   time.Time             `json:"since"`
+			Plugins []metrics.PluginStats `json:"plugins"`
+		}{since, all})
+		return
+	}
+
+	fmt.Printf("Plugin metrics since %s (%s)\n\n", since.Format("2006-01-02"), store.Path())
+	fmt.Printf("%-16s %8s %8s %9s %8s %10s %10s %9s\n", "PLUGIN", "MATCHES", "SUGGEST", "ACCEPTED", "WORKED", "AVG", "MAX", "TIMEOUTS")
+	for _, p := range all {
+		fmt.Printf("%-16s %8d %8d %8.0f%% %7.0f%% %10s %10s %9d\n",
+			p.Plugin, p.Matches, p.Suggestions, p.AcceptanceRate()*100, p.SuccessRate()*100,
+			p.AverageLatency().Round(time.Microsecond), p.MaxLatency.Round(time.Microsecond), p.Timeouts)
+	}
+}
+
+func resetPluginStats() {
+	store := metrics.NewFromConfig()
+	if store == nil {
+		logger.Warn("Plugin metrics are disabled (PLUGIN_METRICS=false)")
+		return
+	}
+	if err := store.Reset(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to reset plugin metrics: %v", err))
+		return
+	}
+	logger.Success("Plugin metrics reset")
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/tui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [command]",
+	Short: "Run a command in a full-screen terminal UI",
+	Long: `Run a command in a full-screen terminal UI that shows its output, the errors
+detected in it and the ranked suggestions, which can be applied, edited or
+dismissed. Without a command, browse the suggestion history.
+
+Keys: ↑/↓ select, enter apply, e edit, d dismiss, h history, q quit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runTUI(args)
+	},
+}
+
+func init() {
+	// Flags after the command belong to it, as with exec
+	tuiCmd.Flags().SetInterspersed(false)
+}
+
+func runTUI(args []string) {
+	// Log messages and fixes would draw over the screen; they go to the
+	// output pane, and to the log file only while plugins load
+	console := logger.Console()
+	logger.SetConsole(io.Discard)
+	eng := newEngine()
+	model := tui.New(eng, history.NewFromConfig(), strings.Fields(strings.Join(args, " ")))
+	eng.SetIO(nil, model.Output(), model.Output())
+	logger.SetConsole(model.Output())
+
+	_, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
+	logger.SetConsole(console)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to run TUI: %v", err))
+		exit(1)
+	}
+	if model.Failed() {
+		exit(1)
+	}
+}
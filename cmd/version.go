@@ -6,12 +6,15 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// version is the LogAid release
+const version = "1.0.0"
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number of LogAid",
 	Long:  `Print the version number of LogAid`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("LogAid v1.0.0")
+		fmt.Printf("LogAid v%s\n", version)
 		fmt.Println("AI-powered Linux CLI assistant")
 		fmt.Println("Built with ❤️  in Go")
 	},
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/logwatch"
+	"github.com/ayushsharma-1/LogAid/internal/notify"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchUnit      string
+	watchFromStart bool
+	watchWebhook   string
+	watchCooldown  time.Duration
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [file]",
+	Short: "Tail a log and suggest fixes for the errors in it",
+	Long: `Follow a log file, or the journal of a systemd unit with --unit, and print a
+suggestion for every new error that appears in it. Suggestions are never
+executed. Repeats of an error are ignored for --cooldown, and with --webhook
+each one is also posted as JSON, or as NOTIFY_WEBHOOK_TEMPLATE renders it,
+so LogAid can keep an eye on a service. Errors and suggestions are also
+sent to the channels NOTIFY_RULES routes them to, such as Slack or Discord.`,
+	Example: `  logaid watch /var/log/nginx/error.log
+  logaid watch --unit nginx --webhook https://example.com/hook`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if watchUnit != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("give a log file to watch, or a systemd unit with --unit")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		watchLog(args)
+	},
+}
+
+func init() {
+	watchCmd.Flags().StringVarP(&watchUnit, "unit", "u", "", "Follow the journal of this systemd unit instead of a file")
+	watchCmd.Flags().BoolVar(&watchFromStart, "from-start", false, "Read the file from the beginning instead of only new lines")
+	watchCmd.Flags().StringVar(&watchWebhook, "webhook", "", "Also POST each suggestion as JSON to this URL")
+	watchCmd.Flags().DurationVar(&watchCooldown, "cooldown", 10*time.Minute, "Ignore repeats of an error for this long")
+}
+
+func watchLog(args []string) {
+	name, read := watchUnit, logwatch.Journal(watchUnit)
+	if watchUnit == "" {
+		name, read = args[0], logwatch.File(args[0], watchFromStart)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	toggleDebugOnHangup(ctx)
+
+	var webhook notify.Channel
+	if watchWebhook != "" {
+		webhook = notify.NewWebhook(watchWebhook)
+	}
+
+	watcher := logwatch.New(newEngine(), name)
+	watcher.Cooldown = watchCooldown
+	logger.Info(fmt.Sprintf("Watching %s for errors (Ctrl+C to stop)", name))
+	err := watcher.Run(ctx, read, func(finding logwatch.Finding) {
+		printFinding(finding)
+		if webhook != nil {
+			if err := webhook.Send(ctx, findingEvent(finding)); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to post to webhook: %v", err))
+			}
+		}
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to watch %s: %v", name, err))
+		exit(1)
+	}
+}
+
+// printFinding shows an error from the log and its suggestion, or writes
+// it as a line of JSON with --json
+func printFinding(finding logwatch.Finding) {
+	if jsonOutput {
+		if err := json.NewEncoder(os.Stdout).Encode(finding); err != nil {
+			logger.Error(fmt.Sprintf("Failed to encode JSON: %v", err))
+		}
+		return
+	}
+
+	logger.Warn(fmt.Sprintf("Error in %s: %s", finding.Log, finding.Line))
+	switch {
+	case finding.Error != "":
+		logger.Error(fmt.Sprintf("Failed to get a suggestion: %s", finding.Error))
+	case finding.Suggestion == nil:
+		logger.Info("No suggestion found")
+	default:
+		logger.Suggestion(fmt.Sprintf("💡 %s (from %s)", finding.Suggestion.Text(), finding.Suggestion.Source))
+		for _, alternative := range finding.Suggestion.Alternatives {
+			logger.Info(fmt.Sprintf("   or %s", alternative))
+		}
+	}
+}
+
+// findingEvent describes finding as a notification for the webhook
+func findingEvent(finding logwatch.Finding) notify.Event {
+	event := notify.Event{Type: notify.EventErrorDetected, Command: finding.Log, Output: finding.Output, Time: finding.Time}
+	if finding.Suggestion != nil {
+		event.Type = notify.EventFixProposed
+		event.Suggestion = finding.Suggestion.Text()
+		event.Source = finding.Suggestion.Source
+	}
+	return event
+}
@@ -0,0 +1,121 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// Usage is the token accounting reported by the provider
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// AuditRecord is one line of the AI audit log. The prompt itself is never
+// stored, only its SHA-256, since it may contain command output with secrets.
+// Secrets in the response are masked unless MASK_SECRETS is off.
+type AuditRecord struct {
+	Time         time.Time `json:"time"`
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	PromptSHA256 string    `json:"prompt_sha256"`
+	Response     []string  `json:"response,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	LatencyMS    int64     `json:"latency_ms"`
+	Usage
+}
+
+// pruned remembers which audit logs had their retention applied by this process
+var (
+	pruned   = make(map[string]bool)
+	prunedMu sync.Mutex
+)
+
+// audit appends a record for an AI call when AI_AUDIT_LOG is enabled
+func (c *AIClient) audit(prompt string, responses []string, usage Usage, latency time.Duration, callErr error) {
+	cfg := config.Current()
+	if cfg == nil || !cfg.AIAuditLog || cfg.AIAuditLogFile == "" {
+		return
+	}
+	path := cfg.AIAuditLogFile
+
+	sum := sha256.Sum256([]byte(prompt))
+	record := AuditRecord{
+		Time:         time.Now().UTC(),
+		Provider:     c.Provider,
+		Model:        c.Model,
+		PromptSHA256: hex.EncodeToString(sum[:]),
+		Response:     responses,
+		LatencyMS:    latency.Milliseconds(),
+		Usage:        usage,
+	}
+	if callErr != nil {
+		record.Error = callErr.Error()
+	}
+	// Answers often repeat the command, credentials included
+	if cfg.MaskSecrets {
+		record.Response = make([]string, len(responses))
+		for i, response := range responses {
+			record.Response[i] = redact.Secrets(response)
+		}
+		record.Error = redact.Secrets(record.Error)
+	}
+
+	if err := WriteAuditRecord(path, record, cfg.AIAuditRetentionDays); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to write AI audit log: %v", err))
+	}
+}
+
+// WriteAuditRecord appends record to the audit log at path. The first write
+// of each process drops records older than retentionDays (0 keeps all).
+func WriteAuditRecord(path string, record AuditRecord, retentionDays int) error {
+	prunedMu.Lock()
+	needsPrune := !pruned[path] && retentionDays > 0
+	pruned[path] = true
+	prunedMu.Unlock()
+
+	if needsPrune {
+		cutoff := time.Now().Add(-time.Duration(retentionDays) * 24 * time.Hour)
+		if err := PruneAuditLog(path, cutoff); err != nil {
+			return err
+		}
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	return state.AppendLine(path, line)
+}
+
+// PruneAuditLog removes records older than cutoff
+func PruneAuditLog(path string, cutoff time.Time) error {
+	return state.Update(path, 0600, func(data []byte) ([]byte, error) {
+		var kept bytes.Buffer
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+		for scanner.Scan() {
+			var record AuditRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err == nil && record.Time.Before(cutoff) {
+				continue
+			}
+			kept.Write(scanner.Bytes())
+			kept.WriteByte('\n')
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+		return kept.Bytes(), nil
+	})
+}
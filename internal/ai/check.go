@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Providers lists the AI providers LogAid can talk to
+var Providers = []string{"gemini", "openai", "llama"}
+
+// checkPrompt is a tiny request used to measure round-trip latency
+const checkPrompt = "Command: gti status\nError: gti: command not found\nProvide a corrected command:"
+
+// CheckResult is the outcome of a provider connectivity check
+type CheckResult struct {
+	Provider string
+	Model    string
+	Models   []string // nil when the provider does not support listing
+	Latency  time.Duration
+	Warning  string
+	Err      error
+}
+
+// Passed reports whether the provider is usable
+func (r CheckResult) Passed() bool {
+	return r.Err == nil
+}
+
+// Check validates the client's API key by listing models, then measures the
+// latency of a small completion request
+func Check(ctx context.Context, client *AIClient) CheckResult {
+	result := CheckResult{Provider: client.Provider, Model: client.Model}
+
+	models, err := client.ListModels(ctx)
+	if err != nil {
+		result.Err = fmt.Errorf("API key check failed: %w", err)
+		return result
+	}
+	result.Models = models
+	if models != nil && !contains(models, client.Model) {
+		result.Warning = fmt.Sprintf("model %s is not in the list of available models", client.Model)
+	}
+
+	start := time.Now()
+	if _, err := client.GenerateSuggestion(ctx, checkPrompt); err != nil {
+		result.Err = fmt.Errorf("completion failed: %w", err)
+		return result
+	}
+	result.Latency = time.Since(start)
+
+	return result
+}
+
+// ListModels returns the model names available to the API key. Listing
+// also validates the key without spending tokens.
+func (c *AIClient) ListModels(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	switch c.Provider {
+	case "gemini":
+		var resp struct {
+			Models []struct {
+				Name string `json:"name"`
+			} `json:"models"`
+		}
+		if err := c.getJSON(ctx, fmt.Sprintf("%s?key=%s", c.BaseURL, c.APIKey), &resp); err != nil {
+			return nil, err
+		}
+		var models []string
+		for _, m := range resp.Models {
+			models = append(models, strings.TrimPrefix(m.Name, "models/"))
+		}
+		return models, nil
+	case "openai":
+		var resp struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		url := strings.TrimSuffix(c.BaseURL, "/chat/completions") + "/models"
+		if err := c.getJSON(ctx, url, &resp); err != nil {
+			return nil, err
+		}
+		var models []string
+		for _, m := range resp.Data {
+			models = append(models, m.ID)
+		}
+		return models, nil
+	case "llama":
+		if _, err := loadLlama(c.modelPath); err != nil {
+			return nil, err
+		}
+		return []string{c.Model}, nil
+	case "mock":
+		return []string{"mock"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported AI provider: %s", c.Provider)
+	}
+}
+
+// getJSON performs an authenticated GET request and decodes the response
+func (c *AIClient) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.Provider == "openai" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	}
+
+	resp, err := c.httpClientOrDefault().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -8,11 +8,15 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ayushsharma-1/LogAid/internal/config"
 	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // AIClient represents the AI service client
@@ -22,40 +26,96 @@ type AIClient struct {
 	Model    string
 	BaseURL  string
 	Timeout  time.Duration
+
+	httpClient *http.Client
+	mock       *MockProvider
+	limiter    *RateLimiter
+	modelPath  string // GGUF file for the llama provider
+}
+
+var (
+	defaultClient *AIClient
+	clientMu      sync.Mutex
+)
+
+// Default returns the lazily-initialized shared AI client. It returns nil if
+// the client cannot be configured; initialization is retried on the next call.
+func Default() *AIClient {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if defaultClient == nil {
+		defaultClient = NewAIClient()
+	}
+	return defaultClient
+}
+
+// Reset discards the shared client so the next call to Default re-reads the
+// configuration
+func Reset() {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	defaultClient = nil
+	resetTransport()
 }
 
 // NewAIClient creates a new AI client based on configuration
 func NewAIClient() *AIClient {
+	return NewAIClientFor(ConfiguredProvider())
+}
+
+// ConfiguredProvider returns the AI provider selected by AI_PROVIDER
+func ConfiguredProvider() string {
+	cfg := config.Current()
 	var provider string
 
 	// Use config if available, otherwise fall back to environment variables
-	if config.AppConfig != nil {
-		provider = config.AppConfig.AIProvider
-		if provider == "" {
-			provider = "gemini" // default
-		}
+	if cfg != nil {
+		provider = cfg.AIProvider
 	} else {
 		provider = os.Getenv("AI_PROVIDER")
-		if provider == "" {
-			provider = "gemini" // default
-		}
 	}
+	if provider == "" {
+		provider = "gemini" // default
+	}
+	return provider
+}
 
+// NewAIClientFor creates a client for the given provider using its configured
+// API key and model. It returns nil if the provider is unsupported or has no key.
+func NewAIClientFor(provider string) *AIClient {
+	cfg := config.Current()
 	timeout := 15 * time.Second
-	if config.AppConfig != nil && config.AppConfig.AIRequestTimeout > 0 {
-		timeout = time.Duration(config.AppConfig.AIRequestTimeout) * time.Second
+	if cfg != nil && cfg.AIRequestTimeout > 0 {
+		timeout = time.Duration(cfg.AIRequestTimeout) * time.Second
+	}
+
+	if useMock(provider) {
+		fixtures := os.Getenv("MOCK_AI_FIXTURES")
+		if cfg != nil {
+			fixtures = cfg.MockAIFixtures
+		}
+
+		mock, err := NewMockProvider(fixtures)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to initialize mock AI provider: %v", err))
+			return nil
+		}
+		return &AIClient{Provider: "mock", Model: "mock", Timeout: timeout, mock: mock}
 	}
 
 	client := &AIClient{
-		Provider: provider,
-		Timeout:  timeout,
+		Provider:   provider,
+		Timeout:    timeout,
+		httpClient: &http.Client{Timeout: timeout, Transport: sharedTransport()},
+		limiter:    rateLimiterFromConfig(),
 	}
 
 	switch provider {
 	case "gemini":
-		if config.AppConfig != nil {
-			client.APIKey = config.AppConfig.GeminiAPIKey
-			client.Model = config.AppConfig.GeminiModel
+		if cfg != nil {
+			client.APIKey = cfg.GeminiAPIKey
+			client.Model = cfg.GeminiModel
 		} else {
 			client.APIKey = os.Getenv("GEMINI_API_KEY")
 			client.Model = os.Getenv("GEMINI_MODEL")
@@ -65,9 +125,9 @@ func NewAIClient() *AIClient {
 		}
 		client.BaseURL = "https://generativelanguage.googleapis.com/v1beta/models"
 	case "openai":
-		if config.AppConfig != nil {
-			client.APIKey = config.AppConfig.OpenAIAPIKey
-			client.Model = config.AppConfig.OpenAIModel
+		if cfg != nil {
+			client.APIKey = cfg.OpenAIAPIKey
+			client.Model = cfg.OpenAIModel
 		} else {
 			client.APIKey = os.Getenv("OPENAI_API_KEY")
 			client.Model = os.Getenv("OPENAI_MODEL")
@@ -76,6 +136,20 @@ func NewAIClient() *AIClient {
 			client.Model = "gpt-4o"
 		}
 		client.BaseURL = "https://api.openai.com/v1/chat/completions"
+	case "llama":
+		if !LlamaSupported() {
+			logger.Error(ErrLlamaUnavailable.Error())
+			return nil
+		}
+		client.modelPath = llamaModelPath()
+		if client.modelPath == "" {
+			logger.Error("LLAMA_MODEL_PATH is not set for provider: llama")
+			return nil
+		}
+		client.Model = filepath.Base(client.modelPath)
+		// Local inference is free and offline, so it is never rate limited
+		client.limiter = nil
+		return client
 	default:
 		logger.Error(fmt.Sprintf("Unsupported AI provider: %s", provider))
 		return nil
@@ -89,9 +163,21 @@ func NewAIClient() *AIClient {
 	return client
 }
 
+// useMock reports whether the mock provider should be used instead of a real API
+func useMock(provider string) bool {
+	cfg := config.Current()
+	if provider == "mock" {
+		return true
+	}
+	if cfg != nil {
+		return cfg.MockAIResponses || cfg.TestMode
+	}
+	return os.Getenv("MOCK_AI_RESPONSES") == "true" || os.Getenv("TEST_MODE") == "true"
+}
+
 // GetSuggestion generates a command suggestion using AI
 func GetSuggestion(ctx context.Context, prompt string) (string, error) {
-	client := NewAIClient()
+	client := Default()
 	if client == nil {
 		return "", fmt.Errorf("failed to initialize AI client")
 	}
@@ -99,25 +185,171 @@ func GetSuggestion(ctx context.Context, prompt string) (string, error) {
 	return client.GenerateSuggestion(ctx, prompt)
 }
 
+// GetSuggestionFor generates a command suggestion on behalf of plugin, using
+// its system prompt override if one is configured
+func GetSuggestionFor(ctx context.Context, plugin, prompt string) (string, error) {
+	client := Default()
+	if client == nil {
+		return "", fmt.Errorf("failed to initialize AI client")
+	}
+
+	suggestions, err := client.generateSuggestions(ctx, plugin, prompt, 1)
+	if err != nil {
+		return "", err
+	}
+	return suggestions[0], nil
+}
+
+// GetSuggestions asks the AI for up to n alternative command suggestions
+func GetSuggestions(ctx context.Context, prompt string, n int) ([]string, error) {
+	client := Default()
+	if client == nil {
+		return nil, fmt.Errorf("failed to initialize AI client")
+	}
+
+	return client.GenerateSuggestions(ctx, prompt, n)
+}
+
+// explainSystemPrompt instructs the model to explain an error and its fix
+const explainSystemPrompt = "You are a Linux command-line expert. Explain command errors and their fixes concisely in plain language."
+
+// Explain asks the AI why command failed with output and why suggestion fixes it
+func Explain(ctx context.Context, command, output, suggestion string) (string, error) {
+	client := Default()
+	if client == nil {
+		return "", fmt.Errorf("failed to initialize AI client")
+	}
+
+	prompt := fmt.Sprintf("Command: %s\nError: %s\nSuggested fix: %s\nExplain in a few sentences why the error happened and why the suggested fix resolves it.", command, TruncateOutput(output), suggestion)
+	return client.GenerateText(ctx, SystemPrompt(explainSystemPrompt, ""), prompt)
+}
+
+// httpClientOrDefault returns the client's HTTP client, falling back to one built on the
+// shared transport for clients constructed without NewAIClient
+func (c *AIClient) httpClientOrDefault() *http.Client {
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: c.Timeout, Transport: sharedTransport()}
+	}
+	return c.httpClient
+}
+
 // GenerateSuggestion generates a suggestion using the configured AI provider
 func (c *AIClient) GenerateSuggestion(ctx context.Context, prompt string) (string, error) {
+	suggestions, err := c.GenerateSuggestions(ctx, prompt, 1)
+	if err != nil {
+		return "", err
+	}
+	return suggestions[0], nil
+}
+
+// GenerateSuggestions asks the provider for n candidate completions (OpenAI
+// "n", Gemini "candidateCount") and returns the distinct commands among them
+func (c *AIClient) GenerateSuggestions(ctx context.Context, prompt string, n int) ([]string, error) {
+	return c.generateSuggestions(ctx, "", prompt, n)
+}
+
+// generateSuggestions requests n candidates with the system prompt of plugin,
+// or the global one when plugin is empty
+func (c *AIClient) generateSuggestions(ctx context.Context, plugin, prompt string, n int) ([]string, error) {
+	responses, err := c.complete(ctx, SystemPrompt(commandSystemPrompt, plugin), prompt, n, commandSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, response := range responses {
+		// Clean up the response to extract just the command
+		responses[i] = c.extractCommand(response)
+		logger.Debug(fmt.Sprintf("AI suggestion: %s", responses[i]))
+	}
+
+	suggestions := dedupe(responses)
+	if len(suggestions) == 0 {
+		return nil, fmt.Errorf("no response from AI")
+	}
+	return suggestions, nil
+}
+
+// GenerateText returns the provider's free-form answer to prompt, e.g. an
+// explanation, without extracting a command from it
+func (c *AIClient) GenerateText(ctx context.Context, system, prompt string) (string, error) {
+	responses, err := c.complete(ctx, system, prompt, 1, nil)
+	if err != nil {
+		return "", err
+	}
+	return responses[0], nil
+}
+
+// complete sends prompt to the provider and returns the raw text of up to n
+// candidates. Providers with a JSON mode constrain their answers to schema
+// when it is set.
+func (c *AIClient) complete(ctx context.Context, system, prompt string, n int, schema *GeminiSchema) ([]string, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
 	defer cancel()
 
+	if n < 1 {
+		n = 1
+	}
+
+	if c.Provider != "mock" && !c.limiter.Allow() {
+		logger.Warn(fmt.Sprintf("AI rate limit of %d requests/minute reached, using plugin quick-fixes only", c.limiter.PerMinute()))
+		return nil, ErrRateLimited
+	}
+
+	ctx, span := telemetry.Start(ctx, "ai.complete",
+		attribute.String("gen_ai.system", c.Provider), attribute.String("gen_ai.request.model", c.Model), attribute.Int("logaid.candidates", n))
+	start := time.Now()
+	var responses []string
+	var usage Usage
+	var err error
 	switch c.Provider {
 	case "gemini":
-		return c.callGemini(ctx, prompt)
+		responses, usage, err = c.callGemini(ctx, system, prompt, n, schema)
 	case "openai":
-		return c.callOpenAI(ctx, prompt)
+		responses, usage, err = c.callOpenAI(ctx, system, prompt, n)
+	case "llama":
+		responses, usage, err = c.callLlama(ctx, system, prompt)
+	case "mock":
+		if c.mock == nil {
+			c.mock, _ = NewMockProvider("")
+		}
+		responses = []string{c.mock.Respond(prompt)}
 	default:
-		return "", fmt.Errorf("unsupported AI provider: %s", c.Provider)
+		err = fmt.Errorf("unsupported AI provider: %s", c.Provider)
+		telemetry.End(span, err)
+		return nil, err
+	}
+
+	latency := time.Since(start)
+	c.audit(system+"\n"+prompt, responses, usage, latency, err)
+	span.SetAttributes(attribute.Int("gen_ai.usage.input_tokens", usage.PromptTokens), attribute.Int("gen_ai.usage.output_tokens", usage.CompletionTokens))
+	telemetry.AIRequest(ctx, c.Provider, c.Model, latency, usage.PromptTokens, usage.CompletionTokens, err != nil)
+	telemetry.End(span, err)
+	return responses, err
+}
+
+// dedupe drops empty and repeated suggestions, ignoring whitespace differences
+func dedupe(suggestions []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, s := range suggestions {
+		key := strings.Join(strings.Fields(s), " ")
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, s)
 	}
+	return unique
 }
 
+// commandSystemPrompt instructs the model to answer with a command only
+const commandSystemPrompt = "You are a Linux command-line expert. Provide only the corrected command, no explanations."
+
 // GeminiRequest represents the request structure for Gemini API
 type GeminiRequest struct {
-	Contents         []GeminiContent        `json:"contents"`
-	GenerationConfig GeminiGenerationConfig `json:"generationConfig"`
+	SystemInstruction *GeminiContent         `json:"systemInstruction,omitempty"`
+	Contents          []GeminiContent        `json:"contents"`
+	GenerationConfig  GeminiGenerationConfig `json:"generationConfig"`
 }
 
 type GeminiContent struct {
@@ -133,11 +365,37 @@ type GeminiGenerationConfig struct {
 	MaxOutputTokens int     `json:"maxOutputTokens"`
 	TopP            float64 `json:"topP"`
 	TopK            int     `json:"topK"`
+	CandidateCount  int     `json:"candidateCount,omitempty"`
+
+	ResponseMimeType string        `json:"responseMimeType,omitempty"`
+	ResponseSchema   *GeminiSchema `json:"responseSchema,omitempty"`
+}
+
+// GeminiSchema is the OpenAPI subset Gemini accepts as a response schema
+type GeminiSchema struct {
+	Type        string                   `json:"type"`
+	Description string                   `json:"description,omitempty"`
+	Properties  map[string]*GeminiSchema `json:"properties,omitempty"`
+	Required    []string                 `json:"required,omitempty"`
+}
+
+// commandSchema describes a CommandResponse
+var commandSchema = &GeminiSchema{
+	Type: "OBJECT",
+	Properties: map[string]*GeminiSchema{
+		"command":     {Type: "STRING", Description: "The corrected shell command, without markdown"},
+		"explanation": {Type: "STRING", Description: "One short sentence on what was wrong"},
+	},
+	Required: []string{"command", "explanation"},
 }
 
 // GeminiResponse represents the response structure from Gemini API
 type GeminiResponse struct {
-	Candidates []GeminiCandidate `json:"candidates"`
+	Candidates    []GeminiCandidate `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
 }
 
 type GeminiCandidate struct {
@@ -145,8 +403,8 @@ type GeminiCandidate struct {
 	FinishReason string        `json:"finishReason"`
 }
 
-// callGemini makes a request to the Gemini API
-func (c *AIClient) callGemini(ctx context.Context, prompt string) (string, error) {
+// callGemini makes a request to the Gemini API for n candidates
+func (c *AIClient) callGemini(ctx context.Context, system, prompt string, n int, schema *GeminiSchema) ([]string, Usage, error) {
 	url := fmt.Sprintf("%s/%s:generateContent?key=%s", c.BaseURL, c.Model, c.APIKey)
 
 	requestBody := GeminiRequest{
@@ -164,47 +422,57 @@ func (c *AIClient) callGemini(ctx context.Context, prompt string) (string, error
 			TopK:            10,
 		},
 	}
+	if system != "" {
+		requestBody.SystemInstruction = &GeminiContent{Parts: []GeminiPart{{Text: system}}}
+	}
+	if n > 1 {
+		requestBody.GenerationConfig.CandidateCount = n
+	}
+	if schema != nil {
+		requestBody.GenerationConfig.ResponseMimeType = "application/json"
+		requestBody.GenerationConfig.ResponseSchema = schema
+	}
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, Usage{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, Usage{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: c.Timeout}
-	resp, err := client.Do(req)
+	resp, err := c.httpClientOrDefault().Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return nil, Usage{}, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, Usage{}, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var geminiResp GeminiResponse
 	if err := json.NewDecoder(resp.Body).Decode(&geminiResp); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return nil, Usage{}, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("no response from AI")
+	var responses []string
+	for _, candidate := range geminiResp.Candidates {
+		if len(candidate.Content.Parts) > 0 {
+			responses = append(responses, strings.TrimSpace(candidate.Content.Parts[0].Text))
+		}
 	}
 
-	suggestion := strings.TrimSpace(geminiResp.Candidates[0].Content.Parts[0].Text)
-
-	// Clean up the response to extract just the command
-	suggestion = c.extractCommand(suggestion)
-
-	logger.Debug(fmt.Sprintf("AI suggestion: %s", suggestion))
-	return suggestion, nil
+	if len(responses) == 0 {
+		return nil, Usage{}, fmt.Errorf("no response from AI")
+	}
+	usage := Usage{PromptTokens: geminiResp.UsageMetadata.PromptTokenCount, CompletionTokens: geminiResp.UsageMetadata.CandidatesTokenCount}
+	return responses, usage, nil
 }
 
 // OpenAIRequest represents the request structure for OpenAI API
@@ -213,6 +481,7 @@ type OpenAIRequest struct {
 	Messages    []OpenAIMessage `json:"messages"`
 	Temperature float64         `json:"temperature"`
 	MaxTokens   int             `json:"max_tokens"`
+	N           int             `json:"n,omitempty"`
 }
 
 type OpenAIMessage struct {
@@ -223,6 +492,10 @@ type OpenAIMessage struct {
 // OpenAIResponse represents the response structure from OpenAI API
 type OpenAIResponse struct {
 	Choices []OpenAIChoice `json:"choices"`
+	Usage   struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
 }
 
 type OpenAIChoice struct {
@@ -230,14 +503,14 @@ type OpenAIChoice struct {
 	FinishReason string        `json:"finish_reason"`
 }
 
-// callOpenAI makes a request to the OpenAI API
-func (c *AIClient) callOpenAI(ctx context.Context, prompt string) (string, error) {
+// callOpenAI makes a request to the OpenAI API for n choices
+func (c *AIClient) callOpenAI(ctx context.Context, system, prompt string, n int) ([]string, Usage, error) {
 	requestBody := OpenAIRequest{
 		Model: c.Model,
 		Messages: []OpenAIMessage{
 			{
 				Role:    "system",
-				Content: "You are a Linux command-line expert. Provide only the corrected command, no explanations.",
+				Content: system,
 			},
 			{
 				Role:    "user",
@@ -247,84 +520,94 @@ func (c *AIClient) callOpenAI(ctx context.Context, prompt string) (string, error
 		Temperature: 0.1,
 		MaxTokens:   500,
 	}
+	if n > 1 {
+		requestBody.N = n
+	}
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, Usage{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, Usage{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 
-	client := &http.Client{Timeout: c.Timeout}
-	resp, err := client.Do(req)
+	resp, err := c.httpClientOrDefault().Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return nil, Usage{}, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, Usage{}, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var openaiResp OpenAIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&openaiResp); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return nil, Usage{}, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	if len(openaiResp.Choices) == 0 {
-		return "", fmt.Errorf("no response from AI")
+		return nil, Usage{}, fmt.Errorf("no response from AI")
 	}
 
-	suggestion := strings.TrimSpace(openaiResp.Choices[0].Message.Content)
-
-	// Clean up the response to extract just the command
-	suggestion = c.extractCommand(suggestion)
+	var responses []string
+	for _, choice := range openaiResp.Choices {
+		responses = append(responses, strings.TrimSpace(choice.Message.Content))
+	}
+	usage := Usage{PromptTokens: openaiResp.Usage.PromptTokens, CompletionTokens: openaiResp.Usage.CompletionTokens}
+	return responses, usage, nil
+}
 
-	logger.Debug(fmt.Sprintf("AI suggestion: %s", suggestion))
-	return suggestion, nil
+// CommandResponse is the structured answer requested from providers that
+// support JSON output
+type CommandResponse struct {
+	Command     string `json:"command"`
+	Explanation string `json:"explanation"`
 }
 
-// extractCommand extracts the actual command from AI response
+// extractCommand extracts the actual command from AI response. Structured
+// responses are decoded; free text falls back to the first line of the first
+// code block, or the first line.
 func (c *AIClient) extractCommand(response string) string {
-	lines := strings.Split(response, "\n")
+	response = strings.TrimSpace(response)
 
+	var structured CommandResponse
+	if err := json.Unmarshal([]byte(response), &structured); err == nil && structured.Command != "" {
+		if structured.Explanation != "" {
+			logger.Debug(fmt.Sprintf("AI explanation: %s", structured.Explanation))
+		}
+		return strings.TrimSpace(structured.Command)
+	}
+
+	lines := strings.Split(response, "\n")
+	first := ""
+	inBlock := false
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
-
-		// Skip empty lines and explanations
-		if line == "" || strings.HasPrefix(line, "Explanation:") ||
-			strings.HasPrefix(line, "Note:") || strings.HasPrefix(line, "The") ||
-			strings.HasPrefix(line, "This") || strings.HasPrefix(line, "Here") {
+		if strings.HasPrefix(line, "```") {
+			inBlock = !inBlock
 			continue
 		}
-
-		// Remove markdown code block markers
-		if strings.HasPrefix(line, "```") {
+		if line == "" {
 			continue
 		}
-
-		// Look for actual command patterns
-		if strings.Contains(line, "sudo") || strings.Contains(line, "apt") ||
-			strings.Contains(line, "npm") || strings.Contains(line, "git") ||
-			strings.Contains(line, "docker") || strings.Contains(line, "pip") {
-			return line
+		if inBlock {
+			return strings.TrimPrefix(line, "$ ")
 		}
-	}
-
-	// If no command pattern found, return the first non-empty line
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" && !strings.HasPrefix(line, "```") {
-			return line
+		if first == "" {
+			first = line
 		}
 	}
 
+	if first != "" {
+		return strings.Trim(first, "`")
+	}
 	return response
 }
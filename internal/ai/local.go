@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// ErrLlamaUnavailable is returned by the llama provider in binaries built
+// without the llama build tag
+var ErrLlamaUnavailable = errors.New("LogAid was built without llama.cpp support; rebuild with -tags llama")
+
+// DefaultLlamaContextSize is the context window used when LLAMA_CONTEXT_SIZE is not set
+const DefaultLlamaContextSize = 2048
+
+// LocalModel generates completions in-process from a model file
+type LocalModel interface {
+	Predict(ctx context.Context, prompt string, maxTokens int) (string, error)
+}
+
+// openLlama loads a GGUF model. It is only set in builds with the llama tag,
+// so the default binary does not need cgo or the llama.cpp library.
+var openLlama func(path string, contextSize, threads int) (LocalModel, error)
+
+// The model is loaded once per process and shared, as loading takes seconds
+var (
+	llamaModel LocalModel
+	llamaPath  string
+	llamaMu    sync.Mutex
+)
+
+// LlamaSupported reports whether this binary can run local models
+func LlamaSupported() bool {
+	return openLlama != nil
+}
+
+// llamaModelPath returns the configured GGUF model path
+func llamaModelPath() string {
+	cfg := config.Current()
+	if cfg != nil {
+		return cfg.LlamaModelPath
+	}
+	return os.Getenv("LLAMA_MODEL_PATH")
+}
+
+// loadLlama returns the shared model for path, loading it on first use
+func loadLlama(path string) (LocalModel, error) {
+	cfg := config.Current()
+	if openLlama == nil {
+		return nil, ErrLlamaUnavailable
+	}
+
+	llamaMu.Lock()
+	defer llamaMu.Unlock()
+
+	if llamaModel != nil && llamaPath == path {
+		return llamaModel, nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to find model file: %w", err)
+	}
+
+	contextSize, threads := DefaultLlamaContextSize, 0
+	if cfg != nil {
+		if cfg.LlamaContextSize > 0 {
+			contextSize = cfg.LlamaContextSize
+		}
+		threads = cfg.LlamaThreads
+	}
+
+	model, err := openLlama(path, contextSize, threads)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load model %s: %w", filepath.Base(path), err)
+	}
+	llamaModel, llamaPath = model, path
+	return model, nil
+}
+
+// callLlama runs prompt through the local model. Sampling is near
+// deterministic, so a single completion is returned regardless of n.
+func (c *AIClient) callLlama(ctx context.Context, system, prompt string) ([]string, Usage, error) {
+	cfg := config.Current()
+	model, err := loadLlama(c.modelPath)
+	if err != nil {
+		return nil, Usage{}, err
+	}
+
+	maxTokens := 500
+	if cfg != nil && cfg.AIMaxTokens > 0 {
+		maxTokens = cfg.AIMaxTokens
+	}
+
+	text, err := model.Predict(ctx, llamaPrompt(system, prompt), maxTokens)
+	if err != nil {
+		return nil, Usage{}, fmt.Errorf("local inference failed: %w", err)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, Usage{}, fmt.Errorf("no response from AI")
+	}
+	return []string{text}, Usage{}, nil
+}
+
+// llamaPrompt formats system and prompt as a plain instruction prompt that
+// small instruction-tuned GGUF models follow reasonably well
+func llamaPrompt(system, prompt string) string {
+	var b strings.Builder
+	if system != "" {
+		b.WriteString("### System:\n")
+		b.WriteString(system)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("### User:\n")
+	b.WriteString(prompt)
+	b.WriteString("\n\n### Response:\n")
+	return b.String()
+}
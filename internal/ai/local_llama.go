@@ -0,0 +1,60 @@
+//go:build llama
+
+package ai
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	llama "github.com/go-skynet/go-llama.cpp"
+)
+
+func init() {
+	openLlama = newLlamaModel
+}
+
+// llamaCPP wraps a llama.cpp model; predictions are serialized because the
+// binding keeps a single inference state per model
+type llamaCPP struct {
+	mu      sync.Mutex
+	model   *llama.LLama
+	threads int
+}
+
+func newLlamaModel(path string, contextSize, threads int) (LocalModel, error) {
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+
+	model, err := llama.New(path, llama.SetContext(contextSize), llama.EnableF16Memory)
+	if err != nil {
+		return nil, err
+	}
+	return &llamaCPP{model: model, threads: threads}, nil
+}
+
+// Predict completes prompt, stopping early when ctx is cancelled
+func (m *llamaCPP) Predict(ctx context.Context, prompt string, maxTokens int) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	text, err := m.model.Predict(prompt,
+		llama.SetTokens(maxTokens),
+		llama.SetThreads(m.threads),
+		llama.SetTemperature(0.1),
+		llama.SetTopP(0.8),
+		llama.SetTopK(10),
+		llama.SetStopWords("###"),
+		llama.SetTokenCallback(func(string) bool {
+			return ctx.Err() == nil
+		}),
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return text, nil
+}
@@ -0,0 +1,83 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// MockResponse is a canned AI response returned when Pattern matches the prompt
+type MockResponse struct {
+	Pattern  string `json:"pattern"`
+	Response string `json:"response"`
+
+	re *regexp.Regexp
+}
+
+// MockProvider is a deterministic, offline AI backend for tests and demos
+type MockProvider struct {
+	responses []MockResponse
+}
+
+// defaultMockResponses cover the scenarios exercised by the test suite
+var defaultMockResponses = []MockResponse{
+	{Pattern: `(?s)Suggested fix: .*Explain`, Response: "The command failed because of the error shown above; the suggested fix addresses its cause."},
+	{Pattern: `(?i)merge conflict|automatic merge failed`, Response: "git status"},
+	{Pattern: `(?i)rebase conflict|could not apply`, Response: "git rebase --continue"},
+	{Pattern: `(?i)unmet dependencies|broken packages`, Response: "sudo apt --fix-broken install"},
+	{Pattern: `(?i)cannot connect to the docker daemon`, Response: "sudo systemctl start docker"},
+	{Pattern: `(?i)permission denied`, Response: "sudo !!"},
+	{Pattern: `(?i)command not found`, Response: "sudo apt install command-not-found"},
+}
+
+// commandLine extracts the failed command from the prompts built by the
+// engine and plugins
+var commandLine = regexp.MustCompile(`(?m)^\s*(?:-\s*User executed command|Command):\s*(.+)$`)
+
+// NewMockProvider creates a mock provider that consults the fixtures file (if
+// any) before the built-in responses
+func NewMockProvider(fixturesFile string) (*MockProvider, error) {
+	var responses []MockResponse
+
+	if fixturesFile != "" {
+		data, err := os.ReadFile(fixturesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mock fixtures: %w", err)
+		}
+		if err := json.Unmarshal(data, &responses); err != nil {
+			return nil, fmt.Errorf("failed to parse mock fixtures: %w", err)
+		}
+	}
+
+	responses = append(responses, defaultMockResponses...)
+	for i := range responses {
+		re, err := regexp.Compile(responses[i].Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mock pattern %q: %w", responses[i].Pattern, err)
+		}
+		responses[i].re = re
+	}
+
+	return &MockProvider{responses: responses}, nil
+}
+
+// Respond returns the first canned response whose pattern matches the prompt.
+// Without a match it echoes the failed command back, so callers always get a
+// non-empty suggestion.
+func (m *MockProvider) Respond(prompt string) string {
+	for _, r := range m.responses {
+		if r.re.MatchString(prompt) {
+			return r.Response
+		}
+	}
+
+	if match := commandLine.FindStringSubmatch(prompt); match != nil {
+		if cmd := strings.TrimSpace(match[1]); cmd != "" {
+			return cmd
+		}
+	}
+
+	return "echo 'LogAid mock suggestion'"
+}
@@ -0,0 +1,29 @@
+package ai
+
+import (
+	"os"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// SystemPrompt prepends the user's SYSTEM_PROMPT, or the plugin's
+// SYSTEM_PROMPT_<PLUGIN> override, to the built-in base instructions
+func SystemPrompt(base, plugin string) string {
+	cfg := config.Current()
+	custom := ""
+	if cfg != nil {
+		custom = cfg.SystemPrompt
+		if plugin != "" {
+			if override := cfg.PluginSystemPrompt(plugin); override != "" {
+				custom = override
+			}
+		}
+	} else {
+		custom = os.Getenv("SYSTEM_PROMPT")
+	}
+
+	if custom == "" {
+		return base
+	}
+	return custom + "\n\n" + base
+}
@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// ErrRateLimited is returned instead of calling the provider when the
+// client-side request budget is exhausted
+var ErrRateLimited = errors.New("AI rate limit reached")
+
+// RateLimiter is a token bucket shared by every LogAid process through a
+// state file, so a command failing in a shell loop cannot burn the quota
+type RateLimiter struct {
+	path      string
+	perMinute int
+	burst     int
+	Now       func() time.Time
+}
+
+// bucket is the persisted state of the token bucket
+type bucket struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// NewRateLimiter allows perMinute requests on average and up to burst at once
+func NewRateLimiter(path string, perMinute, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{path: path, perMinute: perMinute, burst: burst, Now: time.Now}
+}
+
+// rateLimiterFromConfig returns the limiter configured by AI_RATE_LIMIT, or
+// nil when rate limiting is disabled
+func rateLimiterFromConfig() *RateLimiter {
+	cfg := config.Current()
+	if cfg == nil || cfg.AIRateLimit <= 0 {
+		return nil
+	}
+	return NewRateLimiter(filepath.Join(config.Dir(), "ratelimit.json"), cfg.AIRateLimit, cfg.AIRateBurst)
+}
+
+// Allow takes a token from the bucket, reporting false when none is left.
+// State errors fail open so a broken file never blocks suggestions.
+func (l *RateLimiter) Allow() bool {
+	if l == nil {
+		return true
+	}
+
+	allowed := true
+	var b bucket
+	err := state.UpdateJSON(l.path, &b, func() error {
+		now := l.Now()
+		if b.Updated.IsZero() {
+			b.Tokens = float64(l.burst)
+		} else if elapsed := now.Sub(b.Updated); elapsed > 0 {
+			b.Tokens += elapsed.Minutes() * float64(l.perMinute)
+		}
+		if b.Tokens > float64(l.burst) {
+			b.Tokens = float64(l.burst)
+		}
+		b.Updated = now
+
+		if b.Tokens < 1 {
+			allowed = false
+			return nil
+		}
+		b.Tokens--
+		return nil
+	})
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Rate limiter unavailable: %v", err))
+		return true
+	}
+	return allowed
+}
+
+// PerMinute returns the configured request rate
+func (l *RateLimiter) PerMinute() int {
+	return l.perMinute
+}
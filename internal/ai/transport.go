@@ -0,0 +1,116 @@
+package ai
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+var (
+	transport   *http.Transport
+	transportMu sync.Mutex
+)
+
+// sharedTransport returns the transport reused by every AI client so
+// connections to the provider are kept alive between suggestions
+func sharedTransport() *http.Transport {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	if transport == nil {
+		transport = newTransport()
+	}
+	return transport
+}
+
+// resetTransport closes idle connections and discards the shared transport
+func resetTransport() {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	if transport != nil {
+		transport.CloseIdleConnections()
+		transport = nil
+	}
+}
+
+// newTransport builds an HTTP transport honouring HTTPS_PROXY/NO_PROXY, the
+// AI_PROXY_URL override and any extra CA bundle from the configuration
+func newTransport() *http.Transport {
+	cfg := config.Current()
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          10,
+		MaxIdleConnsPerHost:   4,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if cfg == nil {
+		return t
+	}
+
+	if cfg.AIProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.AIProxyURL)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Ignoring invalid AI_PROXY_URL: %v", err))
+		} else {
+			t.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	tlsConfig, err := newTLSConfig(cfg.AICABundle, cfg.AIInsecureSkipVerify)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Using system CA pool only: %v", err))
+	} else {
+		t.TLSClientConfig = tlsConfig
+	}
+
+	return t
+}
+
+// newTLSConfig returns a TLS config trusting the system pool plus the given
+// PEM bundle, optionally skipping verification altogether
+func newTLSConfig(caBundle string, insecure bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if insecure {
+		logger.Warn("AI_INSECURE_SKIP_VERIFY is enabled: TLS certificates of the AI provider are NOT verified")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if caBundle == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", caBundle)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,96 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// DefaultMaxOutputBytes bounds command output embedded in prompts when
+// AI_MAX_OUTPUT_BYTES is not set (roughly 2k tokens)
+const DefaultMaxOutputBytes = 8192
+
+// maxErrorLines is how many of the first error lines are kept
+const maxErrorLines = 10
+
+// maxLineBytes caps a single kept line (minified JS, progress bars...)
+const maxLineBytes = 300
+
+var errorLinePattern = regexp.MustCompile(`(?i)\b(error|fatal|failed|failure|exception|panic|cannot|denied|not found|undefined|unable)\b`)
+
+// TruncateOutput shrinks command output to the configured prompt budget
+func TruncateOutput(output string) string {
+	cfg := config.Current()
+	limit := DefaultMaxOutputBytes
+	if cfg != nil && cfg.AIMaxOutputBytes > 0 {
+		limit = cfg.AIMaxOutputBytes
+	}
+	return Truncate(output, limit)
+}
+
+// Truncate keeps output within limit bytes. Oversized output is replaced by
+// a summary line, the first error lines (where the failure usually starts)
+// and as much of the tail (where the final error usually is) as fits.
+func Truncate(output string, limit int) string {
+	if len(output) <= limit {
+		return output
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+
+	var errors []string
+	errorBudget := limit / 3
+	for _, line := range lines {
+		if len(errors) == maxErrorLines || errorBudget <= 0 {
+			break
+		}
+		if errorLinePattern.MatchString(line) {
+			line = clip(strings.TrimSpace(line), maxLineBytes)
+			errors = append(errors, line)
+			errorBudget -= len(line) + 1
+		}
+	}
+
+	summary := fmt.Sprintf("[output truncated: %d lines, %d bytes; showing first error lines and the last lines]", len(lines), len(output))
+
+	var b strings.Builder
+	b.WriteString(summary)
+	b.WriteString("\n")
+	if len(errors) > 0 {
+		b.WriteString("First errors:\n")
+		b.WriteString(strings.Join(errors, "\n"))
+		b.WriteString("\n...\n")
+	}
+	b.WriteString("Last lines:\n")
+
+	// Fill what is left of the budget from the end
+	remaining := limit - b.Len()
+	var tail []string
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := clip(lines[i], maxLineBytes)
+		if remaining-len(line)-1 < 0 {
+			break
+		}
+		tail = append(tail, line)
+		remaining -= len(line) + 1
+	}
+	for i := len(tail) - 1; i >= 0; i-- {
+		b.WriteString(tail[i])
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func clip(line string, n int) string {
+	if len(line) <= n {
+		return line
+	}
+	for n > 0 && !utf8.RuneStart(line[n]) {
+		n--
+	}
+	return line[:n] + "..."
+}
@@ -0,0 +1,247 @@
+// Package api serves LogAid's suggestion pipeline over HTTP and gRPC for
+// 'logaid serve', so dashboards, IDEs and other tools can ask it for fixes
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// maxRequestBytes limits the size of a request body
+const maxRequestBytes = 1 << 20
+
+// defaultHistoryLimit is how many entries /v1/history returns by default
+const defaultHistoryLimit = 50
+
+// SuggestRequest is the body of POST /v1/suggest
+type SuggestRequest struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+	// Context is extra information about where the command ran, such as the
+	// working directory or OS, given to the plugins and AI with the output
+	Context string `json:"context,omitempty"`
+}
+
+// Candidate is one of the ranked fixes in a SuggestResponse
+type Candidate struct {
+	Rank    int    `json:"rank"`
+	Command string `json:"command"`
+}
+
+// SuggestResponse is the reply to POST /v1/suggest. Suggestions is empty
+// when no fix was found
+type SuggestResponse struct {
+	Source      string      `json:"source,omitempty"` // plugin, "AI", "cache" or "personal model"
+	Confidence  float64     `json:"confidence,omitempty"`
+	Suggestions []Candidate `json:"suggestions"`
+	ConfigEdit  string      `json:"config_edit,omitempty"` // a configuration change instead of a command
+}
+
+// HistoryResponse is the reply to GET /v1/history
+type HistoryResponse struct {
+	Entries []history.Entry `json:"entries"` // newest first
+}
+
+// HealthResponse is the reply to GET /v1/health
+type HealthResponse struct {
+	Status  string    `json:"status"`
+	Started time.Time `json:"started"`
+	Plugins int       `json:"plugins"`
+}
+
+// errorResponse is the body of every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server answers HTTP and gRPC requests with an Engine
+type Server struct {
+	engine  *engine.Engine
+	history *history.Store
+	token   string
+	started time.Time
+	mu      sync.Mutex // plugins are not safe for concurrent use
+}
+
+// NewServer creates a server for eng. Requests other than health checks
+// must carry token as a bearer token; an empty token disables
+// authentication. store may be nil when history is disabled
+func NewServer(eng *engine.Engine, store *history.Store, token string) *Server {
+	return &Server{engine: eng, history: store, token: token, started: time.Now()}
+}
+
+// Handler returns the HTTP handler serving the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", s.health)
+	mux.Handle("POST /v1/suggest", s.authenticated(s.suggest))
+	mux.Handle("GET /v1/history", s.authenticated(s.listHistory))
+	return mux
+}
+
+// authenticated rejects requests without the server's bearer token
+func (s *Server) authenticated(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="logaid"`)
+				writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+				return
+			}
+		}
+		next(w, r)
+	})
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok", Started: s.started, Plugins: len(s.engine.Plugins())})
+}
+
+func (s *Server) suggest(w http.ResponseWriter, r *http.Request) {
+	var req SuggestRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := s.Suggest(r.Context(), req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) listHistory(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", value))
+			return
+		}
+		limit = n
+	}
+
+	entries, err := s.History(limit)
+	switch {
+	case errors.Is(err, ErrHistoryDisabled):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, HistoryResponse{Entries: entries})
+	}
+}
+
+// validate checks that req describes a failed command
+func (req SuggestRequest) validate() error {
+	if strings.TrimSpace(req.Command) == "" && strings.TrimSpace(req.Output) == "" {
+		return errors.New("command or output is required")
+	}
+	return nil
+}
+
+// Suggest runs the suggestion pipeline for req and records the result in
+// the history, for both the HTTP and gRPC APIs
+func (s *Server) Suggest(ctx context.Context, req SuggestRequest) (*SuggestResponse, error) {
+	output := req.Output
+	if req.Context != "" {
+		output = strings.TrimRight(output, "\n") + "\n\n" + req.Context
+	}
+
+	s.mu.Lock()
+	suggestion, err := s.engine.Suggest(ctx, req.Command, output)
+	if err == nil && suggestion != nil {
+		s.engine.Propose(req.Command, output, suggestion)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("API suggestion failed: %v", err))
+		return nil, fmt.Errorf("failed to get a suggestion: %w", err)
+	}
+
+	resp := &SuggestResponse{Suggestions: []Candidate{}}
+	if suggestion != nil {
+		resp.Source, resp.Confidence = suggestion.Source, suggestion.Confidence
+		for i, candidate := range suggestion.Candidates() {
+			resp.Suggestions = append(resp.Suggestions, Candidate{Rank: i + 1, Command: candidate})
+		}
+		if suggestion.ConfigEdit != nil {
+			resp.ConfigEdit = suggestion.Text()
+		}
+	}
+	return resp, nil
+}
+
+// ErrHistoryDisabled is returned by History when HISTORY_FILE is empty
+var ErrHistoryDisabled = errors.New("history is disabled (HISTORY_FILE is empty)")
+
+// History returns up to limit history entries, newest first. A limit of 0
+// returns the default of 50
+func (s *Server) History(limit int) ([]history.Entry, error) {
+	if s.history == nil {
+		return nil, ErrHistoryDisabled
+	}
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	entries, err := s.history.List(limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if entries == nil {
+		entries = []history.Entry{}
+	}
+	return entries, nil
+}
+
+// writeJSON sends v with status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to write API response: %v", err))
+	}
+}
+
+// writeError sends err as a JSON error with status
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// IsLoopback reports whether addr, a host:port to listen on, only accepts
+// connections from this machine
+func IsLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	logaidv1 "github.com/ayushsharma-1/LogAid/api/logaid/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewGRPC returns a gRPC server for the LogAid service defined in
+// api/logaid/v1, checking the same token as the HTTP API
+func (s *Server) NewGRPC() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := s.authorize(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authorize(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	)
+	logaidv1.RegisterLogAidServer(server, &grpcService{server: s})
+	return server
+}
+
+// authorize checks the bearer token in the "authorization" metadata
+func (s *Server) authorize(ctx context.Context) error {
+	if s.token == "" {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid token")
+}
+
+// grpcService implements logaidv1.LogAidServer with a Server
+type grpcService struct {
+	logaidv1.UnimplementedLogAidServer
+	server *Server
+}
+
+func (g *grpcService) Suggest(ctx context.Context, req *logaidv1.SuggestRequest) (*logaidv1.SuggestResponse, error) {
+	resp, err := g.suggest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(resp), nil
+}
+
+func (g *grpcService) StreamSuggest(req *logaidv1.SuggestRequest, stream logaidv1.LogAid_StreamSuggestServer) error {
+	err := stream.Send(&logaidv1.SuggestEvent{Event: &logaidv1.SuggestEvent_Stage{Stage: "analyzing"}})
+	if err != nil {
+		return err
+	}
+	resp, err := g.suggest(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	done := toProto(resp)
+	for _, candidate := range done.Suggestions {
+		err := stream.Send(&logaidv1.SuggestEvent{Event: &logaidv1.SuggestEvent_Candidate{Candidate: candidate}})
+		if err != nil {
+			return err
+		}
+	}
+	return stream.Send(&logaidv1.SuggestEvent{Event: &logaidv1.SuggestEvent_Done{Done: done}})
+}
+
+func (g *grpcService) History(ctx context.Context, req *logaidv1.HistoryRequest) (*logaidv1.HistoryResponse, error) {
+	if req.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid limit %d", req.Limit)
+	}
+	entries, err := g.server.History(int(req.Limit))
+	if errors.Is(err, ErrHistoryDisabled) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &logaidv1.HistoryResponse{}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &logaidv1.HistoryEntry{
+			Id:          entry.ID,
+			Time:        timestamppb.New(entry.Time),
+			Command:     entry.Command,
+			Output:      entry.Output,
+			Suggestion:  entry.Suggestion,
+			Source:      entry.Source,
+			Status:      entry.Status,
+			Explanation: entry.Explanation,
+		})
+	}
+	return resp, nil
+}
+
+// suggest validates req and runs the suggestion pipeline
+func (g *grpcService) suggest(ctx context.Context, req *logaidv1.SuggestRequest) (*SuggestResponse, error) {
+	request := SuggestRequest{Command: req.Command, Output: req.Output, Context: req.Context}
+	if err := request.validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp, err := g.server.Suggest(ctx, request)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return resp, nil
+}
+
+// toProto converts a SuggestResponse to its gRPC message
+func toProto(resp *SuggestResponse) *logaidv1.SuggestResponse {
+	message := &logaidv1.SuggestResponse{Source: resp.Source, Confidence: resp.Confidence, ConfigEdit: resp.ConfigEdit}
+	for _, candidate := range resp.Suggestions {
+		message.Suggestions = append(message.Suggestions, &logaidv1.Candidate{Rank: int32(candidate.Rank), Command: candidate.Command})
+	}
+	return message
+}
@@ -0,0 +1,145 @@
+// Package audit keeps the append-only log of the commands LogAid executed on
+// the user's behalf. Each record holds the hash of the one before it, so
+// editing or removing a record breaks the chain and is caught by Verify.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// Record is one executed fix
+type Record struct {
+	Time          time.Time `json:"time"`
+	User          string    `json:"user,omitempty"`
+	Host          string    `json:"host,omitempty"`
+	FailedCommand string    `json:"failed_command,omitempty"`
+	Command       string    `json:"command"`
+	Source        string    `json:"source,omitempty"` // plugin, "AI", "cache" or "personal model"
+	Confirmation  string    `json:"confirmation"`     // accepted, auto-confirmed
+	ExitCode      int       `json:"exit_code"`        // -1 when the command could not be started
+	Prev          string    `json:"prev"`             // hash of the previous record, empty for the first
+	Hash          string    `json:"hash"`
+}
+
+// sum returns the hash of the record: the SHA-256 of its JSON without Hash
+func (r Record) sum() string {
+	r.Hash = ""
+	data, _ := json.Marshal(r)
+	digest := sha256.Sum256(data)
+	return hex.EncodeToString(digest[:])
+}
+
+// Append chains record to the last one in the log at path and appends it
+func Append(path string, record Record) error {
+	lock, err := state.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	last, err := lastHash(file)
+	if err != nil {
+		return err
+	}
+	record.Prev = last
+	record.Hash = record.sum()
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return file.Sync()
+}
+
+// lastHash returns the hash of the last record in file
+func lastHash(file *os.File) (string, error) {
+	var last []byte
+	scanner := newScanner(file)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			last = append(last[:0], scanner.Bytes()...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read audit log: %w", err)
+	}
+	if last == nil {
+		return "", nil
+	}
+	var record Record
+	if err := json.Unmarshal(last, &record); err != nil {
+		return "", fmt.Errorf("failed to parse last audit record: %w", err)
+	}
+	return record.Hash, nil
+}
+
+// Read returns the records in the log at path, oldest first. A missing log
+// has no records
+func Read(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := newScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: failed to parse audit record: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return records, nil
+}
+
+// Verify checks that no record was changed, inserted or removed since it was
+// written. It returns the number of records checked
+func Verify(records []Record) (int, error) {
+	prev := ""
+	for i, record := range records {
+		if record.Prev != prev {
+			return i, fmt.Errorf("record %d (%s): chain broken, a record before it was removed or changed", i+1, record.Time.Format(time.RFC3339))
+		}
+		if record.sum() != record.Hash {
+			return i, fmt.Errorf("record %d (%s): hash mismatch, the record was modified", i+1, record.Time.Format(time.RFC3339))
+		}
+		prev = record.Hash
+	}
+	return len(records), nil
+}
+
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	return scanner
+}
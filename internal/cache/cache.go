@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// DefaultSimilarity is the minimum Jaccard similarity for reusing a
+// correction pattern learned from a different error message
+const DefaultSimilarity = 0.8
+
+// maxEntries bounds the size of the suggestion cache file
+const maxEntries = 500
+
+// Entry is a cached correction, stored as templates so it can be reused for
+// other values of the same variables
+type Entry struct {
+	Error      string    `json:"error"`
+	Command    string    `json:"command"`
+	Variables  []string  `json:"variables,omitempty"`
+	Suggestion string    `json:"suggestion"`
+	Source     string    `json:"source"`
+	Hits       int       `json:"hits"`
+	Created    time.Time `json:"created"`
+	LastUsed   time.Time `json:"last_used"`
+}
+
+// file is the on-disk layout of the suggestion cache
+type file struct {
+	Entries []Entry `json:"entries"`
+	Lookups int     `json:"lookups,omitempty"` // hits, and misses answered elsewhere and stored
+	Hits    int     `json:"hits,omitempty"`
+}
+
+// Cache stores AI suggestions keyed by normalized error signatures
+type Cache struct {
+	path       string
+	ttl        time.Duration
+	similarity float64
+}
+
+// New creates a cache persisted at path. A zero ttl disables expiry.
+func New(path string, ttl time.Duration, similarity float64) *Cache {
+	return &Cache{path: path, ttl: ttl, similarity: similarity}
+}
+
+// NewFromConfig creates the suggestion cache from CACHE_* settings. It
+// returns nil when caching is disabled.
+func NewFromConfig() *Cache {
+	cfg := config.Current()
+	if cfg == nil || !cfg.CacheSuggestions {
+		return nil
+	}
+
+	dir := cfg.CacheDir
+	if dir == "" {
+		dir = filepath.Join(config.Dir(), "cache")
+	}
+
+	ttl := time.Duration(cfg.CacheDuration) * time.Second
+	return New(filepath.Join(dir, "suggestions.json"), ttl, DefaultSimilarity)
+}
+
+// Path returns the location of the cache file
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Lookup returns a cached suggestion for the failed command. Exact matches
+// are returned as stored; correction patterns that refer to variables are
+// reused for new values when the error signatures are similar enough. The
+// cache file is only written on a hit, to record it.
+func (c *Cache) Lookup(command, output string) (string, bool) {
+	sig := NewSignature(command, output)
+	now := time.Now()
+
+	var current file
+	if err := state.ReadJSON(c.path, &current); err != nil || c.find(current.Entries, sig, now) < 0 {
+		return "", false
+	}
+
+	var result string
+	var found bool
+
+	var data file
+	err := state.UpdateJSON(c.path, &data, func() error {
+		// Another process may have changed the entries since they were read
+		best := c.find(data.Entries, sig, now)
+		if best < 0 {
+			return nil
+		}
+
+		data.Lookups++
+		data.Hits++
+		data.Entries[best].Hits++
+		data.Entries[best].LastUsed = now
+		result = Expand(data.Entries[best].Suggestion, sig.Variables)
+		found = true
+		return nil
+	})
+	if err != nil {
+		return "", false
+	}
+
+	return result, found
+}
+
+// find returns the index of the entry that answers sig, or -1
+func (c *Cache) find(entries []Entry, sig Signature, now time.Time) int {
+	key := sig.Key()
+	best := -1
+	bestScore := 0.0
+
+	for i, entry := range entries {
+		if c.expired(entry, now) || entry.Command != sig.Command {
+			continue
+		}
+
+		if entry.signature().Key() == key {
+			return i // exact hit always wins
+		}
+
+		if !HasPlaceholders(entry.Suggestion) || len(entry.Variables) != len(sig.Variables) {
+			continue
+		}
+		if score := Similarity(entry.Error, sig.Error); score >= c.similarity && score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// Store remembers the suggestion produced for a failed command
+func (c *Cache) Store(command, output, suggestion, source string) error {
+	sig := NewSignature(command, output)
+	key := sig.Key()
+	now := time.Now()
+
+	var data file
+	return state.UpdateJSON(c.path, &data, func() error {
+		// A suggestion is stored after a lookup missed, so it counts one
+		data.Lookups++
+
+		entries := data.Entries[:0]
+		for _, entry := range data.Entries {
+			if c.expired(entry, now) || entry.signature().Key() == key {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+
+		entries = append(entries, Entry{
+			Error:      sig.Error,
+			Command:    sig.Command,
+			Variables:  sig.Variables,
+			Suggestion: TemplatizeSuggestion(suggestion, sig.Variables),
+			Source:     source,
+			Created:    now,
+			LastUsed:   now,
+		})
+
+		if len(entries) > maxEntries {
+			sort.Slice(entries, func(i, j int) bool {
+				return entries[i].LastUsed.After(entries[j].LastUsed)
+			})
+			entries = entries[:maxEntries]
+		}
+
+		data.Entries = entries
+		return nil
+	})
+}
+
+// Forget removes the cached correction stored for exactly this failure,
+// e.g. after the user rejected the suggestion. Patterns learned from other
+// failures are kept
+func (c *Cache) Forget(command, output string) error {
+	key := NewSignature(command, output).Key()
+
+	var data file
+	return state.UpdateJSON(c.path, &data, func() error {
+		entries := data.Entries[:0]
+		for _, entry := range data.Entries {
+			if entry.signature().Key() == key {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		data.Entries = entries
+		return nil
+	})
+}
+
+func (c *Cache) expired(entry Entry, now time.Time) bool {
+	return c.ttl > 0 && now.Sub(entry.LastUsed) > c.ttl
+}
+
+// signature returns the signature the entry was stored under
+func (e Entry) signature() Signature {
+	return Signature{Error: e.Error, Command: e.Command, Variables: e.Variables}
+}
@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// Stats summarizes the contents and use of a cache
+type Stats struct {
+	Entries int
+	Size    int64 // bytes on disk
+	Lookups int
+	Hits    int
+	Oldest  time.Time
+	Newest  time.Time
+	Plugins map[string]int // entries per plugin or tool
+}
+
+// HitRate returns the fraction of lookups answered from the cache
+func (s Stats) HitRate() float64 {
+	if s.Lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Lookups)
+}
+
+// Add counts an entry for plugin created at created
+func (s *Stats) Add(plugin string, created time.Time) {
+	s.Entries++
+	if s.Plugins == nil {
+		s.Plugins = map[string]int{}
+	}
+	s.Plugins[plugin]++
+	if s.Oldest.IsZero() || created.Before(s.Oldest) {
+		s.Oldest = created
+	}
+	if created.After(s.Newest) {
+		s.Newest = created
+	}
+}
+
+// Filter selects cache entries to clear. The zero Filter selects everything
+type Filter struct {
+	Plugin    string        // plugin or tool the entry belongs to
+	OlderThan time.Duration // time since the entry was last used
+}
+
+// Matches reports whether an entry of plugin last used at lastUsed is selected
+func (f Filter) Matches(plugin string, lastUsed, now time.Time) bool {
+	if f.Plugin != "" && plugin != f.Plugin {
+		return false
+	}
+	return f.OlderThan == 0 || now.Sub(lastUsed) > f.OlderThan
+}
+
+// Tool returns the program the cached command runs, e.g. apt for
+// "sudo apt install {0}"
+func (e Entry) Tool() string {
+	fields := strings.Fields(e.Command)
+	for len(fields) > 1 && fields[0] == "sudo" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// Entries returns the cached corrections, most recently used first
+func (c *Cache) Entries() ([]Entry, error) {
+	var data file
+	if err := state.ReadJSON(c.path, &data); err != nil {
+		return nil, err
+	}
+	sort.Slice(data.Entries, func(i, j int) bool {
+		return data.Entries[i].LastUsed.After(data.Entries[j].LastUsed)
+	})
+	return data.Entries, nil
+}
+
+// Stats summarizes the cache. Entries are counted by the tool they correct
+func (c *Cache) Stats() (Stats, error) {
+	var data file
+	if err := state.ReadJSON(c.path, &data); err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{Lookups: data.Lookups, Hits: data.Hits}
+	if info, err := os.Stat(c.path); err == nil {
+		stats.Size = info.Size()
+	}
+	for _, entry := range data.Entries {
+		stats.Add(entry.Tool(), entry.Created)
+	}
+	return stats, nil
+}
+
+// Clear removes the entries selected by filter and returns how many were
+// removed. Clearing everything also resets the hit counters
+func (c *Cache) Clear(filter Filter) (int, error) {
+	removed := 0
+	var data file
+	err := state.UpdateJSON(c.path, &data, func() error {
+		now := time.Now()
+		entries := data.Entries[:0]
+		for _, entry := range data.Entries {
+			if filter.Matches(entry.Tool(), entry.LastUsed, now) || filter.Matches(entry.Source, entry.LastUsed, now) {
+				removed++
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		data.Entries = entries
+		if filter == (Filter{}) {
+			data.Lookups, data.Hits = 0, 0
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// Prune drops the least recently used entries until the cache file is at
+// most maxSize bytes, and returns how many were dropped
+func (c *Cache) Prune(maxSize int64) (int, error) {
+	removed := 0
+	var data file
+	err := state.UpdateJSON(c.path, &data, func() error {
+		sort.Slice(data.Entries, func(i, j int) bool {
+			return data.Entries[i].LastUsed.After(data.Entries[j].LastUsed)
+		})
+		keep := KeepWithin(len(data.Entries), maxSize, func(i int) interface{} { return data.Entries[i] })
+		removed = len(data.Entries) - keep
+		data.Entries = data.Entries[:keep]
+		return nil
+	})
+	return removed, err
+}
+
+// KeepWithin returns how many of n entries, ordered most valuable first,
+// fit in maxSize bytes of indented JSON
+func KeepWithin(n int, maxSize int64, entry func(i int) interface{}) int {
+	// Room for the enclosing object and its counters
+	size := int64(64)
+	for i := 0; i < n; i++ {
+		encoded, err := json.MarshalIndent(entry(i), "    ", "  ")
+		if err != nil {
+			return i
+		}
+		size += int64(len(encoded)) + 6
+		if size > maxSize {
+			return i
+		}
+	}
+	return n
+}
+
+// sizeUnits are the suffixes ParseSize accepts, largest first
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses a size such as 512KB or 10MB
+func ParseSize(s string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q, use a number of bytes or e.g. 512KB, 10MB", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// FormatSize renders a byte count such as 1.5MB
+func FormatSize(n int64) string {
+	for _, unit := range sizeUnits {
+		if n >= unit.bytes && unit.bytes > 1 {
+			return strconv.FormatFloat(float64(n)/float64(unit.bytes), 'f', 1, 64) + unit.suffix
+		}
+	}
+	return fmt.Sprintf("%dB", n)
+}
@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	numberPattern = regexp.MustCompile(`\b\d+(\.\d+)*\b`)
+	hexPattern    = regexp.MustCompile(`\b[0-9a-f]{7,}\b`)
+	pathPattern   = regexp.MustCompile(`(?:~|\.{0,2})/[^\s'"]+`)
+	spacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// maxSignatureLines bounds how much of the output contributes to a signature
+const maxSignatureLines = 5
+
+// Signature is the normalized form of a (command, output) pair. Arguments of
+// the command that also appear in the output (package names, branches, image
+// names...) are treated as variables and replaced by numbered placeholders.
+type Signature struct {
+	Error     string   // normalized error text
+	Command   string   // command template
+	Variables []string // values of the placeholders, in order
+}
+
+// NewSignature normalizes a failed command and its output
+func NewSignature(command, output string) Signature {
+	fields := strings.Fields(command)
+
+	var variables []string
+	lowerOutput := strings.ToLower(output)
+	for i, field := range fields {
+		if i == 0 || strings.HasPrefix(field, "-") || len(field) < 2 {
+			continue
+		}
+		if containsWord(lowerOutput, strings.ToLower(field)) && !contains(variables, field) {
+			variables = append(variables, field)
+		}
+	}
+
+	return Signature{
+		Error:     normalizeOutput(output, variables),
+		Command:   Templatize(command, variables),
+		Variables: variables,
+	}
+}
+
+// Key returns the exact-match cache key for the signature
+func (s Signature) Key() string {
+	return s.Command + "\x00" + s.Error + "\x00" + strings.Join(s.Variables, "\x00")
+}
+
+// Templatize replaces every variable value in text with its placeholder
+func Templatize(text string, variables []string) string {
+	for i, v := range variables {
+		text = replaceWord(text, v, placeholder(i), isWordChar)
+	}
+	return text
+}
+
+// TemplatizeSuggestion is like Templatize but also matches variables that are
+// part of a hyphenated or dotted name, so "foo-dev" becomes "{{1}}-dev"
+func TemplatizeSuggestion(text string, variables []string) string {
+	for i, v := range variables {
+		text = replaceWord(text, v, placeholder(i), isIdentChar)
+	}
+	return text
+}
+
+// Expand replaces placeholders in template with the given variable values
+func Expand(template string, variables []string) string {
+	for i, v := range variables {
+		template = strings.ReplaceAll(template, placeholder(i), v)
+	}
+	return template
+}
+
+// HasPlaceholders reports whether template refers to any variable
+func HasPlaceholders(template string) bool {
+	return strings.Contains(template, "{{")
+}
+
+// Similarity returns the Jaccard similarity of the word sets of two
+// normalized error texts (1.0 means identical)
+func Similarity(a, b string) float64 {
+	setA := wordSet(a)
+	setB := wordSet(b)
+	if len(setA) == 0 && len(setB) == 0 {
+		return 1
+	}
+
+	intersection := 0
+	for w := range setA {
+		if setB[w] {
+			intersection++
+		}
+	}
+	union := len(setA) + len(setB) - intersection
+	return float64(intersection) / float64(union)
+}
+
+func normalizeOutput(output string, variables []string) string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+		if len(lines) == maxSignatureLines {
+			break
+		}
+	}
+
+	// Variables use a digit-safe marker so the number pattern leaves them alone
+	text := strings.ToLower(strings.Join(lines, "\n"))
+	for i, v := range variables {
+		text = replaceWord(text, strings.ToLower(v), fmt.Sprintf("<var%d>", i+1), isWordChar)
+	}
+	text = pathPattern.ReplaceAllString(text, "<path>")
+	text = hexPattern.ReplaceAllString(text, "<hex>")
+	text = numberPattern.ReplaceAllString(text, "<n>")
+	return spacePattern.ReplaceAllString(text, " ")
+}
+
+func placeholder(i int) string {
+	return fmt.Sprintf("{{%d}}", i+1)
+}
+
+// isWordChar reports whether c can be part of a command argument
+func isWordChar(c byte) bool {
+	return c == '-' || c == '_' || c == '.' || c == '@' || c == '/' ||
+		(c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isIdentChar reports whether c can be part of an identifier
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// replaceWord replaces whole-word occurrences of word in text
+func replaceWord(text, word, replacement string, wordChar func(byte) bool) string {
+	var b strings.Builder
+	for {
+		i := indexWord(text, word, wordChar)
+		if i < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		b.WriteString(text[:i])
+		b.WriteString(replacement)
+		text = text[i+len(word):]
+	}
+}
+
+func containsWord(text, word string) bool {
+	return indexWord(text, word, isWordChar) >= 0
+}
+
+// indexWord finds word in text where it is not part of a longer word as
+// defined by wordChar
+func indexWord(text, word string, wordChar func(byte) bool) int {
+	offset := 0
+	for {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return -1
+		}
+		start := offset + i
+		end := start + len(word)
+		before := start == 0 || !wordChar(text[start-1])
+		after := end == len(text) || !wordChar(text[end]) ||
+			(text[end] == '.' && (end+1 == len(text) || !wordChar(text[end+1])))
+		if before && after {
+			return start
+		}
+		offset = start + 1
+	}
+}
+
+func wordSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(text) {
+		set[w] = true
+	}
+	return set
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+// Package ci describes the result of a CI step run by 'logaid ci' as GitHub
+// Actions workflow annotations and a job summary
+package ci
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/notify"
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+)
+
+// SummaryEnv names the file GitHub Actions renders as the job summary
+const SummaryEnv = "GITHUB_STEP_SUMMARY"
+
+// summaryOutputLines is how much of the output the job summary shows, from
+// the end
+const summaryOutputLines = 50
+
+// locationPattern matches compiler-style "file:line[:column]:" prefixes, so
+// the annotation can point at the file
+var locationPattern = regexp.MustCompile(`^([^\s:]+\.[A-Za-z0-9]+):(\d+)(?::(\d+))?:`)
+
+// Annotation returns the workflow command that shows the result of a step
+// in the GitHub Actions UI: an error when it failed, a warning when an error
+// was seen in a step that succeeded or was fixed. It returns "" when there
+// is nothing to report
+func Annotation(report *engine.Report) string {
+	failed := report.ExitCode != 0 && !report.Fixed
+	if !failed && !report.ErrorDetected {
+		return ""
+	}
+	report = masked(report)
+
+	level, title := "warning", fmt.Sprintf("LogAid: error in %s", report.Command)
+	if failed {
+		level, title = "error", fmt.Sprintf("LogAid: %s failed", report.Command)
+	}
+	summary := errorSummary(report)
+
+	properties := []string{"title=" + escapeProperty(title)}
+	if match := locationPattern.FindStringSubmatch(summary); match != nil {
+		properties = append(properties, "file="+escapeProperty(match[1]), "line="+match[2])
+		if match[3] != "" {
+			properties = append(properties, "col="+match[3])
+		}
+	}
+
+	lines := []string{summary}
+	if report.Suggestion != "" {
+		lines = append(lines, fmt.Sprintf("Suggested fix (from %s): %s", report.Source, report.Suggestion))
+	}
+	for _, alternative := range report.Alternatives {
+		lines = append(lines, fmt.Sprintf("  or: %s", alternative))
+	}
+	return fmt.Sprintf("::%s %s::%s", level, strings.Join(properties, ","), escapeData(strings.Join(lines, "\n")))
+}
+
+// Summary returns the Markdown job summary of a step that failed or showed
+// an error, or "" when there is nothing to report
+func Summary(report *engine.Report) string {
+	if report.ExitCode == 0 && !report.ErrorDetected {
+		return ""
+	}
+	report = masked(report)
+
+	var b strings.Builder
+	switch {
+	case report.Fixed:
+		fmt.Fprintf(&b, "### ✅ `%s` failed and LogAid fixed it\n\n", report.Command)
+	case report.ExitCode < 0:
+		fmt.Fprintf(&b, "### ❌ `%s` could not be started\n\n", report.Command)
+	case report.ExitCode != 0:
+		fmt.Fprintf(&b, "### ❌ `%s` failed with exit code %d\n\n", report.Command, report.ExitCode)
+	default:
+		fmt.Fprintf(&b, "### ⚠️ `%s` reported an error\n\n", report.Command)
+	}
+	if summary := errorSummary(report); summary != "" {
+		fmt.Fprintf(&b, "> %s\n\n", summary)
+	}
+
+	switch {
+	case report.Suggestion != "":
+		b.WriteString("**Suggested fix**")
+		if report.Source != "" {
+			fmt.Fprintf(&b, " from %s", report.Source)
+		}
+		if report.Confidence > 0 {
+			fmt.Fprintf(&b, " (confidence %.2f)", report.Confidence)
+		}
+		fmt.Fprintf(&b, ":\n\n%s\n", codeBlock("sh", report.Suggestion))
+		for _, alternative := range report.Alternatives {
+			fmt.Fprintf(&b, "- or `%s`\n", alternative)
+		}
+		if len(report.Alternatives) > 0 {
+			b.WriteString("\n")
+		}
+		if report.Note != "" {
+			fmt.Fprintf(&b, "%s\n\n", report.Note)
+		}
+		if !report.Fixed && report.FixExitCode == nil {
+			b.WriteString("_Suggested only; LogAid did not run it._\n\n")
+		}
+	case report.Error != "":
+		fmt.Fprintf(&b, "_No fix found: %s_\n\n", report.Error)
+	default:
+		b.WriteString("_No fix found._\n\n")
+	}
+
+	if output := tail(report.Output, summaryOutputLines); output != "" {
+		fmt.Fprintf(&b, "<details><summary>Output</summary>\n\n%s\n</details>\n", codeBlock("text", output))
+	}
+	return b.String()
+}
+
+// WriteSummary appends markdown to the job summary file named by
+// GITHUB_STEP_SUMMARY, if any
+func WriteSummary(markdown string) error {
+	path := os.Getenv(SummaryEnv)
+	if path == "" || markdown == "" {
+		return nil
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open job summary: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(markdown + "\n"); err != nil {
+		return fmt.Errorf("failed to write job summary: %w", err)
+	}
+	return nil
+}
+
+// masked returns a copy of report with secrets masked, unless MASK_SECRETS
+// is off: annotations and summaries are visible to everyone with access to
+// the repository
+func masked(report *engine.Report) *engine.Report {
+	cfg := config.Current()
+	if cfg != nil && !cfg.MaskSecrets {
+		return report
+	}
+	r := *report
+	r.Command = redact.Secrets(r.Command)
+	r.Output = redact.Secrets(r.Output)
+	r.Suggestion = redact.Secrets(r.Suggestion)
+	r.Alternatives = nil
+	for _, alternative := range report.Alternatives {
+		r.Alternatives = append(r.Alternatives, redact.Secrets(alternative))
+	}
+	return &r
+}
+
+// errorSummary returns the line of the output that explains the failure
+func errorSummary(report *engine.Report) string {
+	switch {
+	case report.Output != "":
+	case report.ExitCode < 0:
+		return "the command could not be started"
+	case report.ExitCode != 0:
+		return fmt.Sprintf("exited with status %d", report.ExitCode)
+	}
+	return notify.Event{Output: report.Output}.ErrorSummary()
+}
+
+// tail returns the last n lines of s
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// codeBlock fences s, with more backticks than it contains
+func codeBlock(language, s string) string {
+	fence := "```"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	return fmt.Sprintf("%s%s\n%s\n%s\n", fence, language, s, fence)
+}
+
+// escapeData escapes the message of a workflow command
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -4,7 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"unicode"
 
+	"github.com/ayushsharma-1/LogAid/internal/theme"
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
@@ -20,19 +25,51 @@ type Config struct {
 	MaxAIRetries     int     `mapstructure:"MAX_AI_RETRIES"`
 	AITemperature    float64 `mapstructure:"AI_TEMPERATURE"`
 	AIMaxTokens      int     `mapstructure:"AI_MAX_TOKENS"`
+	AICandidates     int     `mapstructure:"AI_CANDIDATES"`
+	AIMaxOutputBytes int     `mapstructure:"AI_MAX_OUTPUT_BYTES"`
+	AIRateLimit      int     `mapstructure:"AI_RATE_LIMIT"`
+	AIRateBurst      int     `mapstructure:"AI_RATE_BURST"`
+	SystemPrompt     string  `mapstructure:"SYSTEM_PROMPT"`
+
+	// Local Model (llama build tag)
+	LlamaModelPath   string `mapstructure:"LLAMA_MODEL_PATH"`
+	LlamaContextSize int    `mapstructure:"LLAMA_CONTEXT_SIZE"`
+	LlamaThreads     int    `mapstructure:"LLAMA_THREADS"`
+
+	// AI Network Configuration
+	AIProxyURL           string `mapstructure:"AI_PROXY_URL"`
+	AICABundle           string `mapstructure:"AI_CA_BUNDLE"`
+	AIInsecureSkipVerify bool   `mapstructure:"AI_INSECURE_SKIP_VERIFY"`
+
+	// AI Audit Log
+	AIAuditLog           bool   `mapstructure:"AI_AUDIT_LOG"`
+	AIAuditLogFile       string `mapstructure:"AI_AUDIT_LOG_FILE"`
+	AIAuditRetentionDays int    `mapstructure:"AI_AUDIT_RETENTION_DAYS"`
+
+	// Audit log of the fixes LogAid executed
+	ExecAuditLog     bool   `mapstructure:"EXEC_AUDIT_LOG"`
+	ExecAuditLogFile string `mapstructure:"EXEC_AUDIT_LOG_FILE"`
+
+	// OpenTelemetry export
+	OTelEndpoint    string `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTelHeaders     string `mapstructure:"OTEL_EXPORTER_OTLP_HEADERS"`
+	OTelServiceName string `mapstructure:"OTEL_SERVICE_NAME"`
 
 	// Logging Configuration
-	LogLevel        string `mapstructure:"LOG_LEVEL"`
-	LogFile         string `mapstructure:"LOG_FILE"`
-	EnableDebugLogs bool   `mapstructure:"ENABLE_DEBUG_LOGS"`
-	LogRotation     bool   `mapstructure:"LOG_ROTATION"`
-	MaxLogSize      string `mapstructure:"MAX_LOG_SIZE"`
-	MaxLogFiles     int    `mapstructure:"MAX_LOG_FILES"`
+	LogLevel         string `mapstructure:"LOG_LEVEL"`
+	LogFile          string `mapstructure:"LOG_FILE"`
+	EnableDebugLogs  bool   `mapstructure:"ENABLE_DEBUG_LOGS"`
+	LogRotation      bool   `mapstructure:"LOG_ROTATION"`
+	MaxLogSize       string `mapstructure:"MAX_LOG_SIZE"`
+	MaxLogFiles      int    `mapstructure:"MAX_LOG_FILES"`
+	LogAsync         bool   `mapstructure:"LOG_ASYNC"`
+	LogFlushInterval int    `mapstructure:"LOG_FLUSH_INTERVAL"`
 
 	// Plugin Configuration
 	PluginsDir             string `mapstructure:"PLUGINS_DIR"`
 	EnablePlugins          string `mapstructure:"ENABLE_PLUGINS"`
 	PluginTimeout          int    `mapstructure:"PLUGIN_TIMEOUT"`
+	PluginRegistry         string `mapstructure:"PLUGIN_REGISTRY"`
 	APTSearchSuggestions   bool   `mapstructure:"APT_SEARCH_SUGGESTIONS"`
 	APTEnableBackports     bool   `mapstructure:"APT_ENABLE_BACKPORTS"`
 	GitAutoCorrect         bool   `mapstructure:"GIT_AUTO_CORRECT"`
@@ -46,6 +83,9 @@ type Config struct {
 	EnableColors        bool   `mapstructure:"ENABLE_COLORS"`
 	EnableASCIILogo     bool   `mapstructure:"ENABLE_ASCII_LOGO"`
 	AutoConfirm         bool   `mapstructure:"AUTO_CONFIRM"`
+	AssumeYes           bool   `mapstructure:"ASSUME_YES"`
+	AssumeNo            bool   `mapstructure:"ASSUME_NO"`
+	Quiet               bool   `mapstructure:"QUIET"`
 	SuggestionTimeout   int    `mapstructure:"SUGGESTION_TIMEOUT"`
 	MaxSuggestions      int    `mapstructure:"MAX_SUGGESTIONS"`
 	ShowConfidenceScore bool   `mapstructure:"SHOW_CONFIDENCE_SCORE"`
@@ -54,6 +94,7 @@ type Config struct {
 	ColorSuggestion     string `mapstructure:"COLOR_SUGGESTION"`
 	ColorSuccess        string `mapstructure:"COLOR_SUCCESS"`
 	ColorWarning        string `mapstructure:"COLOR_WARNING"`
+	Theme               string `mapstructure:"THEME"`
 
 	// History & Caching
 	HistoryFile         string `mapstructure:"HISTORY_FILE"`
@@ -62,6 +103,15 @@ type Config struct {
 	CacheSuggestions    bool   `mapstructure:"CACHE_SUGGESTIONS"`
 	CacheDuration       int    `mapstructure:"CACHE_DURATION"`
 	CacheDir            string `mapstructure:"CACHE_DIR"`
+	PersonalModel       bool   `mapstructure:"PERSONAL_MODEL"`
+	LearnFixes          bool   `mapstructure:"LEARN_FIXES"`
+	FrequentFixMinCount int    `mapstructure:"FREQUENT_FIX_MIN_COUNT"`
+	PluginMetrics       bool   `mapstructure:"PLUGIN_METRICS"`
+	RecordSessions      bool   `mapstructure:"RECORD_SESSIONS"`
+	SessionsDir         string `mapstructure:"SESSIONS_DIR"`
+	FeedbackUpload      bool   `mapstructure:"FEEDBACK_UPLOAD"`
+	FeedbackEndpoint    string `mapstructure:"FEEDBACK_ENDPOINT"`
+	RetentionDays       int    `mapstructure:"RETENTION_DAYS"`
 
 	// Security & Safety
 	DangerousCommandsCheck  bool   `mapstructure:"DANGEROUS_COMMANDS_CHECK"`
@@ -69,25 +119,66 @@ type Config struct {
 	SandboxMode             bool   `mapstructure:"SANDBOX_MODE"`
 	WhitelistCommands       bool   `mapstructure:"WHITELIST_COMMANDS"`
 	BlacklistCommands       string `mapstructure:"BLACKLIST_COMMANDS"`
+	MaskSecrets             bool   `mapstructure:"MASK_SECRETS"`
+
+	// Notifications
+	NotifyRules           string `mapstructure:"NOTIFY_RULES"`
+	NotifySlackWebhook    string `mapstructure:"NOTIFY_SLACK_WEBHOOK"`
+	NotifyWebhookURL      string `mapstructure:"NOTIFY_WEBHOOK_URL"`
+	NotifyDiscordWebhook  string `mapstructure:"NOTIFY_DISCORD_WEBHOOK"`
+	NotifyTemplate        string `mapstructure:"NOTIFY_TEMPLATE"`
+	NotifyWebhookTemplate string `mapstructure:"NOTIFY_WEBHOOK_TEMPLATE"`
+	NotifyQuietHours      string `mapstructure:"NOTIFY_QUIET_HOURS"`
+	NotifyRateLimit       int    `mapstructure:"NOTIFY_RATE_LIMIT"`
 
 	// Performance Settings
 	PTYBufferSize     int    `mapstructure:"PTY_BUFFER_SIZE"`
 	ConcurrentPlugins bool   `mapstructure:"CONCURRENT_PLUGINS"`
 	EnableAsyncAI     bool   `mapstructure:"ENABLE_ASYNC_AI"`
 	MemoryLimit       string `mapstructure:"MEMORY_LIMIT"`
+	DaemonSocket      string `mapstructure:"DAEMON_SOCKET"`
+	APIAddr           string `mapstructure:"API_ADDR"`
+	APIToken          string `mapstructure:"API_TOKEN"`
+	GRPCAddr          string `mapstructure:"GRPC_ADDR"`
+	ContainerRuntime  string `mapstructure:"CONTAINER_RUNTIME"`
 
 	// Development & Testing
 	DebugMode              bool   `mapstructure:"DEBUG_MODE"`
 	TestMode               bool   `mapstructure:"TEST_MODE"`
 	MockAIResponses        bool   `mapstructure:"MOCK_AI_RESPONSES"`
+	MockAIFixtures         string `mapstructure:"MOCK_AI_FIXTURES"`
 	EnableTelemetry        bool   `mapstructure:"ENABLE_TELEMETRY"`
 	TelemetryEndpoint      string `mapstructure:"TELEMETRY_ENDPOINT"`
 	TestDataDir            string `mapstructure:"TEST_DATA_DIR"`
 	IntegrationTestTimeout int    `mapstructure:"INTEGRATION_TEST_TIMEOUT"`
 	E2ETestContainers      bool   `mapstructure:"E2E_TEST_CONTAINERS"`
+
+	// Keys whose names are not known in advance, resolved at load time
+	SystemPrompts  map[string]string // SYSTEM_PROMPT_<PLUGIN> overrides
+	PluginSettings map[string]string // values third-party plugins may read
 }
 
-var AppConfig *Config
+// current is the configuration in effect. It is replaced, never modified,
+// so a reload does not race with the goroutines reading it
+var current atomic.Pointer[Config]
+
+// Current returns the configuration in effect, or nil before Init. The
+// returned value must not be modified; use Override or SetCurrent
+func Current() *Config {
+	return current.Load()
+}
+
+// SetCurrent makes cfg the configuration in effect
+func SetCurrent(cfg *Config) {
+	current.Store(cfg)
+}
+
+// processEnv holds the variables set before .env was loaded; they keep
+// precedence over .env when the configuration is reloaded
+var processEnv map[string]bool
+
+// dotEnvKeys holds the variables last loaded from .env
+var dotEnvKeys map[string]string
 
 // Init initializes the configuration
 func Init() error {
@@ -100,12 +191,18 @@ func Init() error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	processEnv = make(map[string]bool)
+	for _, kv := range os.Environ() {
+		processEnv[strings.SplitN(kv, "=", 2)[0]] = true
+	}
+
 	// Load .env file if it exists
 	envFile := filepath.Join(configDir, ".env")
 	if _, err := os.Stat(envFile); err == nil {
 		if err := godotenv.Load(envFile); err != nil {
 			return fmt.Errorf("failed to load .env file: %w", err)
 		}
+		dotEnvKeys, _ = godotenv.Read(envFile)
 	}
 
 	// Configure viper
@@ -113,7 +210,37 @@ func Init() error {
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath(configDir)
 	viper.AutomaticEnv()
+	bindEnvs()
 
+	return load()
+}
+
+// Reload re-reads ~/.logaid/.env and config.yaml after they changed.
+// Variables from the environment LogAid was started in win over .env
+func Reload() error {
+	values, err := godotenv.Read(filepath.Join(getConfigDir(), ".env"))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load .env file: %w", err)
+	}
+	for key, value := range values {
+		if !processEnv[key] {
+			os.Setenv(key, value)
+		}
+	}
+	// Keys removed from .env fall back to config.yaml and the defaults
+	for key := range dotEnvKeys {
+		if _, ok := values[key]; !ok && !processEnv[key] {
+			os.Unsetenv(key)
+		}
+	}
+	dotEnvKeys = values
+
+	return load()
+}
+
+// load reads the config file and, when it is valid, replaces the current
+// configuration. An invalid configuration is reported and not put in effect
+func load() error {
 	// Try to read config file
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -121,34 +248,198 @@ func Init() error {
 		}
 	}
 
-	// Unmarshal config
-	AppConfig = &Config{}
-	if err := viper.Unmarshal(AppConfig); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
+	// Report values of the wrong type rather than decoding them as zero
+	if errs := validateTypes(); len(errs) > 0 {
+		return errs
 	}
 
-	// Expand home directory in paths
-	if err := expandPaths(); err != nil {
-		return fmt.Errorf("failed to expand paths: %w", err)
+	cfg, err := decode()
+	if err != nil {
+		return err
 	}
-
+	if errs := validateValues(cfg).Errors(); len(errs) > 0 {
+		return errs
+	}
+	SetCurrent(cfg)
 	return nil
 }
 
+// decode builds a configuration from the values viper has read
+func decode() (*Config, error) {
+	cfg := &Config{}
+	if err := viper.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	// Expand home directory in paths
+	if err := expandPaths(cfg); err != nil {
+		return nil, fmt.Errorf("failed to expand paths: %w", err)
+	}
+	resolveExtraKeys(cfg)
+	return cfg, nil
+}
+
 func setDefaults() {
 	viper.SetDefault("AI_PROVIDER", "gemini")
 	viper.SetDefault("LOG_LEVEL", "info")
 	viper.SetDefault("LOG_FILE", "~/.logaid/logs/logaid.log")
+	viper.SetDefault("LOG_ASYNC", true)
+	viper.SetDefault("LOG_FLUSH_INTERVAL", 1)
 	viper.SetDefault("PLUGINS_DIR", "~/.logaid/plugins")
-	viper.SetDefault("ENABLE_PLUGINS", "apt,npm,git,docker,pip,systemctl")
+	viper.SetDefault("PLUGIN_TIMEOUT", 5)
+	viper.SetDefault("APT_SEARCH_SUGGESTIONS", true)
+	viper.SetDefault("APT_ENABLE_BACKPORTS", false)
+	viper.SetDefault("GIT_AUTO_CORRECT", false)
+	viper.SetDefault("GIT_SUGGEST_ALIASES", true)
+	viper.SetDefault("NPM_SUGGEST_ALTERNATIVES", true)
+	viper.SetDefault("DOCKER_HUB_SEARCH", true)
+	viper.SetDefault("DOCKER_SUGGEST_TAGS", true)
+	viper.SetDefault("PIP_SUGGEST_VERSIONS", true)
+	viper.SetDefault("PLUGIN_REGISTRY", "https://raw.githubusercontent.com/ayushsharma-1/LogAid/main/registry/index.json")
+	viper.SetDefault("ENABLE_PLUGINS", "apt,npm,git,docker,pip,systemctl,bun,poetry,pacman,zypper,apk,ssh,make,archive,permission,apache,psql,svn,java,cc,tsc,pytest,gotest,shell,notfound,sed,find,kafka,gpg,rustup")
 	viper.SetDefault("ENABLE_COLORS", true)
+	viper.SetDefault("THEME", theme.Default)
 	viper.SetDefault("AUTO_CONFIRM", false)
+	viper.SetDefault("ASSUME_YES", false)
+	viper.SetDefault("ASSUME_NO", false)
+	viper.SetDefault("QUIET", false)
 	viper.SetDefault("SUGGESTION_TIMEOUT", 30)
 	viper.SetDefault("HISTORY_FILE", "~/.logaid/logs/history.json")
 	viper.SetDefault("MAX_HISTORY_ENTRIES", 1000)
+	viper.SetDefault("CACHE_SUGGESTIONS", true)
+	viper.SetDefault("CACHE_DURATION", 3600)
+	viper.SetDefault("CACHE_DIR", "~/.logaid/cache")
+	viper.SetDefault("PERSONAL_MODEL", true)
+	viper.SetDefault("LEARN_FIXES", true)
+	viper.SetDefault("FREQUENT_FIX_MIN_COUNT", 3)
+	viper.SetDefault("PLUGIN_METRICS", true)
+	viper.SetDefault("RECORD_SESSIONS", false)
+	viper.SetDefault("SESSIONS_DIR", "~/.logaid/sessions")
+	viper.SetDefault("FEEDBACK_UPLOAD", false)
+	viper.SetDefault("FEEDBACK_ENDPOINT", "https://api.logaid.ayushsharma.site/feedback")
+	viper.SetDefault("RETENTION_DAYS", 0)
+	viper.SetDefault("MASK_SECRETS", true)
+	viper.SetDefault("DANGEROUS_COMMANDS_CHECK", true)
+	viper.SetDefault("REQUIRE_SUDO_CONFIRMATION", true)
 	viper.SetDefault("PTY_BUFFER_SIZE", 4096)
+	viper.SetDefault("DAEMON_SOCKET", "~/.logaid/daemon.sock")
+	viper.SetDefault("API_ADDR", "127.0.0.1:8765")
+	viper.SetDefault("API_TOKEN", "")
+	viper.SetDefault("GRPC_ADDR", "127.0.0.1:8766")
+	viper.SetDefault("CONTAINER_RUNTIME", "auto")
 	viper.SetDefault("AI_REQUEST_TIMEOUT", 10)
+	viper.SetDefault("AI_CANDIDATES", 1)
+	viper.SetDefault("AI_MAX_OUTPUT_BYTES", 8192)
+	viper.SetDefault("AI_RATE_LIMIT", 20)
+	viper.SetDefault("AI_RATE_BURST", 5)
+	viper.SetDefault("SYSTEM_PROMPT", "")
+	viper.SetDefault("LLAMA_MODEL_PATH", "")
+	viper.SetDefault("LLAMA_CONTEXT_SIZE", 2048)
+	viper.SetDefault("LLAMA_THREADS", 0)
+	viper.SetDefault("AI_PROXY_URL", "")
+	viper.SetDefault("AI_CA_BUNDLE", "")
+	viper.SetDefault("AI_INSECURE_SKIP_VERIFY", false)
+	viper.SetDefault("AI_AUDIT_LOG", false)
+	viper.SetDefault("AI_AUDIT_LOG_FILE", "~/.logaid/audit/ai.jsonl")
+	viper.SetDefault("AI_AUDIT_RETENTION_DAYS", 90)
+	viper.SetDefault("EXEC_AUDIT_LOG", true)
+	viper.SetDefault("EXEC_AUDIT_LOG_FILE", "~/.logaid/audit/exec.jsonl")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_HEADERS", "")
+	viper.SetDefault("OTEL_SERVICE_NAME", "logaid")
 	viper.SetDefault("ENABLE_TELEMETRY", false)
+	viper.SetDefault("TEST_MODE", false)
+	viper.SetDefault("MOCK_AI_RESPONSES", false)
+	viper.SetDefault("MOCK_AI_FIXTURES", "")
+	viper.SetDefault("NOTIFY_RULES", "")
+	viper.SetDefault("NOTIFY_SLACK_WEBHOOK", "")
+	viper.SetDefault("NOTIFY_WEBHOOK_URL", "")
+	viper.SetDefault("NOTIFY_DISCORD_WEBHOOK", "")
+	viper.SetDefault("NOTIFY_TEMPLATE", "")
+	viper.SetDefault("NOTIFY_WEBHOOK_TEMPLATE", "")
+	viper.SetDefault("NOTIFY_QUIET_HOURS", "")
+	viper.SetDefault("NOTIFY_RATE_LIMIT", 10)
+}
+
+// ColorTheme returns the THEME with the COLOR_* overrides applied
+func (c *Config) ColorTheme() (theme.Theme, error) {
+	return theme.Get(c.Theme, theme.Theme{
+		Error:      c.ColorError,
+		Warning:    c.ColorWarning,
+		Success:    c.ColorSuccess,
+		Suggestion: c.ColorSuggestion,
+	})
+}
+
+// bindEnvs registers every Config key with viper so values that only exist
+// in the environment are picked up by Unmarshal
+func bindEnvs() {
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); key != "" {
+			viper.BindEnv(key)
+		}
+	}
+}
+
+// resolveExtraKeys reads the SYSTEM_PROMPT_<PLUGIN> overrides and the
+// settings plugins may read, so plugins never query viper during a reload
+func resolveExtraKeys(cfg *Config) {
+	cfg.SystemPrompts = make(map[string]string)
+	cfg.PluginSettings = make(map[string]string)
+
+	keys := viper.AllKeys()
+	for _, kv := range os.Environ() {
+		keys = append(keys, strings.SplitN(kv, "=", 2)[0])
+	}
+	for _, key := range keys {
+		key = strings.ToUpper(key)
+		if strings.HasPrefix(key, "SYSTEM_PROMPT_") {
+			viper.BindEnv(key)
+			cfg.SystemPrompts[key] = viper.GetString(key)
+		}
+		if strings.HasPrefix(key, pluginSettingPrefix) {
+			viper.BindEnv(key)
+			cfg.PluginSettings[key] = viper.GetString(key)
+		}
+	}
+}
+
+// pluginSettingPrefix starts the keys third-party plugins may read: a plugin
+// called terraform reads PLUGIN_TERRAFORM_FLAGS as FLAGS
+const pluginSettingPrefix = "PLUGIN_"
+
+// envName turns a plugin name into the form used in keys, e.g. my-plugin
+// becomes MY_PLUGIN
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
+
+// PluginSystemPrompt returns the SYSTEM_PROMPT_<PLUGIN> override for a
+// plugin, e.g. SYSTEM_PROMPT_APT, or "" when none is set
+func (c *Config) PluginSystemPrompt(plugin string) string {
+	return c.SystemPrompts["SYSTEM_PROMPT_"+envName(plugin)]
+}
+
+// PluginSetting returns the PLUGIN_<PLUGIN>_<KEY> setting of a third-party
+// plugin. Plugins read only their own settings, never other configuration
+// or the environment
+func (c *Config) PluginSetting(plugin, key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	value, ok := c.PluginSettings[pluginSettingPrefix+envName(plugin)+"_"+strings.ToUpper(key)]
+	return value, ok
+}
+
+// Dir returns the LogAid state directory (~/.logaid)
+func Dir() string {
+	return getConfigDir()
 }
 
 func getConfigDir() string {
@@ -159,25 +450,60 @@ func getConfigDir() string {
 	return filepath.Join(homeDir, ".logaid")
 }
 
-func expandPaths() error {
+func expandPaths(cfg *Config) error {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return err
 	}
 
 	// Expand LogFile path
-	if filepath.HasPrefix(AppConfig.LogFile, "~/") {
-		AppConfig.LogFile = filepath.Join(homeDir, AppConfig.LogFile[2:])
+	if filepath.HasPrefix(cfg.LogFile, "~/") {
+		cfg.LogFile = filepath.Join(homeDir, cfg.LogFile[2:])
 	}
 
 	// Expand PluginsDir path
-	if filepath.HasPrefix(AppConfig.PluginsDir, "~/") {
-		AppConfig.PluginsDir = filepath.Join(homeDir, AppConfig.PluginsDir[2:])
+	if filepath.HasPrefix(cfg.PluginsDir, "~/") {
+		cfg.PluginsDir = filepath.Join(homeDir, cfg.PluginsDir[2:])
+	}
+
+	// Expand AICABundle path
+	if filepath.HasPrefix(cfg.AICABundle, "~/") {
+		cfg.AICABundle = filepath.Join(homeDir, cfg.AICABundle[2:])
+	}
+
+	// Expand AIAuditLogFile path
+	if filepath.HasPrefix(cfg.AIAuditLogFile, "~/") {
+		cfg.AIAuditLogFile = filepath.Join(homeDir, cfg.AIAuditLogFile[2:])
+	}
+
+	// Expand ExecAuditLogFile path
+	if filepath.HasPrefix(cfg.ExecAuditLogFile, "~/") {
+		cfg.ExecAuditLogFile = filepath.Join(homeDir, cfg.ExecAuditLogFile[2:])
+	}
+
+	// Expand LlamaModelPath path
+	if filepath.HasPrefix(cfg.LlamaModelPath, "~/") {
+		cfg.LlamaModelPath = filepath.Join(homeDir, cfg.LlamaModelPath[2:])
+	}
+
+	// Expand CacheDir path
+	if filepath.HasPrefix(cfg.CacheDir, "~/") {
+		cfg.CacheDir = filepath.Join(homeDir, cfg.CacheDir[2:])
 	}
 
 	// Expand HistoryFile path
-	if filepath.HasPrefix(AppConfig.HistoryFile, "~/") {
-		AppConfig.HistoryFile = filepath.Join(homeDir, AppConfig.HistoryFile[2:])
+	if filepath.HasPrefix(cfg.HistoryFile, "~/") {
+		cfg.HistoryFile = filepath.Join(homeDir, cfg.HistoryFile[2:])
+	}
+
+	// Expand SessionsDir path
+	if filepath.HasPrefix(cfg.SessionsDir, "~/") {
+		cfg.SessionsDir = filepath.Join(homeDir, cfg.SessionsDir[2:])
+	}
+
+	// Expand DaemonSocket path
+	if filepath.HasPrefix(cfg.DaemonSocket, "~/") {
+		cfg.DaemonSocket = filepath.Join(homeDir, cfg.DaemonSocket[2:])
 	}
 
 	return nil
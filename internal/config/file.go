@@ -0,0 +1,307 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/state"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// FilePath returns the YAML configuration file, ~/.logaid/config.yaml
+func FilePath() string {
+	return filepath.Join(getConfigDir(), "config.yaml")
+}
+
+// Keys returns every configuration key in sorted order
+func Keys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NormalizeKey returns key in the upper-case form used by Config, e.g.
+// log_level becomes LOG_LEVEL
+func NormalizeKey(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(key), "-", "_"))
+}
+
+// field returns the Config field for key
+func field(key string) (reflect.StructField, bool) {
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("mapstructure") == key {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// secretKeyParts mark keys whose values are not shown in change logs
+var secretKeyParts = []string{"KEY", "TOKEN", "SECRET", "PASSWORD", "WEBHOOK", "HEADERS"}
+
+// Changes describes the keys whose values differ between two configurations,
+// e.g. "LOG_LEVEL: info -> debug", in key order. Secret values are not shown
+func Changes(old, new *Config) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	oldValue, newValue := reflect.ValueOf(*old), reflect.ValueOf(*new)
+	var changes []string
+	for _, key := range Keys() {
+		f, _ := field(key)
+		before, after := oldValue.FieldByIndex(f.Index).Interface(), newValue.FieldByIndex(f.Index).Interface()
+		if reflect.DeepEqual(before, after) {
+			continue
+		}
+		if isSecret(key) {
+			changes = append(changes, key+" changed")
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s -> %s", key, describe(before), describe(after)))
+	}
+	return changes
+}
+
+// isSecret reports whether key holds a credential
+func isSecret(key string) bool {
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe formats a value for Changes, quoting empty strings
+func describe(value interface{}) string {
+	if s, ok := value.(string); ok && s == "" {
+		return `""`
+	}
+	return fmt.Sprint(value)
+}
+
+// ParseValue converts value to the type of key, so "true" becomes a bool for
+// ENABLE_COLORS and "5" an int for PLUGIN_TIMEOUT, and checks it is one of
+// the allowed values of keys such as AI_PROVIDER
+func ParseValue(key, value string) (interface{}, error) {
+	key = NormalizeKey(key)
+	f, ok := field(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown configuration key %s", key)
+	}
+
+	if allowed, ok := enums[key]; ok {
+		if !containsString(allowed, value) {
+			return nil, fmt.Errorf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+		}
+		return value, nil
+	}
+
+	switch f.Type.Kind() {
+	case reflect.Bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false, got %q", key, value)
+		}
+		return v, nil
+	case reflect.Int:
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number, got %q", key, value)
+		}
+		return v, nil
+	case reflect.Float64:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number, got %q", key, value)
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
+// Get returns the value of key currently in effect, from the environment,
+// .env, config.yaml or the defaults
+func Get(key string) (interface{}, error) {
+	key = NormalizeKey(key)
+	f, ok := field(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown configuration key %s", key)
+	}
+	cfg := Current()
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration not initialized")
+	}
+	return reflect.ValueOf(cfg).Elem().FieldByIndex(f.Index).Interface(), nil
+}
+
+// Override sets key to value for the rest of the run, above the environment
+// and config files, e.g. from a command-line flag. It survives Reload
+func Override(key string, value interface{}) error {
+	key = NormalizeKey(key)
+	f, ok := field(key)
+	if !ok {
+		return fmt.Errorf("unknown configuration key %s", key)
+	}
+	v := reflect.ValueOf(value)
+	if v.Type() != f.Type {
+		return fmt.Errorf("%s must be a %s, got %T", key, f.Type, value)
+	}
+
+	viper.Set(key, value)
+	if cfg := Current(); cfg != nil {
+		// A copy is published, as others may be reading the current one
+		updated := *cfg
+		reflect.ValueOf(&updated).Elem().FieldByIndex(f.Index).Set(v)
+		SetCurrent(&updated)
+	}
+	return nil
+}
+
+// SetInFile stores key with value in the YAML file at path, keeping the
+// comments and order of the other keys
+func SetInFile(path, key, value string) error {
+	key = NormalizeKey(key)
+	parsed, err := ParseValue(key, value)
+	if err != nil {
+		return err
+	}
+
+	return state.Update(path, 0644, func(data []byte) ([]byte, error) {
+		doc, mapping, err := parseFile(data)
+		if err != nil {
+			return nil, err
+		}
+		var valueNode yaml.Node
+		if err := valueNode.Encode(parsed); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+
+		if i := findKey(mapping, key); i >= 0 {
+			mapping.Content[i+1] = &valueNode
+		} else {
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+			mapping.Content = append(mapping.Content, keyNode, &valueNode)
+		}
+		return encodeFile(doc)
+	})
+}
+
+// UnsetInFile removes key from the YAML file at path
+func UnsetInFile(path, key string) error {
+	key = NormalizeKey(key)
+	if _, ok := field(key); !ok {
+		return fmt.Errorf("unknown configuration key %s", key)
+	}
+
+	return state.Update(path, 0644, func(data []byte) ([]byte, error) {
+		doc, mapping, err := parseFile(data)
+		if err != nil {
+			return nil, err
+		}
+		i := findKey(mapping, key)
+		if i < 0 {
+			return nil, fmt.Errorf("%s is not set in %s", key, path)
+		}
+		mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+		return encodeFile(doc)
+	})
+}
+
+// ValidateFile checks the values in the YAML file at path the way Validate
+// checks the loaded configuration. The error is for a file that cannot be
+// read or parsed
+func ValidateFile(path string) (ValidationErrors, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var errs ValidationErrors
+	for _, raw := range sortedKeys(values) {
+		key := NormalizeKey(raw)
+		if _, ok := field(key); !ok || values[raw] == nil {
+			continue
+		}
+		if err := checkValue(key, fmt.Sprint(values[raw])); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return append(errs, unknownKeys(path)...), nil
+}
+
+// parseFile decodes a YAML file into its document node and top-level
+// mapping, creating both for an empty file
+func parseFile(data []byte) (*yaml.Node, *yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("config file must be a mapping of keys to values")
+	}
+	return &doc, doc.Content[0], nil
+}
+
+// encodeFile renders a YAML document
+func encodeFile(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// findKey returns the index of key in a mapping node's content, matching
+// keys written in any case, or -1
+func findKey(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if NormalizeKey(mapping.Content[i].Value) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,342 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/fuzzy"
+	"github.com/ayushsharma-1/LogAid/internal/theme"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Providers lists the accepted AI_PROVIDER values
+var Providers = []string{"gemini", "openai", "llama", "mock"}
+
+// LogLevels lists the accepted LOG_LEVEL values
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// ContainerRuntimes lists the accepted CONTAINER_RUNTIME values
+var ContainerRuntimes = []string{"auto", "docker", "podman"}
+
+// enums are the keys that only accept a fixed set of values
+var enums = map[string][]string{
+	"AI_PROVIDER":       Providers,
+	"LOG_LEVEL":         LogLevels,
+	"THEME":             theme.Names(),
+	"CONTAINER_RUNTIME": ContainerRuntimes,
+}
+
+// ValidationError is a problem with one configuration key and how to fix it.
+// Warnings do not stop LogAid from starting
+type ValidationError struct {
+	Key     string
+	Problem string
+	Fix     string
+	Warning bool
+}
+
+func (e *ValidationError) Error() string {
+	if e.Fix == "" {
+		return fmt.Sprintf("%s: %s", e.Key, e.Problem)
+	}
+	return fmt.Sprintf("%s: %s (%s)", e.Key, e.Problem, e.Fix)
+}
+
+// ValidationErrors is every problem found in the configuration
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	lines := []string{"invalid configuration:"}
+	for _, err := range errs {
+		lines = append(lines, "  "+err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Errors returns the problems that are not warnings
+func (errs ValidationErrors) Errors() ValidationErrors {
+	var fatal ValidationErrors
+	for _, err := range errs {
+		if !err.Warning {
+			fatal = append(fatal, err)
+		}
+	}
+	return fatal
+}
+
+// add records a problem with key
+func (errs *ValidationErrors) add(key, fix, format string, args ...interface{}) {
+	*errs = append(*errs, &ValidationError{Key: key, Problem: fmt.Sprintf(format, args...), Fix: fix})
+}
+
+// Validate checks the loaded configuration: the type of every value, the
+// allowed values of enumerations, that paths are writable and that no
+// mutually exclusive options are combined
+func Validate() ValidationErrors {
+	if errs := validateTypes(); len(errs) > 0 {
+		return errs
+	}
+	// load does not put an invalid configuration in effect, so without one
+	// the values are checked as read
+	cfg := Current()
+	if cfg == nil {
+		var err error
+		if cfg, err = decode(); err != nil {
+			return nil
+		}
+	}
+	return validateValues(cfg)
+}
+
+// validateTypes checks the raw values from the environment, .env and
+// config.yaml before they are decoded, so a typo is reported instead of
+// being turned into a zero value
+func validateTypes() ValidationErrors {
+	var errs ValidationErrors
+	for _, key := range Keys() {
+		raw, ok := viper.Get(key).(string)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := checkValue(key, raw); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// checkValue reports a value that does not parse as the type of key or is
+// not one of its allowed values
+func checkValue(key, value string) *ValidationError {
+	_, err := ParseValue(key, value)
+	if err == nil {
+		return nil
+	}
+	return &ValidationError{Key: key, Problem: strings.TrimPrefix(err.Error(), key+" "), Fix: valueFix(key)}
+}
+
+// valueFix suggests a valid value for key, where it is set
+func valueFix(key string) string {
+	example := ""
+	f, _ := field(key)
+	switch f.Type.Kind() {
+	case reflect.Bool:
+		example = "true"
+	case reflect.Int:
+		example = "10"
+	case reflect.Float64:
+		example = "0.5"
+	}
+	if allowed, ok := enums[key]; ok {
+		example = allowed[0]
+	}
+	if example == "" {
+		return ""
+	}
+
+	// The environment and .env take precedence over config.yaml
+	if _, ok := os.LookupEnv(key); ok {
+		return fmt.Sprintf("set %s=%s in the environment or %s", key, example, filepath.Join(Dir(), ".env"))
+	}
+	return fmt.Sprintf("run 'logaid config set %s %s'", key, example)
+}
+
+// validateValues checks ranges, URLs, paths and option combinations in the
+// decoded configuration
+func validateValues(cfg *Config) ValidationErrors {
+	var errs ValidationErrors
+
+	v := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Tag.Get("mapstructure")
+		value := v.Field(i)
+		switch {
+		case value.Kind() == reflect.Int && value.Int() < 0:
+			// Counts, sizes and timeouts
+			errs.add(key, "use 0 or a positive number", "must not be negative, got %d", value.Int())
+		case enums[key] != nil && value.String() != "":
+			if err := checkValue(key, value.String()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if cfg.AITemperature < 0 || cfg.AITemperature > 2 {
+		errs.add("AI_TEMPERATURE", "use a value between 0 and 2", "out of range, got %g", cfg.AITemperature)
+	}
+
+	colors := []struct{ key, value string }{
+		{"COLOR_ERROR", cfg.ColorError},
+		{"COLOR_SUGGESTION", cfg.ColorSuggestion},
+		{"COLOR_SUCCESS", cfg.ColorSuccess},
+		{"COLOR_WARNING", cfg.ColorWarning},
+	}
+	for _, c := range colors {
+		if _, err := theme.Parse(c.value); err != nil {
+			errs.add(c.key, "use a name such as red or bright-cyan, a hex color such as #ff8800, or none", "%v", err)
+		}
+	}
+
+	urls := []struct{ key, value string }{
+		{"AI_PROXY_URL", cfg.AIProxyURL},
+		{"NOTIFY_SLACK_WEBHOOK", cfg.NotifySlackWebhook},
+		{"NOTIFY_WEBHOOK_URL", cfg.NotifyWebhookURL},
+		{"NOTIFY_DISCORD_WEBHOOK", cfg.NotifyDiscordWebhook},
+		{"TELEMETRY_ENDPOINT", cfg.TelemetryEndpoint},
+		{"FEEDBACK_ENDPOINT", cfg.FeedbackEndpoint},
+		{"OTEL_EXPORTER_OTLP_ENDPOINT", cfg.OTelEndpoint},
+	}
+	for _, u := range urls {
+		if u.value == "" {
+			continue
+		}
+		if parsed, err := url.Parse(u.value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			errs.add(u.key, "use a full URL such as https://example.com/path", "invalid URL %q", u.value)
+		}
+	}
+
+	paths := []struct {
+		key, dir string
+	}{
+		{"LOG_FILE", fileDir(cfg.LogFile)},
+		{"HISTORY_FILE", fileDir(cfg.HistoryFile)},
+		{"CACHE_DIR", cfg.CacheDir},
+		{"PLUGINS_DIR", cfg.PluginsDir},
+		{"DAEMON_SOCKET", fileDir(cfg.DaemonSocket)},
+		{"SESSIONS_DIR", cfg.SessionsDir},
+	}
+	if cfg.AIAuditLog {
+		if cfg.AIAuditLogFile == "" {
+			errs.add("AI_AUDIT_LOG_FILE", "set a file or turn off AI_AUDIT_LOG", "is empty while AI_AUDIT_LOG is on")
+		}
+		paths = append(paths, struct{ key, dir string }{"AI_AUDIT_LOG_FILE", fileDir(cfg.AIAuditLogFile)})
+	}
+	if cfg.ExecAuditLog {
+		if cfg.ExecAuditLogFile == "" {
+			errs.add("EXEC_AUDIT_LOG_FILE", "set a file or turn off EXEC_AUDIT_LOG", "is empty while EXEC_AUDIT_LOG is on")
+		}
+		paths = append(paths, struct{ key, dir string }{"EXEC_AUDIT_LOG_FILE", fileDir(cfg.ExecAuditLogFile)})
+	}
+	for _, p := range paths {
+		if p.dir == "" {
+			continue
+		}
+		if err := checkWritable(p.dir); err != nil {
+			errs.add(p.key, fmt.Sprintf("fix the permissions or run 'logaid config set %s <path>'", p.key), "%s is not writable: %v", p.dir, err)
+		}
+	}
+
+	if cfg.AssumeYes && cfg.AssumeNo {
+		errs.add("ASSUME_NO", "unset ASSUME_YES or ASSUME_NO", "cannot be combined with ASSUME_YES")
+	}
+
+	for _, a := range []struct{ key, addr string }{{"API_ADDR", cfg.APIAddr}, {"GRPC_ADDR", cfg.GRPCAddr}} {
+		if a.addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(a.addr); err != nil {
+			errs.add(a.key, "use host:port, e.g. 127.0.0.1:8765", "invalid address %q", a.addr)
+		}
+	}
+
+	if cfg.AICABundle != "" {
+		if _, err := os.Stat(cfg.AICABundle); err != nil {
+			errs.add("AI_CA_BUNDLE", "point it at a PEM file or unset it", "cannot read %s", cfg.AICABundle)
+		}
+		if cfg.AIInsecureSkipVerify {
+			errs.add("AI_INSECURE_SKIP_VERIFY", "unset one of them; prefer AI_CA_BUNDLE", "cannot be combined with AI_CA_BUNDLE")
+		}
+	}
+
+	errs = append(errs, unknownKeys(viper.ConfigFileUsed())...)
+	return errs
+}
+
+// unknownKeys warns about keys in config.yaml that LogAid does not know.
+// They may be settings for third-party plugins, so they are not errors
+func unknownKeys(path string) ValidationErrors {
+	var errs ValidationErrors
+	if path == "" {
+		return errs
+	}
+	var values map[string]interface{}
+	data, err := os.ReadFile(path)
+	if err != nil || yaml.Unmarshal(data, &values) != nil {
+		return errs
+	}
+	for _, raw := range sortedKeys(values) {
+		key := NormalizeKey(raw)
+		if _, ok := field(key); ok || strings.HasPrefix(key, "SYSTEM_PROMPT_") {
+			continue
+		}
+		fix := "remove it unless a plugin reads it"
+		if match := closestKey(key); match != "" {
+			fix = fmt.Sprintf("did you mean %s?", match)
+		}
+		errs = append(errs, &ValidationError{Key: raw, Problem: "unknown key in " + path, Fix: fix, Warning: true})
+	}
+	return errs
+}
+
+// closestKey returns the configuration key nearest to key, or ""
+func closestKey(key string) string {
+	best, bestDistance := "", fuzzy.MaxDistance(key)+1
+	for _, candidate := range Keys() {
+		if d := fuzzy.Distance(key, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// fileDir returns the directory of path, or "" when path is empty
+func fileDir(path string) string {
+	if path == "" {
+		return ""
+	}
+	return filepath.Dir(path)
+}
+
+// checkWritable reports whether files can be created in dir, or in its
+// nearest existing parent when dir does not exist yet
+func checkWritable(dir string) error {
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return err
+		}
+		dir = parent
+	}
+
+	file, err := os.CreateTemp(dir, ".logaid-*")
+	if err != nil {
+		return fmt.Errorf("cannot create files in %s", dir)
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
+
+// containsString reports whether list holds value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
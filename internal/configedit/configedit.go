@@ -0,0 +1,202 @@
+package configedit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// Edit describes setting a single key in a configuration file such as
+// ~/.npmrc (flat key=value) or ~/.gitconfig (INI sections)
+type Edit struct {
+	Path        string `json:"path"`
+	Section     string `json:"section,omitempty"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Separator   string `json:"separator,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// journalEntry records an applied edit so it can be reverted
+type journalEntry struct {
+	Path    string    `json:"path"`
+	Backup  string    `json:"backup,omitempty"`
+	Created bool      `json:"created"`
+	Time    time.Time `json:"time"`
+}
+
+// String returns a short description of the edit
+func (e *Edit) String() string {
+	target := e.Key
+	if e.Section != "" {
+		target = e.Section + "." + e.Key
+	}
+	return fmt.Sprintf("set %s=%s in %s", target, e.Value, e.Path)
+}
+
+// line renders the key/value line written to the file
+func (e *Edit) line() string {
+	sep := e.Separator
+	if sep == "" {
+		sep = "="
+	}
+	line := e.Key + sep + e.Value
+	if e.Section != "" {
+		line = "\t" + line
+	}
+	return line
+}
+
+// resolvedPath expands a leading ~/ in the edit path
+func (e *Edit) resolvedPath() string {
+	if strings.HasPrefix(e.Path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, e.Path[2:])
+		}
+	}
+	return e.Path
+}
+
+// Preview returns a diff-like description of what Apply would change
+func (e *Edit) Preview() (string, error) {
+	current, err := readFile(e.resolvedPath())
+	if err != nil {
+		return "", err
+	}
+
+	_, removed, added := e.render(current)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n", e.Path)
+	if removed != "" {
+		fmt.Fprintf(&b, "- %s\n", strings.TrimSpace(removed))
+	}
+	for _, line := range added {
+		fmt.Fprintf(&b, "+ %s\n", strings.TrimSpace(line))
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// Apply writes the edit, backing up the previous file so it can be reverted
+func (e *Edit) Apply() error {
+	path := e.resolvedPath()
+	current, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	_, statErr := os.Stat(path)
+	entry := journalEntry{Path: path, Created: os.IsNotExist(statErr), Time: time.Now()}
+
+	if !entry.Created {
+		backupDir := filepath.Join(config.Dir(), "backups")
+		if err := os.MkdirAll(backupDir, 0700); err != nil {
+			return fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		entry.Backup = filepath.Join(backupDir, fmt.Sprintf("%d-%s", entry.Time.UnixNano(), filepath.Base(path)))
+		if err := state.WriteFileAtomic(entry.Backup, []byte(current), 0600); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+
+	updated, _, _ := e.render(current)
+	if err := state.WriteFileAtomic(path, []byte(updated), 0644); err != nil {
+		return err
+	}
+
+	return state.WriteJSON(journalPath(), entry)
+}
+
+// RevertLast restores the file changed by the most recent Apply and returns its path
+func RevertLast() (string, error) {
+	var entry journalEntry
+	if err := state.ReadJSON(journalPath(), &entry); err != nil {
+		return "", fmt.Errorf("failed to read edit journal: %w", err)
+	}
+	if entry.Path == "" {
+		return "", fmt.Errorf("no configuration edit to revert")
+	}
+
+	if entry.Created {
+		if err := os.Remove(entry.Path); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to remove %s: %w", entry.Path, err)
+		}
+	} else {
+		backup, err := os.ReadFile(entry.Backup)
+		if err != nil {
+			return "", fmt.Errorf("failed to read backup: %w", err)
+		}
+		if err := state.WriteFileAtomic(entry.Path, backup, 0644); err != nil {
+			return "", fmt.Errorf("failed to restore %s: %w", entry.Path, err)
+		}
+	}
+
+	if err := os.Remove(journalPath()); err != nil {
+		return "", fmt.Errorf("failed to clear edit journal: %w", err)
+	}
+	return entry.Path, nil
+}
+
+// render returns the updated content together with the replaced line (if
+// any) and the lines that were added
+func (e *Edit) render(content string) (string, string, []string) {
+	var lines []string
+	if content != "" {
+		lines = strings.Split(strings.TrimRight(content, "\n"), "\n")
+	}
+
+	newLine := e.line()
+	start, end := 0, len(lines)
+
+	if e.Section != "" {
+		header := "[" + strings.ToLower(e.Section) + "]"
+		start = -1
+		for i, line := range lines {
+			trimmed := strings.ToLower(strings.TrimSpace(line))
+			if start == -1 && trimmed == header {
+				start = i + 1
+			} else if start != -1 && strings.HasPrefix(trimmed, "[") {
+				end = i
+				break
+			}
+		}
+
+		if start == -1 {
+			added := []string{"[" + e.Section + "]", newLine}
+			lines = append(lines, added...)
+			return strings.Join(lines, "\n") + "\n", "", added
+		}
+	}
+
+	for i := start; i < end; i++ {
+		key, _, found := strings.Cut(lines[i], "=")
+		if found && strings.TrimSpace(key) == e.Key {
+			removed := lines[i]
+			lines[i] = newLine
+			return strings.Join(lines, "\n") + "\n", removed, []string{newLine}
+		}
+	}
+
+	lines = append(lines[:end], append([]string{newLine}, lines[end:]...)...)
+	return strings.Join(lines, "\n") + "\n", "", []string{newLine}
+}
+
+func readFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+func journalPath() string {
+	return filepath.Join(config.Dir(), "backups", "last-edit.json")
+}
@@ -0,0 +1,253 @@
+// Package container runs commands inside a Docker or Podman container, for
+// 'logaid exec --container', and adapts suggested fixes to what the
+// container has: its package manager, no sudo and usually no systemd
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// Runtimes are the container engines LogAid can use, in the order they are
+// tried when CONTAINER_RUNTIME is auto
+var Runtimes = []string{"docker", "podman"}
+
+// probe prints what the container's environment offers, one key=value per line
+const probe = `. /etc/os-release 2>/dev/null; echo "os=$ID"; echo "uid=$(id -u)"
+for pm in apt-get apk dnf microdnf yum zypper pacman; do
+	if command -v $pm >/dev/null 2>&1; then echo "pm=$pm"; break; fi
+done
+command -v sudo >/dev/null 2>&1 && echo "sudo=1"
+[ -d /run/systemd/system ] && echo "systemd=1"
+exit 0`
+
+// Env describes the container commands run in
+type Env struct {
+	Runtime        string `json:"runtime"` // docker or podman
+	ID             string `json:"id"`
+	Name           string `json:"name"`            // as given to --container
+	OS             string `json:"os,omitempty"`    // ID from /etc/os-release, e.g. alpine
+	PackageManager string `json:"package_manager"` // e.g. apk, empty if none was found
+	Root           bool   `json:"root"`
+	Sudo           bool   `json:"sudo"`
+	Systemd        bool   `json:"systemd"`
+}
+
+// Runtime returns the container engine to use: CONTAINER_RUNTIME, or the
+// first of Runtimes installed
+func Runtime() (string, error) {
+	cfg := config.Current()
+	if cfg != nil && cfg.ContainerRuntime != "" && cfg.ContainerRuntime != "auto" {
+		return cfg.ContainerRuntime, nil
+	}
+	for _, runtime := range Runtimes {
+		if _, err := exec.LookPath(runtime); err == nil {
+			return runtime, nil
+		}
+	}
+	return "", fmt.Errorf("neither %s is installed", strings.Join(Runtimes, " nor "))
+}
+
+// Open finds the running container called target, a container name or ID or
+// the service of a Compose project in the working directory, and detects
+// its environment
+func Open(ctx context.Context, target string) (*Env, error) {
+	runtime, err := Runtime()
+	if err != nil {
+		return nil, err
+	}
+	id, err := resolve(ctx, runtime, target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Distroless images have no shell to probe with; commands still run
+	out, _ := exec.CommandContext(ctx, runtime, "exec", id, "sh", "-c", probe).Output()
+	env := ParseEnv(string(out))
+	env.Runtime, env.ID, env.Name = runtime, id, target
+	return &env, nil
+}
+
+// resolve returns the ID of the running container target names
+func resolve(ctx context.Context, runtime, target string) (string, error) {
+	out, err := exec.CommandContext(ctx, runtime, "inspect", "--type", "container", "--format", "{{.Id}} {{.State.Running}}", target).Output()
+	if err == nil {
+		id, running, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+		if running != "true" {
+			return "", fmt.Errorf("container %s is not running", target)
+		}
+		return id, nil
+	}
+
+	out, err = exec.CommandContext(ctx, runtime, "compose", "ps", "-q", target).Output()
+	if ids := strings.Fields(string(out)); err == nil && len(ids) > 0 {
+		return ids[0], nil
+	}
+	return "", fmt.Errorf("no running container or compose service %q", target)
+}
+
+// ParseEnv returns the environment described by the output of the probe
+// Open runs in the container
+func ParseEnv(out string) Env {
+	var e Env
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), "=")
+		switch key {
+		case "os":
+			e.OS = value
+		case "uid":
+			e.Root = value == "0"
+		case "pm":
+			e.PackageManager = value
+		case "sudo":
+			e.Sudo = true
+		case "systemd":
+			e.Systemd = true
+		}
+	}
+	return e
+}
+
+// Wrap returns cmd run inside the container with the runtime's exec, keeping
+// its input. The command's environment is the runtime's, not the container's
+func (e *Env) Wrap(cmd *exec.Cmd) *exec.Cmd {
+	args := []string{"exec"}
+	if cmd.Stdin != nil {
+		args = append(args, "-i")
+	}
+	if cmd.Dir != "" {
+		args = append(args, "-w", cmd.Dir)
+	}
+	args = append(append(args, e.ID), cmd.Args...)
+
+	wrapped := exec.Command(e.Runtime, args...)
+	wrapped.Stdin, wrapped.Stdout, wrapped.Stderr = cmd.Stdin, cmd.Stdout, cmd.Stderr
+	wrapped.Env = cmd.Env
+	return wrapped
+}
+
+// Describe returns the environment as a sentence for the AI prompt
+func (e *Env) Describe() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "The command runs inside a %s container", e.Runtime)
+	if e.OS != "" {
+		fmt.Fprintf(&b, " based on %s", e.OS)
+	}
+	if e.Root {
+		b.WriteString(" as root")
+	}
+	if e.PackageManager != "" {
+		fmt.Fprintf(&b, "; its package manager is %s", e.PackageManager)
+	}
+	if !e.Sudo || e.Root {
+		b.WriteString("; do not use sudo")
+	}
+	if !e.Systemd {
+		b.WriteString("; systemd is not running, so do not use systemctl")
+	}
+	b.WriteString(".")
+	return b.String()
+}
+
+// installCommands are how each package manager installs packages, without
+// asking, as containers have no one to answer
+var installCommands = map[string][]string{
+	"apt-get":  {"apt-get", "install", "-y"},
+	"apk":      {"apk", "add"},
+	"dnf":      {"dnf", "install", "-y"},
+	"microdnf": {"microdnf", "install", "-y"},
+	"yum":      {"yum", "install", "-y"},
+	"zypper":   {"zypper", "--non-interactive", "install"},
+	"pacman":   {"pacman", "-S", "--noconfirm"},
+}
+
+// installVerbs are the subcommands that install packages, by package manager
+var installVerbs = map[string][]string{
+	"apt":      {"install"},
+	"apt-get":  {"install"},
+	"apk":      {"add"},
+	"dnf":      {"install"},
+	"microdnf": {"install"},
+	"yum":      {"install"},
+	"zypper":   {"install", "in"},
+	"pacman":   {"-S", "-Sy", "-Syu"},
+	"brew":     {"install"},
+}
+
+// ErrNeedsSystemd is returned by Adapt for fixes that manage services with
+// systemctl in a container without systemd
+var ErrNeedsSystemd = errors.New("systemd is not running in the container")
+
+// Adapt rewrites a suggested command to work in the container: sudo is
+// dropped where it is not installed or not needed, and packages are
+// installed with the container's package manager
+func (e *Env) Adapt(command string) (string, error) {
+	fields := strings.Fields(command)
+	var sudo []string
+	if len(fields) > 0 && fields[0] == "sudo" {
+		// sudo and its own flags, such as -E
+		n := 1
+		for n < len(fields) && strings.HasPrefix(fields[n], "-") {
+			n++
+		}
+		sudo, fields = fields[:n], fields[n:]
+		if e.Root || !e.Sudo {
+			sudo = nil
+		}
+	}
+	if len(fields) == 0 {
+		return command, nil
+	}
+
+	if fields[0] == "systemctl" && !e.Systemd {
+		return "", ErrNeedsSystemd
+	}
+	if install, ok := installCommands[e.PackageManager]; ok && fields[0] != e.PackageManager {
+		if packages, ok := installedPackages(fields); ok {
+			fields = append(append([]string(nil), install...), packages...)
+		}
+	}
+	return strings.Join(append(sudo, fields...), " "), nil
+}
+
+// installedPackages returns the packages a package manager install command
+// installs
+func installedPackages(fields []string) ([]string, bool) {
+	if len(fields) < 3 {
+		return nil, false
+	}
+	verbs, ok := installVerbs[fields[0]]
+	if !ok {
+		return nil, false
+	}
+	for i, field := range fields[1:] {
+		if !contains(verbs, field) {
+			if strings.HasPrefix(field, "-") {
+				continue
+			}
+			return nil, false
+		}
+		var packages []string
+		for _, arg := range fields[i+2:] {
+			if !strings.HasPrefix(arg, "-") {
+				packages = append(packages, arg)
+			}
+		}
+		return packages, len(packages) > 0
+	}
+	return nil, false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
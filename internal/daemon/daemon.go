@@ -0,0 +1,288 @@
+// Package daemon serves suggestions over a unix socket, so every terminal
+// shares one process with the plugins, caches and AI client already loaded
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// Request types
+const (
+	RequestSuggest  = "suggest"
+	RequestStatus   = "status"
+	RequestStop     = "stop"
+	RequestLogLevel = "log-level"
+)
+
+// Request is a message from a client, one JSON object per line
+type Request struct {
+	Type    string `json:"type"`
+	Command string `json:"command,omitempty"`
+	Output  string `json:"output,omitempty"`
+	Level   string `json:"level,omitempty"` // for log-level, empty to only read it
+}
+
+// Response is the daemon's reply to a Request
+type Response struct {
+	Suggestion *engine.Suggestion `json:"suggestion,omitempty"`
+	Status     *Status            `json:"status,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// Status describes a running daemon
+type Status struct {
+	PID      int       `json:"pid"`
+	Started  time.Time `json:"started"`
+	Plugins  int       `json:"plugins"`
+	Requests int64     `json:"requests"` // suggestions served
+	LogLevel string    `json:"log_level"`
+}
+
+// SocketPath returns the configured socket, DAEMON_SOCKET, or "" when the
+// daemon is disabled
+func SocketPath() string {
+	cfg := config.Current()
+	if cfg == nil {
+		return ""
+	}
+	return cfg.DaemonSocket
+}
+
+// Server answers requests on a unix socket with an Engine
+type Server struct {
+	engine   *engine.Engine
+	path     string
+	started  time.Time
+	requests atomic.Int64
+	mu       sync.Mutex // plugins are not safe for concurrent use
+	stop     context.CancelFunc
+}
+
+// NewServer creates a server for eng listening on the socket at path
+func NewServer(eng *engine.Engine, path string) *Server {
+	return &Server{engine: eng, path: path}
+}
+
+// Serve listens on the socket until ctx is done or a client sends stop. A
+// socket left behind by a daemon that died is replaced
+func (s *Server) Serve(ctx context.Context) error {
+	if err := s.claimSocket(); err != nil {
+		return err
+	}
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.path, err)
+	}
+	defer os.Remove(s.path)
+	if err := os.Chmod(s.path, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to restrict %s: %w", s.path, err)
+	}
+
+	ctx, s.stop = context.WithCancel(ctx)
+	defer s.stop()
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	s.started = time.Now()
+	logger.Info(fmt.Sprintf("LogAid daemon listening on %s", s.path))
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go s.handle(ctx, conn)
+	}
+}
+
+// claimSocket prepares the socket path, refusing to replace a live daemon
+func (s *Server) claimSocket() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if _, err := os.Stat(s.path); err != nil {
+		return nil
+	}
+	if _, err := NewClient(s.path).Status(context.Background()); err == nil {
+		return fmt.Errorf("a daemon is already listening on %s", s.path)
+	}
+	if err := os.Remove(s.path); err != nil {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	return nil
+}
+
+// handle answers the requests on one connection
+func (s *Server) handle(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req Request
+		var resp Response
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp.Error = fmt.Sprintf("invalid request: %v", err)
+		} else {
+			resp = s.answer(ctx, req)
+		}
+		if err := encoder.Encode(resp); err != nil {
+			logger.Debug(fmt.Sprintf("Failed to reply to daemon client: %v", err))
+			return
+		}
+		if req.Type == RequestStop {
+			s.stop()
+			return
+		}
+	}
+}
+
+// answer handles a single request
+func (s *Server) answer(ctx context.Context, req Request) Response {
+	switch req.Type {
+	case RequestSuggest:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests.Add(1)
+		suggestion, err := s.engine.Suggest(ctx, req.Command, req.Output)
+		if err != nil {
+			return Response{Error: err.Error()}
+		}
+		return Response{Suggestion: suggestion}
+	case RequestStatus:
+		return Response{Status: &Status{
+			PID:      os.Getpid(),
+			Started:  s.started,
+			Plugins:  len(s.engine.Plugins()),
+			Requests: s.requests.Load(),
+			LogLevel: logger.Level(),
+		}}
+	case RequestLogLevel:
+		if req.Level == "" {
+			return Response{Status: &Status{LogLevel: logger.Level()}}
+		}
+		if !validLogLevel(req.Level) {
+			return Response{Error: fmt.Sprintf("unknown log level %q, use one of %s", req.Level, strings.Join(config.LogLevels, ", "))}
+		}
+		logger.SetLevel(req.Level)
+		logger.Info(fmt.Sprintf("Log level set to %s", logger.Level()))
+		return Response{Status: &Status{LogLevel: logger.Level()}}
+	case RequestStop:
+		logger.Info("LogAid daemon stopping")
+		return Response{}
+	}
+	return Response{Error: fmt.Sprintf("unknown request type %q", req.Type)}
+}
+
+// validLogLevel reports whether level is one of the LOG_LEVEL values
+func validLogLevel(level string) bool {
+	for _, valid := range config.LogLevels {
+		if strings.EqualFold(level, valid) {
+			return true
+		}
+	}
+	return false
+}
+
+// Client talks to a daemon. It implements engine.Suggester
+type Client struct {
+	Path    string
+	Timeout time.Duration // for requests whose context has no deadline
+}
+
+// NewClient creates a client for the daemon listening on path
+func NewClient(path string) *Client {
+	return &Client{Path: path, Timeout: 30 * time.Second}
+}
+
+// Suggest asks the daemon for a fix for a failed command
+func (c *Client) Suggest(ctx context.Context, command, output string) (*engine.Suggestion, error) {
+	resp, err := c.call(ctx, Request{Type: RequestSuggest, Command: command, Output: output})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Suggestion, nil
+}
+
+// Status asks the daemon how it is doing
+func (c *Client) Status(ctx context.Context) (*Status, error) {
+	resp, err := c.call(ctx, Request{Type: RequestStatus})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == nil {
+		return nil, errors.New("daemon sent no status")
+	}
+	return resp.Status, nil
+}
+
+// SetLogLevel changes the daemon's log level, or only reads it when level is
+// empty. It returns the level in effect
+func (c *Client) SetLogLevel(ctx context.Context, level string) (string, error) {
+	resp, err := c.call(ctx, Request{Type: RequestLogLevel, Level: level})
+	if err != nil {
+		return "", err
+	}
+	if resp.Status == nil {
+		return "", errors.New("daemon sent no log level")
+	}
+	return resp.Status.LogLevel, nil
+}
+
+// Stop asks the daemon to exit
+func (c *Client) Stop(ctx context.Context) error {
+	_, err := c.call(ctx, Request{Type: RequestStop})
+	return err
+}
+
+// call sends req and waits for the response
+func (c *Client) call(ctx context.Context, req Request) (*Response, error) {
+	if _, ok := ctx.Deadline(); !ok && c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", c.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return &resp, nil
+}
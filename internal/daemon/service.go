@@ -0,0 +1,108 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ServiceName is the systemd user unit 'logaid daemon install' writes
+const ServiceName = "logaid.service"
+
+// serviceMarker identifies a unit written by 'logaid daemon install'
+const serviceMarker = "# Installed by 'logaid daemon install'"
+
+// serviceUnit starts the daemon at login and restarts it if it crashes. The
+// socket and log paths are fixed at install time, so the service and the
+// terminals agree on them whatever environment systemd starts it with
+const serviceUnit = `%s; remove it with 'logaid daemon uninstall'.
+[Unit]
+Description=LogAid daemon, serving suggestions to every terminal
+Documentation=https://github.com/ayushsharma-1/LogAid
+
+[Service]
+Type=simple
+ExecStart=%s daemon
+Environment=%s
+Environment=%s
+Restart=on-failure
+RestartSec=5
+
+[Install]
+WantedBy=default.target
+`
+
+// ServiceDir returns the directory systemd reads the units of the user's
+// own services from
+func ServiceDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "systemd", "user"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "systemd", "user"), nil
+}
+
+// ServiceUnit returns the unit that runs the LogAid binary at executable as
+// a daemon listening on socket and logging to logFile
+func ServiceUnit(executable, socket, logFile string) (string, error) {
+	if socket == "" {
+		return "", errors.New("the daemon is disabled (DAEMON_SOCKET is empty)")
+	}
+	if !filepath.IsAbs(executable) || !filepath.IsAbs(socket) {
+		return "", errors.New("the executable and socket paths must be absolute")
+	}
+	return fmt.Sprintf(serviceUnit, serviceMarker, unitQuote(executable),
+		unitQuote("DAEMON_SOCKET="+socket), unitQuote("LOG_FILE="+logFile)), nil
+}
+
+// InstallService writes unit to dir, replacing a unit written before. A
+// unit of the same name LogAid did not write is left alone
+func InstallService(dir, unit string) (string, error) {
+	path := filepath.Join(dir, ServiceName)
+	if exists(path) && !ServiceInstalled(dir) {
+		return path, fmt.Errorf("%s was not written by LogAid; remove it first", path)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return path, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(path, []byte(unit), 0644); err != nil {
+		return path, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// UninstallService removes the unit written by InstallService from dir. It
+// returns false when there was none
+func UninstallService(dir string) (bool, error) {
+	if !ServiceInstalled(dir) {
+		return false, nil
+	}
+	path := filepath.Join(dir, ServiceName)
+	if err := os.Remove(path); err != nil {
+		return false, fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// ServiceInstalled reports whether dir has a unit written by InstallService
+func ServiceInstalled(dir string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, ServiceName))
+	return err == nil && strings.HasPrefix(string(data), serviceMarker)
+}
+
+// unitQuote quotes s as one word of a unit file setting, escaping the
+// specifiers systemd would otherwise expand
+func unitQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%").Replace(s)
+	return `"` + s + `"`
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,361 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// Check statuses
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+)
+
+// dialTimeout bounds the reachability check of the AI provider
+const dialTimeout = 5 * time.Second
+
+// providerHosts are the API endpoints the network check dials
+var providerHosts = map[string]string{
+	"gemini": "generativelanguage.googleapis.com:443",
+	"openai": "api.openai.com:443",
+}
+
+// shellRCFiles are the startup files, relative to the home directory, that a
+// LogAid shell hook is installed in
+var shellRCFiles = []string{".bashrc", ".zshrc", ".config/fish/config.fish"}
+
+// Result is the outcome of one check. Fix says how to resolve a warning or
+// failure
+type Result struct {
+	Name   string
+	Status string
+	Detail string
+	Fix    string
+}
+
+// Doctor diagnoses the LogAid environment
+type Doctor struct {
+	Home string // where shell startup files are looked for
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// New creates a doctor for the current user
+func New() *Doctor {
+	home, _ := os.UserHomeDir()
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	return &Doctor{Home: home, Dial: dialer.DialContext}
+}
+
+// Run performs every check in order
+func (d *Doctor) Run(ctx context.Context) []Result {
+	checks := []func(context.Context) Result{
+		d.checkConfig,
+		d.checkAPIKey,
+		d.checkNetwork,
+		d.checkPTY,
+		d.checkShellHook,
+		d.checkPlugins,
+		d.checkDirectories,
+	}
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, check(ctx))
+	}
+	return results
+}
+
+// Failed reports whether any result is a failure
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// checkConfig reports the problems config.Validate finds
+func (d *Doctor) checkConfig(ctx context.Context) Result {
+	result := Result{Name: "Configuration", Status: StatusOK}
+	problems := config.Validate()
+	if len(problems) > 0 {
+		result.Status = StatusWarn
+		if len(problems.Errors()) > 0 {
+			result.Status = StatusFail
+		}
+		var details, fixes []string
+		for _, problem := range problems {
+			details = append(details, fmt.Sprintf("%s: %s", problem.Key, problem.Problem))
+			if problem.Fix != "" {
+				fixes = append(fixes, problem.Fix)
+			}
+		}
+		result.Detail = strings.Join(details, "; ")
+		result.Fix = strings.Join(fixes, "; ")
+		return result
+	}
+	if config.Current() == nil {
+		result.Status = StatusFail
+		result.Detail = "configuration is not loaded"
+		result.Fix = fmt.Sprintf("Check %s and %s for syntax errors", filepath.Join(config.Dir(), ".env"), config.FilePath())
+		return result
+	}
+
+	result.Detail = fmt.Sprintf("AI provider %s, config in %s", ai.ConfiguredProvider(), config.Dir())
+	return result
+}
+
+// checkAPIKey verifies that the configured provider has credentials, or a
+// model file for llama
+func (d *Doctor) checkAPIKey(ctx context.Context) Result {
+	cfg := config.Current()
+	result := Result{Name: "API key", Status: StatusOK}
+	if cfg == nil {
+		result.Status = StatusFail
+		result.Detail = "configuration is not loaded"
+		return result
+	}
+
+	envFile := filepath.Join(config.Dir(), ".env")
+	switch provider := ai.ConfiguredProvider(); provider {
+	case "gemini":
+		if cfg.GeminiAPIKey == "" {
+			result.Status = StatusFail
+			result.Detail = "GEMINI_API_KEY is not set"
+			result.Fix = fmt.Sprintf("Add GEMINI_API_KEY=<key> to %s (get one at https://aistudio.google.com/app/apikey)", envFile)
+			return result
+		}
+		result.Detail = "GEMINI_API_KEY is set"
+	case "openai":
+		if cfg.OpenAIAPIKey == "" {
+			result.Status = StatusFail
+			result.Detail = "OPENAI_API_KEY is not set"
+			result.Fix = fmt.Sprintf("Add OPENAI_API_KEY=<key> to %s (get one at https://platform.openai.com/api-keys)", envFile)
+			return result
+		}
+		result.Detail = "OPENAI_API_KEY is set"
+	case "llama":
+		if !ai.LlamaSupported() {
+			result.Status = StatusFail
+			result.Detail = ai.ErrLlamaUnavailable.Error()
+			result.Fix = "Rebuild LogAid with -tags llama, or set AI_PROVIDER to gemini or openai"
+			return result
+		}
+		if _, err := os.Stat(cfg.LlamaModelPath); cfg.LlamaModelPath == "" || err != nil {
+			result.Status = StatusFail
+			result.Detail = fmt.Sprintf("model file %q not found", cfg.LlamaModelPath)
+			result.Fix = "Set LLAMA_MODEL_PATH to a GGUF model file"
+			return result
+		}
+		result.Detail = fmt.Sprintf("using model %s", cfg.LlamaModelPath)
+	default:
+		result.Detail = fmt.Sprintf("%s provider needs no key", provider)
+	}
+	return result
+}
+
+// checkNetwork dials the provider API, or AI_PROXY_URL when one is set
+func (d *Doctor) checkNetwork(ctx context.Context) Result {
+	cfg := config.Current()
+	result := Result{Name: "Network", Status: StatusOK}
+	addr, ok := providerHosts[ai.ConfiguredProvider()]
+	if !ok {
+		result.Detail = "provider runs locally, no network needed"
+		return result
+	}
+	target := "AI provider"
+	if cfg != nil && cfg.AIProxyURL != "" {
+		proxy, err := url.Parse(cfg.AIProxyURL)
+		if err != nil || proxy.Host == "" {
+			result.Status = StatusFail
+			result.Detail = fmt.Sprintf("invalid AI_PROXY_URL %q", cfg.AIProxyURL)
+			result.Fix = "Set AI_PROXY_URL to a URL such as http://proxy.example.com:3128"
+			return result
+		}
+		addr = proxy.Host
+		if proxy.Port() == "" {
+			addr = net.JoinHostPort(proxy.Hostname(), "80")
+		}
+		target = "proxy"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	conn, err := d.Dial(ctx, "tcp", addr)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("cannot reach %s %s: %v", target, addr, err)
+		result.Fix = "Check your connection, firewall and AI_PROXY_URL; offline fixes from plugins keep working"
+		return result
+	}
+	conn.Close()
+	result.Detail = fmt.Sprintf("reached %s %s (run 'logaid ai test' to check the API itself)", target, addr)
+	return result
+}
+
+// checkPTY verifies that pseudo-terminals can be allocated
+func (d *Doctor) checkPTY(ctx context.Context) Result {
+	result := Result{Name: "PTY support", Status: StatusOK}
+	if runtime.GOOS == "windows" {
+		result.Status = StatusWarn
+		result.Detail = "pseudo-terminals are not supported on Windows"
+		result.Fix = "Run commands through 'logaid exec', or use LogAid inside WSL"
+		return result
+	}
+
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("cannot open /dev/ptmx: %v", err)
+		result.Fix = "Mount devpts (mount -t devpts devpts /dev/pts), then run logaid doctor again"
+		return result
+	}
+	ptmx.Close()
+	result.Detail = "/dev/ptmx is available"
+	return result
+}
+
+// checkShellHook looks for LogAid in the shell startup files
+func (d *Doctor) checkShellHook(ctx context.Context) Result {
+	result := Result{Name: "Shell hook"}
+	for _, name := range shellRCFiles {
+		path := filepath.Join(d.Home, name)
+		data, err := os.ReadFile(path)
+		if err == nil && strings.Contains(string(data), "logaid") {
+			result.Status = StatusOK
+			result.Detail = fmt.Sprintf("found in %s", path)
+			return result
+		}
+	}
+	result.Status = StatusWarn
+	result.Detail = fmt.Sprintf("LogAid is not started from %s", strings.Join(shellRCFiles, ", "))
+	result.Fix = "Add 'logaid' to your shell startup file, or wrap commands with 'logaid exec <command>'"
+	return result
+}
+
+// checkPlugins reports enabled plugins that do not exist and files in
+// PLUGINS_DIR that fail to load
+func (d *Doctor) checkPlugins(ctx context.Context) Result {
+	cfg := config.Current()
+	result := Result{Name: "Plugins", Status: StatusOK}
+	if cfg == nil {
+		result.Status = StatusFail
+		result.Detail = "configuration is not loaded"
+		return result
+	}
+
+	loaded := plugins.LoadAllPlugins()
+	names := make(map[string]bool, len(loaded))
+	for _, plugin := range loaded {
+		names[plugin.Name()] = true
+	}
+
+	var unknown []string
+	for _, name := range strings.Split(cfg.EnablePlugins, ",") {
+		if name = strings.TrimSpace(name); name != "" && !names[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	var broken []string
+	if dir := cfg.PluginsDir; dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			broken = append(broken, fmt.Sprintf("cannot read %s: %v", dir, err))
+		}
+		timeout := time.Duration(cfg.PluginTimeout) * time.Second
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			if _, err := plugins.LoadExternalPlugin(filepath.Join(dir, entry.Name()), timeout); err != nil {
+				broken = append(broken, err.Error())
+			}
+		}
+	}
+
+	switch {
+	case len(broken) > 0:
+		result.Status = StatusFail
+		result.Detail = strings.Join(broken, "; ")
+		result.Fix = fmt.Sprintf("Fix or remove the broken files in %s, or reinstall them with 'logaid plugin update'", cfg.PluginsDir)
+	case len(unknown) > 0:
+		result.Status = StatusWarn
+		result.Detail = fmt.Sprintf("ENABLE_PLUGINS names unknown plugins: %s", strings.Join(unknown, ", "))
+		result.Fix = "Remove them from ENABLE_PLUGINS or install them with 'logaid plugin install'"
+	default:
+		result.Detail = fmt.Sprintf("%d plugins loaded", len(loaded))
+	}
+	return result
+}
+
+// checkDirectories verifies that LogAid can write its log, cache, history,
+// plugins and config directories
+func (d *Doctor) checkDirectories(ctx context.Context) Result {
+	cfg := config.Current()
+	result := Result{Name: "Directories", Status: StatusOK}
+	if cfg == nil {
+		result.Status = StatusFail
+		result.Detail = "configuration is not loaded"
+		return result
+	}
+
+	dirs := []struct{ key, dir string }{
+		{"LOG_FILE", parentDir(cfg.LogFile)},
+		{"CACHE_DIR", cfg.CacheDir},
+		{"HISTORY_FILE", parentDir(cfg.HistoryFile)},
+		{"PLUGINS_DIR", cfg.PluginsDir},
+		{"config", config.Dir()},
+	}
+	var failed, keys []string
+	for _, entry := range dirs {
+		if entry.dir == "" {
+			continue
+		}
+		if err := writable(entry.dir); err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%s): %v", entry.dir, entry.key, err))
+			keys = append(keys, entry.key)
+		}
+	}
+	if len(failed) > 0 {
+		result.Status = StatusFail
+		result.Detail = strings.Join(failed, "; ")
+		result.Fix = fmt.Sprintf("Fix the permissions (chmod u+w) or point %s at a writable location", strings.Join(keys, ", "))
+		return result
+	}
+	result.Detail = "log, cache, history, plugins and config directories are writable"
+	return result
+}
+
+// parentDir returns the directory of path, or "" when path is empty
+func parentDir(path string) string {
+	if path == "" {
+		return ""
+	}
+	return filepath.Dir(path)
+}
+
+// writable creates dir if needed and checks a file can be created in it
+func writable(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, ".logaid-doctor-*")
+	if err != nil {
+		return err
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
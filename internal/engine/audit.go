@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/audit"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+)
+
+// auditExecution appends the fix that was just executed to the audit log
+// when EXEC_AUDIT_LOG is enabled. err is the result of running it
+func (e *Engine) auditExecution(suggestion *Suggestion, err error) {
+	cfg := config.Current()
+	if cfg == nil || !cfg.ExecAuditLog || cfg.ExecAuditLogFile == "" {
+		return
+	}
+
+	// The TUI and API apply suggestions the user picked, outside a report
+	confirmation := DecisionAccepted
+	if e.report != nil && e.report.Decision != "" {
+		confirmation = e.report.Decision
+	}
+	record := audit.Record{
+		Time:          time.Now().UTC(),
+		FailedCommand: suggestion.command,
+		Command:       suggestion.Command,
+		Source:        suggestion.Source,
+		Confirmation:  confirmation,
+		ExitCode:      exitCode(err),
+	}
+	if cfg.MaskSecrets {
+		record.FailedCommand = redact.Secrets(record.FailedCommand)
+		record.Command = redact.Secrets(record.Command)
+	}
+	if u, err := user.Current(); err == nil {
+		record.User = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		record.Host = host
+	}
+
+	if err := audit.Append(cfg.ExecAuditLogFile, record); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to write audit log: %v", err))
+	}
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/container"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// SetContainer runs the monitored commands and their fixes inside the
+// container env describes, and adapts suggestions to it. nil runs them on
+// the host
+func (e *Engine) SetContainer(env *container.Env) {
+	e.container = env
+}
+
+// adaptToContainer rewrites the commands of a suggestion for the container,
+// dropping the ones that cannot work there. Configuration changes are
+// dropped too, as they would edit the host's files
+func (e *Engine) adaptToContainer(s *Suggestion) *Suggestion {
+	if e.container == nil || s == nil {
+		return s
+	}
+	if s.ConfigEdit != nil {
+		logger.Debug(fmt.Sprintf("Not suggesting a configuration change in container %s: %s", e.container.Name, s.Text()))
+		return nil
+	}
+
+	var candidates []string
+	for _, candidate := range s.Candidates() {
+		adapted, err := e.container.Adapt(candidate)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Not suggesting %s: %v", candidate, err))
+			continue
+		}
+		if adapted != candidate {
+			logger.Debug(fmt.Sprintf("Adapted %s to the container: %s", candidate, adapted))
+			s.AutoApply = false
+		}
+		if !contains(candidates, adapted) {
+			candidates = append(candidates, adapted)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	s.Command, s.Alternatives = candidates[0], candidates[1:]
+	return s
+}
@@ -9,48 +9,83 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/cache"
 	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/configedit"
+	"github.com/ayushsharma-1/LogAid/internal/container"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/learning"
 	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/metrics"
+	"github.com/ayushsharma-1/LogAid/internal/model"
+	"github.com/ayushsharma-1/LogAid/internal/notify"
 	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/ayushsharma-1/LogAid/internal/risk"
+	"github.com/ayushsharma-1/LogAid/internal/session"
+	"github.com/ayushsharma-1/LogAid/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Engine represents the core LogAid engine
 type Engine struct {
-	plugins []plugins.Plugin
+	mu       sync.RWMutex // guards plugins, which Watch replaces, and remote
+	plugins  []plugins.Plugin
+	notifier *notify.Router
+	cache    *cache.Cache
+	model    *model.Store
+	learning *learning.Store
+	history  *history.Store
+	metrics  *metrics.Store
+	remote   Suggester // asked before the plugins, see NewRemote
+
+	container *container.Env // where commands and fixes run, the host when nil
+
+	// Where commands and fixes read and write; the terminal when stdout is nil
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	report         *Report // set while monitoring a command
+	recorder       *session.Recorder
 }
 
 // New creates a new Engine instance
 func New() *Engine {
 	return &Engine{
-		plugins: plugins.LoadAllPlugins(),
+		plugins:  plugins.LoadAllPlugins(),
+		notifier: notify.NewFromConfig(),
+		cache:    cache.NewFromConfig(),
+		model:    model.NewFromConfig(),
+		learning: learning.NewFromConfig(),
+		history:  history.NewFromConfig(),
+		metrics:  metrics.NewFromConfig(),
 	}
 }
 
+// notify sends an event through the configured notification router, if any
+func (e *Engine) notify(event notify.Event) {
+	e.notifier.Notify(context.Background(), event)
+}
+
 // ProcessError processes a command error and returns a suggestion
 func (e *Engine) ProcessError(ctx context.Context, command, output string) (string, error) {
-	// Try plugins first
-	for _, plugin := range e.plugins {
-		if plugin.Match(command, output) {
-			suggestion := plugin.Suggest(command, output)
-			if suggestion != "" {
-				return suggestion, nil
-			}
-		}
-	}
-
-	// If no plugin matched, use AI directly
-	suggestion, err := ai.GetSuggestion(ctx, fmt.Sprintf("Command: %s\nError: %s\nProvide a corrected command:", command, output))
+	ctx, span := telemetry.Start(ctx, "engine.ProcessError", attribute.String("logaid.tool", tool(command)))
+	suggestion, err := e.Suggest(ctx, command, output)
+	telemetry.End(span, err)
 	if err != nil {
-		return "", fmt.Errorf("failed to get AI suggestion: %w", err)
+		return "", err
+	}
+	if suggestion == nil {
+		return "", nil
 	}
 
-	return suggestion, nil
+	return suggestion.Text(), nil
 }
 
-// detectError checks if the output contains error indicators
-func (e *Engine) detectError(output string) bool {
+// DetectError checks if the output contains error indicators
+func (e *Engine) DetectError(output string) bool {
 	errorIndicators := []string{
 		"error:",
 		"Error:",
@@ -81,70 +116,400 @@ func (e *Engine) detectError(output string) bool {
 	return false
 }
 
-func (e *Engine) handleError(command, output string) bool {
-	logger.Warn("Error detected in command output")
+// Analyze finds a fix for a command whose output shows an error and
+// proposes it, without asking for confirmation. It returns nil when there
+// is no error or no fix
+func (e *Engine) Analyze(ctx context.Context, command, output string) (*Suggestion, error) {
+	if !e.DetectError(output) {
+		return nil, nil
+	}
+	e.notify(notify.Event{Type: notify.EventErrorDetected, Command: command, Output: output})
 
-	// Try plugins first
-	for _, plugin := range e.plugins {
-		if plugin.Match(command, output) {
-			suggestion := plugin.Suggest(command, output)
-			if suggestion != "" {
-				return e.presentSuggestion(command, output, suggestion, plugin.Name())
-			}
-		}
+	suggestion, err := e.Suggest(ctx, command, output)
+	if err != nil || suggestion == nil {
+		return nil, err
 	}
+	e.Propose(command, output, suggestion)
+	return suggestion, nil
+}
+
+func (e *Engine) handleError(command, output string) bool {
+	logger.Warn("Error detected in command output")
+	e.notify(notify.Event{Type: notify.EventErrorDetected, Command: command, Output: output})
 
-	// If no plugin matched, use AI
-	ctx := context.Background()
-	suggestion, err := ai.GetSuggestion(ctx, fmt.Sprintf("Command: %s\nError: %s\nProvide a corrected command:", command, output))
+	ctx, span := telemetry.Start(context.Background(), "engine.ProcessError", attribute.String("logaid.tool", tool(command)))
+	suggestion, err := e.Suggest(ctx, command, output)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to get AI suggestion: %v", err))
+		e.report.failed(err)
+		telemetry.End(span, err)
 		return false
 	}
 
-	if suggestion != "" {
-		return e.presentSuggestion(command, output, suggestion, "AI")
+	fixed := false
+	if suggestion != nil {
+		fixed = e.presentSuggestion(ctx, command, output, suggestion)
 	}
-
-	return false
+	span.SetAttributes(attribute.Bool("logaid.fixed", fixed))
+	telemetry.End(span, nil)
+	return fixed
 }
 
-func (e *Engine) presentSuggestion(command, output, suggestion, source string) bool {
-	logger.Warn(fmt.Sprintf("Suggestion from %s:", source))
-	logger.Info(fmt.Sprintf("💡 %s", suggestion))
+func (e *Engine) presentSuggestion(ctx context.Context, command, output string, suggestion *Suggestion) bool {
+	assessment, tiered := suggestion.Risk(), risk.Enabled()
+	// Safe fixes run on Enter, the rest have to be asked for
+	oneKey := tiered && assessment.Level == risk.Safe && len(suggestion.Alternatives) == 0
+	prompt := "Execute this suggestion? [y/N]: "
+	if oneKey {
+		prompt = "Execute this suggestion? [Y/n]: "
+	}
+	if len(suggestion.Alternatives) > 0 {
+		prompt = fmt.Sprintf("Choose a suggestion to execute [1-%d, Enter to skip]: ", len(suggestion.Candidates()))
+	}
+	switch {
+	case logger.Quiet():
+		// Just the fixes, one per line
+		for _, candidate := range append([]string{suggestion.Text()}, suggestion.Alternatives...) {
+			fmt.Fprintln(logger.Console(), candidate)
+		}
+	case len(suggestion.Alternatives) > 0:
+		logger.Warn(fmt.Sprintf("Suggestions from %s:", suggestion.Source))
+		for i, candidate := range suggestion.Candidates() {
+			logger.Suggestion(fmt.Sprintf("💡 %d) %s%s", i+1, candidate, riskTag(risk.Classify(candidate))))
+		}
+	default:
+		logger.Warn(fmt.Sprintf("Suggestion from %s:", suggestion.Source))
+		logger.Suggestion(fmt.Sprintf("💡 %s%s", suggestion.Text(), riskTag(assessment)))
+	}
+	if suggestion.Note != "" {
+		logger.Info(suggestion.Note)
+	}
 
-	// Check if auto-confirm is enabled
-	if config.AppConfig != nil && config.AppConfig.AutoConfirm {
+	if suggestion.ConfigEdit != nil {
+		if suggestion.ConfigEdit.Description != "" {
+			logger.Info(suggestion.ConfigEdit.Description)
+		}
+		preview, err := suggestion.ConfigEdit.Preview()
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to preview configuration change: %v", err))
+			return false
+		}
+		fmt.Fprintln(logger.Console(), preview)
+		prompt = "Apply this configuration change? [y/N]: "
+	}
+
+	e.Propose(command, output, suggestion)
+
+	destructive := tiered && assessment.Level == risk.Destructive
+	switch mode := confirmation(); {
+	case mode == confirmNever:
+		logger.Info("Not executing suggestion (suggest only)")
+		e.report.decide(DecisionSuggested)
+		return false
+	case mode == confirmAlways && destructive:
+		logger.Warn(fmt.Sprintf("Not executing a destructive suggestion without confirmation: %s", assessment.Reason))
+		e.report.decide(DecisionSuggested)
+		return false
+	case mode == confirmAlways:
 		logger.Info("Auto-confirm enabled, executing suggestion...")
-		return e.executeSuggestion(suggestion)
+		e.report.decide(DecisionAutoConfirmed)
+		return e.apply(ctx, suggestion)
+	case suggestion.AutoApply && suggestion.ConfigEdit == nil && !destructive:
+		logger.Info(fmt.Sprintf("Unambiguous fix from %s, executing suggestion...", suggestion.Source))
+		e.report.decide(DecisionAutoConfirmed)
+		return e.apply(ctx, suggestion)
 	}
 
 	// Prompt user for confirmation
-	logger.Info("Execute this suggestion? [y/N]: ")
-
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	in, _, _ := e.streams()
+	if in == nil {
+		in = strings.NewReader("")
+	}
+	reader := bufio.NewReader(in)
+	input, err := ask(reader, prompt)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to read user input: %v", err))
 		return false
 	}
+	if oneKey && strings.TrimSpace(input) == "" {
+		input = "y"
+	}
 
-	input = strings.TrimSpace(strings.ToLower(input))
-	if input == "y" || input == "yes" {
-		logger.Info("Executing suggestion...")
-		return e.executeSuggestion(suggestion)
-	} else {
+	choice, ok := suggestion.Pick(input)
+	if ok && tiered && suggestion.ConfigEdit == nil {
+		ok = confirmDestructive(reader, choice)
+	}
+	if !ok {
 		logger.Info("Suggestion ignored.")
+		e.report.decide(DecisionRejected)
+		e.Dismiss(suggestion)
+		return false
+	}
+	e.Choose(suggestion, choice)
+	logger.Info("Executing suggestion...")
+	e.report.decide(DecisionAccepted)
+	return e.apply(ctx, suggestion)
+}
+
+// ask prints prompt and reads the answer from reader
+func ask(reader *bufio.Reader, prompt string) (string, error) {
+	if logger.Quiet() {
+		fmt.Fprint(logger.Console(), prompt)
+	} else {
+		logger.Info(prompt)
+	}
+	return reader.ReadString('\n')
+}
+
+// confirmDestructive asks for a destructive command to be typed back before
+// it runs, so that a reflexive "y" cannot delete anything. Other commands
+// need no second confirmation
+func confirmDestructive(reader *bufio.Reader, command string) bool {
+	assessment := risk.Classify(command)
+	if assessment.Level != risk.Destructive {
+		return true
+	}
+	logger.Warn(fmt.Sprintf("This fix is destructive: %s", assessment.Reason))
+	input, err := ask(reader, "Type the command to run it, or press Enter to cancel: ")
+	if err != nil {
+		return false
+	}
+	typed := strings.Join(strings.Fields(input), " ")
+	if typed != strings.Join(strings.Fields(command), " ") {
+		if typed != "" {
+			logger.Warn("The command typed does not match the suggestion")
+		}
+		return false
+	}
+	return true
+}
+
+// riskTag labels a fix that is not safe in the list of suggestions
+func riskTag(assessment risk.Assessment) string {
+	if assessment.Level == risk.Safe {
+		return ""
+	}
+	return fmt.Sprintf(" [%s: %s]", assessment.Level, assessment.Reason)
+}
+
+// Propose records that suggestion was offered for a command that failed
+// with output: it is sent to the notification channels and the history
+func (e *Engine) Propose(command, output string, suggestion *Suggestion) {
+	suggestion.command, suggestion.output = command, output
+	e.notify(suggestion.event(notify.EventFixProposed))
+	e.recordSuggestion(command, output, suggestion)
+	e.report.suggested(suggestion)
+}
+
+// Choose makes choice, an alternative or a command the user edited, the
+// suggestion's command and remembers it for similar errors
+func (e *Engine) Choose(suggestion *Suggestion, choice string) {
+	if choice == "" || choice == suggestion.Command || suggestion.ConfigEdit != nil {
+		return
+	}
+	e.cacheSuggestion(suggestion.command, suggestion.output, choice)
+	suggestion.Command = choice
+	e.report.suggested(suggestion)
+}
+
+// Dismiss records that the user rejected a proposed suggestion
+func (e *Engine) Dismiss(suggestion *Suggestion) {
+	e.recordOutcome(suggestion, false, false)
+	e.forgetSuggestion(suggestion.command, suggestion.output, suggestion)
+	e.updateHistory(suggestion, history.StatusRejected)
+	e.learnOutcome(suggestion, (*learning.Store).Rejected)
+}
+
+// How suggestions are confirmed
+const (
+	confirmAsk    = iota // prompt on stdin
+	confirmAlways        // --yes, ASSUME_YES or AUTO_CONFIRM
+	confirmNever         // --no or ASSUME_NO: suggest only
+)
+
+// confirmation returns how suggestions are confirmed. ASSUME_YES and
+// ASSUME_NO, set by --yes and --no, take precedence over AUTO_CONFIRM
+func confirmation() int {
+	cfg := config.Current()
+	switch {
+	case cfg == nil:
+		return confirmAsk
+	case cfg.AssumeNo:
+		return confirmNever
+	case cfg.AssumeYes, cfg.AutoConfirm:
+		return confirmAlways
+	}
+	return confirmAsk
+}
+
+// Apply executes a proposed fix, or makes its configuration change, and
+// reports whether it worked
+func (e *Engine) Apply(suggestion *Suggestion) bool {
+	return e.apply(context.Background(), suggestion)
+}
+
+// apply is Apply with the trace context of the error being fixed
+func (e *Engine) apply(ctx context.Context, suggestion *Suggestion) bool {
+	var ok bool
+	if suggestion.ConfigEdit != nil {
+		ok = e.applyConfigEdit(suggestion.ConfigEdit)
+	} else {
+		err := e.executeSuggestion(ctx, suggestion)
+		code := exitCode(err)
+		suggestion.exitCode = &code
+		e.auditExecution(suggestion, err)
+		e.report.ran(err)
+		ok = err == nil
+	}
+	e.report.fixed(ok)
+
+	e.recordOutcome(suggestion, true, ok)
+	eventType := notify.EventFixFailed
+	if ok {
+		eventType = notify.EventFixApplied
+		e.learnFix(suggestion.command, suggestion)
+		e.updateHistory(suggestion, history.StatusApplied)
+		e.learnOutcome(suggestion, (*learning.Store).Succeeded)
+	} else {
+		e.forgetSuggestion(suggestion.command, suggestion.output, suggestion)
+		e.updateHistory(suggestion, history.StatusFailed)
+		e.learnOutcome(suggestion, (*learning.Store).Failed)
+	}
+	e.notify(suggestion.event(eventType))
+
+	return ok
+}
+
+// forgetSuggestion drops a rejected or failed AI suggestion from the cache so
+// it is not offered again
+func (e *Engine) forgetSuggestion(command, output string, suggestion *Suggestion) {
+	if e.cache == nil || (suggestion.Source != "AI" && suggestion.Source != "cache") {
+		return
+	}
+	if err := e.cache.Forget(command, output); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to update suggestion cache: %v", err))
+	}
+}
+
+// recordSuggestion adds a proposed fix to the history so it can be explained
+// later with 'logaid explain'
+func (e *Engine) recordSuggestion(command, output string, suggestion *Suggestion) {
+	if e.history == nil {
+		return
+	}
+	entry := history.Entry{
+		Command:    command,
+		Output:     ai.TruncateOutput(output),
+		Suggestion: suggestion.Text(),
+		Source:     suggestion.Source,
+		Status:     history.StatusProposed,
+		Provider:   suggestion.provider,
+		Model:      suggestion.model,
+		LatencyMS:  suggestion.latency.Milliseconds(),
+	}
+	if suggestion.fromPlugin {
+		entry.Plugin = suggestion.Source
+	}
+	entry.Session = history.CurrentSession()
+	if dir, err := os.Getwd(); err == nil {
+		entry.Dir = dir
+	}
+	if e.report != nil {
+		entry.ExitCode = e.report.ExitCode
+	}
+	entry, err := e.history.Add(entry)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to record history: %v", err))
+		return
+	}
+	suggestion.historyID = entry.ID
+}
+
+// updateHistory records the outcome of a proposed fix
+func (e *Engine) updateHistory(suggestion *Suggestion, status string) {
+	if e.history == nil || suggestion.historyID == 0 {
+		return
+	}
+	err := e.history.Update(suggestion.historyID, func(entry *history.Entry) {
+		entry.Suggestion = suggestion.Text()
+		entry.Status = status
+		if suggestion.exitCode != nil {
+			entry.FixExitCode = suggestion.exitCode
+		}
+	})
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to update history: %v", err))
+	}
+}
+
+// recordOutcome counts an accepted or rejected plugin suggestion in the
+// plugin metrics
+func (e *Engine) recordOutcome(suggestion *Suggestion, accepted, succeeded bool) {
+	if e.metrics == nil || !suggestion.fromPlugin {
+		return
+	}
+	batch := metrics.Batch{}
+	batch.Outcome(suggestion.Source, accepted, succeeded)
+	e.saveMetrics(batch)
+}
+
+// saveMetrics adds the stats collected while handling a command to the
+// plugin metrics
+func (e *Engine) saveMetrics(batch metrics.Batch) {
+	if e.metrics == nil {
+		return
+	}
+	if err := e.metrics.Add(batch); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to update plugin metrics: %v", err))
+	}
+}
+
+// learnFix teaches the personal model a command fix the user accepted
+func (e *Engine) learnFix(command string, suggestion *Suggestion) {
+	if e.model == nil || suggestion.Command == "" {
+		return
+	}
+	if err := e.model.RecordFix(command, suggestion.Command); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to update personal model: %v", err))
+	}
+}
+
+// learnOutcome records what happened to a suggested command for its error,
+// so fixes that worked are offered first and rejected ones are dropped
+func (e *Engine) learnOutcome(suggestion *Suggestion, record func(s *learning.Store, command, output, suggestion string) error) {
+	if e.learning == nil || suggestion.Command == "" || suggestion.command == "" {
+		return
+	}
+	if err := record(e.learning, suggestion.command, suggestion.output, suggestion.Command); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to update learned fixes: %v", err))
+	}
+}
+
+func (e *Engine) applyConfigEdit(edit *configedit.Edit) bool {
+	if err := edit.Apply(); err != nil {
+		logger.Error(fmt.Sprintf("Failed to apply configuration change: %v", err))
 		return false
 	}
+
+	logger.Success(fmt.Sprintf("Updated %s (undo with 'logaid revert')", edit.Path))
+	return true
 }
 
-func (e *Engine) executeSuggestion(suggestion string) bool {
+func (e *Engine) executeSuggestion(ctx context.Context, s *Suggestion) (err error) {
+	suggestion := s.Command
+	_, span := telemetry.Start(ctx, "engine.Execute", attribute.String("logaid.source", s.Source), attribute.String("logaid.tool", tool(suggestion)))
+	start := time.Now()
+	defer func() {
+		span.SetAttributes(attribute.Int("process.exit.code", exitCode(err)))
+		telemetry.Execution(ctx, s.Source, time.Since(start), exitCode(err))
+		telemetry.End(span, err)
+	}()
+
 	// Parse the suggestion into command and args
 	parts := strings.Fields(suggestion)
 	if len(parts) == 0 {
 		logger.Error("Invalid suggestion: empty command")
-		return false
+		return fmt.Errorf("empty command")
 	}
 
 	var cmd *exec.Cmd
@@ -154,35 +519,108 @@ func (e *Engine) executeSuggestion(suggestion string) bool {
 		cmd = exec.Command(parts[0])
 	}
 
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = e.streams()
+	if e.container != nil {
+		cmd = e.container.Wrap(cmd)
+	}
 
 	logger.Info(fmt.Sprintf("Running: %s", suggestion))
-	err := cmd.Run()
+	err = cmd.Run()
 	if err != nil {
 		logger.Error(fmt.Sprintf("Suggestion execution failed: %v", err))
-		return false
+		return err
 	} else {
 		logger.Info("Suggestion executed successfully!")
-		return true
+		return nil
 	}
 }
 
+// SetIO runs fixes with stdin and sends their output to stdout and stderr
+// instead of the terminal. Confirmations are read from stdin too; a nil
+// stdin gives fixes no input and declines every prompt
+func (e *Engine) SetIO(stdin io.Reader, stdout, stderr io.Writer) {
+	e.stdin, e.stdout, e.stderr = stdin, stdout, stderr
+}
+
+// SetRecorder records the commands monitored from now on, their output,
+// LogAid's messages and the fixes offered, in r. nil stops recording
+func (e *Engine) SetRecorder(r *session.Recorder) {
+	e.recorder = r
+}
+
+// streams returns the input and outputs of monitored commands and fixes
+func (e *Engine) streams() (io.Reader, io.Writer, io.Writer) {
+	if e.stdout == nil {
+		return os.Stdin, os.Stdout, os.Stderr
+	}
+	return e.stdin, e.stdout, e.stderr
+}
+
 // ExecuteWithMonitoring executes a command with LogAid monitoring
 func ExecuteWithMonitoring(cmd *exec.Cmd) error {
-	engine := New()
+	_, err := New().Run(cmd, nil)
+	return err
+}
+
+// Run executes a command with LogAid monitoring like ExecuteWithMonitoring
+// and reports what happened. The output of the command and of any fix is
+// copied to out, or to stdout and stderr when out is nil
+func (e *Engine) Run(cmd *exec.Cmd, out io.Writer) (*Report, error) {
+	// Long-running commands may outlive a plugin install or config edit.
+	// A remote engine leaves that to the daemon
+	if e.remoteSuggester() == nil {
+		ctx, stop := context.WithCancel(context.Background())
+		defer stop()
+		if err := e.Watch(ctx); err != nil {
+			logger.Debug(fmt.Sprintf("Not watching plugins: %v", err))
+		}
+	}
+
+	return e.Monitor(cmd, out)
+}
+
+// Monitor executes cmd, offers a fix when its output shows an error and
+// reports what happened. The output of the command and of any fix is copied
+// to out, or to stdout and stderr when out is nil. The error is the command's
+// unless the fix succeeded
+func (e *Engine) Monitor(cmd *exec.Cmd, out io.Writer) (*Report, error) {
+	if out != nil {
+		stdin, stdout, stderr := e.stdin, e.stdout, e.stderr
+		in, _, _ := e.streams()
+		e.SetIO(in, out, out)
+		defer e.SetIO(stdin, stdout, stderr)
+	}
+	if e.recorder != nil {
+		stdin, stdout, stderr := e.stdin, e.stdout, e.stderr
+		in, out, errOut := e.streams()
+		e.SetIO(in, e.recorder.Writer(session.StreamStdout, out), e.recorder.Writer(session.StreamStderr, errOut))
+		defer e.SetIO(stdin, stdout, stderr)
+
+		console := logger.Console()
+		logger.SetConsole(e.recorder.Writer(session.StreamLog, console))
+		defer logger.SetConsole(console)
+	}
+	e.report = &Report{Command: strings.Join(cmd.Args, " "), recorder: e.recorder}
+	if e.container != nil {
+		cmd = e.container.Wrap(cmd)
+	}
+	defer func() { e.report = nil }()
+	defer flushTelemetry()
+	report := e.report
 
 	// Capture both stdout and stderr
 	var stdout, stderr bytes.Buffer
-	cmd.Stdout = io.MultiWriter(os.Stdout, &stdout)
-	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	_, consoleOut, consoleErr := e.streams()
+	cmd.Stdout = io.MultiWriter(consoleOut, &stdout)
+	cmd.Stderr = io.MultiWriter(consoleErr, &stderr)
 
 	// Execute the command
 	err := cmd.Run()
+	report.ExitCode = exitCode(err)
+	e.recorder.Exit(session.EventExit, report.ExitCode)
 
 	// Combine command for logging
-	command := strings.Join(cmd.Args, " ")
+	command := report.Command
 
 	if err != nil {
 		// Command failed, analyze the error
@@ -193,22 +631,24 @@ func ExecuteWithMonitoring(cmd *exec.Cmd) error {
 
 		logger.Error(fmt.Sprintf("Command failed: %s", command))
 
-		if engine.detectError(output) {
+		if e.DetectError(output) {
+			report.detected(output)
 			// If we successfully handle the error (user accepts and suggestion works), return success
-			if engine.handleError(command, output) {
-				return nil // Suggestion executed successfully, don't return original error
+			if e.handleError(command, output) {
+				return report, nil // Suggestion executed successfully, don't return original error
 			}
 		}
 
-		return err // Return original error if no suggestion or suggestion failed
+		return report, err // Return original error if no suggestion or suggestion failed
 	}
 
 	// Check stdout for potential issues even if command succeeded
 	output := stdout.String()
-	if engine.detectError(output) {
+	if e.DetectError(output) {
 		logger.Warn("Potential issues detected in command output")
-		engine.handleError(command, output)
+		report.detected(output)
+		e.handleError(command, output)
 	}
 
-	return nil
+	return report, nil
 }
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ayushsharma-1/LogAid/internal/cache"
+	"github.com/ayushsharma-1/LogAid/internal/configedit"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/learning"
+	"github.com/ayushsharma-1/LogAid/internal/metrics"
+	"github.com/ayushsharma-1/LogAid/internal/model"
+	"github.com/ayushsharma-1/LogAid/internal/notify"
+)
+
+// Suggester finds fixes for failed commands somewhere else, such as a
+// running 'logaid daemon'
+type Suggester interface {
+	Suggest(ctx context.Context, command, output string) (*Suggestion, error)
+}
+
+// NewRemote creates an Engine that asks remote for suggestions instead of
+// loading the plugins itself. If remote fails, the plugins are loaded and
+// used from then on
+func NewRemote(remote Suggester) *Engine {
+	return &Engine{
+		remote:   remote,
+		notifier: notify.NewFromConfig(),
+		cache:    cache.NewFromConfig(),
+		model:    model.NewFromConfig(),
+		learning: learning.NewFromConfig(),
+		history:  history.NewFromConfig(),
+		metrics:  metrics.NewFromConfig(),
+	}
+}
+
+// suggestionJSON is the encoding of a Suggestion
+type suggestionJSON struct {
+	Command      string           `json:"command,omitempty"`
+	Alternatives []string         `json:"alternatives,omitempty"`
+	ConfigEdit   *configedit.Edit `json:"config_edit,omitempty"`
+	Source       string           `json:"source"`
+	Confidence   float64          `json:"confidence,omitempty"`
+	AutoApply    bool             `json:"auto_apply,omitempty"`
+	Note         string           `json:"note,omitempty"`
+	FromPlugin   bool             `json:"from_plugin,omitempty"`
+}
+
+// MarshalJSON encodes the suggestion, including whether a plugin made it
+func (s *Suggestion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(suggestionJSON{
+		Command:      s.Command,
+		Alternatives: s.Alternatives,
+		ConfigEdit:   s.ConfigEdit,
+		Source:       s.Source,
+		Confidence:   s.Confidence,
+		AutoApply:    s.AutoApply,
+		Note:         s.Note,
+		FromPlugin:   s.fromPlugin,
+	})
+}
+
+// UnmarshalJSON decodes a suggestion encoded by MarshalJSON
+func (s *Suggestion) UnmarshalJSON(data []byte) error {
+	var decoded suggestionJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*s = Suggestion{
+		Command:      decoded.Command,
+		Alternatives: decoded.Alternatives,
+		ConfigEdit:   decoded.ConfigEdit,
+		Source:       decoded.Source,
+		Confidence:   decoded.Confidence,
+		AutoApply:    decoded.AutoApply,
+		Note:         decoded.Note,
+		fromPlugin:   decoded.FromPlugin,
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"errors"
+	"os/exec"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/session"
+)
+
+// Decisions about a suggestion, as recorded in a Report
+const (
+	DecisionAccepted      = "accepted"
+	DecisionAutoConfirmed = "auto-confirmed"
+	DecisionRejected      = "rejected"
+	DecisionSuggested     = "suggested" // --no: shown but not executed
+)
+
+// Report describes what happened to a monitored command, for scripts that
+// read 'logaid --json exec'
+type Report struct {
+	Command       string   `json:"command"`
+	ExitCode      int      `json:"exit_code"` // -1 when the command could not be started
+	ErrorDetected bool     `json:"error_detected"`
+	Output        string   `json:"output,omitempty"` // the output the error was detected in
+	Source        string   `json:"source,omitempty"` // plugin, "AI", "cache", "history", "learned" or "personal model"
+	Plugin        string   `json:"plugin,omitempty"` // set when a plugin made the suggestion
+	Suggestion    string   `json:"suggestion,omitempty"`
+	Alternatives  []string `json:"alternatives,omitempty"`
+	Confidence    float64  `json:"confidence,omitempty"` // the plugin's match score
+	Note          string   `json:"note,omitempty"`       // e.g. how often the fix worked before
+	Risk          string   `json:"risk,omitempty"`       // safe, moderate or destructive
+	Decision      string   `json:"decision,omitempty"`
+	Fixed         bool     `json:"fixed"`
+	FixExitCode   *int     `json:"fix_exit_code,omitempty"` // exit code of the suggested command
+	Error         string   `json:"error,omitempty"`         // why no suggestion could be made
+
+	recorder *session.Recorder // also records each step when set
+}
+
+// detected records the output an error was detected in
+func (r *Report) detected(output string) {
+	if r == nil {
+		return
+	}
+	r.ErrorDetected = true
+	r.Output = ai.TruncateOutput(output)
+	r.recorder.Record(session.Event{Type: session.EventError, Data: r.Output})
+}
+
+// suggested records the suggestion offered for the command
+func (r *Report) suggested(suggestion *Suggestion) {
+	if r == nil {
+		return
+	}
+	r.Source = suggestion.Source
+	if suggestion.fromPlugin {
+		r.Plugin = suggestion.Source
+	}
+	r.Suggestion = suggestion.Text()
+	r.Alternatives = suggestion.Alternatives
+	r.Confidence = suggestion.Confidence
+	r.Note = suggestion.Note
+	r.Risk = suggestion.Risk().Level.String()
+	r.recorder.Record(session.Event{Type: session.EventSuggestion, Data: r.Suggestion, Source: r.Source})
+}
+
+// decide records what was done with the suggestion
+func (r *Report) decide(decision string) {
+	if r != nil {
+		r.Decision = decision
+		r.recorder.Record(session.Event{Type: session.EventDecision, Data: decision})
+	}
+}
+
+// ran records the result of running the suggested command
+func (r *Report) ran(err error) {
+	if r != nil {
+		code := exitCode(err)
+		r.FixExitCode = &code
+		r.recorder.Exit(session.EventFix, code)
+	}
+}
+
+// fixed records whether applying the suggestion worked
+func (r *Report) fixed(ok bool) {
+	if r != nil {
+		r.Fixed = ok
+	}
+}
+
+// failed records why no suggestion could be made
+func (r *Report) failed(err error) {
+	if r != nil {
+		r.Error = err.Error()
+	}
+}
+
+// exitCode returns the exit status of a command that returned err, or -1
+// when it could not be started
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,485 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/configedit"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/metrics"
+	"github.com/ayushsharma-1/LogAid/internal/model"
+	"github.com/ayushsharma-1/LogAid/internal/notify"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/ayushsharma-1/LogAid/internal/risk"
+	"github.com/ayushsharma-1/LogAid/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Suggestion is a proposed fix for a failed command: either a command to run
+// or a change to a configuration file
+type Suggestion struct {
+	Command      string
+	Alternatives []string // further candidate commands, offered in the picker
+	ConfigEdit   *configedit.Edit
+	Source       string
+	Confidence   float64 // match score of the plugin that made it, 0 otherwise
+	AutoApply    bool    // the plugin is sure enough to run it without asking
+	Note         string  // why it is offered, e.g. how often it worked before
+
+	historyID       int64
+	fromPlugin      bool   // Source names a plugin
+	command, output string // the failed command, set by Propose
+	provider, model string // the AI that made it, for AI suggestions
+	latency         time.Duration
+	exitCode        *int // of the suggested command once it ran
+}
+
+// event returns a notification about the suggestion
+func (s *Suggestion) event(eventType string) notify.Event {
+	return notify.Event{Type: eventType, Risk: s.Risk().Level.String(), Command: s.command, Output: s.output, Suggestion: s.Text(), Source: s.Source}
+}
+
+// Risk returns how dangerous applying the suggestion is. Configuration
+// changes are moderate, as they change how a tool behaves from then on
+func (s *Suggestion) Risk() risk.Assessment {
+	if s.ConfigEdit != nil {
+		return risk.Assessment{Level: risk.Moderate, Reason: "changes a configuration file"}
+	}
+	return risk.Classify(s.Command)
+}
+
+// Text returns the suggestion as a single line for display
+func (s *Suggestion) Text() string {
+	if s.ConfigEdit != nil {
+		return s.ConfigEdit.String()
+	}
+	return s.Command
+}
+
+// Candidates returns every command the user can pick from, best first
+func (s *Suggestion) Candidates() []string {
+	if s.Command == "" {
+		return nil
+	}
+	return append([]string{s.Command}, s.Alternatives...)
+}
+
+// Pick interprets the user's answer to the picker: "y" or "1" selects the
+// first candidate, "2".."N" the others. It returns false if nothing was
+// chosen. Configuration edits are accepted with "y" and yield no command.
+func (s *Suggestion) Pick(answer string) (string, bool) {
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	if answer == "y" || answer == "yes" {
+		return s.Command, true
+	}
+	if s.ConfigEdit != nil {
+		return "", false
+	}
+
+	candidates := s.Candidates()
+	index, err := strconv.Atoi(answer)
+	if err != nil || index < 1 || index > len(candidates) {
+		return "", false
+	}
+	return candidates[index-1], true
+}
+
+// Suggest finds a fix for a failed command, trying the personal model and
+// plugins before the AI
+func (e *Engine) Suggest(ctx context.Context, command, output string) (*Suggestion, error) {
+	ctx, span := telemetry.Start(ctx, "engine.Suggest", attribute.String("logaid.tool", tool(command)))
+	start := time.Now()
+	suggestion, err := e.suggest(ctx, command, output)
+	suggestion = e.adaptToContainer(suggestion)
+	if suggestion != nil {
+		suggestion.latency = time.Since(start)
+		span.SetAttributes(attribute.String("logaid.source", suggestion.Source))
+		telemetry.Suggestion(ctx, suggestion.Source)
+	}
+	telemetry.End(span, err)
+	return suggestion, err
+}
+
+// suggest is Suggest without the span
+func (e *Engine) suggest(ctx context.Context, command, output string) (*Suggestion, error) {
+	if suggestion, ok := e.suggestRemote(ctx, command, output); ok {
+		return suggestion, nil
+	}
+
+	// Suggestions rated harmful are only made when nothing else is found
+	harmful := e.harmfulSuggestions()
+	var demoted *Suggestion
+
+	// Suggestions rejected twice for the same error are not made again
+	rejected := map[string]bool{}
+	if e.learning != nil {
+		rejected = e.learning.Blocked(command, output)
+	}
+
+	// The fix the user applied most often to this very failure comes first,
+	// then one that worked for the same kind of error
+	if fix, ok := e.frequentFix(command, output); ok && !harmful[fix.Command] && !rejected[fix.Command] {
+		return &Suggestion{Command: fix.Command, Source: "history", Note: frequencyNote(fix), AutoApply: e.autoApply(command, output, fix.Command)}, nil
+	}
+	if e.learning != nil {
+		if fix, ok := e.learning.Fix(command, output); ok && !harmful[fix] {
+			return &Suggestion{Command: fix, Source: "learned", AutoApply: e.autoApply(command, output, fix)}, nil
+		}
+	}
+
+	// Words the user actually types win over generic typo dictionaries when
+	// the output shows a mistyped word. Other failures never use them, as the
+	// arguments were typed right and only the plugins know what went wrong
+	if model.ShowsTypo(output) {
+		if suggestion := e.personalCorrection(command, output, rejected); suggestion != nil {
+			return suggestion, nil
+		}
+	}
+
+	batch := metrics.Batch{}
+	defer e.saveMetrics(batch)
+
+	matchTimeout, suggestTimeout := pluginTimeouts()
+	for _, m := range e.matchingPlugins(ctx, batch, command, output, matchTimeout) {
+		plugin := m.plugin
+
+		// Configuration changes are preferred when a plugin offers one
+		if configSuggester, ok := plugin.(plugins.ConfigSuggester); ok {
+			edit, _ := callPlugin(ctx, batch, plugin, "SuggestConfig", matchTimeout, func() *configedit.Edit {
+				return configSuggester.SuggestConfig(command, output)
+			})
+			if edit != nil {
+				batch.Suggestion(plugin.Name())
+				suggestion := &Suggestion{ConfigEdit: edit, Source: plugin.Name(), Confidence: m.score, fromPlugin: true}
+				if !harmful[suggestion.Text()] {
+					return suggestion, nil
+				}
+				if demoted == nil {
+					demoted = suggestion
+				}
+				continue
+			}
+		}
+
+		suggestion, _ := callPlugin(ctx, batch, plugin, "Suggest", suggestTimeout, func() string {
+			return plugin.Suggest(command, output)
+		})
+		if suggestion != "" && !rejected[suggestion] {
+			batch.Suggestion(plugin.Name())
+			if !harmful[suggestion] {
+				return &Suggestion{Command: suggestion, Source: plugin.Name(), Confidence: m.score, AutoApply: e.autoApply(command, output, suggestion), fromPlugin: true}, nil
+			}
+			if demoted == nil {
+				demoted = &Suggestion{Command: suggestion, Source: plugin.Name(), Confidence: m.score, fromPlugin: true}
+			}
+		}
+	}
+
+	// Reuse a correction learned for the same error signature
+	if e.cache != nil {
+		if cached, ok := e.cache.Lookup(command, output); ok && !harmful[cached] && !rejected[cached] {
+			logger.Debug(fmt.Sprintf("Suggestion cache hit: %s", cached))
+			return &Suggestion{Command: cached, Source: "cache"}, nil
+		}
+	}
+
+	// If no plugin matched, use AI directly
+	prompt := fmt.Sprintf("Command: %s\nError: %s\n", command, ai.TruncateOutput(output))
+	if e.container != nil {
+		prompt += fmt.Sprintf("Environment: %s\n", e.container.Describe())
+	}
+	candidates, err := ai.GetSuggestions(ctx, prompt+"Provide a corrected command:", aiCandidates())
+	if errors.Is(err, ai.ErrRateLimited) {
+		return demoted, nil
+	}
+	if err != nil {
+		if demoted != nil {
+			logger.Debug(fmt.Sprintf("AI suggestion failed, falling back to a suggestion rated harmful: %v", err))
+			return demoted, nil
+		}
+		return nil, fmt.Errorf("failed to get AI suggestion: %w", err)
+	}
+	candidates = without(candidates, rejected)
+	if len(candidates) == 0 || candidates[0] == "" {
+		return demoted, nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return !harmful[candidates[i]] && harmful[candidates[j]]
+	})
+	if max := maxSuggestions(); max > 0 && len(candidates) > max {
+		candidates = candidates[:max]
+	}
+	if demoted != nil && demoted.Command != "" && !contains(candidates, demoted.Command) {
+		candidates = append(candidates, demoted.Command)
+	}
+
+	if !harmful[candidates[0]] {
+		e.cacheSuggestion(command, output, candidates[0])
+	}
+
+	suggestion := &Suggestion{Command: candidates[0], Alternatives: candidates[1:], Source: "AI"}
+	if client := ai.Default(); client != nil {
+		suggestion.provider, suggestion.model = client.Provider, client.Model
+	}
+	return suggestion, nil
+}
+
+// personalCorrection returns the command with the words the user probably
+// mistyped replaced by the ones they use, or nil
+func (e *Engine) personalCorrection(command, output string, rejected map[string]bool) *Suggestion {
+	if e.model == nil {
+		return nil
+	}
+	m, err := e.model.Load()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to load personal model: %v", err))
+		return nil
+	}
+	if corrected, ok := m.Correct(command); ok && !rejected[corrected] {
+		return &Suggestion{Command: corrected, Source: "personal model", AutoApply: e.autoApply(command, output, corrected)}
+	}
+	return nil
+}
+
+// minFrequentShare is the share of a failure's fixes the most frequent one
+// must account for to be offered first
+const minFrequentShare = 0.5
+
+// frequentFix returns the fix applied most often to earlier occurrences of
+// the failure, if it was applied at least FREQUENT_FIX_MIN_COUNT times and
+// for most of them
+func (e *Engine) frequentFix(command, output string) (history.Resolution, bool) {
+	cfg := config.Current()
+	if e.history == nil || cfg == nil || cfg.FrequentFixMinCount <= 0 {
+		return history.Resolution{}, false
+	}
+	resolutions, err := e.history.Resolutions(command, output)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to rank fixes from history: %v", err))
+		return history.Resolution{}, false
+	}
+	if len(resolutions) == 0 {
+		return history.Resolution{}, false
+	}
+	best := resolutions[0]
+	return best, best.Count >= cfg.FrequentFixMinCount && best.Share >= minFrequentShare
+}
+
+// frequencyNote tells the user how often a fix from the history worked
+func frequencyNote(fix history.Resolution) string {
+	if fix.Share >= 1 {
+		return fmt.Sprintf("You fixed this the same way %d times", fix.Count)
+	}
+	return fmt.Sprintf("You fixed this the same way %d times (%.0f%% of the time)", fix.Count, fix.Share*100)
+}
+
+// autoApply reports whether a plugin vouches for running suggestion without
+// asking, whichever source made it
+func (e *Engine) autoApply(command, output, suggestion string) bool {
+	for _, plugin := range e.Plugins() {
+		if applier, ok := plugin.(plugins.AutoApplier); ok && applier.AutoApply(command, output, suggestion) {
+			return true
+		}
+	}
+	return false
+}
+
+// harmfulSuggestions returns the suggestions rated harmful with 'logaid
+// feedback'
+func (e *Engine) harmfulSuggestions() map[string]bool {
+	if e.history == nil {
+		return nil
+	}
+	harmful, err := e.history.Harmful()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to load feedback: %v", err))
+		return nil
+	}
+	return harmful
+}
+
+// contains reports whether list holds s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// without returns list minus the items in drop
+func without(list []string, drop map[string]bool) []string {
+	var kept []string
+	for _, item := range list {
+		if !drop[item] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// remoteSuggester returns the remote suggester, or nil once suggestions
+// are made locally
+func (e *Engine) remoteSuggester() Suggester {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.remote
+}
+
+// suggestRemote asks the remote suggester, if any. When it fails the plugins
+// are loaded so this and later suggestions are made locally
+func (e *Engine) suggestRemote(ctx context.Context, command, output string) (*Suggestion, bool) {
+	remote := e.remoteSuggester()
+	if remote == nil {
+		return nil, false
+	}
+
+	suggestion, err := remote.Suggest(ctx, command, output)
+	if err == nil {
+		return suggestion, true
+	}
+	logger.Debug(fmt.Sprintf("Daemon unavailable, suggesting locally: %v", err))
+
+	e.mu.Lock()
+	if e.remote != nil {
+		e.remote = nil
+		e.plugins = plugins.LoadAllPlugins()
+	}
+	e.mu.Unlock()
+	return nil, false
+}
+
+// pluginMatch is a plugin that matched a command and how confident it is
+type pluginMatch struct {
+	plugin plugins.Plugin
+	score  float64
+}
+
+// matchingPlugins returns the plugins that match command/output, the most
+// confident first. Plugins with equal scores keep their load order
+func (e *Engine) matchingPlugins(ctx context.Context, batch metrics.Batch, command, output string, timeout time.Duration) []pluginMatch {
+	var matches []pluginMatch
+	for _, plugin := range e.Plugins() {
+		matched, ok := callPlugin(ctx, batch, plugin, "Match", timeout, func() bool {
+			return plugin.Match(command, output)
+		})
+		if !ok || !matched {
+			continue
+		}
+		batch.Match(plugin.Name())
+		score, _ := callPlugin(ctx, batch, plugin, "Score", timeout, func() float64 {
+			return plugins.Score(plugin, command, output)
+		})
+		matches = append(matches, pluginMatch{plugin: plugin, score: score})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	for _, m := range matches {
+		logger.Debug(fmt.Sprintf("Plugin %s matched with score %.2f", m.plugin.Name(), m.score))
+	}
+	return matches
+}
+
+// PluginMatch names a plugin that recognizes an error and how confident it is
+type PluginMatch struct {
+	Plugin string  `json:"plugin"`
+	Score  float64 `json:"score"`
+}
+
+// Recognize returns the plugins that match a failed command, the most
+// confident first, without asking them for a fix
+func (e *Engine) Recognize(ctx context.Context, command, output string) []PluginMatch {
+	timeout, _ := pluginTimeouts()
+	var matches []PluginMatch
+	for _, m := range e.matchingPlugins(ctx, metrics.Batch{}, command, output, timeout) {
+		matches = append(matches, PluginMatch{Plugin: m.plugin.Name(), Score: m.score})
+	}
+	return matches
+}
+
+// pluginTimeouts returns the budgets for Match and Suggest from PLUGIN_TIMEOUT.
+// Suggest may fall back to the AI, so it also gets AI_REQUEST_TIMEOUT
+func pluginTimeouts() (match, suggest time.Duration) {
+	cfg := config.Current()
+	match = 5 * time.Second
+	if cfg != nil && cfg.PluginTimeout > 0 {
+		match = time.Duration(cfg.PluginTimeout) * time.Second
+	}
+	suggest = match + 10*time.Second
+	if cfg != nil && cfg.AIRequestTimeout > 0 {
+		suggest = match + time.Duration(cfg.AIRequestTimeout)*time.Second
+	}
+	return match, suggest
+}
+
+// callPlugin runs fn, a call into plugin, and gives up when it takes longer
+// than timeout or ctx is done. A plugin that gives up keeps running in the
+// background; its result is discarded. The call is timed in batch
+func callPlugin[T any](ctx context.Context, batch metrics.Batch, plugin plugins.Plugin, method string, timeout time.Duration, fn func() T) (T, bool) {
+	_, span := telemetry.Start(ctx, "plugin."+method, attribute.String("logaid.plugin", plugin.Name()))
+	defer span.End()
+	result := make(chan T, 1)
+	start := time.Now()
+	go func() {
+		result <- fn()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var zero T
+	select {
+	case value := <-result:
+		batch.Call(plugin.Name(), time.Since(start), false)
+		telemetry.PluginCall(ctx, plugin.Name(), method, time.Since(start), false)
+		return value, true
+	case <-timer.C:
+		logger.Warn(fmt.Sprintf("Plugin %s timed out after %s in %s, skipping it", plugin.Name(), timeout, method))
+		batch.Call(plugin.Name(), timeout, true)
+		telemetry.PluginCall(ctx, plugin.Name(), method, timeout, true)
+		span.SetAttributes(attribute.Bool("logaid.timed_out", true))
+		return zero, false
+	case <-ctx.Done():
+		return zero, false
+	}
+}
+
+// cacheSuggestion remembers an AI suggestion for similar errors
+func (e *Engine) cacheSuggestion(command, output, suggestion string) {
+	if e.cache == nil {
+		return
+	}
+	if err := e.cache.Store(command, output, suggestion, "AI"); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to cache suggestion: %v", err))
+	}
+}
+
+// aiCandidates returns how many alternatives to request from the AI
+func aiCandidates() int {
+	cfg := config.Current()
+	if cfg == nil || cfg.AICandidates < 1 {
+		return 1
+	}
+	return cfg.AICandidates
+}
+
+// maxSuggestions returns how many suggestions the picker shows (0 = no limit)
+func maxSuggestions() int {
+	cfg := config.Current()
+	if cfg == nil {
+		return 0
+	}
+	return cfg.MaxSuggestions
+}
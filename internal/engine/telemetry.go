@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/telemetry"
+)
+
+// tool returns the program a command line runs, e.g. apt for "sudo apt
+// install". Spans carry only this, never whole commands that may hold secrets
+func tool(command string) string {
+	for _, field := range strings.Fields(command) {
+		if field == "sudo" || strings.Contains(field, "=") || strings.HasPrefix(field, "-") {
+			continue
+		}
+		return filepath.Base(field)
+	}
+	return ""
+}
+
+// flushTelemetry exports the spans and metrics of a monitored command, which
+// may be followed by os.Exit
+func flushTelemetry() {
+	if err := telemetry.Flush(context.Background()); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to export telemetry: %v", err))
+	}
+}
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay collects the burst of events an install or editor save produces
+// into a single reload
+const reloadDelay = 200 * time.Millisecond
+
+// configFiles are the files in the config directory that trigger a reload
+var configFiles = map[string]bool{".env": true, "config.yaml": true}
+
+// Plugins returns the plugins currently in use
+func (e *Engine) Plugins() []plugins.Plugin {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.plugins
+}
+
+// SetPlugins replaces the plugins in use
+func (e *Engine) SetPlugins(loaded []plugins.Plugin) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.plugins = loaded
+}
+
+// ReloadPlugins loads the plugin set again from the current configuration
+func (e *Engine) ReloadPlugins() {
+	loaded := plugins.LoadAllPlugins()
+	e.SetPlugins(loaded)
+	logger.Debug(fmt.Sprintf("Reloaded %d plugins", len(loaded)))
+}
+
+// Watch reloads the configuration and plugins when PLUGINS_DIR, the config
+// directory or the corrections directory changes, until ctx is done. A new
+// log level and AI provider apply immediately
+func (e *Engine) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	configDir := config.Dir()
+	if err := watcher.Add(configDir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch %s: %w", configDir, err)
+	}
+	pluginsDir := e.watchPluginsDir(watcher, "")
+	correctionsDir := plugins.CorrectionsDir()
+	if err := watcher.Add(correctionsDir); err != nil {
+		logger.Debug(fmt.Sprintf("Not watching %s: %v", correctionsDir, err))
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var timer <-chan time.Time
+		configChanged := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				dir, name := filepath.Split(event.Name)
+				dir = filepath.Clean(dir)
+				switch {
+				case dir == configDir && configFiles[name]:
+					configChanged = true
+				case dir == pluginsDir && !strings.HasPrefix(name, "."):
+				case dir == correctionsDir && !strings.HasPrefix(name, "."):
+				default:
+					continue
+				}
+				timer = time.After(reloadDelay)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Debug(fmt.Sprintf("Plugin watcher error: %v", err))
+			case <-timer:
+				timer = nil
+				if configChanged {
+					configChanged = false
+					previous := config.Current()
+					if err := config.Reload(); err != nil {
+						logger.Warn(fmt.Sprintf("Failed to reload configuration: %v", err))
+						continue
+					}
+					applyConfig(previous, config.Current())
+					pluginsDir = e.watchPluginsDir(watcher, pluginsDir)
+				}
+				e.ReloadPlugins()
+			}
+		}
+	}()
+	return nil
+}
+
+// applyConfig puts a reloaded configuration into effect and logs what changed
+func applyConfig(previous, current *config.Config) {
+	changes := config.Changes(previous, current)
+	if len(changes) == 0 {
+		return
+	}
+	// Logged at the previous level, so lowering it still shows the change
+	logger.Info(fmt.Sprintf("Configuration reloaded: %s", strings.Join(changes, ", ")))
+	logger.SetLevel(current.LogLevel)
+	logger.SetColors(current.EnableColors)
+	logger.SetMasking(current.MaskSecrets)
+	logger.SetAsync(current.LogAsync, time.Duration(current.LogFlushInterval)*time.Second)
+	if t, err := current.ColorTheme(); err == nil {
+		logger.SetTheme(t)
+	}
+	// The AI client is rebuilt with the new provider, model and keys
+	ai.Reset()
+}
+
+// watchPluginsDir moves the watch from the previous plugins directory to the
+// configured one and returns it
+func (e *Engine) watchPluginsDir(watcher *fsnotify.Watcher, previous string) string {
+	cfg := config.Current()
+	if cfg == nil || cfg.PluginsDir == "" {
+		return ""
+	}
+	dir := filepath.Clean(cfg.PluginsDir)
+	if dir == previous {
+		return dir
+	}
+	if previous != "" {
+		watcher.Remove(previous)
+	}
+
+	// Create it so plugins installed later are picked up
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to create plugins directory: %v", err))
+		return ""
+	}
+	if err := watcher.Add(dir); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to watch %s: %v", dir, err))
+		return ""
+	}
+	return dir
+}
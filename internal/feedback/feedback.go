@@ -0,0 +1,86 @@
+// Package feedback sends anonymized ratings of suggestions upstream when the
+// user opted in with FEEDBACK_UPLOAD
+package feedback
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+)
+
+// Report is what is sent for a rating. It names the programs involved but
+// never includes their arguments, the output or the user's note
+type Report struct {
+	Rating         string `json:"rating"` // helpful or harmful
+	Source         string `json:"source"` // plugin, AI, cache or personal model
+	Tool           string `json:"tool"`   // program of the failed command, e.g. git
+	SuggestionTool string `json:"suggestion_tool"`
+	Version        string `json:"version"`
+}
+
+// NewReport builds the anonymized report for a rated history entry
+func NewReport(entry history.Entry, version string) Report {
+	return Report{
+		Rating:         entry.Feedback,
+		Source:         entry.Source,
+		Tool:           program(entry.Command),
+		SuggestionTool: program(entry.Suggestion),
+		Version:        version,
+	}
+}
+
+// program returns the name of the program a command line runs, skipping sudo
+// and environment assignments
+func program(command string) string {
+	for _, field := range strings.Fields(command) {
+		if field == "sudo" || strings.Contains(field, "=") || strings.HasPrefix(field, "-") {
+			continue
+		}
+		return filepath.Base(field)
+	}
+	return ""
+}
+
+// Enabled reports whether the user opted in to uploading feedback
+func Enabled() bool {
+	cfg := config.Current()
+	return cfg != nil && cfg.FeedbackUpload && cfg.FeedbackEndpoint != ""
+}
+
+// Upload posts report to endpoint
+func Upload(ctx context.Context, client *http.Client, endpoint string, report Report) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feedback: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send feedback: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("feedback upload failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+)
+
+// ExportFormats are the formats Export writes
+var ExportFormats = []string{"csv", "json", "md"}
+
+// Redact returns entries with secrets, the home directory and the user and
+// host names scrubbed from their text, for sharing
+func Redact(entries []Entry) []Entry {
+	redacted := make([]Entry, len(entries))
+	for i, entry := range entries {
+		for _, text := range []*string{&entry.Command, &entry.Output, &entry.Suggestion, &entry.Explanation, &entry.Note, &entry.Dir} {
+			*text = redact.String(*text)
+		}
+		redacted[i] = entry
+	}
+	return redacted
+}
+
+// Export writes entries to w as csv, json or md (a Markdown summary and table)
+func Export(w io.Writer, entries []Entry, format string) error {
+	switch format {
+	case "csv":
+		return exportCSV(w, entries)
+	case "json":
+		if entries == nil {
+			entries = []Entry{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case "md":
+		_, err := io.WriteString(w, markdown(entries))
+		return err
+	}
+	return fmt.Errorf("unknown format %q, use one of %s", format, strings.Join(ExportFormats, ", "))
+}
+
+// csvHeader names the columns written by exportCSV
+var csvHeader = []string{
+	"time", "first_time", "count", "session", "dir", "command", "exit_code", "output", "suggestion", "source",
+	"plugin", "provider", "model", "latency_ms", "status", "fix_exit_code", "feedback", "note",
+}
+
+func exportCSV(w io.Writer, entries []Entry) error {
+	out := csv.NewWriter(w)
+	out.Write(csvHeader)
+	for _, e := range entries {
+		fixExitCode := ""
+		if e.FixExitCode != nil {
+			fixExitCode = strconv.Itoa(*e.FixExitCode)
+		}
+		out.Write([]string{
+			e.Time.Format(time.RFC3339), e.First().Format(time.RFC3339), strconv.Itoa(e.Occurrences()), e.Session, e.Dir, e.Command,
+			strconv.Itoa(e.ExitCode), e.Output, e.Suggestion, e.Source, e.Plugin, e.Provider, e.Model,
+			strconv.FormatInt(e.LatencyMS, 10), e.Status, fixExitCode, e.Feedback, e.Note,
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// markdown renders entries as a summary of outcomes and sources followed by
+// one table row per entry
+func markdown(entries []Entry) string {
+	var b strings.Builder
+	b.WriteString("## LogAid history\n\n")
+	if len(entries) == 0 {
+		b.WriteString("No failures recorded.\n")
+		return b.String()
+	}
+
+	failures := 0
+	outcomes := map[string]int{}
+	sources := map[string]int{}
+	for _, e := range entries {
+		failures += e.Occurrences()
+		outcomes[e.Status] += e.Occurrences()
+		sources[e.Source] += e.Occurrences()
+	}
+	fmt.Fprintf(&b, "%d failure(s) from %s to %s.\n\n", failures,
+		entries[0].First().UTC().Format("2006-01-02 15:04"), entries[len(entries)-1].Time.UTC().Format("2006-01-02 15:04 UTC"))
+
+	b.WriteString("| Outcome | Count |\n|---|---|\n")
+	for _, status := range []string{StatusApplied, StatusFailed, StatusRejected, StatusProposed} {
+		if outcomes[status] > 0 {
+			fmt.Fprintf(&b, "| %s | %d |\n", status, outcomes[status])
+		}
+	}
+
+	b.WriteString("\n| Source | Count |\n|---|---|\n")
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if sources[names[i]] != sources[names[j]] {
+			return sources[names[i]] > sources[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		fmt.Fprintf(&b, "| %s | %d |\n", cell(name), sources[name])
+	}
+
+	b.WriteString("\n| Time | Command | Exit | Suggestion | Source | Outcome | Seen |\n|---|---|---|---|---|---|---|\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "| %s | `%s` | %d | `%s` | %s | %s | %d |\n", e.Time.UTC().Format("2006-01-02 15:04"),
+			cell(e.Command), e.ExitCode, cell(e.Suggestion), cell(e.Source), e.Status, e.Occurrences())
+	}
+	return b.String()
+}
+
+// cell escapes a value for a markdown table cell
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "`", "'")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,73 @@
+package history
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/cache"
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+)
+
+// Resolution is a fix that was applied to a failure and worked
+type Resolution struct {
+	Command string
+	Count   int     // times it fixed the failure
+	Share   float64 // of all the times the failure was fixed
+}
+
+// Resolutions returns the fixes applied successfully to earlier occurrences
+// of the same failure, the most frequent first. Failures are the same when
+// their normalized signatures match, including the packages, files and
+// branches they name
+func (s *Store) Resolutions(command, output string) ([]Resolution, error) {
+	entries, err := s.List(0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compare against the failure as it would have been recorded
+	probe := Entry{Command: command, Output: output}
+	mask(&probe)
+	sig := cache.NewSignature(probe.Command, probe.Output)
+
+	counts := map[string]int{}
+	total := 0
+	for _, entry := range entries {
+		if entry.Status != StatusApplied || entry.Suggestion == "" {
+			continue
+		}
+		if !sameFailure(sig, cache.NewSignature(entry.Command, entry.Output)) {
+			continue
+		}
+		total += entry.Occurrences()
+		if !strings.Contains(entry.Suggestion, redact.Mask) {
+			counts[entry.Suggestion] += entry.Occurrences()
+		}
+	}
+
+	resolutions := make([]Resolution, 0, len(counts))
+	for fix, count := range counts {
+		resolutions = append(resolutions, Resolution{Command: fix, Count: count, Share: float64(count) / float64(total)})
+	}
+	sort.Slice(resolutions, func(i, j int) bool {
+		if resolutions[i].Count != resolutions[j].Count {
+			return resolutions[i].Count > resolutions[j].Count
+		}
+		return resolutions[i].Command < resolutions[j].Command
+	})
+	return resolutions, nil
+}
+
+// sameFailure reports whether two signatures describe the same error of the
+// same command with the same arguments
+func sameFailure(a, b cache.Signature) bool {
+	if a.Command != b.Command || a.Error != b.Error || len(a.Variables) != len(b.Variables) {
+		return false
+	}
+	for i := range a.Variables {
+		if a.Variables[i] != b.Variables[i] {
+			return false
+		}
+	}
+	return true
+}
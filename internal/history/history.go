@@ -0,0 +1,324 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// Status of a suggestion in the history
+const (
+	StatusProposed = "proposed"
+	StatusApplied  = "applied"
+	StatusFailed   = "failed"
+	StatusRejected = "rejected"
+)
+
+// Feedback users give on a suggestion with 'logaid feedback'
+const (
+	FeedbackHelpful = "helpful"
+	FeedbackHarmful = "harmful"
+)
+
+// defaultMaxEntries is used when MAX_HISTORY_ENTRIES is not set
+const defaultMaxEntries = 1000
+
+// Entry is a failed command together with the fix LogAid proposed for it
+type Entry struct {
+	ID          int64     `json:"id"`
+	Time        time.Time `json:"time"`
+	Command     string    `json:"command"`
+	Output      string    `json:"output"`
+	Suggestion  string    `json:"suggestion"`
+	Source      string    `json:"source"`
+	Status      string    `json:"status"`
+	Explanation string    `json:"explanation,omitempty"`
+	Feedback    string    `json:"feedback,omitempty"` // helpful or harmful
+	Note        string    `json:"note,omitempty"`     // the user's comment on the feedback
+
+	Session     string `json:"session,omitempty"`       // shell session the command failed in
+	Dir         string `json:"dir,omitempty"`           // working directory the command failed in
+	ExitCode    int    `json:"exit_code,omitempty"`     // of the failed command, 0 when unknown
+	FixExitCode *int   `json:"fix_exit_code,omitempty"` // of the suggested command once it ran
+	Plugin      string `json:"plugin,omitempty"`        // set when a plugin made the suggestion
+	Provider    string `json:"provider,omitempty"`      // AI provider and model, for AI suggestions
+	Model       string `json:"model,omitempty"`
+	LatencyMS   int64  `json:"latency_ms,omitempty"` // time taken to find the suggestion
+
+	Count     int       `json:"count,omitempty"`      // times the same failure and suggestion were seen
+	FirstTime time.Time `json:"first_time,omitempty"` // when they were first seen, if more than once
+}
+
+// Occurrences returns how often the failure was seen
+func (e Entry) Occurrences() int {
+	if e.Count < 1 {
+		return 1
+	}
+	return e.Count
+}
+
+// First returns when the failure was first seen
+func (e Entry) First() time.Time {
+	if e.FirstTime.IsZero() {
+		return e.Time
+	}
+	return e.FirstTime
+}
+
+// key identifies repeats of the same failure with the same suggestion in
+// the same session
+func (e Entry) key() string {
+	return e.Session + "\x00" + e.Command + "\x00" + e.Output + "\x00" + e.Suggestion
+}
+
+// merge folds older, an earlier occurrence of the same failure, into e
+func (e *Entry) merge(older Entry) {
+	e.Count = e.Occurrences() + older.Occurrences()
+	e.FirstTime = older.First()
+	if e.Explanation == "" {
+		e.Explanation = older.Explanation
+	}
+	if e.Feedback == "" {
+		e.Feedback, e.Note = older.Feedback, older.Note
+	}
+}
+
+// file is the on-disk layout of the history file
+type file struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Store is the suggestion history shared by all LogAid processes
+type Store struct {
+	path       string
+	maxEntries int
+}
+
+// New creates a history store persisted at path, keeping at most maxEntries
+func New(path string, maxEntries int) *Store {
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxEntries
+	}
+	return &Store{path: path, maxEntries: maxEntries}
+}
+
+// NewFromConfig creates the store for HISTORY_FILE. It returns nil when no
+// history file is configured.
+func NewFromConfig() *Store {
+	cfg := config.Current()
+	if cfg == nil || cfg.HistoryFile == "" {
+		return nil
+	}
+	return New(cfg.HistoryFile, cfg.MaxHistoryEntries)
+}
+
+// mask scrubs secrets from the text of entry unless MASK_SECRETS is off
+func mask(entry *Entry) {
+	cfg := config.Current()
+	if cfg == nil || !cfg.MaskSecrets {
+		return
+	}
+	for _, text := range []*string{&entry.Command, &entry.Output, &entry.Suggestion, &entry.Explanation, &entry.Note} {
+		*text = redact.Secrets(*text)
+	}
+}
+
+// Path returns the location of the history file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add appends an entry, assigning its ID and time, and returns it
+func (s *Store) Add(entry Entry) (Entry, error) {
+	var data file
+	err := state.UpdateJSON(s.path, &data, func() error {
+		if entry.Time.IsZero() {
+			entry.Time = time.Now()
+		}
+		entry.ID = entry.Time.UnixNano()
+		mask(&entry)
+
+		// A repeated failure replaces its earlier entry and counts it
+		for i := len(data.Entries) - 1; i >= 0; i-- {
+			if data.Entries[i].key() == entry.key() {
+				entry.merge(data.Entries[i])
+				data.Entries = append(data.Entries[:i], data.Entries[i+1:]...)
+				break
+			}
+		}
+		if n := len(data.Entries); n > 0 && data.Entries[n-1].ID >= entry.ID {
+			entry.ID = data.Entries[n-1].ID + 1
+		}
+
+		data.Entries = append(data.Entries, entry)
+		if len(data.Entries) > s.maxEntries {
+			data.Entries = data.Entries[len(data.Entries)-s.maxEntries:]
+		}
+		return nil
+	})
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to record history: %w", err)
+	}
+	return entry, nil
+}
+
+// Update changes the entry with the given ID
+func (s *Store) Update(id int64, fn func(entry *Entry)) error {
+	var data file
+	return state.UpdateJSON(s.path, &data, func() error {
+		for i := range data.Entries {
+			if data.Entries[i].ID == id {
+				fn(&data.Entries[i])
+				mask(&data.Entries[i])
+				return nil
+			}
+		}
+		return fmt.Errorf("history entry %d not found", id)
+	})
+}
+
+// List returns up to n of the most recent entries, oldest first (all if n <= 0)
+func (s *Store) List(n int) ([]Entry, error) {
+	var data file
+	if err := state.ReadJSON(s.path, &data); err != nil {
+		return nil, err
+	}
+	if n > 0 && len(data.Entries) > n {
+		return data.Entries[len(data.Entries)-n:], nil
+	}
+	return data.Entries, nil
+}
+
+// Prune drops the entries last seen before cutoff (none when it is zero),
+// collapses repeated failures into one entry and trims the history to its
+// maximum size. It returns the number of entries removed
+func (s *Store) Prune(cutoff time.Time) (int, error) {
+	var data file
+	removed := 0
+	err := state.UpdateJSON(s.path, &data, func() error {
+		before := len(data.Entries)
+		var kept []Entry
+		for _, entry := range data.Entries {
+			if cutoff.IsZero() || !entry.Time.Before(cutoff) {
+				kept = append(kept, entry)
+			}
+		}
+		kept = collapse(kept)
+		if len(kept) > s.maxEntries {
+			kept = kept[len(kept)-s.maxEntries:]
+		}
+		data.Entries = kept
+		removed = before - len(kept)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune history: %w", err)
+	}
+	return removed, nil
+}
+
+// collapse merges repeats of the same failure into their latest entry
+func collapse(entries []Entry) []Entry {
+	latest := map[string]int{}
+	var kept []Entry // newest first
+	for i := len(entries) - 1; i >= 0; i-- {
+		if j, ok := latest[entries[i].key()]; ok {
+			kept[j].merge(entries[i])
+			continue
+		}
+		latest[entries[i].key()] = len(kept)
+		kept = append(kept, entries[i])
+	}
+	for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+		kept[i], kept[j] = kept[j], kept[i]
+	}
+	return kept
+}
+
+// Filter selects history entries. Zero values match everything
+type Filter struct {
+	Search     string    // text in the command, output, suggestion or source
+	FailedOnly bool      // entries whose fix failed
+	Since      time.Time // entries at or after this time
+	Session    string    // entries recorded in this shell session
+}
+
+// Matches reports whether entry is selected by f
+func (f Filter) Matches(entry Entry) bool {
+	if f.FailedOnly && entry.Status != StatusFailed {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if f.Session != "" && entry.Session != f.Session {
+		return false
+	}
+	if f.Search == "" {
+		return true
+	}
+	search := strings.ToLower(f.Search)
+	for _, text := range []string{entry.Command, entry.Output, entry.Suggestion, entry.Source} {
+		if strings.Contains(strings.ToLower(text), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// Search returns the entries selected by filter, oldest first
+func (s *Store) Search(filter Filter) ([]Entry, error) {
+	entries, err := s.List(0)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Entry
+	for _, entry := range entries {
+		if filter.Matches(entry) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches, nil
+}
+
+// Get returns the entry with the given ID, or nil if there is none
+func (s *Store) Get(id int64) (*Entry, error) {
+	entries, err := s.List(0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Harmful returns the suggestions users rated harmful
+func (s *Store) Harmful() (map[string]bool, error) {
+	entries, err := s.List(0)
+	if err != nil {
+		return nil, err
+	}
+	harmful := map[string]bool{}
+	for _, entry := range entries {
+		if entry.Feedback == FeedbackHarmful {
+			harmful[entry.Suggestion] = true
+		}
+	}
+	return harmful, nil
+}
+
+// Last returns the most recent entry, or nil if the history is empty
+func (s *Store) Last() (*Entry, error) {
+	entries, err := s.List(1)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return &entries[0], nil
+}
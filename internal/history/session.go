@@ -0,0 +1,82 @@
+package history
+
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+// SessionEnv overrides the shell session entries are recorded in, e.g. to
+// give each tmux pane or CI job its own
+const SessionEnv = "LOGAID_SESSION"
+
+// CurrentSession identifies the shell session LogAid runs in: LOGAID_SESSION
+// if set, otherwise the session of the terminal
+func CurrentSession() string {
+	if id := os.Getenv(SessionEnv); id != "" {
+		return id
+	}
+	return terminalSession()
+}
+
+// Session summarizes the entries recorded in one shell session
+type Session struct {
+	ID       string    `json:"id"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Entries  int       `json:"entries"`
+	Failures int       `json:"failures"` // failed commands, counting repeats
+	Fixed    int       `json:"fixed"`    // entries whose fix was applied and worked
+}
+
+// Sessions returns the sessions in the history, the most recently active
+// last. Entries recorded before sessions were tracked are left out
+func (s *Store) Sessions() ([]Session, error) {
+	entries, err := s.List(0)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]*Session{}
+	var sessions []*Session
+	for _, entry := range entries {
+		if entry.Session == "" {
+			continue
+		}
+		session := byID[entry.Session]
+		if session == nil {
+			session = &Session{ID: entry.Session, Start: entry.First()}
+			byID[entry.Session] = session
+			sessions = append(sessions, session)
+		}
+		if entry.First().Before(session.Start) {
+			session.Start = entry.First()
+		}
+		if entry.Time.After(session.End) {
+			session.End = entry.Time
+		}
+		session.Entries++
+		session.Failures += entry.Occurrences()
+		if entry.Status == StatusApplied {
+			session.Fixed++
+		}
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].End.Before(sessions[j].End)
+	})
+	summaries := make([]Session, len(sessions))
+	for i, session := range sessions {
+		summaries[i] = *session
+	}
+	return summaries, nil
+}
+
+// LastSession returns the ID of the most recently active session, or "" if
+// no entry has one
+func (s *Store) LastSession() (string, error) {
+	sessions, err := s.Sessions()
+	if err != nil || len(sessions) == 0 {
+		return "", err
+	}
+	return sessions[len(sessions)-1].ID, nil
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package history
+
+import (
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalSession returns the ID of the Unix session LogAid runs in, shared
+// by the shell of a terminal and every command started from it
+func terminalSession() string {
+	sid, err := unix.Getsid(0)
+	if err != nil {
+		return ""
+	}
+	return strconv.Itoa(sid)
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package history
+
+import (
+	"os"
+	"strconv"
+)
+
+// terminalSession returns the process ID of the shell that started LogAid
+func terminalSession() string {
+	return strconv.Itoa(os.Getppid())
+}
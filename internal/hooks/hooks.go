@@ -0,0 +1,149 @@
+// Package hooks wraps git hooks so LogAid explains their failures, for
+// 'logaid hooks'. The original hook is kept next to the wrapper and still
+// decides whether the commit or push goes ahead
+package hooks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Default are the hooks installed when none are named
+var Default = []string{"pre-commit", "pre-push"}
+
+// Supported are the hooks that can be wrapped: the ones that can fail and
+// stop git with output worth explaining
+var Supported = []string{"pre-commit", "pre-merge-commit", "prepare-commit-msg", "commit-msg", "pre-rebase", "pre-push"}
+
+// OriginalSuffix is appended to the name of a hook that was wrapped
+const OriginalSuffix = ".logaid-orig"
+
+// marker identifies a wrapper written by Install
+const marker = "# Installed by 'logaid hooks install'"
+
+// wrapper runs the original hook through 'logaid hooks run', or directly
+// when LogAid is no longer installed
+const wrapper = `#!/bin/sh
+%s; the original hook, if any,
+# is %[2]s%[3]s. Remove this with 'logaid hooks uninstall'.
+if command -v logaid >/dev/null 2>&1; then
+	exec logaid hooks run %[2]s "$@"
+fi
+hook="$(dirname "$0")/%[2]s%[3]s"
+[ -x "$hook" ] || exit 0
+exec "$hook" "$@"
+`
+
+// Status is the state of a hook in a repository
+type Status struct {
+	Name      string `json:"name"`
+	Installed bool   `json:"installed"` // the LogAid wrapper is in place
+	Original  bool   `json:"original"`  // there is a hook of the repository's own
+}
+
+// Dir returns the hooks directory of the git repository the working
+// directory is in, honouring core.hooksPath
+func Dir() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("not in a git repository: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("failed to run git: %w", err)
+	}
+	dir, err := filepath.Abs(strings.TrimSpace(string(out)))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve hooks directory: %w", err)
+	}
+	return dir, nil
+}
+
+// Check returns an error if name is not a hook that can be wrapped
+func Check(name string) error {
+	for _, supported := range Supported {
+		if name == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported hook %q, expected one of %s", name, strings.Join(Supported, ", "))
+}
+
+// Original returns the path the repository's own hook is kept at once the
+// hook is wrapped
+func Original(dir, name string) string {
+	return filepath.Join(dir, name+OriginalSuffix)
+}
+
+// Install wraps the hook name in dir, keeping the existing hook as the
+// original. Installing again rewrites the wrapper
+func Install(dir, name string) (Status, error) {
+	if err := Check(name); err != nil {
+		return Status{}, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return Status{}, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	path := filepath.Join(dir, name)
+	status := Get(dir, name)
+	if !status.Installed && exists(path) {
+		if status.Original {
+			return status, fmt.Errorf("both %s and %s exist; move one of them away first", path, Original(dir, name))
+		}
+		if err := os.Rename(path, Original(dir, name)); err != nil {
+			return status, fmt.Errorf("failed to move hook %s: %w", name, err)
+		}
+		status.Original = true
+	}
+
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(wrapper, marker, name, OriginalSuffix)), 0755); err != nil {
+		return status, fmt.Errorf("failed to write hook %s: %w", name, err)
+	}
+	status.Installed = true
+	return status, nil
+}
+
+// Uninstall removes the wrapper of the hook name in dir and puts the
+// original back. Hooks LogAid did not install are left alone
+func Uninstall(dir, name string) (Status, error) {
+	if err := Check(name); err != nil {
+		return Status{}, err
+	}
+	status := Get(dir, name)
+	if !status.Installed {
+		return status, nil
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.Remove(path); err != nil {
+		return status, fmt.Errorf("failed to remove hook %s: %w", name, err)
+	}
+	status.Installed = false
+	if status.Original {
+		if err := os.Rename(Original(dir, name), path); err != nil {
+			return status, fmt.Errorf("failed to restore hook %s: %w", name, err)
+		}
+	}
+	return status, nil
+}
+
+// Get returns the state of the hook name in dir
+func Get(dir, name string) Status {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	return Status{
+		Name:      name,
+		Installed: err == nil && bytes.Contains(data, []byte(marker)),
+		Original:  exists(Original(dir, name)),
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,193 @@
+// Package learning remembers what happened to the suggestions made for each
+// kind of error. A fix that was accepted and worked is offered first the next
+// time the same error appears, and a suggestion rejected twice is not made
+// again. Errors are keyed by their normalized signature (see cache.Signature),
+// so a fix learned for "apt install foo" also applies to "apt install bar".
+package learning
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/cache"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// MaxRejections is how often a suggestion can be rejected for an error
+// before it is no longer proposed for it
+const MaxRejections = 2
+
+// maxEntries bounds the size of the learning file
+const maxEntries = 1000
+
+// Entry is what happened to one suggestion for one error signature
+type Entry struct {
+	Command    string    `json:"command"`    // command template
+	Error      string    `json:"error"`      // normalized error text
+	Suggestion string    `json:"suggestion"` // with placeholders for the command's variables
+	Succeeded  int       `json:"succeeded"`  // accepted and ran successfully
+	Failed     int       `json:"failed"`     // accepted but did not work
+	Rejected   int       `json:"rejected"`
+	LastUsed   time.Time `json:"last_used"`
+}
+
+// trusted reports whether the entry's fix should be offered first
+func (e Entry) trusted() bool {
+	return e.Succeeded > e.Failed && e.Rejected < MaxRejections
+}
+
+// file is the on-disk layout of the learning store
+type file struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Store persists the outcomes of suggestions
+type Store struct {
+	path string
+}
+
+// New creates a store persisted at path
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// NewFromConfig creates the store for ~/.logaid/learning.json. It returns
+// nil when LEARN_FIXES is disabled.
+func NewFromConfig() *Store {
+	cfg := config.Current()
+	if cfg == nil || !cfg.LearnFixes {
+		return nil
+	}
+	return New(filepath.Join(config.Dir(), "learning.json"))
+}
+
+// Path returns the location of the learning file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Succeeded records that suggestion was accepted for the failed command and
+// fixed it
+func (s *Store) Succeeded(command, output, suggestion string) error {
+	return s.record(command, output, suggestion, func(entry *Entry) { entry.Succeeded++ })
+}
+
+// Failed records that suggestion was accepted but did not work
+func (s *Store) Failed(command, output, suggestion string) error {
+	return s.record(command, output, suggestion, func(entry *Entry) { entry.Failed++ })
+}
+
+// Rejected records that the user turned suggestion down
+func (s *Store) Rejected(command, output, suggestion string) error {
+	return s.record(command, output, suggestion, func(entry *Entry) { entry.Rejected++ })
+}
+
+// record updates the entry of suggestion for the failed command with fn
+func (s *Store) record(command, output, suggestion string, fn func(entry *Entry)) error {
+	sig := cache.NewSignature(command, output)
+	template := cache.TemplatizeSuggestion(suggestion, sig.Variables)
+
+	var data file
+	return state.UpdateJSON(s.path, &data, func() error {
+		var entry *Entry
+		for i := range data.Entries {
+			e := &data.Entries[i]
+			if e.Command == sig.Command && e.Error == sig.Error && e.Suggestion == template {
+				entry = e
+				break
+			}
+		}
+		if entry == nil {
+			data.Entries = append(data.Entries, Entry{Command: sig.Command, Error: sig.Error, Suggestion: template})
+			entry = &data.Entries[len(data.Entries)-1]
+		}
+		fn(entry)
+		entry.LastUsed = time.Now()
+
+		if len(data.Entries) > maxEntries {
+			sort.Slice(data.Entries, func(i, j int) bool {
+				return data.Entries[i].LastUsed.After(data.Entries[j].LastUsed)
+			})
+			data.Entries = data.Entries[:maxEntries]
+		}
+		return nil
+	})
+}
+
+// matching returns the entries for the signature of the failed command
+func (s *Store) matching(command, output string) ([]Entry, cache.Signature, error) {
+	sig := cache.NewSignature(command, output)
+	var data file
+	if err := state.ReadJSON(s.path, &data); err != nil {
+		return nil, sig, err
+	}
+	var entries []Entry
+	for _, entry := range data.Entries {
+		if entry.Command == sig.Command && entry.Error == sig.Error {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, sig, nil
+}
+
+// Fix returns the fix that worked most often for the failed command's error,
+// unless it failed as often or was rejected twice
+func (s *Store) Fix(command, output string) (string, bool) {
+	entries, sig, err := s.matching(command, output)
+	if err != nil {
+		return "", false
+	}
+	var best *Entry
+	for i := range entries {
+		entry := &entries[i]
+		if !entry.trusted() {
+			continue
+		}
+		if best == nil || entry.Succeeded > best.Succeeded ||
+			(entry.Succeeded == best.Succeeded && entry.LastUsed.After(best.LastUsed)) {
+			best = entry
+		}
+	}
+	if best == nil {
+		return "", false
+	}
+	return cache.Expand(best.Suggestion, sig.Variables), true
+}
+
+// Blocked returns the suggestions rejected twice for the failed command's
+// error, which are not proposed for it again
+func (s *Store) Blocked(command, output string) map[string]bool {
+	blocked := map[string]bool{}
+	entries, sig, err := s.matching(command, output)
+	if err != nil {
+		return blocked
+	}
+	for _, entry := range entries {
+		if entry.Rejected >= MaxRejections {
+			blocked[cache.Expand(entry.Suggestion, sig.Variables)] = true
+		}
+	}
+	return blocked
+}
+
+// Prune forgets the outcomes last recorded before cutoff and returns how many
+// were removed
+func (s *Store) Prune(cutoff time.Time) (int, error) {
+	removed := 0
+	var data file
+	err := state.UpdateJSON(s.path, &data, func() error {
+		entries := data.Entries[:0]
+		for _, entry := range data.Entries {
+			if entry.LastUsed.Before(cutoff) {
+				removed++
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		data.Entries = entries
+		return nil
+	})
+	return removed, err
+}
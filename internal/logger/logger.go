@@ -2,27 +2,37 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+	"github.com/ayushsharma-1/LogAid/internal/theme"
 	"github.com/fatih/color"
 )
 
 var (
-	InfoColor    = color.New(color.FgCyan)
-	WarnColor    = color.New(color.FgYellow)
-	ErrorColor   = color.New(color.FgRed)
-	SuccessColor = color.New(color.FgGreen)
-	DebugColor   = color.New(color.FgMagenta)
+	InfoColor       = color.New(color.FgCyan)
+	WarnColor       = color.New(color.FgYellow)
+	ErrorColor      = color.New(color.FgRed)
+	SuccessColor    = color.New(color.FgGreen)
+	DebugColor      = color.New(color.FgMagenta)
+	SuggestionColor = color.New(color.FgCyan)
 )
 
 type Logger struct {
 	level    string
 	file     *os.File
+	out      *fileWriter // buffers writes to file
 	logger   *log.Logger
+	console  io.Writer // where messages are shown, stdout by default
 	colorful bool
+	quiet    bool // hide info and success messages on the console
+	mask     bool // mask secrets in the log file
 }
 
 var AppLogger *Logger
@@ -56,32 +66,53 @@ func Init() error {
 		return fmt.Errorf("failed to open log file: %w", err)
 	}
 
+	interval := defaultFlushInterval
+	if seconds, err := strconv.Atoi(os.Getenv("LOG_FLUSH_INTERVAL")); err == nil && seconds > 0 {
+		interval = time.Duration(seconds) * time.Second
+	}
+	out := newFileWriter(file, os.Getenv("LOG_ASYNC") == "false", interval)
+
 	AppLogger = &Logger{
 		level:    strings.ToLower(level),
 		file:     file,
-		logger:   log.New(file, "", log.LstdFlags),
-		colorful: os.Getenv("ENABLE_COLORS") != "false",
+		out:      out,
+		logger:   log.New(out, "", log.LstdFlags),
+		console:  os.Stdout,
+		colorful: os.Getenv("ENABLE_COLORS") != "false" && !theme.Disabled(),
+		mask:     os.Getenv("MASK_SECRETS") != "false",
 	}
 
 	return nil
 }
 
-// Close closes the logger
+// Close flushes buffered messages and closes the log file
 func (l *Logger) Close() error {
+	if l.out != nil {
+		l.out.Close()
+	}
 	if l.file != nil {
 		return l.file.Close()
 	}
 	return nil
 }
 
+// write appends msg to the log file, with secrets masked unless MASK_SECRETS
+// is off
+func (l *Logger) write(level, msg string) {
+	if l.mask {
+		msg = redact.Secrets(msg)
+	}
+	l.logger.Printf("[%s] %s", level, msg)
+}
+
 // Debug logs a debug message
 func (l *Logger) Debug(msg string) {
 	if l.shouldLog("debug") {
-		l.logger.Printf("[DEBUG] %s", msg)
+		l.write("DEBUG", msg)
 		if l.colorful {
-			DebugColor.Printf("[DEBUG] %s\n", msg)
+			DebugColor.Fprintf(l.console, "[DEBUG] %s\n", msg)
 		} else {
-			fmt.Printf("[DEBUG] %s\n", msg)
+			fmt.Fprintf(l.console, "[DEBUG] %s\n", msg)
 		}
 	}
 }
@@ -89,11 +120,29 @@ func (l *Logger) Debug(msg string) {
 // Info logs an info message
 func (l *Logger) Info(msg string) {
 	if l.shouldLog("info") {
-		l.logger.Printf("[INFO] %s", msg)
+		l.write("INFO", msg)
+		if l.quiet {
+			return
+		}
 		if l.colorful {
-			InfoColor.Printf("[INFO] %s\n", msg)
+			InfoColor.Fprintf(l.console, "[INFO] %s\n", msg)
 		} else {
-			fmt.Printf("[INFO] %s\n", msg)
+			fmt.Fprintf(l.console, "[INFO] %s\n", msg)
+		}
+	}
+}
+
+// Suggestion logs a suggested fix like Info, in the suggestion color
+func (l *Logger) Suggestion(msg string) {
+	if l.shouldLog("info") {
+		l.write("INFO", msg)
+		if l.quiet {
+			return
+		}
+		if l.colorful {
+			SuggestionColor.Fprintf(l.console, "[INFO] %s\n", msg)
+		} else {
+			fmt.Fprintf(l.console, "[INFO] %s\n", msg)
 		}
 	}
 }
@@ -101,11 +150,11 @@ func (l *Logger) Info(msg string) {
 // Warn logs a warning message
 func (l *Logger) Warn(msg string) {
 	if l.shouldLog("warn") {
-		l.logger.Printf("[WARN] %s", msg)
+		l.write("WARN", msg)
 		if l.colorful {
-			WarnColor.Printf("[WARN] %s\n", msg)
+			WarnColor.Fprintf(l.console, "[WARN] %s\n", msg)
 		} else {
-			fmt.Printf("[WARN] %s\n", msg)
+			fmt.Fprintf(l.console, "[WARN] %s\n", msg)
 		}
 	}
 }
@@ -113,22 +162,29 @@ func (l *Logger) Warn(msg string) {
 // Error logs an error message
 func (l *Logger) Error(msg string) {
 	if l.shouldLog("error") {
-		l.logger.Printf("[ERROR] %s", msg)
+		l.write("ERROR", msg)
+		// Errors are often followed by an exit, keep them on disk
+		if l.out != nil {
+			l.out.Flush()
+		}
 		if l.colorful {
-			ErrorColor.Printf("[ERROR] %s\n", msg)
+			ErrorColor.Fprintf(l.console, "[ERROR] %s\n", msg)
 		} else {
-			fmt.Printf("[ERROR] %s\n", msg)
+			fmt.Fprintf(l.console, "[ERROR] %s\n", msg)
 		}
 	}
 }
 
 // Success logs a success message
 func (l *Logger) Success(msg string) {
-	l.logger.Printf("[SUCCESS] %s", msg)
+	l.write("SUCCESS", msg)
+	if l.quiet {
+		return
+	}
 	if l.colorful {
-		SuccessColor.Printf("✓ %s\n", msg)
+		SuccessColor.Fprintf(l.console, "✓ %s\n", msg)
 	} else {
-		fmt.Printf("✓ %s\n", msg)
+		fmt.Fprintf(l.console, "✓ %s\n", msg)
 	}
 }
 
@@ -153,6 +209,116 @@ func (l *Logger) shouldLog(level string) bool {
 	return msgLevel >= currentLevel
 }
 
+// SetConsole shows messages on w instead of stdout, e.g. stderr when stdout
+// carries JSON
+func SetConsole(w io.Writer) {
+	if AppLogger != nil {
+		AppLogger.console = w
+	}
+}
+
+// SetLevel changes the lowest level written, e.g. after LOG_LEVEL changed
+func SetLevel(level string) {
+	if AppLogger != nil && level != "" {
+		AppLogger.level = strings.ToLower(level)
+	}
+}
+
+// Level returns the lowest level written
+func Level() string {
+	if AppLogger == nil {
+		return "info"
+	}
+	return AppLogger.level
+}
+
+// ToggleDebug switches to debug logging, or back to configured when debug
+// logging is already on. It returns the new level
+func ToggleDebug(configured string) string {
+	level := "debug"
+	if Level() == "debug" {
+		level = strings.ToLower(configured)
+		if level == "" || level == "debug" {
+			level = "info"
+		}
+	}
+	SetLevel(level)
+	return level
+}
+
+// SetAsync buffers writes to the log file, flushed every interval and on
+// exit, or writes every message synchronously when enabled is false
+func SetAsync(enabled bool, interval time.Duration) {
+	if AppLogger != nil && AppLogger.out != nil {
+		AppLogger.out.configure(!enabled, interval)
+	}
+}
+
+// Flush writes buffered messages to the log file
+func Flush() {
+	if AppLogger != nil && AppLogger.out != nil {
+		AppLogger.out.Flush()
+	}
+}
+
+// SetMasking turns masking secrets in the log file on or off
+func SetMasking(enabled bool) {
+	if AppLogger != nil {
+		AppLogger.mask = enabled
+	}
+}
+
+// SetColors turns colors on the console on or off. NO_COLOR keeps them off
+func SetColors(enabled bool) {
+	if AppLogger != nil {
+		AppLogger.colorful = enabled && !theme.Disabled()
+	}
+}
+
+// SetTheme colors messages with t
+func SetTheme(t theme.Theme) error {
+	colors := []struct {
+		target **color.Color
+		spec   string
+	}{
+		{&ErrorColor, t.Error}, {&WarnColor, t.Warning}, {&SuccessColor, t.Success},
+		{&SuggestionColor, t.Suggestion}, {&InfoColor, t.Info}, {&DebugColor, t.Debug},
+	}
+	parsed := make([]*color.Color, len(colors))
+	for i, c := range colors {
+		value, err := theme.Parse(c.spec)
+		if err != nil {
+			return err
+		}
+		parsed[i] = value
+	}
+	for i, c := range colors {
+		*c.target = parsed[i]
+	}
+	return nil
+}
+
+// SetQuiet hides info and success messages on the console; they are still
+// written to the log file
+func SetQuiet(quiet bool) {
+	if AppLogger != nil {
+		AppLogger.quiet = quiet
+	}
+}
+
+// Quiet reports whether info and success messages are hidden
+func Quiet() bool {
+	return AppLogger != nil && AppLogger.quiet
+}
+
+// Console returns where messages are shown
+func Console() io.Writer {
+	if AppLogger == nil {
+		return os.Stdout
+	}
+	return AppLogger.console
+}
+
 // Global logging functions for convenience
 func Debug(msg string) {
 	if AppLogger != nil {
@@ -166,6 +332,12 @@ func Info(msg string) {
 	}
 }
 
+func Suggestion(msg string) {
+	if AppLogger != nil {
+		AppLogger.Suggestion(msg)
+	}
+}
+
 func Warn(msg string) {
 	if AppLogger != nil {
 		AppLogger.Warn(msg)
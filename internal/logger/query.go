@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// timeLayout is how the standard log package stamps each line of the file
+const timeLayout = "2006/01/02 15:04:05"
+
+// linePattern matches a line of the log file: time, [LEVEL] and message
+var linePattern = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}) \[([A-Z]+)\] (.*)$`)
+
+// Entry is a message read back from the log file
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"` // debug, info, warn, error or success
+	Message string    `json:"message"`
+}
+
+// Filter selects entries of the log file. Zero values match everything
+type Filter struct {
+	Level  string         // lowest level shown; success counts as info
+	Since  time.Time      // entries at or after this time
+	Plugin string         // entries that mention the plugin by name
+	Grep   *regexp.Regexp // entries whose message matches
+}
+
+// levelRank orders levels like shouldLog
+var levelRank = map[string]int{"debug": 0, "info": 1, "success": 1, "warn": 2, "error": 3}
+
+// Matches reports whether entry is selected by f
+func (f Filter) Matches(entry Entry) bool {
+	if f.Level != "" && levelRank[entry.Level] < levelRank[strings.ToLower(f.Level)] {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if f.Plugin != "" && !mentions(entry.Message, f.Plugin) {
+		return false
+	}
+	return f.Grep == nil || f.Grep.MatchString(entry.Message)
+}
+
+// mentions reports whether message names plugin as a whole word
+func mentions(message, plugin string) bool {
+	pattern := regexp.MustCompile(`(?i)(^|[^A-Za-z0-9_-])` + regexp.QuoteMeta(plugin) + `($|[^A-Za-z0-9_-])`)
+	return pattern.MatchString(message)
+}
+
+// Query returns the entries of the log file at path selected by filter,
+// oldest first. Lines without a timestamp continue the previous message
+func Query(path string, filter Filter) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	var current *Entry
+	flush := func() {
+		if current != nil && filter.Matches(*current) {
+			entries = append(entries, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		match := linePattern.FindStringSubmatch(line)
+		if match == nil {
+			if current != nil {
+				current.Message += "\n" + line
+			}
+			continue
+		}
+		flush()
+		stamp, err := time.ParseInLocation(timeLayout, match[1], time.Local)
+		if err != nil {
+			continue
+		}
+		current = &Entry{Time: stamp, Level: strings.ToLower(match[2]), Message: match[3]}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read log file: %w", err)
+	}
+	return entries, nil
+}
+
+// Path returns the log file messages are written to
+func Path() string {
+	if AppLogger == nil || AppLogger.file == nil {
+		return ""
+	}
+	return AppLogger.file.Name()
+}
+
+// Prune drops the messages logged before cutoff from the log file at path.
+// The file is rewritten in place, so processes that have it open keep
+// appending to it
+func Prune(path string, cutoff time.Time) error {
+	if AppLogger != nil && AppLogger.out != nil && Path() == path {
+		AppLogger.out.mu.Lock()
+		defer AppLogger.out.mu.Unlock()
+		AppLogger.out.flushLocked()
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read log file: %w", err)
+	}
+	var kept strings.Builder
+	keep := false
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if match := linePattern.FindStringSubmatch(strings.TrimSuffix(line, "\n")); match != nil {
+			stamp, err := time.ParseInLocation(timeLayout, match[1], time.Local)
+			keep = err != nil || !stamp.Before(cutoff)
+		}
+		if keep {
+			kept.WriteString(line)
+		}
+	}
+	if err := os.WriteFile(path, []byte(kept.String()), 0666); err != nil {
+		return fmt.Errorf("failed to prune log file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"time"
+)
+
+// bufferSize is how much is buffered before a write goes to the file anyway
+const bufferSize = 64 * 1024
+
+// defaultFlushInterval is used when LOG_FLUSH_INTERVAL is not set
+const defaultFlushInterval = time.Second
+
+// fileWriter buffers log lines in memory and writes them to the file in the
+// background, so logging adds no disk I/O to the suggestion path. Each
+// message is written whole, so lines of processes sharing the file do not
+// interleave. In synchronous mode every message goes straight to the file
+type fileWriter struct {
+	mu       sync.Mutex
+	file     *os.File
+	buf      bytes.Buffer
+	sync     bool
+	interval time.Duration
+	stop     chan struct{} // closes the flush loop; nil while synchronous
+}
+
+// newFileWriter writes to file, asynchronously unless sync is set
+func newFileWriter(file *os.File, sync bool, interval time.Duration) *fileWriter {
+	w := &fileWriter{file: file}
+	w.configure(sync, interval)
+	return w
+}
+
+// Write buffers p, one log message
+func (w *fileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.sync {
+		return w.file.Write(p)
+	}
+	if w.buf.Len()+len(p) > bufferSize {
+		if err := w.flushLocked(); err != nil {
+			return 0, err
+		}
+	}
+	return w.buf.Write(p)
+}
+
+// Flush writes the buffered messages to the file
+func (w *fileWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flushLocked()
+}
+
+func (w *fileWriter) flushLocked() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.file.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// configure switches between synchronous and buffered writes flushed every
+// interval
+func (w *fileWriter) configure(sync bool, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultFlushInterval
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.sync == sync && w.interval == interval && (sync || w.stop != nil) {
+		return
+	}
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+	w.flushLocked()
+	w.sync, w.interval = sync, interval
+	if !sync {
+		w.stop = make(chan struct{})
+		go w.flushEvery(interval, w.stop)
+	}
+}
+
+// flushEvery flushes the buffer every interval until stop is closed
+func (w *fileWriter) flushEvery(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.Flush()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Close flushes the buffer and stops the background flushes; later messages
+// are written synchronously
+func (w *fileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+	w.sync = true
+	return w.flushLocked()
+}
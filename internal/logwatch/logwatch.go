@@ -0,0 +1,146 @@
+// Package logwatch follows a log file or a systemd journal and suggests
+// fixes for the errors that appear in it, for 'logaid watch'
+package logwatch
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+)
+
+// Finding is an error seen in a log and the fix suggested for it
+type Finding struct {
+	Log        string             `json:"log"` // file or unit the error was seen in
+	Time       time.Time          `json:"time"`
+	Line       string             `json:"line"`   // the line the error was detected in
+	Output     string             `json:"output"` // the lines around it the fix was based on
+	Suggestion *engine.Suggestion `json:"suggestion,omitempty"`
+	Error      string             `json:"error,omitempty"` // why no suggestion could be made
+}
+
+// Watcher detects errors in the lines of a log and asks an engine for fixes
+type Watcher struct {
+	Engine   *engine.Engine
+	Log      string        // passed to the engine as the failed command
+	Before   int           // lines before an error included in its output
+	After    int           // lines after an error included in its output
+	Settle   time.Duration // how long to wait for the lines after an error
+	Cooldown time.Duration // repeats of an error within this are ignored
+
+	recent []string
+	seen   map[string]time.Time
+}
+
+// New creates a watcher for the log called name with the default settings
+func New(eng *engine.Engine, name string) *Watcher {
+	return &Watcher{
+		Engine:   eng,
+		Log:      name,
+		Before:   10,
+		After:    10,
+		Settle:   time.Second,
+		Cooldown: 10 * time.Minute,
+	}
+}
+
+// Run reads the log with read and calls found for each new error, until ctx
+// is done or the log ends
+func (w *Watcher) Run(ctx context.Context, read Reader, found func(Finding)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan string, 256)
+	done := make(chan error, 1)
+	go func() {
+		done <- read(ctx, lines)
+		close(lines)
+	}()
+
+	var pending *Finding
+	var after int
+	settle := time.NewTimer(time.Hour)
+	settle.Stop()
+	flush := func() {
+		if pending != nil {
+			w.suggest(ctx, pending)
+			found(*pending)
+			pending = nil
+		}
+	}
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				flush()
+				return <-done
+			}
+			if pending != nil {
+				pending.Output += "\n" + line
+				if after++; after >= w.After {
+					settle.Stop()
+					flush()
+				}
+				w.remember(line)
+				continue
+			}
+			if w.Engine.DetectError(line) && w.isNew(line) {
+				pending = &Finding{Log: w.Log, Time: time.Now(), Line: strings.TrimSpace(line), Output: strings.Join(append(w.recent, line), "\n")}
+				after = 0
+				if w.After <= 0 {
+					flush()
+				} else {
+					settle.Reset(w.Settle)
+				}
+			}
+			w.remember(line)
+		case <-settle.C:
+			flush()
+		case <-ctx.Done():
+			return <-done
+		}
+	}
+}
+
+// suggest asks the engine for a fix for finding
+func (w *Watcher) suggest(ctx context.Context, finding *Finding) {
+	suggestion, err := w.Engine.Analyze(ctx, w.Log, finding.Output)
+	if err != nil {
+		finding.Error = err.Error()
+		return
+	}
+	finding.Suggestion = suggestion
+}
+
+// remember keeps line as context for the next error
+func (w *Watcher) remember(line string) {
+	if w.Before <= 0 {
+		return
+	}
+	w.recent = append(w.recent, line)
+	if len(w.recent) > w.Before {
+		w.recent = w.recent[len(w.recent)-w.Before:]
+	}
+}
+
+// volatile matches the parts of a log line that differ between repeats of
+// the same error: timestamps, PIDs, ports, addresses and IDs
+var volatile = regexp.MustCompile(`[0-9a-fA-F]*[0-9][0-9a-fA-F]*`)
+
+// isNew reports whether line is an error not seen within the cooldown, and
+// records it as seen
+func (w *Watcher) isNew(line string) bool {
+	if w.seen == nil {
+		w.seen = map[string]time.Time{}
+	}
+	key := volatile.ReplaceAllString(strings.TrimSpace(line), "#")
+	now := time.Now()
+	if last, ok := w.seen[key]; ok && now.Sub(last) < w.Cooldown {
+		return false
+	}
+	w.seen[key] = now
+	return true
+}
@@ -0,0 +1,137 @@
+package logwatch
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// pollInterval is how often a followed file is checked for new lines
+const pollInterval = 250 * time.Millisecond
+
+// Reader sends the lines of a log to lines until ctx is done or the log ends
+type Reader func(ctx context.Context, lines chan<- string) error
+
+// File follows the log file at path like 'tail -F': it starts at the end,
+// or at the beginning when fromStart is set, and reopens the file when it
+// is truncated or rotated
+func File(path string, fromStart bool) Reader {
+	return func(ctx context.Context, lines chan<- string) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer func() { f.Close() }()
+		if !fromStart {
+			if _, err := f.Seek(0, io.SeekEnd); err != nil {
+				return fmt.Errorf("failed to seek to the end of %s: %w", path, err)
+			}
+		}
+
+		reader := bufio.NewReader(f)
+		var partial string
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			line, err := reader.ReadString('\n')
+			partial += line
+			if err == nil {
+				if !send(ctx, lines, strings.TrimRight(partial, "\r\n")) {
+					return nil
+				}
+				partial = ""
+				continue
+			}
+			if err != io.EOF {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+			if reopened, ok := reopen(f, path); ok {
+				f = reopened
+				reader.Reset(f)
+				partial = ""
+			}
+		}
+	}
+}
+
+// reopen returns path opened again when it no longer is the file f reads,
+// or has become shorter than what was read
+func reopen(f *os.File, path string) (*os.File, bool) {
+	current, err := f.Stat()
+	if err != nil {
+		return nil, false
+	}
+	latest, err := os.Stat(path)
+	if err != nil {
+		// Rotated away and not yet recreated
+		return nil, false
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, false
+	}
+	if os.SameFile(current, latest) && latest.Size() >= offset {
+		return nil, false
+	}
+
+	reopened, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	f.Close()
+	return reopened, true
+}
+
+// Journal follows the systemd journal of unit with 'journalctl -f'
+func Journal(unit string) Reader {
+	return func(ctx context.Context, lines chan<- string) error {
+		cmd := exec.CommandContext(ctx, "journalctl", "--follow", "--unit", unit, "--lines", "0", "--output", "cat")
+		cmd.Env = os.Environ()
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return fmt.Errorf("failed to read journalctl output: %w", err)
+		}
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start journalctl: %w", err)
+		}
+
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if !send(ctx, lines, scanner.Text()) {
+				break
+			}
+		}
+		err = cmd.Wait()
+		if ctx.Err() != nil {
+			return nil
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("journalctl exited with status %d", exitErr.ExitCode())
+		}
+		return err
+	}
+}
+
+// send delivers line unless ctx is done first
+func send(ctx context.Context, lines chan<- string, line string) bool {
+	select {
+	case lines <- line:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,203 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// Commands the code actions run through workspace/executeCommand
+const (
+	// CommandApplyFix accepts a fix. A command is returned for the editor
+	// to run in the terminal; a configuration change is made by LogAid
+	CommandApplyFix = "logaid.applyFix"
+	// CommandDismissFix rejects the fixes for a failure and hides it
+	CommandDismissFix = "logaid.dismissFix"
+)
+
+const (
+	syncFull        = 1 // TextDocumentSyncKind.Full
+	severityError   = 1 // DiagnosticSeverity.Error
+	kindQuickFix    = "quickfix"
+	diagnosticLabel = "logaid"
+)
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type diagnostic struct {
+	Range    lspRange       `json:"range"`
+	Severity int            `json:"severity"`
+	Source   string         `json:"source"`
+	Message  string         `json:"message"`
+	Data     diagnosticData `json:"data"`
+}
+
+// diagnosticData tells the client which command failed
+type diagnosticData struct {
+	Command string `json:"command"`
+}
+
+type lspCommand struct {
+	Title     string        `json:"title"`
+	Command   string        `json:"command"`
+	Arguments []commandArgs `json:"arguments"`
+}
+
+// commandArgs are the argument of CommandApplyFix and CommandDismissFix
+type commandArgs struct {
+	URI  string `json:"uri"`
+	Line int    `json:"line"`          // of the failure's diagnostic
+	Fix  string `json:"fix,omitempty"` // the candidate command chosen
+}
+
+type codeAction struct {
+	Title       string       `json:"title"`
+	Kind        string       `json:"kind"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+	IsPreferred bool         `json:"isPreferred,omitempty"`
+	Command     lspCommand   `json:"command"`
+}
+
+type codeActionParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Range lspRange `json:"range"`
+}
+
+type executeCommandParams struct {
+	Command   string            `json:"command"`
+	Arguments []json.RawMessage `json:"arguments"`
+}
+
+// diagnostic describes f for the client
+func (f failure) diagnostic() diagnostic {
+	return diagnostic{
+		Range:    lspRange{Start: position{Line: f.Line}, End: position{Line: f.Line, Character: f.Width}},
+		Severity: severityError,
+		Source:   diagnosticLabel,
+		Message:  fmt.Sprintf("%s failed: %s", f.Command, f.Message),
+		Data:     diagnosticData{Command: f.Command},
+	}
+}
+
+// publish sends the diagnostics of a document, leaving out dismissed failures
+func (s *Server) publish(uri string) error {
+	diagnostics := []diagnostic{}
+	for _, f := range s.documents[uri] {
+		if !s.dismissed[f.key()] {
+			diagnostics = append(diagnostics, f.diagnostic())
+		}
+	}
+	return s.notify("textDocument/publishDiagnostics", map[string]interface{}{"uri": uri, "diagnostics": diagnostics})
+}
+
+// codeActions offers the fixes for the failures in the requested range,
+// asking the engine for them the first time
+func (s *Server) codeActions(ctx context.Context, p codeActionParams) []codeAction {
+	actions := []codeAction{}
+	for _, f := range s.documents[p.TextDocument.URI] {
+		if f.Line < p.Range.Start.Line || f.Line > p.Range.End.Line || s.dismissed[f.key()] {
+			continue
+		}
+		suggestion, err := s.suggest(ctx, f)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to get a suggestion for %s: %v", f.Command, err))
+			continue
+		}
+		if suggestion == nil {
+			continue
+		}
+
+		diagnostics := []diagnostic{f.diagnostic()}
+		args := commandArgs{URI: p.TextDocument.URI, Line: f.Line}
+		if suggestion.ConfigEdit != nil {
+			title := fmt.Sprintf("Change configuration: %s", suggestion.ConfigEdit.String())
+			actions = append(actions, codeAction{Title: title, Kind: kindQuickFix, Diagnostics: diagnostics, IsPreferred: true,
+				Command: lspCommand{Title: title, Command: CommandApplyFix, Arguments: []commandArgs{args}}})
+		}
+		for i, candidate := range suggestion.Candidates() {
+			args.Fix = candidate
+			title := fmt.Sprintf("Run: %s (LogAid, from %s)", candidate, suggestion.Source)
+			actions = append(actions, codeAction{Title: title, Kind: kindQuickFix, Diagnostics: diagnostics, IsPreferred: i == 0,
+				Command: lspCommand{Title: title, Command: CommandApplyFix, Arguments: []commandArgs{args}}})
+		}
+		args.Fix = ""
+		actions = append(actions, codeAction{Title: "Dismiss LogAid suggestion", Kind: kindQuickFix, Diagnostics: diagnostics,
+			Command: lspCommand{Title: "Dismiss", Command: CommandDismissFix, Arguments: []commandArgs{args}}})
+	}
+	return actions
+}
+
+// suggest returns the fix for f, proposing it once so it is recorded in
+// the history and sent to the notification channels
+func (s *Server) suggest(ctx context.Context, f failure) (*engine.Suggestion, error) {
+	key := f.key()
+	if suggestion, found := s.suggestions[key]; found {
+		return suggestion, nil
+	}
+	suggestion, err := s.engine.Suggest(ctx, f.Command, f.Output)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion != nil {
+		s.engine.Propose(f.Command, f.Output, suggestion)
+	}
+	s.suggestions[key] = suggestion
+	return suggestion, nil
+}
+
+// executeCommand runs CommandApplyFix or CommandDismissFix
+func (s *Server) executeCommand(p executeCommandParams) (interface{}, error) {
+	if p.Command != CommandApplyFix && p.Command != CommandDismissFix {
+		return nil, fmt.Errorf("unknown command %q", p.Command)
+	}
+	if len(p.Arguments) != 1 {
+		return nil, fmt.Errorf("%s takes one argument", p.Command)
+	}
+	var args commandArgs
+	if err := json.Unmarshal(p.Arguments[0], &args); err != nil {
+		return nil, fmt.Errorf("invalid argument: %w", err)
+	}
+
+	var target *failure
+	for _, f := range s.documents[args.URI] {
+		if f.Line == args.Line {
+			target = &f
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("no failure at line %d of %s", args.Line, args.URI)
+	}
+	suggestion := s.suggestions[target.key()]
+	if suggestion == nil {
+		return nil, errors.New("no fix was offered for this failure")
+	}
+
+	if p.Command == CommandDismissFix {
+		s.engine.Dismiss(suggestion)
+		s.dismissed[target.key()] = true
+		return nil, s.publish(args.URI)
+	}
+	if suggestion.ConfigEdit != nil {
+		return map[string]interface{}{"applied": s.engine.Apply(suggestion)}, nil
+	}
+	if args.Fix == "" {
+		args.Fix = suggestion.Command
+	}
+	s.engine.Choose(suggestion, args.Fix)
+	return map[string]interface{}{"command": args.Fix}, nil
+}
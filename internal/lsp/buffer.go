@@ -0,0 +1,64 @@
+package lsp
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf16"
+)
+
+// promptPattern matches a shell prompt and captures the command typed after
+// it, e.g. "user@host:~/app$ npm start", "# apt install nginx" or "❯ git pul"
+var promptPattern = regexp.MustCompile(`^\S*(?:[$#%]|❯)\s+(\S.*)$`)
+
+// ansiPattern matches the color and cursor escapes left in terminal output
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;?]*[A-Za-z]")
+
+// failure is a command in a terminal buffer whose output shows an error
+type failure struct {
+	Line    int    // of the first error line in the buffer, from 0
+	Message string // the error line
+	Width   int    // of the error line in UTF-16 code units, as LSP counts
+	Command string
+	Output  string
+}
+
+// key identifies the failure across edits of the buffer
+func (f failure) key() string {
+	return f.Command + "\x00" + f.Output
+}
+
+// parseBuffer finds the failed commands in a terminal buffer. A command
+// starts at a prompt line and its output runs to the next prompt; it failed
+// if detect finds an error in one of the output lines
+func parseBuffer(text string, detect func(string) bool) []failure {
+	var failures []failure
+	var current *failure
+	var output []string
+	flush := func() {
+		if current != nil && current.Message != "" {
+			current.Output = strings.Join(output, "\n")
+			failures = append(failures, *current)
+		}
+		current, output = nil, nil
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		line = ansiPattern.ReplaceAllString(strings.TrimRight(line, "\r"), "")
+		if match := promptPattern.FindStringSubmatch(line); match != nil {
+			flush()
+			current = &failure{Command: strings.TrimSpace(match[1])}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		output = append(output, line)
+		if current.Message == "" && detect(line) {
+			current.Line = i
+			current.Message = strings.TrimSpace(line)
+			current.Width = len(utf16.Encode([]rune(line)))
+		}
+	}
+	flush()
+	return failures
+}
@@ -0,0 +1,272 @@
+// Package lsp serves LogAid to editors as a language server over stdio, for
+// 'logaid lsp'. The documents are terminal buffers: every failed command in
+// them is published as a diagnostic, and its fixes are offered as code
+// actions an editor can show as "apply fix" buttons
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// JSON-RPC and LSP error codes
+const (
+	codeParseError           = -32700
+	codeInvalidRequest       = -32600
+	codeMethodNotFound       = -32601
+	codeInvalidParams        = -32602
+	codeServerNotInitialized = -32002
+)
+
+// message is a JSON-RPC 2.0 request, notification or response
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  interface{}     `json:"params,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// request is a message as read, with the params left to the method
+type request struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server answers LSP requests with an Engine
+type Server struct {
+	engine  *engine.Engine
+	version string // LogAid version reported to clients
+
+	out         io.Writer
+	initialized bool
+	shutdown    bool
+	documents   map[string][]failure          // by URI
+	suggestions map[string]*engine.Suggestion // by failure key, nil when there is no fix
+	dismissed   map[string]bool               // failure keys
+}
+
+// NewServer creates a language server for eng
+func NewServer(eng *engine.Engine, version string) *Server {
+	return &Server{
+		engine:      eng,
+		version:     version,
+		documents:   make(map[string][]failure),
+		suggestions: make(map[string]*engine.Suggestion),
+		dismissed:   make(map[string]bool),
+	}
+}
+
+// Serve reads messages from r and writes responses and diagnostics to w,
+// framed with Content-Length headers, until the client sends exit, r ends
+// or ctx is done
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = w
+	reader := bufio.NewReader(r)
+
+	type read struct {
+		body []byte
+		err  error
+	}
+	messages := make(chan read)
+	go func() {
+		defer close(messages)
+		for {
+			body, err := readMessage(reader)
+			select {
+			case messages <- read{body, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m, ok := <-messages:
+			if !ok || errors.Is(m.err, io.EOF) {
+				return nil
+			}
+			if m.err != nil {
+				return m.err
+			}
+			exit, err := s.handle(ctx, m.body)
+			if err != nil || exit {
+				return err
+			}
+		}
+	}
+}
+
+// readMessage reads the body of the next message
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || length < 0 {
+				return nil, fmt.Errorf("invalid Content-Length %q", strings.TrimSpace(value))
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("missing Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+	return body, nil
+}
+
+// send writes a message with its Content-Length header
+func (s *Server) send(msg *message) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	if _, err := fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// notify sends a notification to the client
+func (s *Server) notify(method string, params interface{}) error {
+	return s.send(&message{Method: method, Params: params})
+}
+
+// handle answers one message and reports whether the client asked to exit
+func (s *Server) handle(ctx context.Context, body []byte) (bool, error) {
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return false, s.send(&message{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+	}
+
+	if req.ID == nil {
+		if req.Method == "exit" {
+			return true, nil
+		}
+		if err := s.handleNotification(req.Method, req.Params); err != nil {
+			logger.Debug(fmt.Sprintf("LSP %s: %v", req.Method, err))
+		}
+		return false, nil
+	}
+
+	result, rpcErr := s.call(ctx, req.Method, req.Params)
+	if rpcErr != nil {
+		return false, s.send(&message{ID: req.ID, Error: rpcErr})
+	}
+	if result == nil {
+		result = json.RawMessage("null")
+	}
+	return false, s.send(&message{ID: req.ID, Result: result})
+}
+
+// call runs a request method
+func (s *Server) call(ctx context.Context, method string, params json.RawMessage) (interface{}, *rpcError) {
+	switch {
+	case method == "initialize":
+		s.initialized = true
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":       map[string]interface{}{"openClose": true, "change": syncFull},
+				"codeActionProvider":     map[string]interface{}{"codeActionKinds": []string{kindQuickFix}},
+				"executeCommandProvider": map[string]interface{}{"commands": []string{CommandApplyFix, CommandDismissFix}},
+			},
+			"serverInfo": map[string]string{"name": "logaid", "version": s.version},
+		}, nil
+	case !s.initialized:
+		return nil, &rpcError{Code: codeServerNotInitialized, Message: "initialize must be sent first"}
+	case s.shutdown:
+		return nil, &rpcError{Code: codeInvalidRequest, Message: "the server is shutting down"}
+	case method == "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case method == "textDocument/codeAction":
+		var p codeActionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return s.codeActions(ctx, p), nil
+	case method == "workspace/executeCommand":
+		var p executeCommandParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		result, err := s.executeCommand(p)
+		if err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return result, nil
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", method)}
+}
+
+// handleNotification follows the terminal buffers the client has open
+func (s *Server) handleNotification(method string, params json.RawMessage) error {
+	var p struct {
+		TextDocument struct {
+			URI  string `json:"uri"`
+			Text string `json:"text"`
+		} `json:"textDocument"`
+		ContentChanges []struct {
+			Text string `json:"text"`
+		} `json:"contentChanges"`
+	}
+	switch method {
+	case "textDocument/didOpen", "textDocument/didChange", "textDocument/didClose":
+		if err := json.Unmarshal(params, &p); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	uri, text := p.TextDocument.URI, p.TextDocument.Text
+	switch method {
+	case "textDocument/didChange":
+		// Full sync: the last change holds the whole buffer
+		if len(p.ContentChanges) == 0 {
+			return nil
+		}
+		text = p.ContentChanges[len(p.ContentChanges)-1].Text
+	case "textDocument/didClose":
+		delete(s.documents, uri)
+		return s.publish(uri)
+	}
+	s.documents[uri] = parseBuffer(text, s.engine.DetectError)
+	return s.publish(uri)
+}
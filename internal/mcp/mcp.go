@@ -0,0 +1,163 @@
+// Package mcp serves LogAid's error analysis and fix suggestions as Model
+// Context Protocol tools over stdio, for 'logaid mcp', so AI agents can use
+// the plugins' knowledge instead of working fixes out themselves
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// protocolVersions are the MCP revisions the server speaks, newest first
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// message is a JSON-RPC 2.0 request, notification or response
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server answers MCP requests with an Engine
+type Server struct {
+	engine  *engine.Engine
+	version string // LogAid version reported to clients
+	tools   []tool
+}
+
+// NewServer creates an MCP server for eng
+func NewServer(eng *engine.Engine, version string) *Server {
+	s := &Server{engine: eng, version: version}
+	s.tools = s.defineTools()
+	return s
+}
+
+// Serve reads requests from r and writes responses to w, one JSON message
+// per line, until r ends or ctx is done
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(w)
+
+	lines := make(chan []byte)
+	go func() {
+		defer close(lines)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-lines:
+			if !ok {
+				return scanner.Err()
+			}
+			if len(line) == 0 {
+				continue
+			}
+			if resp := s.handle(ctx, line); resp != nil {
+				if err := encoder.Encode(resp); err != nil {
+					return fmt.Errorf("failed to write response: %w", err)
+				}
+			}
+		}
+	}
+}
+
+// handle answers one message. Notifications and responses get no reply
+func (s *Server) handle(ctx context.Context, line []byte) *message {
+	var req message
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &message{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}}
+	}
+	if req.ID == nil {
+		logger.Debug(fmt.Sprintf("MCP notification: %s", req.Method))
+		return nil
+	}
+	if req.Method == "" {
+		return &message{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: "method is required"}}
+	}
+
+	result, err := s.call(ctx, req.Method, req.Params)
+	if err != nil {
+		return &message{JSONRPC: "2.0", ID: req.ID, Error: err}
+	}
+	return &message{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// call runs a request method
+func (s *Server) call(ctx context.Context, method string, params json.RawMessage) (interface{}, *rpcError) {
+	switch method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(params, &p)
+		return map[string]interface{}{
+			"protocolVersion": negotiate(p.ProtocolVersion),
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "logaid", "version": s.version},
+			"instructions":    "Call suggest_fix with a failed shell command and its output before working out a fix yourself; LogAid's plugins know the usual fixes for package managers, git, docker, systemd and more.",
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": s.tools}, nil
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		for _, t := range s.tools {
+			if t.Name == p.Name {
+				return s.runTool(ctx, t, p.Arguments), nil
+			}
+		}
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", p.Name)}
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", method)}
+}
+
+// negotiate picks the protocol revision to use with a client that asked
+// for requested
+func negotiate(requested string) string {
+	for _, version := range protocolVersions {
+		if version == requested {
+			return version
+		}
+	}
+	return protocolVersions[0]
+}
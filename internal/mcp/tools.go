@@ -0,0 +1,181 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// tool is an MCP tool and the function that runs it
+type tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+	run         func(ctx context.Context, args toolArgs) (string, error)
+}
+
+// toolArgs are the arguments of every tool; each uses some of them
+type toolArgs struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+	Context string `json:"context"`
+}
+
+// content is the text result of a tool call
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// toolResult is the result of tools/call. Failures are reported here, not
+// as JSON-RPC errors, so the agent sees them
+type toolResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// objectSchema returns the JSON schema of an object with string properties
+func objectSchema(required []string, properties map[string]string) map[string]interface{} {
+	props := map[string]interface{}{}
+	for name, description := range properties {
+		props[name] = map[string]string{"type": "string", "description": description}
+	}
+	schema := map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// defineTools returns the tools the server offers
+func (s *Server) defineTools() []tool {
+	return []tool{
+		{
+			Name:        "suggest_fix",
+			Description: "Suggest ranked fixes for a failed shell command from LogAid's plugins, learned corrections and AI. Returns the commands to run, best first, and where the fix came from.",
+			InputSchema: objectSchema([]string{"command", "output"}, map[string]string{
+				"command": "The command that failed, e.g. 'apt install ngnix'",
+				"output":  "The error output of the command",
+				"context": "Optional details about where it ran, such as the working directory or OS",
+			}),
+			run: s.suggestFix,
+		},
+		{
+			Name:        "analyze_error",
+			Description: "Check command or log output for errors: which lines show an error and which LogAid plugins recognize it, with their confidence.",
+			InputSchema: objectSchema([]string{"output"}, map[string]string{
+				"output":  "Command or log output to analyze",
+				"command": "The command that produced the output, if any",
+			}),
+			run: s.analyzeError,
+		},
+		{
+			Name:        "list_plugins",
+			Description: "List the LogAid plugins loaded, i.e. the tools whose errors LogAid knows how to fix.",
+			InputSchema: objectSchema(nil, nil),
+			run:         s.listPlugins,
+		},
+	}
+}
+
+// runTool calls t with the JSON arguments
+func (s *Server) runTool(ctx context.Context, t tool, arguments json.RawMessage) toolResult {
+	var args toolArgs
+	if len(arguments) > 0 {
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return errorResult(fmt.Errorf("invalid arguments: %w", err))
+		}
+	}
+	text, err := t.run(ctx, args)
+	if err != nil {
+		return errorResult(err)
+	}
+	return toolResult{Content: []content{{Type: "text", Text: text}}}
+}
+
+// errorResult reports a failed tool call
+func errorResult(err error) toolResult {
+	return toolResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}
+}
+
+func (s *Server) suggestFix(ctx context.Context, args toolArgs) (string, error) {
+	if strings.TrimSpace(args.Command) == "" {
+		return "", errors.New("command is required")
+	}
+	output := args.Output
+	if args.Context != "" {
+		output = strings.TrimRight(output, "\n") + "\n\n" + args.Context
+	}
+
+	suggestion, err := s.engine.Suggest(ctx, args.Command, output)
+	if err != nil {
+		return "", fmt.Errorf("failed to get a suggestion: %w", err)
+	}
+	if suggestion == nil {
+		return "LogAid has no fix for this error.", nil
+	}
+	s.engine.Propose(args.Command, output, suggestion)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Suggested by %s", suggestion.Source)
+	if suggestion.Confidence > 0 {
+		fmt.Fprintf(&b, " (confidence %.2f)", suggestion.Confidence)
+	}
+	b.WriteString(":\n")
+	if edit := suggestion.ConfigEdit; edit != nil {
+		fmt.Fprintf(&b, "Configuration change: %s\n", edit.String())
+		if edit.Description != "" {
+			b.WriteString(edit.Description + "\n")
+		}
+		return b.String(), nil
+	}
+	for i, candidate := range suggestion.Candidates() {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, candidate)
+	}
+	return b.String(), nil
+}
+
+func (s *Server) analyzeError(ctx context.Context, args toolArgs) (string, error) {
+	if strings.TrimSpace(args.Output) == "" {
+		return "", errors.New("output is required")
+	}
+
+	var lines []string
+	for _, line := range strings.Split(args.Output, "\n") {
+		if s.engine.DetectError(line) {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	if len(lines) == 0 {
+		return "No error detected.", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("Error lines:\n")
+	for _, line := range lines {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	matches := s.engine.Recognize(ctx, args.Command, args.Output)
+	if len(matches) == 0 {
+		b.WriteString("No plugin recognizes this error; suggest_fix will ask the AI.\n")
+		return b.String(), nil
+	}
+	b.WriteString("Recognized by:\n")
+	for _, m := range matches {
+		fmt.Fprintf(&b, "- %s (confidence %.2f)\n", m.Plugin, m.Score)
+	}
+	return b.String(), nil
+}
+
+func (s *Server) listPlugins(ctx context.Context, args toolArgs) (string, error) {
+	var names []string
+	for _, plugin := range s.engine.Plugins() {
+		names = append(names, plugin.Name())
+	}
+	if len(names) == 0 {
+		return "No plugins are loaded.", nil
+	}
+	return strings.Join(names, "\n"), nil
+}
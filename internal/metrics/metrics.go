@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// Stats are the counters kept for one plugin
+type Stats struct {
+	Calls       int64         `json:"calls"`       // Match, Score, SuggestConfig and Suggest calls
+	Timeouts    int64         `json:"timeouts"`    // calls abandoned after PLUGIN_TIMEOUT
+	Matches     int64         `json:"matches"`     // commands the plugin matched
+	Suggestions int64         `json:"suggestions"` // suggestions the plugin made
+	Accepted    int64         `json:"accepted"`
+	Rejected    int64         `json:"rejected"`
+	Succeeded   int64         `json:"succeeded"` // accepted fixes that ran successfully
+	Latency     time.Duration `json:"latency"`   // total time spent in calls
+	MaxLatency  time.Duration `json:"max_latency"`
+}
+
+// AcceptanceRate returns the share of suggestions the user accepted
+func (s Stats) AcceptanceRate() float64 {
+	return ratio(s.Accepted, s.Suggestions)
+}
+
+// SuccessRate returns the share of accepted suggestions that worked
+func (s Stats) SuccessRate() float64 {
+	return ratio(s.Succeeded, s.Accepted)
+}
+
+// AverageLatency returns the mean duration of a call into the plugin
+func (s Stats) AverageLatency() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Latency / time.Duration(s.Calls)
+}
+
+// add sums other into s
+func (s *Stats) add(other Stats) {
+	s.Calls += other.Calls
+	s.Timeouts += other.Timeouts
+	s.Matches += other.Matches
+	s.Suggestions += other.Suggestions
+	s.Accepted += other.Accepted
+	s.Rejected += other.Rejected
+	s.Succeeded += other.Succeeded
+	s.Latency += other.Latency
+	if other.MaxLatency > s.MaxLatency {
+		s.MaxLatency = other.MaxLatency
+	}
+}
+
+func ratio(n, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// Batch collects the stats of one suggestion round in memory so they can be
+// saved with a single write. It is not safe for concurrent use
+type Batch map[string]*Stats
+
+// stats returns the entry for plugin, creating it
+func (b Batch) stats(plugin string) *Stats {
+	s, ok := b[plugin]
+	if !ok {
+		s = &Stats{}
+		b[plugin] = s
+	}
+	return s
+}
+
+// Call records a call into plugin that took d
+func (b Batch) Call(plugin string, d time.Duration, timedOut bool) {
+	s := b.stats(plugin)
+	s.Calls++
+	s.Latency += d
+	if d > s.MaxLatency {
+		s.MaxLatency = d
+	}
+	if timedOut {
+		s.Timeouts++
+	}
+}
+
+// Match records that plugin matched a command
+func (b Batch) Match(plugin string) {
+	b.stats(plugin).Matches++
+}
+
+// Suggestion records that plugin made a suggestion
+func (b Batch) Suggestion(plugin string) {
+	b.stats(plugin).Suggestions++
+}
+
+// Outcome records what the user did with the suggestion of plugin: accepted
+// it (and whether it worked) or rejected it
+func (b Batch) Outcome(plugin string, accepted, succeeded bool) {
+	s := b.stats(plugin)
+	switch {
+	case !accepted:
+		s.Rejected++
+	case succeeded:
+		s.Accepted++
+		s.Succeeded++
+	default:
+		s.Accepted++
+	}
+}
+
+// file is the on-disk layout of the metrics file
+type file struct {
+	Since   time.Time         `json:"since"`
+	Plugins map[string]*Stats `json:"plugins"`
+}
+
+// Store persists plugin stats shared by all LogAid processes
+type Store struct {
+	path string
+}
+
+// New creates a store persisted at path
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// NewFromConfig creates the store for ~/.logaid/metrics.json. It returns nil
+// when PLUGIN_METRICS is disabled.
+func NewFromConfig() *Store {
+	cfg := config.Current()
+	if cfg == nil || !cfg.PluginMetrics {
+		return nil
+	}
+	return New(filepath.Join(config.Dir(), "metrics.json"))
+}
+
+// Path returns the location of the metrics file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add sums a batch into the stored stats
+func (s *Store) Add(batch Batch) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	var data file
+	return state.UpdateJSON(s.path, &data, func() error {
+		if data.Since.IsZero() {
+			data.Since = time.Now()
+		}
+		if data.Plugins == nil {
+			data.Plugins = map[string]*Stats{}
+		}
+		for plugin, stats := range batch {
+			if data.Plugins[plugin] == nil {
+				data.Plugins[plugin] = &Stats{}
+			}
+			data.Plugins[plugin].add(*stats)
+		}
+		return nil
+	})
+}
+
+// PluginStats is the stats of one plugin, as returned by Load
+type PluginStats struct {
+	Plugin string `json:"plugin"`
+	Stats
+}
+
+// Load returns the stats of every plugin, the most matched first, and when
+// recording started
+func (s *Store) Load() ([]PluginStats, time.Time, error) {
+	var data file
+	if err := state.ReadJSON(s.path, &data); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var all []PluginStats
+	for plugin, stats := range data.Plugins {
+		all = append(all, PluginStats{Plugin: plugin, Stats: *stats})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Matches != all[j].Matches {
+			return all[i].Matches > all[j].Matches
+		}
+		return all[i].Plugin < all[j].Plugin
+	})
+	return all, data.Since, nil
+}
+
+// Reset deletes the recorded stats
+func (s *Store) Reset() error {
+	var data file
+	return state.UpdateJSON(s.path, &data, func() error {
+		data = file{}
+		return nil
+	})
+}
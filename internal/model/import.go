@@ -0,0 +1,238 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/fuzzy"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// Shell history formats read by ReadHistory
+const (
+	FormatBash = "bash"
+	FormatZsh  = "zsh"
+	FormatFish = "fish"
+)
+
+// retypeWindow is how soon a command must follow a mistyped one to count as
+// its correction, when the history has timestamps
+const retypeWindow = 2 * time.Minute
+
+// topTools is how many of the most used commands Import reports
+const topTools = 10
+
+// Command is a command read from a shell history file
+type Command struct {
+	Text string
+	Time time.Time // when it ran, zero if the history has no timestamps
+}
+
+// Import describes a shell history file imported into the model
+type Import struct {
+	Path     string    `json:"path"`
+	Format   string    `json:"format"`
+	Commands int       `json:"commands"`
+	First    time.Time `json:"first,omitempty"` // oldest timestamp, if the file has them
+	Last     time.Time `json:"last,omitempty"`
+	Tools    []Usage   `json:"tools"` // the most used commands
+	Fixes    []Fix     `json:"fixes"` // typos retyped correctly right after
+}
+
+// ReadHistory returns the commands of a bash, zsh or fish history, oldest
+// first, and its format. Timestamps are taken from bash HISTTIMEFORMAT
+// comments ("#1700000000"), zsh extended history and fish "when:" fields
+func ReadHistory(r io.Reader) ([]Command, string, error) {
+	var commands []Command
+	format := FormatBash
+	var stamp time.Time
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "- cmd: "):
+			format = FormatFish
+		case strings.HasPrefix(line, "when: ") && format == FormatFish:
+			if len(commands) > 0 {
+				commands[len(commands)-1].Time = unixTime(strings.TrimPrefix(line, "when: "))
+			}
+			continue
+		case strings.HasPrefix(line, ": "):
+			format = FormatZsh
+			meta, _, _ := strings.Cut(strings.TrimPrefix(line, ": "), ";")
+			seconds, _, _ := strings.Cut(meta, ":")
+			stamp = unixTime(seconds)
+		case strings.HasPrefix(line, "#"):
+			stamp = unixTime(strings.TrimPrefix(line, "#"))
+		}
+
+		command := parseHistoryLine(line)
+		if command == "" {
+			continue
+		}
+		commands = append(commands, Command{Text: command, Time: stamp})
+		stamp = time.Time{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	return commands, format, nil
+}
+
+// unixTime parses seconds since the epoch, returning the zero time for
+// anything else
+func unixTime(s string) time.Time {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// Import reads a shell history file, such as one copied from another
+// machine, into the model. Its word counts are kept apart from the shell
+// history scanned by Refresh, so importing the same file again replaces
+// them, and typos retyped correctly right after are learned as corrections
+func (s *Store) Import(path string) (*Import, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	commands, format, err := ReadHistory(file)
+	file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file %s: %w", path, err)
+	}
+
+	result := &Import{Path: path, Format: format, Commands: len(commands)}
+	var all map[string]map[string]int
+	for _, command := range commands {
+		all = observe(all, command.Text)
+	}
+	fixes, mistyped := retypes(commands, all)
+	result.Fixes = fixes
+
+	// The mistyped commands should not count as words in use
+	var counts map[string]map[string]int
+	for i, command := range commands {
+		if !mistyped[i] {
+			counts = observe(counts, command.Text)
+		}
+		if command.Time.IsZero() {
+			continue
+		}
+		if result.First.IsZero() || command.Time.Before(result.First) {
+			result.First = command.Time
+		}
+		if command.Time.After(result.Last) {
+			result.Last = command.Time
+		}
+	}
+	for _, usage := range (&Model{History: counts}).Top(topTools) {
+		if usage.Context == "" {
+			result.Tools = append(result.Tools, usage)
+		}
+	}
+
+	scanned := contains(s.historyFiles, path)
+	m := &Model{}
+	err = state.UpdateJSON(s.path, m, func() error {
+		if !scanned {
+			if m.Imported == nil {
+				m.Imported = map[string]map[string]map[string]int{}
+			}
+			m.Imported[path] = counts
+		}
+		for _, fix := range result.Fixes {
+			m.importFix(fix)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save personal model: %w", err)
+	}
+	if scanned {
+		// Counted by the regular scan; make it current
+		if _, err := s.Refresh(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// retypes finds commands followed by the same command with typos fixed, such
+// as "gti status" then "git status", and returns the corrections with how
+// often each was made and the indexes of the mistyped commands. The
+// corrected word must be the more used one
+func retypes(commands []Command, counts map[string]map[string]int) ([]Fix, map[int]bool) {
+	found := map[Fix]int{}
+	mistyped := map[int]bool{}
+	for i := 1; i < len(commands); i++ {
+		typo, fixed := commands[i-1], commands[i]
+		if !typo.Time.IsZero() && !fixed.Time.IsZero() && fixed.Time.Sub(typo.Time) > retypeWindow {
+			continue
+		}
+		from, to := strings.Fields(typo.Text), strings.Fields(fixed.Text)
+		if len(from) != len(to) {
+			continue
+		}
+		var changed []Fix
+		for j := range from {
+			if from[j] == to[j] {
+				continue
+			}
+			context, ok := contextOf(to, j)
+			if !ok || !correctable(from[j]) || !correctable(to[j]) ||
+				fuzzy.Distance(from[j], to[j]) > fuzzy.MaxDistance(to[j]) ||
+				counts[context][to[j]] <= counts[context][from[j]] {
+				changed = nil
+				break
+			}
+			changed = append(changed, Fix{Context: context, From: from[j], To: to[j]})
+		}
+		for _, fix := range changed {
+			found[fix]++
+		}
+		if len(changed) > 0 {
+			mistyped[i-1] = true
+		}
+	}
+
+	fixes := make([]Fix, 0, len(found))
+	for fix, count := range found {
+		fix.Count = count
+		fixes = append(fixes, fix)
+	}
+	sort.Slice(fixes, func(i, j int) bool {
+		if fixes[i].Count != fixes[j].Count {
+			return fixes[i].Count > fixes[j].Count
+		}
+		return fixes[i].From < fixes[j].From
+	})
+	return fixes, mistyped
+}
+
+// importFix adds a correction found in imported history, keeping the higher
+// count if it is already known so importing a file twice counts it once
+func (m *Model) importFix(fix Fix) {
+	for i := range m.Fixes {
+		f := &m.Fixes[i]
+		if f.Context == fix.Context && f.From == fix.From && f.To == fix.To {
+			f.Count = max(f.Count, fix.Count)
+			return
+		}
+	}
+	m.Fixes = append(m.Fixes, fix)
+}
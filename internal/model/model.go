@@ -0,0 +1,282 @@
+package model
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/fuzzy"
+)
+
+// acceptedWeight is how many history uses a word from an accepted fix counts as
+const acceptedWeight = 3
+
+// minUses is how often a word must have been used before it is offered as
+// a correction, so one-off commands do not drive suggestions
+const minUses = 2
+
+// typoPattern matches output saying a word of the command was not
+// recognized: the failures a correction of the command's words can fix
+var typoPattern = regexp.MustCompile(`(?i)command not found|is not a git command|did not match any|unable to locate package|no such file|unknown (?:command|subcommand)`)
+
+// ShowsTypo reports whether output shows that a word of the command was
+// mistyped, rather than the command failing for another reason
+func ShowsTypo(output string) bool {
+	return typoPattern.MatchString(output)
+}
+
+// Fix is a word-level correction the user accepted
+type Fix struct {
+	Context string `json:"context"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Count   int    `json:"count"`
+}
+
+// Usage is how often a word was used in a context
+type Usage struct {
+	Context string `json:"context"`
+	Word    string `json:"word"`
+	Count   int    `json:"count"`
+}
+
+// Model is the personal typo model: how often each command, subcommand and
+// argument (branch, package, image...) is used in a given context. The
+// context of a word is the command and subcommand before it, e.g. "git co".
+type Model struct {
+	History        map[string]map[string]int `json:"history"`
+	Accepted       map[string]map[string]int `json:"accepted"`
+	Fixes          []Fix                     `json:"fixes"`
+	HistoryUpdated time.Time                 `json:"history_updated"`
+
+	// Counts of shell history files imported with 'logaid import-history',
+	// by path
+	Imported map[string]map[string]map[string]int `json:"imported,omitempty"`
+}
+
+// Count returns the weighted number of uses of word in context
+func (m *Model) Count(context, word string) int {
+	count := m.History[context][word] + acceptedWeight*m.Accepted[context][word]
+	for _, counts := range m.Imported {
+		count += counts[context][word]
+	}
+	return count
+}
+
+// Correct rewrites the words of command the user probably mistyped into the
+// ones they actually use, returning false when nothing changed
+func (m *Model) Correct(command string) (string, bool) {
+	fields := strings.Fields(command)
+	changed := false
+
+	for i, word := range fields {
+		context, ok := contextOf(fields, i)
+		if !ok || !correctable(word) || m.Count(context, word) > 0 {
+			continue
+		}
+
+		if fix := m.fix(context, word); fix != "" {
+			fields[i] = fix
+			changed = true
+			continue
+		}
+
+		if candidate := m.closest(context, word); candidate != "" {
+			fields[i] = candidate
+			changed = true
+		}
+	}
+
+	if !changed {
+		return command, false
+	}
+	return strings.Join(fields, " "), true
+}
+
+// Observe counts the words of a command taken from shell history
+func (m *Model) Observe(command string) {
+	m.History = observe(m.History, command)
+}
+
+// Learn records an accepted fix: the words of the fixed command are counted
+// and every changed word is remembered as a correction
+func (m *Model) Learn(original, fixed string) {
+	m.Accepted = observe(m.Accepted, fixed)
+
+	from, to := strings.Fields(original), strings.Fields(fixed)
+	if len(from) != len(to) {
+		return
+	}
+
+	for i := range from {
+		context, ok := contextOf(to, i)
+		if !ok || from[i] == to[i] {
+			continue
+		}
+		m.addFix(context, from[i], to[i])
+	}
+}
+
+// Top returns the most used words, at most limit per context
+func (m *Model) Top(limit int) []Usage {
+	seen := make(map[string]bool)
+	var usages []Usage
+	for _, counts := range m.counts() {
+		for context, words := range counts {
+			for word := range words {
+				key := context + "\x00" + word
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				usages = append(usages, Usage{Context: context, Word: word, Count: m.Count(context, word)})
+			}
+		}
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Context != usages[j].Context {
+			return usages[i].Context < usages[j].Context
+		}
+		if usages[i].Count != usages[j].Count {
+			return usages[i].Count > usages[j].Count
+		}
+		return usages[i].Word < usages[j].Word
+	})
+
+	var top []Usage
+	perContext := make(map[string]int)
+	for _, u := range usages {
+		if perContext[u.Context] < limit {
+			top = append(top, u)
+			perContext[u.Context]++
+		}
+	}
+	return top
+}
+
+// counts returns every set of word counts: shell history, accepted fixes and
+// imported history files
+func (m *Model) counts() []map[string]map[string]int {
+	counts := []map[string]map[string]int{m.History, m.Accepted}
+	for _, imported := range m.Imported {
+		counts = append(counts, imported)
+	}
+	return counts
+}
+
+func (m *Model) fix(context, word string) string {
+	best := Fix{}
+	for _, f := range m.Fixes {
+		if f.Context == context && f.From == word && f.Count > best.Count {
+			best = f
+		}
+	}
+	return best.To
+}
+
+func (m *Model) addFix(context, from, to string) {
+	for i := range m.Fixes {
+		if m.Fixes[i].Context == context && m.Fixes[i].From == from && m.Fixes[i].To == to {
+			m.Fixes[i].Count++
+			return
+		}
+	}
+	m.Fixes = append(m.Fixes, Fix{Context: context, From: from, To: to, Count: 1})
+}
+
+// RemoveFix forgets the corrections of the typo from in the given contexts,
+// or in every context when none is given, and returns how many were removed
+func (m *Model) RemoveFix(from string, contexts ...string) int {
+	fixes := m.Fixes[:0]
+	for _, f := range m.Fixes {
+		if f.From == from && (len(contexts) == 0 || contains(contexts, f.Context)) {
+			continue
+		}
+		fixes = append(fixes, f)
+	}
+	removed := len(m.Fixes) - len(fixes)
+	m.Fixes = fixes
+	return removed
+}
+
+// closest returns the most used known word within typo distance of word
+func (m *Model) closest(context, word string) string {
+	maxDistance := fuzzy.MaxDistance(word)
+
+	candidates := make(map[string]bool)
+	for _, counts := range m.counts() {
+		for w := range counts[context] {
+			candidates[w] = true
+		}
+	}
+
+	best, bestCount, bestDistance := "", 0, 0
+	for candidate := range candidates {
+		count := m.Count(context, candidate)
+		if count < minUses {
+			continue
+		}
+		distance := fuzzy.Distance(word, candidate)
+		if distance == 0 || distance > maxDistance {
+			continue
+		}
+		if best == "" || count > bestCount ||
+			(count == bestCount && (distance < bestDistance || (distance == bestDistance && candidate < best))) {
+			best, bestCount, bestDistance = candidate, count, distance
+		}
+	}
+	return best
+}
+
+func observe(counts map[string]map[string]int, command string) map[string]map[string]int {
+	if counts == nil {
+		counts = make(map[string]map[string]int)
+	}
+
+	fields := strings.Fields(command)
+	for i, word := range fields {
+		context, ok := contextOf(fields, i)
+		if !ok || !correctable(word) {
+			continue
+		}
+		if counts[context] == nil {
+			counts[context] = make(map[string]int)
+		}
+		counts[context][word]++
+	}
+	return counts
+}
+
+// contextOf returns the context of the word at position i: "" for the
+// command itself, the command for its subcommand, and "command subcommand"
+// for everything after. A leading sudo is ignored.
+func contextOf(fields []string, i int) (string, bool) {
+	start := 0
+	if len(fields) > 0 && fields[0] == "sudo" {
+		start = 1
+	}
+	if i < start {
+		return "", false
+	}
+	if i == start {
+		return "", true
+	}
+
+	context := []string{fields[start]}
+	for _, field := range fields[start+1 : i] {
+		if !strings.HasPrefix(field, "-") {
+			context = append(context, field)
+			break
+		}
+	}
+	return strings.Join(context, " "), true
+}
+
+// correctable reports whether word looks like a name worth correcting
+// rather than a flag, path, assignment or quoted string
+func correctable(word string) bool {
+	return len(word) >= 2 && !strings.HasPrefix(word, "-") &&
+		!strings.ContainsAny(word, "/=~'\"$*?|&;<>()`")
+}
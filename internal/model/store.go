@@ -0,0 +1,193 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// refreshInterval is how long shell history counts are reused before the
+// history files are scanned again
+const refreshInterval = 24 * time.Hour
+
+// maxHistoryLines bounds how many commands of each history file are scanned
+const maxHistoryLines = 20000
+
+// Store persists the personal model and keeps it in sync with shell history
+type Store struct {
+	path         string
+	historyFiles []string
+}
+
+// New creates a store persisted at path that learns from historyFiles
+func New(path string, historyFiles []string) *Store {
+	return &Store{path: path, historyFiles: historyFiles}
+}
+
+// NewFromConfig creates the store for ~/.logaid/model.json. It returns nil
+// when PERSONAL_MODEL is disabled.
+func NewFromConfig() *Store {
+	cfg := config.Current()
+	if cfg == nil || !cfg.PersonalModel {
+		return nil
+	}
+	return New(filepath.Join(config.Dir(), "model.json"), HistoryFiles())
+}
+
+// HistoryFiles returns the shell history files of the current user
+func HistoryFiles() []string {
+	var files []string
+	if histfile := os.Getenv("HISTFILE"); histfile != "" {
+		files = append(files, histfile)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return files
+	}
+	for _, name := range []string{".bash_history", ".zsh_history", ".local/share/fish/fish_history"} {
+		path := filepath.Join(home, name)
+		if !contains(files, path) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// Path returns the location of the model file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load returns the model, rescanning shell history when it is out of date
+func (s *Store) Load() (*Model, error) {
+	m := &Model{}
+	if err := state.ReadJSON(s.path, m); err != nil {
+		return nil, err
+	}
+
+	if time.Since(m.HistoryUpdated) < refreshInterval {
+		return m, nil
+	}
+	return s.Refresh()
+}
+
+// Refresh rebuilds the shell history counts of the model
+func (s *Store) Refresh() (*Model, error) {
+	history := &Model{}
+	for _, path := range s.historyFiles {
+		if err := readHistory(path, history.Observe); err != nil {
+			return nil, err
+		}
+	}
+
+	m := &Model{}
+	err := state.UpdateJSON(s.path, m, func() error {
+		m.History = history.History
+		m.HistoryUpdated = time.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save personal model: %w", err)
+	}
+	return m, nil
+}
+
+// RecordFix learns from a fix the user accepted
+func (s *Store) RecordFix(original, fixed string) error {
+	m := &Model{}
+	return state.UpdateJSON(s.path, m, func() error {
+		m.Learn(original, fixed)
+		return nil
+	})
+}
+
+// Fixes returns the learned corrections, sorted by context and typo, without
+// rescanning shell history
+func (s *Store) Fixes() ([]Fix, error) {
+	m := &Model{}
+	if err := state.ReadJSON(s.path, m); err != nil {
+		return nil, err
+	}
+	sort.Slice(m.Fixes, func(i, j int) bool {
+		if m.Fixes[i].Context != m.Fixes[j].Context {
+			return m.Fixes[i].Context < m.Fixes[j].Context
+		}
+		return m.Fixes[i].From < m.Fixes[j].From
+	})
+	return m.Fixes, nil
+}
+
+// RemoveFix forgets the corrections of a typo, see Model.RemoveFix
+func (s *Store) RemoveFix(from string, contexts ...string) (int, error) {
+	m := &Model{}
+	removed := 0
+	err := state.UpdateJSON(s.path, m, func() error {
+		removed = m.RemoveFix(from, contexts...)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to save personal model: %w", err)
+	}
+	return removed, nil
+}
+
+// readHistory passes the last maxHistoryLines commands of a bash, zsh or
+// fish history file to fn
+func readHistory(path string, fn func(command string)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open history file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	commands, _, err := ReadHistory(file)
+	if err != nil {
+		return fmt.Errorf("failed to read history file %s: %w", path, err)
+	}
+	if len(commands) > maxHistoryLines {
+		commands = commands[len(commands)-maxHistoryLines:]
+	}
+	for _, command := range commands {
+		fn(command.Text)
+	}
+	return nil
+}
+
+// parseHistoryLine extracts the command from a history line, handling zsh
+// extended history (": 1700000000:0;git status") and fish ("- cmd: git status")
+func parseHistoryLine(line string) string {
+	line = strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(line, "#"):
+		return "" // bash timestamps
+	case strings.HasPrefix(line, ": "):
+		if _, command, ok := strings.Cut(line, ";"); ok {
+			return command
+		}
+		return ""
+	case strings.HasPrefix(line, "- cmd: "):
+		return strings.TrimPrefix(line, "- cmd: ")
+	case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "when: "), strings.HasPrefix(line, "paths:"):
+		return "" // other fish fields
+	}
+	return line
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"text/template"
+	"time"
+)
+
+// TerminalChannel writes events to stderr of the current terminal
+type TerminalChannel struct {
+	Out io.Writer
+}
+
+func (c *TerminalChannel) Name() string {
+	return "terminal"
+}
+
+func (c *TerminalChannel) Send(ctx context.Context, event Event) error {
+	out := c.Out
+	if out == nil {
+		out = os.Stderr
+	}
+	_, err := fmt.Fprintf(out, "🔔 %s\n", event.Summary())
+	return err
+}
+
+// DesktopChannel shows a native desktop notification
+type DesktopChannel struct{}
+
+func (c *DesktopChannel) Name() string {
+	return "desktop"
+}
+
+func (c *DesktopChannel) Send(ctx context.Context, event Event) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.CommandContext(ctx, "notify-send", "LogAid", event.Summary())
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title \"LogAid\"", event.Summary())
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	return cmd.Run()
+}
+
+// SlackChannel posts events to a Slack incoming webhook
+type SlackChannel struct {
+	WebhookURL string
+	Template   *template.Template // the message, DefaultTemplate when nil
+	Client     *http.Client
+}
+
+func (c *SlackChannel) Name() string {
+	return "slack"
+}
+
+func (c *SlackChannel) Send(ctx context.Context, event Event) error {
+	text, err := Render(c.Template, event)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, c.Client, c.WebhookURL, map[string]string{"text": text})
+}
+
+// DiscordChannel posts events to a Discord webhook
+type DiscordChannel struct {
+	WebhookURL string
+	Template   *template.Template // the message, DefaultTemplate when nil
+	Client     *http.Client
+}
+
+func (c *DiscordChannel) Name() string {
+	return "discord"
+}
+
+func (c *DiscordChannel) Send(ctx context.Context, event Event) error {
+	text, err := Render(c.Template, event)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, c.Client, c.WebhookURL, map[string]string{"content": truncate(text, maxDiscordMessage)})
+}
+
+// maxDiscordMessage is the longest message Discord accepts, in characters
+const maxDiscordMessage = 2000
+
+// WebhookChannel posts the raw event as JSON to an arbitrary URL, or the
+// rendered Template when one is set
+type WebhookChannel struct {
+	URL      string
+	Template *template.Template // renders the JSON body
+	Client   *http.Client
+}
+
+func (c *WebhookChannel) Name() string {
+	return "webhook"
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, event Event) error {
+	if c.Template == nil {
+		return postJSON(ctx, c.Client, c.URL, event)
+	}
+	body, err := Render(c.Template, event)
+	if err != nil {
+		return err
+	}
+	return post(ctx, c.Client, c.URL, []byte(body))
+}
+
+// postJSON sends payload as a JSON POST request and checks the status code
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return post(ctx, client, url, body)
+}
+
+// post sends body as a JSON POST request and checks the status code
+func post(ctx context.Context, client *http.Client, url string, body []byte) error {
+	if url == "" {
+		return fmt.Errorf("no URL configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
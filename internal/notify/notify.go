@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event types emitted by the engine
+const (
+	EventErrorDetected = "error_detected"
+	EventFixProposed   = "fix_proposed"
+	EventFixApplied    = "fix_applied"
+	EventFixFailed     = "fix_failed"
+)
+
+// Risk levels attached to events
+const (
+	RiskSafe        = "safe"
+	RiskModerate    = "moderate"
+	RiskDestructive = "destructive"
+)
+
+// Event describes something LogAid wants to tell the user about
+type Event struct {
+	Type       string    `json:"type"`
+	Risk       string    `json:"risk,omitempty"`
+	Command    string    `json:"command"`
+	Output     string    `json:"output,omitempty"`
+	Suggestion string    `json:"suggestion,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	Host       string    `json:"host,omitempty"` // machine the event happened on
+	Time       time.Time `json:"time"`
+}
+
+// Summary returns a one-line human readable description of the event
+func (e Event) Summary() string {
+	switch e.Type {
+	case EventErrorDetected:
+		return fmt.Sprintf("Command failed: %s", e.Command)
+	case EventFixProposed:
+		return fmt.Sprintf("Fix proposed by %s for '%s': %s", e.Source, e.Command, e.Suggestion)
+	case EventFixApplied:
+		return fmt.Sprintf("Fix applied for '%s': %s", e.Command, e.Suggestion)
+	case EventFixFailed:
+		return fmt.Sprintf("Fix failed for '%s': %s", e.Command, e.Suggestion)
+	default:
+		return strings.TrimSpace(fmt.Sprintf("%s: %s", e.Type, e.Command))
+	}
+}
+
+// errorWords mark the lines of output that explain a failure
+var errorWords = []string{"error", "fatal", "failed", "not found", "denied", "e: ", "err!", "panic", "exception", "unable to"}
+
+// maxErrorSummary bounds the length of ErrorSummary, in characters
+const maxErrorSummary = 200
+
+// ErrorSummary returns the line of the output that best explains the
+// failure: the last one mentioning an error, or else the last line
+func (e Event) ErrorSummary() string {
+	var last, summary string
+	for _, line := range strings.Split(e.Output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		last = line
+		lower := strings.ToLower(line)
+		for _, word := range errorWords {
+			if strings.Contains(lower, word) {
+				summary = line
+				break
+			}
+		}
+	}
+	if summary == "" {
+		summary = last
+	}
+	return truncate(summary, maxErrorSummary)
+}
+
+// truncate shortens s to at most max characters, ending it with "..." when
+// anything was cut. It never splits a multi-byte character
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max-3]) + "..."
+}
+
+// Channel delivers events to a single destination
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
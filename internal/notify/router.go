@@ -0,0 +1,300 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+)
+
+// Rule maps an event type and risk level to a set of channels.
+// "*" (or an empty value) matches anything.
+type Rule struct {
+	Event    string
+	Risk     string
+	Channels []string
+}
+
+// Matches reports whether the rule applies to the given event
+func (r Rule) Matches(event Event) bool {
+	if r.Event != "" && r.Event != "*" && r.Event != event.Type {
+		return false
+	}
+	if r.Risk != "" && r.Risk != "*" && r.Risk != event.Risk {
+		return false
+	}
+	return true
+}
+
+// ParseRules parses a routing spec such as
+// "fix_proposed:destructive=slack,terminal;*=terminal".
+// Rules are evaluated in order and the first match wins.
+func ParseRules(spec string) ([]Rule, error) {
+	var rules []Rule
+
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		selector, channelList, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid notification rule %q: missing '='", part)
+		}
+
+		rule := Rule{}
+		eventType, risk, _ := strings.Cut(strings.TrimSpace(selector), ":")
+		rule.Event = strings.TrimSpace(eventType)
+		rule.Risk = strings.TrimSpace(risk)
+
+		for _, channel := range strings.Split(channelList, ",") {
+			channel = strings.TrimSpace(channel)
+			if channel != "" {
+				rule.Channels = append(rule.Channels, channel)
+			}
+		}
+		if len(rule.Channels) == 0 {
+			return nil, fmt.Errorf("invalid notification rule %q: no channels", part)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// QuietHours is a daily time window during which only the terminal channel is used
+type QuietHours struct {
+	Start time.Duration // offset from midnight
+	End   time.Duration // offset from midnight
+}
+
+// ParseQuietHours parses a window such as "22:00-07:00"
+func ParseQuietHours(spec string) (*QuietHours, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	from, to, found := strings.Cut(spec, "-")
+	if !found {
+		return nil, fmt.Errorf("invalid quiet hours %q: expected HH:MM-HH:MM", spec)
+	}
+
+	start, err := parseClock(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuietHours{Start: start, End: end}, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: expected HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t falls inside the quiet window
+func (q *QuietHours) Contains(t time.Time) bool {
+	if q == nil {
+		return false
+	}
+
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if q.Start <= q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	// Window wraps around midnight
+	return offset >= q.Start || offset < q.End
+}
+
+// Router dispatches events to channels according to rules
+type Router struct {
+	rules      []Rule
+	channels   map[string]Channel
+	quietHours *QuietHours
+	rateLimit  int // max messages per channel per minute, 0 = unlimited
+	sent       map[string][]time.Time
+	mask       bool // mask secrets before sending, from MASK_SECRETS
+	mu         sync.Mutex
+
+	// Now returns the current time; tests may override it
+	Now func() time.Time
+}
+
+// NewRouter creates a router with the given rules and no channels registered
+func NewRouter(rules []Rule, quietHours *QuietHours, rateLimit int) *Router {
+	return &Router{
+		rules:      rules,
+		channels:   make(map[string]Channel),
+		quietHours: quietHours,
+		rateLimit:  rateLimit,
+		sent:       make(map[string][]time.Time),
+		Now:        time.Now,
+	}
+}
+
+// NewFromConfig builds a router from the NOTIFY_* settings.
+// It returns nil when no rules are configured.
+func NewFromConfig() *Router {
+	cfg := config.Current()
+	if cfg == nil || cfg.NotifyRules == "" {
+		return nil
+	}
+
+	rules, err := ParseRules(cfg.NotifyRules)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Ignoring notification rules: %v", err))
+		return nil
+	}
+
+	quietHours, err := ParseQuietHours(cfg.NotifyQuietHours)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Ignoring quiet hours: %v", err))
+	}
+
+	message := configTemplate("NOTIFY_TEMPLATE", cfg.NotifyTemplate)
+
+	router := NewRouter(rules, quietHours, cfg.NotifyRateLimit)
+	router.mask = cfg.MaskSecrets
+	router.Register(&TerminalChannel{})
+	router.Register(&DesktopChannel{})
+	if cfg.NotifySlackWebhook != "" {
+		router.Register(&SlackChannel{WebhookURL: cfg.NotifySlackWebhook, Template: message})
+	}
+	if cfg.NotifyDiscordWebhook != "" {
+		router.Register(&DiscordChannel{WebhookURL: cfg.NotifyDiscordWebhook, Template: message})
+	}
+	if cfg.NotifyWebhookURL != "" {
+		router.Register(NewWebhook(cfg.NotifyWebhookURL))
+	}
+
+	return router
+}
+
+// NewWebhook returns a webhook channel posting to url the body rendered from
+// NOTIFY_WEBHOOK_TEMPLATE, or the event as JSON when it is not set
+func NewWebhook(url string) *WebhookChannel {
+	cfg := config.Current()
+	channel := &WebhookChannel{URL: url}
+	if cfg != nil {
+		channel.Template = configTemplate("NOTIFY_WEBHOOK_TEMPLATE", cfg.NotifyWebhookTemplate)
+	}
+	return channel
+}
+
+// configTemplate parses a template setting, warning and falling back to the
+// default when it is invalid
+func configTemplate(key, text string) *template.Template {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Ignoring %s: %v", key, err))
+		return nil
+	}
+	return tmpl
+}
+
+// Register adds or replaces a channel
+func (r *Router) Register(channel Channel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.channels[channel.Name()] = channel
+}
+
+// Route returns the channel names the first matching rule selects for the event
+func (r *Router) Route(event Event) []string {
+	for _, rule := range r.rules {
+		if rule.Matches(event) {
+			return rule.Channels
+		}
+	}
+	return nil
+}
+
+// Notify delivers the event to every routed channel, honouring quiet hours and
+// rate limits. Delivery errors are logged, not returned.
+func (r *Router) Notify(ctx context.Context, event Event) {
+	if r == nil {
+		return
+	}
+
+	now := r.Now()
+	if event.Time.IsZero() {
+		event.Time = now
+	}
+	if event.Host == "" {
+		event.Host, _ = os.Hostname()
+	}
+	if r.mask {
+		event.Command = redact.Secrets(event.Command)
+		event.Output = redact.Secrets(event.Output)
+		event.Suggestion = redact.Secrets(event.Suggestion)
+	}
+
+	for _, name := range r.Route(event) {
+		if name != "terminal" && r.quietHours.Contains(now) {
+			logger.Debug(fmt.Sprintf("Quiet hours: skipping %s notification", name))
+			continue
+		}
+
+		r.mu.Lock()
+		channel, exists := r.channels[name]
+		allowed := exists && r.allow(name, now)
+		r.mu.Unlock()
+
+		if !exists {
+			logger.Debug(fmt.Sprintf("Notification channel not configured: %s", name))
+			continue
+		}
+		if !allowed {
+			logger.Debug(fmt.Sprintf("Rate limit reached for %s notifications", name))
+			continue
+		}
+
+		if err := channel.Send(ctx, event); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to send %s notification: %v", name, err))
+		}
+	}
+}
+
+// allow records a send for the channel if it is within the rate limit.
+// Callers must hold r.mu.
+func (r *Router) allow(name string, now time.Time) bool {
+	if r.rateLimit <= 0 || name == "terminal" {
+		return true
+	}
+
+	window := now.Add(-time.Minute)
+	recent := r.sent[name][:0]
+	for _, t := range r.sent[name] {
+		if t.After(window) {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= r.rateLimit {
+		r.sent[name] = recent
+		return false
+	}
+
+	r.sent[name] = append(recent, now)
+	return true
+}
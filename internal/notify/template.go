@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultTemplate is the message posted to Slack and Discord unless
+// NOTIFY_TEMPLATE is set
+const DefaultTemplate = `{{if .Risk}}[{{.Risk}}] {{end}}{{.Summary}}
+{{- with .ErrorSummary}}
+> {{.}}{{end}}
+{{- with .Host}}
+Host: {{.}}{{end}}`
+
+// templateFuncs are available in notification templates in addition to the
+// fields and methods of Event (.Summary, .ErrorSummary)
+var templateFuncs = template.FuncMap{
+	// json quotes a value for a JSON payload, e.g. {"text": {{json .Summary}}}
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// ParseTemplate parses a notification template. The event is its data, so
+// {{.Command}}, {{.Suggestion}}, {{.ErrorSummary}}, {{.Host}} and the other
+// fields of Event can be used
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("notification").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// defaultTemplate is DefaultTemplate, parsed
+var defaultTemplate = template.Must(ParseTemplate(DefaultTemplate))
+
+// Render fills tmpl, or DefaultTemplate when it is nil, with event
+func Render(tmpl *template.Template, event Event) (string, error) {
+	if tmpl == nil {
+		tmpl = defaultTemplate
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, event); err != nil {
+		return "", fmt.Errorf("failed to render notification: %w", err)
+	}
+	return b.String(), nil
+}
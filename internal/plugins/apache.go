@@ -0,0 +1,269 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// ApachePlugin handles apachectl/apache2ctl configtest failures on both the
+// Debian (apache2, a2enmod) and RHEL (httpd, conf.modules.d) layouts
+type ApachePlugin struct {
+	Root string // where installed modules and configuration are looked up; "" means /
+}
+
+var (
+	apacheSyntaxError    = regexp.MustCompile(`Syntax error on line (\d+) of ([^:\s]+):`)
+	apacheInvalidCommand = regexp.MustCompile(`Invalid command '([^']+)'`)
+	apacheBindFailed     = regexp.MustCompile(`could not bind to address \[?[^\]\s]*\]?:(\d+)`)
+	apacheUnknownModule  = regexp.MustCompile(`ERROR: Module (\S+) does not exist`)
+)
+
+// apacheDirectiveModules maps directives to the module that provides them
+var apacheDirectiveModules = map[string]string{
+	"RewriteEngine":          "rewrite",
+	"RewriteRule":            "rewrite",
+	"RewriteCond":            "rewrite",
+	"RewriteBase":            "rewrite",
+	"SSLEngine":              "ssl",
+	"SSLCertificateFile":     "ssl",
+	"SSLCertificateKeyFile":  "ssl",
+	"SSLProtocol":            "ssl",
+	"ProxyPass":              "proxy_http",
+	"ProxyPassReverse":       "proxy_http",
+	"ProxyPreserveHost":      "proxy",
+	"ProxyRequests":          "proxy",
+	"Header":                 "headers",
+	"RequestHeader":          "headers",
+	"ExpiresActive":          "expires",
+	"ExpiresByType":          "expires",
+	"AddOutputFilterByType":  "filter",
+	"RemoteIPHeader":         "remoteip",
+	"RemoteIPTrustedProxy":   "remoteip",
+	"WSGIScriptAlias":        "wsgi",
+	"WSGIDaemonProcess":      "wsgi",
+	"php_value":              "php",
+	"php_flag":               "php",
+	"AuthUserFile":           "authn_file",
+	"AuthLDAPURL":            "authnz_ldap",
+	"Dav":                    "dav",
+	"CacheEnable":            "cache",
+	"SecRuleEngine":          "security2",
+	"H2Push":                 "http2",
+	"SetEnvIf":               "setenvif",
+	"BrowserMatch":           "setenvif",
+	"ModPagespeed":           "pagespeed",
+	"PassengerRuby":          "passenger",
+	"FcgidWrapper":           "fcgid",
+	"ProxyFCGIBackendType":   "proxy_fcgi",
+	"ProxyHTMLURLMap":        "proxy_html",
+	"ProxyWebsocketFallback": "proxy_wstunnel",
+}
+
+// apacheDebianModulesDir holds the modules installed on Debian
+const apacheDebianModulesDir = "/usr/lib/apache2/modules"
+
+// apacheServerNameConf sets a global ServerName on Debian
+const apacheServerNameConf = "/etc/apache2/conf-available/servername.conf"
+
+// apacheModulePackages are modules that are not shipped with Apache itself
+var apacheModulePackages = map[string][2]string{ // module: {Debian package, RHEL package}
+	"ssl":         {"", "mod_ssl"},
+	"wsgi":        {"libapache2-mod-wsgi-py3", "python3-mod_wsgi"},
+	"php":         {"libapache2-mod-php", "php"},
+	"security2":   {"libapache2-mod-security2", "mod_security"},
+	"fcgid":       {"libapache2-mod-fcgid", "mod_fcgid"},
+	"passenger":   {"libapache2-mod-passenger", "mod_passenger"},
+	"authnz_ldap": {"", "mod_ldap"},
+}
+
+func (p *ApachePlugin) Name() string {
+	return "apache"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *ApachePlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "apachectl", "apache2ctl", "httpd", "apache2", "a2enmod", "a2ensite") {
+		return false
+	}
+
+	apacheErrors := []string{
+		"syntax error on line",
+		"invalid command",
+		"could not bind to address",
+		"address already in use",
+		"no listening sockets available",
+		"does not exist",
+		"could not reliably determine the server's fully qualified domain name",
+	}
+
+	return containsAny(output, apacheErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *ApachePlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues. Each is a single
+// command, as fixes run without a shell; a fix that takes several steps
+// suggests the next one each time configtest fails
+func (p *ApachePlugin) getQuickFix(cmd string, output string) string {
+	debian := p.isDebian(cmd, output)
+
+	// Directives from modules that are not loaded
+	if m := apacheInvalidCommand.FindStringSubmatch(output); m != nil {
+		if module, ok := apacheDirectiveModules[m[1]]; ok {
+			return p.enableModule(module, debian)
+		}
+	}
+
+	if m := apacheUnknownModule.FindStringSubmatch(output); m != nil {
+		if module := closestMatch(m[1], p.knownModules()); module != "" && module != m[1] {
+			return strings.Replace(cmd, m[1], module, 1)
+		}
+	}
+
+	if m := apacheBindFailed.FindStringSubmatch(output); m != nil {
+		// Find the process already using the port
+		return fmt.Sprintf("sudo ss -ltnp sport = :%s", m[1])
+	}
+
+	// Any other syntax error: open the file at the reported line
+	if m := apacheSyntaxError.FindStringSubmatch(output); m != nil {
+		return p.edit(m[2], m[1])
+	}
+
+	// Only a warning, but printed on every configtest
+	if strings.Contains(output, "AH00558") {
+		// Set ServerName in a file of its own, then enable it
+		if debian {
+			if p.exists(apacheServerNameConf) {
+				return "sudo a2enconf servername"
+			}
+			return p.edit(apacheServerNameConf, "")
+		}
+		return p.edit("/etc/httpd/conf/httpd.conf", "")
+	}
+
+	return ""
+}
+
+// isDebian reports whether the Debian layout (apache2, a2enmod) is in use
+func (p *ApachePlugin) isDebian(cmd string, output string) bool {
+	return isCommand(cmd, "apache2ctl", "apache2", "a2enmod", "a2ensite") || strings.Contains(output, "/etc/apache2/")
+}
+
+// enableModule returns the command that installs module, or enables it once
+// it is installed
+func (p *ApachePlugin) enableModule(module string, debian bool) string {
+	packages := apacheModulePackages[module]
+	if debian {
+		if packages[0] != "" && !p.exists(filepath.Join(apacheDebianModulesDir, "mod_"+module+".so")) {
+			return "sudo apt install " + packages[0]
+		}
+		if module == "proxy_http" {
+			return "sudo a2enmod proxy proxy_http"
+		}
+		return "sudo a2enmod " + module
+	}
+
+	if packages[1] != "" {
+		return "sudo dnf install " + packages[1]
+	}
+	// RHEL loads the modules listed in conf.modules.d; add the LoadModule line
+	return p.edit(fmt.Sprintf("/etc/httpd/conf.modules.d/99-%s.conf", module), "")
+}
+
+// edit returns the command that opens path as root in the user's editor, at
+// line when it is not ""
+func (p *ApachePlugin) edit(path, line string) string {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "nano"
+	}
+	if line != "" {
+		return fmt.Sprintf("sudo %s +%s %s", editor, line, path)
+	}
+	return fmt.Sprintf("sudo %s %s", editor, path)
+}
+
+// exists reports whether path exists under Root
+func (p *ApachePlugin) exists(path string) bool {
+	_, err := os.Stat(filepath.Join(p.Root, path))
+	return err == nil
+}
+
+// knownModules returns the module names used for typo correction
+func (p *ApachePlugin) knownModules() []string {
+	seen := make(map[string]bool)
+	var modules []string
+	for _, module := range apacheDirectiveModules {
+		if !seen[module] {
+			seen[module] = true
+			modules = append(modules, module)
+		}
+	}
+	modules = append(modules, "proxy_balancer", "deflate", "alias", "auth_basic", "cgi", "status", "userdir", "mpm_event", "mpm_prefork")
+	sort.Strings(modules)
+	return modules
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *ApachePlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		// Show the parsed virtual hosts, which also reports syntax errors
+		if p.isDebian(cmd, output) {
+			return "sudo apache2ctl -S"
+		}
+		return "sudo apachectl -S"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *ApachePlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Apache HTTP Server administrator familiar with both Debian (apache2, a2enmod) and RHEL (httpd, conf.modules.d) layouts.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with Apache httpd 2.4
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. It runs without a shell: one command, no &&, pipes, redirections or comments
+3. "Invalid command" usually means a module is not loaded: a2enmod on Debian, LoadModule or a mod_* package on RHEL
+4. For port conflicts, identify the process holding the port
+5. For syntax errors, point at the file and line from the error
+
+EXAMPLES:
+- Input: "sudo apache2ctl configtest" + "Invalid command 'RewriteEngine', perhaps misspelled or defined by a module not included in the server configuration"
+- Output: "sudo a2enmod rewrite"
+
+- Input: "sudo apachectl configtest" + "Invalid command 'SSLEngine'"
+- Output: "sudo dnf install mod_ssl"
+
+Provide the corrected command:`, cmd, output)
+}
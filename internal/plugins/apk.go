@@ -0,0 +1,202 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// ApkPlugin handles Alpine Linux apk errors, common inside containers
+type ApkPlugin struct{}
+
+var apkNoSuchPackage = regexp.MustCompile(`(?m)^\s+(\S+) \(no such package\)`)
+
+func (p *ApkPlugin) Name() string {
+	return "apk"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *ApkPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "apk") {
+		return false
+	}
+
+	apkErrors := []string{
+		"unable to select packages",
+		"no such package",
+		"ignoring apkindex",
+		"try 'apk update'",
+		"temporary error",
+		"unable to lock database",
+		"permission denied",
+		"untrusted signature",
+		"breaks: world",
+	}
+
+	return containsAny(output, apkErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *ApkPlugin) Suggest(cmd string, output string) string {
+	// First try manual corrections for speed
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	// Use AI for complex suggestions
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues. Each is a single
+// command, as fixes run without a shell
+func (p *ApkPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// Handle permission errors (outside containers apk needs root)
+	if (strings.Contains(outputLower, "permission denied") || strings.Contains(outputLower, "unable to lock database")) &&
+		!strings.HasPrefix(cmd, "sudo ") && !strings.HasPrefix(cmd, "doas ") {
+		return "sudo " + cmd
+	}
+
+	// Common package name corrections
+	if strings.Contains(outputLower, "no such package") {
+		for _, m := range apkNoSuchPackage.FindAllStringSubmatch(output, -1) {
+			if correction := p.getPackageCorrection(m[1]); correction != "" {
+				return p.replacePackage(cmd, m[1], correction)
+			}
+		}
+	}
+
+	// Fresh containers ship without a package index
+	if strings.Contains(outputLower, "ignoring apkindex") || strings.Contains(outputLower, "try 'apk update'") ||
+		strings.Contains(outputLower, "temporary error") || strings.Contains(outputLower, "unable to select packages") {
+		if strings.Contains(cmd, "--no-cache") || strings.Contains(cmd, "--update") || strings.Contains(cmd, "apk update") {
+			return ""
+		}
+		return p.withUpdateCache(cmd)
+	}
+
+	return ""
+}
+
+// withUpdateCache makes apk refresh the package index before running cmd
+func (p *ApkPlugin) withUpdateCache(cmd string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if filepath.Base(field) == "apk" {
+			rest := append([]string{"--update-cache"}, fields[i+1:]...)
+			return strings.Join(append(fields[:i+1], rest...), " ")
+		}
+	}
+	return ""
+}
+
+// replacePackage swaps one package argument for another, keeping version pins
+func (p *ApkPlugin) replacePackage(cmd, packageName, correction string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		name := field
+		if idx := strings.IndexAny(field, "=<>~"); idx > 0 {
+			name = field[:idx]
+		}
+		if name == packageName {
+			fields[i] = correction
+			return strings.Join(fields, " ")
+		}
+	}
+	return strings.Replace(cmd, packageName, correction, 1)
+}
+
+// getPackageCorrection maps Debian/Fedora package names to their Alpine equivalents
+func (p *ApkPlugin) getPackageCorrection(packageName string) string {
+	corrections := map[string]string{
+		"build-essential":  "build-base",
+		"libssl-dev":       "openssl-dev",
+		"zlib1g-dev":       "zlib-dev",
+		"python3-pip":      "py3-pip",
+		"pip":              "py3-pip",
+		"python-pip":       "py3-pip",
+		"python3-venv":     "python3",
+		"dnsutils":         "bind-tools",
+		"iputils-ping":     "iputils",
+		"golang":           "go",
+		"default-jdk":      "openjdk21",
+		"openjdk":          "openjdk21",
+		"mysql-client":     "mariadb-client",
+		"mysql-server":     "mariadb",
+		"redis-tools":      "redis",
+		"redis-cli":        "redis",
+		"docker.io":        "docker",
+		"docker-ce":        "docker",
+		"netcat":           "netcat-openbsd",
+		"openssh-server":   "openssh",
+		"fd-find":          "fd",
+		"chromium-browser": "chromium",
+		"gcc-c++":          "g++",
+	}
+
+	corrections = mergeCorrections(p.Name(), correctionPackages, corrections)
+	lower := strings.ToLower(packageName)
+	if correction, exists := corrections[lower]; exists {
+		return correction
+	}
+
+	// Fedora development packages are -dev on Alpine
+	if strings.HasSuffix(lower, "-devel") {
+		return strings.TrimSuffix(packageName, "-devel") + "-dev"
+	}
+
+	return ""
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *ApkPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback: search for the package apk could not find
+		if m := apkNoSuchPackage.FindStringSubmatch(output); m != nil {
+			return "apk search " + m[1]
+		}
+		return ""
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *ApkPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Alpine Linux administrator specializing in apk package management, often inside containers.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Alpine Linux (musl libc) with apk package manager
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Use Alpine package names (build-base, openssl-dev, py3-*, -dev suffix)
+3. It runs without a shell: use apk --update-cache (or --no-cache) rather than apk update &&
+4. Handle common issues: missing index, renamed packages, packages only in the community repository
+5. If package doesn't exist, suggest the closest alternative
+
+EXAMPLES:
+- Input: "apk add build-essential" + "ERROR: unable to select packages:\n  build-essential (no such package):"
+- Output: "apk add build-base"
+
+- Input: "apk add curl" + "WARNING: Ignoring APKINDEX.tar.gz: No such file or directory"
+- Output: "apk --update-cache add curl"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -3,14 +3,28 @@ package plugins
 import (
 	"context"
 	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
 )
 
 // AptPlugin handles APT package manager errors with AI-powered suggestions
 type AptPlugin struct{}
 
+var (
+	aptMissingPackage = regexp.MustCompile(`Unable to locate package (\S+)`)
+	aptBackportsSuite = regexp.MustCompile(`\s(\S+-backports)/`)
+)
+
+// aptSearchTimeout bounds the apt-cache lookups of APT_SEARCH_SUGGESTIONS
+const aptSearchTimeout = 3 * time.Second
+
 func (p *AptPlugin) Name() string {
 	return "apt"
 }
@@ -43,6 +57,21 @@ func (p *AptPlugin) Match(cmd string, output string) bool {
 	return containsAny(output, aptErrors)
 }
 
+// Score rates a match: a known package typo is near certain, while keywords
+// such as "permission denied" may come from anything the command ran
+func (p *AptPlugin) Score(cmd string, output string) float64 {
+	outputLower := strings.ToLower(output)
+	switch {
+	case strings.Contains(outputLower, "unable to locate package") && p.getQuickFix(cmd, output) != "":
+		return 0.95
+	case containsAny(output, []string{"unable to locate package", "has no installation candidate", "could not get lock", "unmet dependencies", "held broken packages"}):
+		return 0.8
+	case containsAny(output, []string{"permission denied", "command not found"}):
+		return 0.4
+	}
+	return DefaultScore
+}
+
 // Suggest generates an AI-powered suggestion for the error
 func (p *AptPlugin) Suggest(cmd string, output string) string {
 	// First try manual corrections for speed
@@ -73,6 +102,13 @@ func (p *AptPlugin) getQuickFix(cmd string, output string) string {
 		return "sudo apt update && " + cmd
 	}
 
+	// A newer or missing package may be in the backports repository
+	if containsAny(output, []string{"unable to locate package", "has no installation candidate", "unmet dependencies"}) {
+		if fix := p.backportsFix(cmd); fix != "" {
+			return fix
+		}
+	}
+
 	// Common package name corrections
 	if strings.Contains(outputLower, "unable to locate package") {
 		parts := strings.Fields(cmd)
@@ -84,11 +120,102 @@ func (p *AptPlugin) getQuickFix(cmd string, output string) string {
 				}
 			}
 		}
+
+		if m := aptMissingPackage.FindStringSubmatch(output); m != nil {
+			switch found := p.searchPackage(m[1]); found {
+			case "":
+			case m[1]:
+				// Known to apt-cache but not to apt: the package lists are stale
+				return "sudo apt update && " + cmd
+			default:
+				return strings.Replace(cmd, m[1], found, 1)
+			}
+		}
+	}
+
+	return ""
+}
+
+// searchPackage looks name up in the local package lists when
+// APT_SEARCH_SUGGESTIONS is on, and returns the closest real package name
+func (p *AptPlugin) searchPackage(name string) string {
+	cfg := config.Current()
+	if cfg == nil || !cfg.APTSearchSuggestions {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), aptSearchTimeout)
+	defer cancel()
+
+	// Typos: rank every known package name by edit distance
+	out, err := exec.CommandContext(ctx, "apt-cache", "pkgnames").Output()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("apt-cache pkgnames failed: %v", err))
+		return ""
+	}
+	if match := closestMatch(strings.ToLower(name), strings.Fields(string(out))); match != "" {
+		return match
 	}
 
+	// Partial names: packages whose name contains it, shortest first
+	out, err = exec.CommandContext(ctx, "apt-cache", "search", "--names-only", regexp.QuoteMeta(name)).Output()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("apt-cache search failed: %v", err))
+		return ""
+	}
+	var matches []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if pkg, _, ok := strings.Cut(line, " - "); ok && strings.Contains(pkg, strings.ToLower(name)) {
+			matches = append(matches, pkg)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return len(matches[i]) < len(matches[j])
+	})
+	if len(matches) > 0 {
+		return matches[0]
+	}
 	return ""
 }
 
+// backportsFix installs the package from the backports suite apt-cache knows
+// it in, when APT_ENABLE_BACKPORTS is on
+func (p *AptPlugin) backportsFix(cmd string) string {
+	cfg := config.Current()
+	if cfg == nil || !cfg.APTEnableBackports || strings.Contains(cmd, "-backports") {
+		return ""
+	}
+	parts := strings.Fields(cmd)
+	install := indexOf(parts, "install")
+	if install < 0 {
+		return ""
+	}
+	name := ""
+	for _, part := range parts[install+1:] {
+		if !strings.HasPrefix(part, "-") {
+			name = part
+			break
+		}
+	}
+	if name == "" {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), aptSearchTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "apt-cache", "madison", name).Output()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("apt-cache madison failed: %v", err))
+		return ""
+	}
+	m := aptBackportsSuite.FindStringSubmatch(string(out))
+	if m == nil {
+		return ""
+	}
+	parts[install] = "install -t " + m[1]
+	return strings.Join(parts, " ")
+}
+
 // getPackageCorrection provides manual corrections for common package name typos
 func (p *AptPlugin) getPackageCorrection(packageName string) string {
 	corrections := map[string]string{
@@ -145,15 +272,16 @@ func (p *AptPlugin) getPackageCorrection(packageName string) string {
 		"scp":          "openssh-client",
 	}
 
+	corrections = mergeCorrections(p.Name(), correctionPackages, corrections)
 	return corrections[strings.ToLower(packageName)]
 }
 
 // getAISuggestion uses AI to generate intelligent suggestions
 func (p *AptPlugin) getAISuggestion(cmd string, output string) string {
-	prompt := p.buildAIPrompt(cmd, output)
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
 
 	ctx := context.Background()
-	suggestion, err := ai.GetSuggestion(ctx, prompt)
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
 	if err != nil {
 		// Fallback to generic suggestion
 		return "sudo apt update && apt search <package-name> && " + cmd
@@ -164,13 +292,17 @@ func (p *AptPlugin) getAISuggestion(cmd string, output string) string {
 
 // buildAIPrompt creates a detailed prompt for the AI
 func (p *AptPlugin) buildAIPrompt(cmd string, output string) string {
+	backports := ""
+	if cfg := config.Current(); cfg != nil && cfg.APTEnableBackports {
+		backports = "\n- Backports: enabled; newer packages may be installed with apt install -t <codename>-backports"
+	}
 	return fmt.Sprintf(`
 You are an expert Linux system administrator specializing in APT package management on Debian/Ubuntu systems.
 
 CONTEXT:
 - User executed command: %s
 - Command output/error: %s
-- System: Debian/Ubuntu with APT package manager
+- System: Debian/Ubuntu with APT package manager%s
 - Goal: Provide the EXACT corrected command to fix the issue
 
 TASK:
@@ -201,5 +333,5 @@ EXAMPLES:
 - Input: "apt update" + "Permission denied"  
 - Output: "sudo apt update"
 
-Provide the corrected command:`, cmd, output)
+Provide the corrected command:`, cmd, output, backports)
 }
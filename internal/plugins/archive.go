@@ -0,0 +1,308 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// ArchivePlugin handles tar, zip, unzip and related archive tool errors
+type ArchivePlugin struct{}
+
+var archiveToolNotFound = regexp.MustCompile(`(?:^|\s)(unzip|zip|7za?|unrar|rar|xz|zstd|bzip2|lz4|tar|gzip): (?:command )?not found`)
+
+// archiveToolPackages maps archive tools to the Debian/Ubuntu package providing them
+var archiveToolPackages = map[string]string{
+	"unzip": "unzip",
+	"zip":   "zip",
+	"7z":    "p7zip-full",
+	"7za":   "p7zip-full",
+	"unrar": "unrar",
+	"rar":   "rar",
+	"xz":    "xz-utils",
+	"zstd":  "zstd",
+	"bzip2": "bzip2",
+	"lz4":   "lz4",
+	"tar":   "tar",
+	"gzip":  "gzip",
+}
+
+// tarCompression maps archive extensions to the tar flag that decompresses them;
+// "" means uncompressed
+var tarCompression = []struct {
+	suffix string
+	flag   string
+}{
+	{".tar.gz", "z"}, {".tgz", "z"},
+	{".tar.bz2", "j"}, {".tbz2", "j"}, {".tbz", "j"},
+	{".tar.xz", "J"}, {".txz", "J"},
+	{".tar.zst", "--zstd"}, {".tzst", "--zstd"},
+	{".tar", ""},
+}
+
+func (p *ArchivePlugin) Name() string {
+	return "archive"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *ArchivePlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "tar", "zip", "unzip", "7z", "7za", "unrar", "rar", "xz", "zstd", "bzip2", "lz4", "gzip") {
+		return false
+	}
+	if archiveToolNotFound.MatchString(output) {
+		return true
+	}
+
+	archiveErrors := []string{
+		"refusing to read archive contents from terminal",
+		"refusing to write archive contents to terminal",
+		"cannot open: no such file or directory",
+		"not in gzip format",
+		"is not a bzip2 file",
+		"file format not recognized",
+		"does not look like a tar archive",
+		"unexpected end of file",
+		"unexpected eof in archive",
+		"end-of-central-directory signature not found",
+		"cannot find zipfile directory",
+		"zipfile is empty",
+	}
+
+	return containsAny(output, archiveErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *ArchivePlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *ArchivePlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	if m := archiveToolNotFound.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("sudo apt install %s && %s", archiveToolPackages[m[1]], cmd)
+	}
+
+	// Truncated downloads: test the archive instead of retrying blindly
+	if strings.Contains(outputLower, "unexpected end of file") || strings.Contains(outputLower, "unexpected eof in archive") ||
+		strings.Contains(outputLower, "end-of-central-directory signature not found") {
+		if archive := p.archiveArg(cmd); archive != "" {
+			return p.testCommand(archive) + " # the archive looks truncated; download it again if this fails"
+		}
+	}
+
+	if !isCommand(cmd, "tar") {
+		return ""
+	}
+
+	fields := strings.Fields(cmd)
+	cluster := p.flagCluster(fields)
+	if cluster < 0 {
+		return ""
+	}
+
+	// -f missing, so tar tried to use the terminal as the archive
+	if strings.Contains(outputLower, "archive contents from terminal") || strings.Contains(outputLower, "archive contents to terminal") {
+		return p.withFileFlag(fields, cluster)
+	}
+
+	// -f must be the last letter of a cluster because it takes the file name:
+	// `tar -xfz a.tar.gz` opens an archive called "z"
+	flags := fields[cluster]
+	if f := strings.Index(flags, "f"); f >= 0 && f < len(flags)-1 && strings.Contains(outputLower, "cannot open") {
+		fields[cluster] = strings.Replace(flags, "f", "", 1) + "f"
+		return strings.Join(fields, " ")
+	}
+
+	// Compression flag does not match the file
+	if strings.Contains(outputLower, "not in gzip format") || strings.Contains(outputLower, "is not a bzip2 file") ||
+		strings.Contains(outputLower, "file format not recognized") || strings.Contains(outputLower, "does not look like a tar archive") {
+		return p.withCompression(fields, cluster, p.archiveArg(cmd))
+	}
+
+	return ""
+}
+
+// flagCluster returns the index of tar's bundled short options ("-xzf" or
+// old-style "xzf"), or -1
+func (p *ArchivePlugin) flagCluster(fields []string) int {
+	cluster := -1
+	for i, field := range fields {
+		if isCommand(field, "tar") {
+			cluster = i + 1
+			break
+		}
+	}
+	if cluster < 1 || cluster >= len(fields) {
+		return -1
+	}
+
+	flags := strings.TrimPrefix(fields[cluster], "-")
+	if strings.HasPrefix(fields[cluster], "--") || flags == "" {
+		return -1
+	}
+	for _, c := range flags {
+		if !strings.ContainsRune("AcdrtuxCfgGhijJklLmMnNoOpPRsSTUvVwWzZ", c) {
+			return -1
+		}
+	}
+	return cluster
+}
+
+// withFileFlag adds -f to the cluster and moves the archive right after it
+func (p *ArchivePlugin) withFileFlag(fields []string, cluster int) string {
+	archive := -1
+	for i, field := range fields {
+		if i != cluster && p.isArchive(field) {
+			archive = i
+			break
+		}
+	}
+	if archive < 0 {
+		return ""
+	}
+
+	name := fields[archive]
+	rest := append([]string{}, fields[:archive]...)
+	rest = append(rest, fields[archive+1:]...)
+	rest[cluster] += "f"
+
+	result := append([]string{}, rest[:cluster+1]...)
+	result = append(result, name)
+	result = append(result, rest[cluster+1:]...)
+	return strings.Join(result, " ")
+}
+
+// withCompression replaces the cluster's compression letter with the one
+// matching the archive extension
+func (p *ArchivePlugin) withCompression(fields []string, cluster int, archive string) string {
+	flag, ok := p.compressionFor(archive)
+	if !ok {
+		return ""
+	}
+
+	flags := fields[cluster]
+	prefix := ""
+	if strings.HasPrefix(flags, "-") {
+		prefix, flags = "-", flags[1:]
+	}
+	flags = strings.NewReplacer("z", "", "j", "", "J", "").Replace(flags)
+
+	long := ""
+	if len(flag) == 1 {
+		// Keep f last since it takes the archive name
+		if strings.HasSuffix(flags, "f") {
+			flags = flags[:len(flags)-1] + flag + "f"
+		} else {
+			flags += flag
+		}
+	} else if flag != "" {
+		long = " " + flag
+	}
+
+	fields[cluster] = prefix + flags
+	return strings.Join(fields, " ") + long
+}
+
+// compressionFor returns the tar flag for archive's extension
+func (p *ArchivePlugin) compressionFor(archive string) (string, bool) {
+	lower := strings.ToLower(archive)
+	for _, c := range tarCompression {
+		if strings.HasSuffix(lower, c.suffix) {
+			return c.flag, true
+		}
+	}
+	return "", false
+}
+
+func (p *ArchivePlugin) isArchive(field string) bool {
+	if _, ok := p.compressionFor(field); ok {
+		return true
+	}
+	return strings.HasSuffix(strings.ToLower(field), ".zip")
+}
+
+// archiveArg returns the first argument that looks like an archive file
+func (p *ArchivePlugin) archiveArg(cmd string) string {
+	for _, field := range commandFields(cmd) {
+		if p.isArchive(field) {
+			return field
+		}
+	}
+	return ""
+}
+
+// testCommand returns a command that checks archive integrity
+func (p *ArchivePlugin) testCommand(archive string) string {
+	lower := strings.ToLower(archive)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return "unzip -t " + archive
+	case strings.HasSuffix(lower, "gz"):
+		return "gzip -t " + archive
+	case strings.HasSuffix(lower, "bz2"), strings.HasSuffix(lower, ".tbz"):
+		return "bzip2 -t " + archive
+	case strings.HasSuffix(lower, "xz"):
+		return "xz -t " + archive
+	case strings.HasSuffix(lower, "zst"):
+		return "zstd -t " + archive
+	}
+	return "tar -tf " + archive + " > /dev/null"
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *ArchivePlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		archive := p.archiveArg(cmd)
+		if archive == "" {
+			archive = "<archive>"
+		}
+		return "file " + archive + " # check the real archive format"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *ArchivePlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in Unix archive tools (tar, gzip, bzip2, xz, zstd, zip, unzip).
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with GNU tar and Info-ZIP
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. In tar option clusters, f must come last because it takes the archive name
+3. Match the compression flag to the archive (-z gzip, -j bzip2, -J xz, --zstd zstd)
+4. For truncated archives, suggest an integrity test or downloading again
+5. Install missing archive tools with the system package manager
+
+EXAMPLES:
+- Input: "tar -xfz site.tar.gz" + "tar: z: Cannot open: No such file or directory"
+- Output: "tar -xzf site.tar.gz"
+
+- Input: "tar -xzf backup.tar.xz" + "gzip: stdin: not in gzip format"
+- Output: "tar -xJf backup.tar.xz"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,248 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// BunPlugin handles bun and bunx errors with AI-powered suggestions
+type BunPlugin struct {
+	Dir string // project directory holding package.json; "" means the working directory
+}
+
+// bunCommands are bun's built-in subcommands
+var bunCommands = []string{
+	"run", "test", "x", "repl", "exec", "install", "add", "remove", "update",
+	"outdated", "link", "unlink", "publish", "patch", "pm", "build", "init",
+	"create", "upgrade", "audit", "info", "why",
+}
+
+var (
+	bunScriptNotFound = regexp.MustCompile(`Script not found "([^"]+)"`)
+	bunPackage404     = regexp.MustCompile(`(?:package "([^"]+)" not found|registry\.npmjs\.org/(\S+?)(?: -)? 404)`)
+)
+
+func (p *BunPlugin) Name() string {
+	return "bun"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *BunPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "bun", "bunx") {
+		return false
+	}
+
+	bunErrors := []string{
+		"script not found",
+		"404",
+		"could not determine executable to run",
+		"not yet implemented in bun",
+		"not implemented in bun",
+		"notimplementederror",
+		"module not found",
+		"cannot find module",
+		"eacces",
+	}
+
+	return containsAny(output, bunErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *BunPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *BunPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// bun treats unknown subcommands as script names, so typos of both land here
+	if m := bunScriptNotFound.FindStringSubmatch(output); m != nil {
+		return p.correctScriptOrCommand(cmd, m[1])
+	}
+
+	if m := bunPackage404.FindStringSubmatch(output); m != nil {
+		name := m[1]
+		if name == "" {
+			name = m[2]
+		}
+		return p.correctPackageName(cmd, name)
+	}
+
+	// Node APIs Bun has not implemented yet: run the same thing under Node
+	if strings.Contains(outputLower, "implemented in bun") || strings.Contains(outputLower, "notimplementederror") {
+		return p.runWithNode(cmd)
+	}
+
+	return ""
+}
+
+// correctScriptOrCommand fixes a misspelled package.json script or bun subcommand
+func (p *BunPlugin) correctScriptOrCommand(cmd string, name string) string {
+	fields := commandFields(cmd)
+	idx := -1
+	for i, field := range fields {
+		if field == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ""
+	}
+
+	if script := closestMatch(name, p.scripts()); script != "" && script != name {
+		fields[idx] = script
+		return p.withSudo(cmd, fields)
+	}
+
+	// Only a bare `bun <typo>` can be a subcommand typo
+	if idx == 1 {
+		if command := closestMatch(name, bunCommands); command != "" {
+			fields[idx] = command
+			return p.withSudo(cmd, fields)
+		}
+	}
+
+	return "bun run # List available scripts"
+}
+
+// correctPackageName replaces a package name that does not exist in the registry
+func (p *BunPlugin) correctPackageName(cmd string, name string) string {
+	name = strings.TrimSuffix(name, "/")
+	correction, ok := mergeCorrections(p.Name(), correctionPackages, npmPackageCorrections)[name]
+	if !ok || correction == name {
+		return ""
+	}
+
+	fields := commandFields(cmd)
+	for i, field := range fields {
+		clean := field
+		if at := strings.LastIndex(field, "@"); at > 0 {
+			clean = field[:at]
+		}
+		if clean == name {
+			fields[i] = correction + strings.TrimPrefix(field, clean)
+			return p.withSudo(cmd, fields)
+		}
+	}
+	return ""
+}
+
+// runWithNode rewrites a bun invocation to use Node.js instead
+func (p *BunPlugin) runWithNode(cmd string) string {
+	fields := commandFields(cmd)
+	if len(fields) < 2 || fields[0] != "bun" {
+		return ""
+	}
+
+	args := fields[1:]
+	if args[0] == "run" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return ""
+	}
+
+	target := args[0]
+	switch filepath.Ext(target) {
+	case ".js", ".mjs", ".cjs":
+		return "node " + strings.Join(args, " ")
+	case ".ts", ".mts", ".cts", ".tsx":
+		return "npx tsx " + strings.Join(args, " ")
+	}
+	return "npm run " + strings.Join(args, " ")
+}
+
+// withSudo joins fields back into a command, keeping a leading sudo
+func (p *BunPlugin) withSudo(cmd string, fields []string) string {
+	result := strings.Join(fields, " ")
+	if strings.HasPrefix(strings.TrimSpace(cmd), "sudo ") {
+		result = "sudo " + result
+	}
+	return result
+}
+
+// scripts returns the script names defined in package.json
+func (p *BunPlugin) scripts() []string {
+	dir := p.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *BunPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "bun --help # Check the correct Bun command syntax"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *BunPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in the Bun JavaScript runtime and package manager.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Bun runtime (bun, bunx) with npm registry packages
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Use Bun syntax (bun add, bun run, bunx) unless Bun cannot run the code
+3. Handle common issues: script name typos, package name typos, missing dependencies
+4. If a Node.js API is not implemented in Bun, suggest running the same entry point with node
+5. If package doesn't exist, suggest the closest alternative
+
+EXAMPLES:
+- Input: "bun run strat" + "error: Script not found \"strat\""
+- Output: "bun run start"
+
+- Input: "bun add expresss" + "error: package \"expresss\" not found registry.npmjs.org/expresss 404"
+- Output: "bun add express"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,253 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// CCPlugin handles gcc and clang compile and link errors
+type CCPlugin struct{}
+
+var (
+	ccMissingHeader    = regexp.MustCompile(`fatal error: '?([\w./+-]+\.h(?:pp)?|[\w]+)'?(?:: No such file or directory| file not found)`)
+	ccUndefinedSymbol  = regexp.MustCompile("undefined reference to [`']([^'`]+)'|Undefined symbols? .*?\"_?([^\"]+)\"")
+	ccCompilerNotFound = regexp.MustCompile(`(?:^|\s)(gcc|g\+\+|cc|c\+\+|clang|clang\+\+): (?:command )?not found`)
+)
+
+// ccHeaderPackages maps headers, or the directory they live in, to the
+// Debian/Ubuntu development package providing them
+var ccHeaderPackages = map[string]string{
+	"openssl/":                 "libssl-dev",
+	"zlib.h":                   "zlib1g-dev",
+	"curl/":                    "libcurl4-openssl-dev",
+	"Python.h":                 "python3-dev",
+	"ffi.h":                    "libffi-dev",
+	"sqlite3.h":                "libsqlite3-dev",
+	"readline/":                "libreadline-dev",
+	"yaml.h":                   "libyaml-dev",
+	"png.h":                    "libpng-dev",
+	"jpeglib.h":                "libjpeg-dev",
+	"gmp.h":                    "libgmp-dev",
+	"pcre.h":                   "libpcre3-dev",
+	"pcre2.h":                  "libpcre2-dev",
+	"ncurses.h":                "libncurses-dev",
+	"curses.h":                 "libncurses-dev",
+	"uuid/":                    "uuid-dev",
+	"bzlib.h":                  "libbz2-dev",
+	"lzma.h":                   "liblzma-dev",
+	"zstd.h":                   "libzstd-dev",
+	"lz4.h":                    "liblz4-dev",
+	"libxml/":                  "libxml2-dev",
+	"expat.h":                  "libexpat1-dev",
+	"boost/":                   "libboost-all-dev",
+	"GL/":                      "libgl-dev",
+	"X11/":                     "libx11-dev",
+	"SDL2/":                    "libsdl2-dev",
+	"gtk/":                     "libgtk-3-dev",
+	"glib.h":                   "libglib2.0-dev",
+	"mysql/":                   "libmysqlclient-dev",
+	"libpq-fe.h":               "libpq-dev",
+	"systemd/":                 "libsystemd-dev",
+	"event2/":                  "libevent-dev",
+	"sodium.h":                 "libsodium-dev",
+	"pcap.h":                   "libpcap-dev",
+	"libusb-1.0/":              "libusb-1.0-0-dev",
+	"alsa/":                    "libasound2-dev",
+	"jansson.h":                "libjansson-dev",
+	"json-c/":                  "libjson-c-dev",
+	"gnutls/":                  "libgnutls28-dev",
+	"stdio.h":                  "build-essential",
+	"stdlib.h":                 "build-essential",
+	"bits/libc-header-start.h": "gcc-multilib",
+	"iostream":                 "g++",
+	"vector":                   "g++",
+	"string":                   "g++",
+}
+
+// ccSymbolLibraries maps well-known functions to the linker flag that provides them
+var ccSymbolLibraries = map[string]string{
+	"sqrt": "-lm", "pow": "-lm", "sin": "-lm", "cos": "-lm", "tan": "-lm", "atan2": "-lm",
+	"exp": "-lm", "log": "-lm", "log10": "-lm", "floor": "-lm", "ceil": "-lm", "fabs": "-lm", "round": "-lm",
+	"dlopen": "-ldl", "dlsym": "-ldl", "dlclose": "-ldl", "dlerror": "-ldl",
+	"deflate": "-lz", "inflate": "-lz", "deflateInit_": "-lz", "inflateInit_": "-lz",
+	"compress": "-lz", "uncompress": "-lz", "crc32": "-lz",
+	"clock_gettime": "-lrt", "shm_open": "-lrt",
+	"readline": "-lreadline", "add_history": "-lreadline",
+	"initscr": "-lncurses", "endwin": "-lncurses", "printw": "-lncurses", "mvprintw": "-lncurses", "refresh": "-lncurses",
+	"PQconnectdb": "-lpq", "PQexec": "-lpq", "PQfinish": "-lpq",
+}
+
+// ccSymbolPrefixes maps library API prefixes to the linker flag that provides them
+var ccSymbolPrefixes = []struct {
+	prefix string
+	flag   string
+}{
+	{"pthread_", "-lpthread"},
+	{"SSL_", "-lssl -lcrypto"}, {"TLS_", "-lssl -lcrypto"},
+	{"EVP_", "-lcrypto"}, {"ERR_", "-lcrypto"}, {"RAND_", "-lcrypto"}, {"SHA256_", "-lcrypto"}, {"MD5_", "-lcrypto"},
+	{"curl_", "-lcurl"},
+	{"sqlite3_", "-lsqlite3"},
+	{"pcap_", "-lpcap"},
+	{"uuid_", "-luuid"},
+	{"png_", "-lpng"},
+	{"xmlRead", "-lxml2"}, {"xmlParse", "-lxml2"}, {"xmlFree", "-lxml2"},
+	{"yaml_", "-lyaml"},
+	{"__gmpz_", "-lgmp"},
+	{"mysql_", "-lmysqlclient"},
+}
+
+func (p *CCPlugin) Name() string {
+	return "cc"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *CCPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "gcc", "g++", "cc", "c++", "clang", "clang++") {
+		return false
+	}
+
+	ccErrors := []string{
+		"no such file or directory",
+		"file not found",
+		"undefined reference to",
+		"undefined symbol",
+		"command not found",
+		": not found",
+	}
+
+	return containsAny(output, ccErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *CCPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *CCPlugin) getQuickFix(cmd string, output string) string {
+	if m := ccCompilerNotFound.FindStringSubmatch(output); m != nil {
+		pkg := "build-essential"
+		if strings.HasPrefix(m[1], "clang") {
+			pkg = "clang"
+		}
+		return fmt.Sprintf("sudo apt install %s && %s", pkg, cmd)
+	}
+
+	if m := ccMissingHeader.FindStringSubmatch(output); m != nil {
+		if pkg := p.headerPackage(m[1]); pkg != "" {
+			return fmt.Sprintf("sudo apt install %s && %s", pkg, cmd)
+		}
+		return ""
+	}
+
+	// Libraries go after the sources and objects that use them
+	for _, m := range ccUndefinedSymbol.FindAllStringSubmatch(output, -1) {
+		symbol := m[1]
+		if symbol == "" {
+			symbol = m[2]
+		}
+
+		// C++ code compiled with the C driver misses libstdc++
+		if strings.Contains(symbol, "std::") || strings.HasPrefix(symbol, "operator new") || strings.HasPrefix(symbol, "__cxa_") {
+			return p.withCXXDriver(cmd)
+		}
+
+		if flag := p.libraryFlag(symbol); flag != "" && !strings.Contains(cmd, flag) {
+			return cmd + " " + flag
+		}
+	}
+
+	return ""
+}
+
+// headerPackage finds the development package providing header
+func (p *CCPlugin) headerPackage(header string) string {
+	if pkg, ok := ccHeaderPackages[header]; ok {
+		return pkg
+	}
+	if idx := strings.Index(header, "/"); idx > 0 {
+		if pkg, ok := ccHeaderPackages[header[:idx+1]]; ok {
+			return pkg
+		}
+	}
+	return ""
+}
+
+// libraryFlag returns the -l flag for the library defining symbol
+func (p *CCPlugin) libraryFlag(symbol string) string {
+	if flag, ok := ccSymbolLibraries[symbol]; ok {
+		return flag
+	}
+	for _, lib := range ccSymbolPrefixes {
+		if strings.HasPrefix(symbol, lib.prefix) {
+			return lib.flag
+		}
+	}
+	return ""
+}
+
+// withCXXDriver swaps gcc/cc/clang for the matching C++ driver
+func (p *CCPlugin) withCXXDriver(cmd string) string {
+	drivers := map[string]string{"gcc": "g++", "cc": "c++", "clang": "clang++"}
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if driver, ok := drivers[field]; ok {
+			fields[i] = driver
+			return strings.Join(fields, " ")
+		}
+	}
+	return ""
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *CCPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "apt-file search <header.h> # find the package providing a header"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *CCPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert C and C++ developer familiar with gcc, clang, the GNU linker and Linux development packages.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Debian/Ubuntu Linux with gcc or clang
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. For missing headers, install the -dev package that provides them, then recompile
+3. For undefined references, add the -l flag for the library after the source files
+4. Compile C++ with g++ or clang++, not gcc
+5. Prefer pkg-config --cflags --libs when the library ships a .pc file
+
+EXAMPLES:
+- Input: "gcc main.c -o app" + "main.c:1:10: fatal error: openssl/ssl.h: No such file or directory"
+- Output: "sudo apt install libssl-dev && gcc main.c -o app"
+
+- Input: "gcc calc.c -o calc" + "undefined reference to `+"`sqrt'"+`"
+- Output: "gcc calc.c -o calc -lm"
+
+Provide the corrected command:`, cmd, output)
+}
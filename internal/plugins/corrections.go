@@ -0,0 +1,96 @@
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of corrections a plugin's corrections file can hold
+const (
+	correctionPackages = "packages"
+	correctionCommands = "commands"
+	correctionServices = "services"
+	correctionImages   = "images"
+)
+
+// userCorrections holds the corrections from the user's files by plugin and
+// kind, for example userCorrections["npm"]["packages"]["utils"]
+var (
+	correctionsMu   sync.RWMutex
+	userCorrections = map[string]map[string]map[string]string{}
+)
+
+// CorrectionsDir returns the directory of user correction files,
+// ~/.logaid/corrections
+func CorrectionsDir() string {
+	return filepath.Join(config.Dir(), "corrections")
+}
+
+// LoadCorrections reads the {plugin}.yaml files in dir, each mapping kinds
+// to typo/correction pairs:
+//
+//	packages:
+//	  utils: "@acme/utils"
+//	commands:
+//	  dpl: deploy
+//
+// They replace the previously loaded corrections and take precedence over the
+// built-in ones. A file that does not parse is skipped
+func LoadCorrections(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	loaded := map[string]map[string]map[string]string{}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to read corrections %s: %v", path, err))
+			continue
+		}
+		var kinds map[string]map[string]string
+		if err := yaml.Unmarshal(data, &kinds); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to parse corrections %s: %v", path, err))
+			continue
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ext)] = kinds
+	}
+
+	correctionsMu.Lock()
+	userCorrections = loaded
+	correctionsMu.Unlock()
+	return nil
+}
+
+// mergeCorrections returns the built-in corrections of a plugin with the
+// user's corrections of that kind laid over them
+func mergeCorrections(plugin, kind string, builtin map[string]string) map[string]string {
+	correctionsMu.RLock()
+	defer correctionsMu.RUnlock()
+
+	user := userCorrections[plugin][kind]
+	if len(user) == 0 {
+		return builtin
+	}
+	merged := make(map[string]string, len(builtin)+len(user))
+	for typo, correction := range builtin {
+		merged[typo] = correction
+	}
+	for typo, correction := range user {
+		merged[typo] = correction
+	}
+	return merged
+}
@@ -3,13 +3,22 @@ package plugins
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
 )
 
 // DockerPlugin handles Docker command errors with AI-powered suggestions
-type DockerPlugin struct{}
+type DockerPlugin struct {
+	Hub string // Docker Hub URL, https://hub.docker.com if empty
+}
+
+// dockerImageRef finds the image reference in pull errors
+var dockerImageRef = regexp.MustCompile(`(?i)unable to find image '([^']+)'|manifest for (\S+) not found`)
 
 func (p *DockerPlugin) Name() string {
 	return "docker"
@@ -72,9 +81,22 @@ func (p *DockerPlugin) getQuickFix(cmd string, output string) string {
 		return p.correctDockerCommand(cmd)
 	}
 
+	// Handle unknown tags
+	if strings.Contains(outputLower, "manifest unknown") && p.suggestTags() {
+		if fix := p.correctImageTag(cmd, output); fix != "" {
+			return fix
+		}
+	}
+
 	// Handle image name typos
 	if strings.Contains(outputLower, "unable to find image") {
-		return p.correctImageName(cmd, output)
+		fix := p.correctImageName(cmd, output)
+		if fix == cmd && p.searchHub() {
+			if found := p.searchImageName(cmd, output); found != "" {
+				return found
+			}
+		}
+		return fix
 	}
 
 	return ""
@@ -110,6 +132,7 @@ func (p *DockerPlugin) correctDockerCommand(cmd string) string {
 		"inspt": "inspect",
 	}
 
+	corrections = mergeCorrections(p.Name(), correctionCommands, corrections)
 	parts := strings.Fields(cmd)
 	if len(parts) >= 2 {
 		command := parts[1]
@@ -161,6 +184,7 @@ func (p *DockerPlugin) correctImageName(cmd string, output string) string {
 	}
 
 	// Extract image name from output
+	imageCorrections = mergeCorrections(p.Name(), correctionImages, imageCorrections)
 	for typo, correct := range imageCorrections {
 		if strings.Contains(strings.ToLower(output), typo) {
 			return strings.Replace(cmd, typo, correct, 1)
@@ -170,12 +194,190 @@ func (p *DockerPlugin) correctImageName(cmd string, output string) string {
 	return cmd
 }
 
+// searchHub reports whether DOCKER_HUB_SEARCH allows Docker Hub lookups
+func (p *DockerPlugin) searchHub() bool {
+	cfg := config.Current()
+	return cfg != nil && cfg.DockerHubSearch
+}
+
+// suggestTags reports whether DOCKER_SUGGEST_TAGS allows Docker Hub lookups
+func (p *DockerPlugin) suggestTags() bool {
+	cfg := config.Current()
+	return cfg != nil && cfg.DockerSuggestTags
+}
+
+// searchImageName replaces an image that is not on Docker Hub with the top
+// Docker Hub search result for its name
+func (p *DockerPlugin) searchImageName(cmd string, output string) string {
+	ref, repo, tag := parseImageRef(output)
+	path := hubRepository(repo)
+	if path == "" {
+		return ""
+	}
+	if exists, err := p.repositoryExists(path); err != nil || exists {
+		// The image exists, so the pull failed for another reason
+		return ""
+	}
+
+	names, err := p.searchRepositories(repo[strings.LastIndex(repo, "/")+1:])
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Docker Hub search failed: %v", err))
+		return ""
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	name := closestMatch(repo, names)
+	if name == "" {
+		name = names[0]
+	}
+	if tag != "" {
+		name += ":" + tag
+	}
+	return replaceImageRef(cmd, ref, name)
+}
+
+// correctImageTag replaces a tag that does not exist with the closest tag of
+// the repository, or latest
+func (p *DockerPlugin) correctImageTag(cmd string, output string) string {
+	ref, repo, tag := parseImageRef(output)
+	path := hubRepository(repo)
+	if path == "" || tag == "" {
+		return ""
+	}
+
+	tags, err := p.repositoryTags(path)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Docker Hub tag lookup failed: %v", err))
+		return ""
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	closest := closestMatch(tag, tags)
+	if closest == "" {
+		closest = tags[0]
+		for _, candidate := range tags {
+			if candidate == "latest" {
+				closest = candidate
+			}
+		}
+	}
+	return replaceImageRef(cmd, ref, repo+":"+closest)
+}
+
+// hub returns the Docker Hub URL without a trailing slash
+func (p *DockerPlugin) hub() string {
+	if p.Hub != "" {
+		return strings.TrimSuffix(p.Hub, "/")
+	}
+	return "https://hub.docker.com"
+}
+
+// repositoryExists asks Docker Hub whether a repository such as library/nginx exists
+func (p *DockerPlugin) repositoryExists(path string) (bool, error) {
+	location := p.hub() + "/v2/repositories/" + path + "/"
+	return cachedLookup("docker", location, func(ctx context.Context) (bool, error) {
+		var repository struct {
+			Name string `json:"name"`
+		}
+		return getJSON(ctx, location, &repository)
+	})
+}
+
+// searchRepositories returns the names of the top Docker Hub search results
+func (p *DockerPlugin) searchRepositories(query string) ([]string, error) {
+	location := p.hub() + "/v2/search/repositories/?page_size=10&query=" + url.QueryEscape(query)
+	return cachedLookup("docker", location, func(ctx context.Context) ([]string, error) {
+		var result struct {
+			Results []struct {
+				RepoName string `json:"repo_name"`
+			} `json:"results"`
+		}
+		if _, err := getJSON(ctx, location, &result); err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, repository := range result.Results {
+			names = append(names, repository.RepoName)
+		}
+		return names, nil
+	})
+}
+
+// repositoryTags returns the most recently pushed tags of a repository
+func (p *DockerPlugin) repositoryTags(path string) ([]string, error) {
+	location := p.hub() + "/v2/repositories/" + path + "/tags/?page_size=100&ordering=last_updated"
+	return cachedLookup("docker", location, func(ctx context.Context) ([]string, error) {
+		var result struct {
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		}
+		if _, err := getJSON(ctx, location, &result); err != nil {
+			return nil, err
+		}
+		var tags []string
+		for _, tag := range result.Results {
+			tags = append(tags, tag.Name)
+		}
+		return tags, nil
+	})
+}
+
+// parseImageRef returns the image reference named in a pull error, split into
+// repository and tag
+func parseImageRef(output string) (ref, repo, tag string) {
+	m := dockerImageRef.FindStringSubmatch(output)
+	if m == nil {
+		return "", "", ""
+	}
+	ref = m[1] + m[2]
+	repo = ref
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		repo, tag = ref[:i], ref[i+1:]
+	}
+	return ref, repo, tag
+}
+
+// hubRepository returns the Docker Hub path of repo (library/ for official
+// images), or "" for images on other registries and digests
+func hubRepository(repo string) string {
+	if repo == "" || strings.Contains(repo, "@") {
+		return ""
+	}
+	parts := strings.Split(repo, "/")
+	if len(parts) > 1 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		if parts[0] != "docker.io" {
+			return ""
+		}
+		parts = parts[1:]
+	}
+	if len(parts) == 1 {
+		return "library/" + parts[0]
+	}
+	return strings.Join(parts, "/")
+}
+
+// replaceImageRef swaps the image in cmd for image. Docker reports images
+// given without a tag as :latest
+func replaceImageRef(cmd, ref, image string) string {
+	parts := strings.Fields(cmd)
+	for i, part := range parts {
+		if part == ref || part+":latest" == ref {
+			parts[i] = image
+			return strings.Join(parts, " ")
+		}
+	}
+	return ""
+}
+
 // getAISuggestion uses AI to generate intelligent suggestions
 func (p *DockerPlugin) getAISuggestion(cmd string, output string) string {
-	prompt := p.buildAIPrompt(cmd, output)
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
 
 	ctx := context.Background()
-	suggestion, err := ai.GetSuggestion(ctx, prompt)
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
 	if err != nil {
 		// Fallback to generic suggestion
 		return "docker --help # Check the correct Docker command syntax"
@@ -0,0 +1,199 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// ExternalRequest is written as JSON to an external plugin's stdin
+type ExternalRequest struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+}
+
+// ExternalResponse is read as JSON from an external plugin's stdout
+type ExternalResponse struct {
+	Match      bool    `json:"match"`
+	Suggestion string  `json:"suggestion"`
+	Confidence float64 `json:"confidence"` // 0-1; 0 means the plugin did not say
+}
+
+// defaultExternalTimeout bounds a plugin run when PLUGIN_TIMEOUT is not set
+const defaultExternalTimeout = 5 * time.Second
+
+// ExternalPlugin runs an executable from PLUGINS_DIR. The executable receives
+// an ExternalRequest on stdin and answers with an ExternalResponse; Match and
+// Suggest share a single run per command/output pair
+type ExternalPlugin struct {
+	Path    string
+	Timeout time.Duration // 0 means defaultExternalTimeout
+
+	cache responseCache
+}
+
+// responseCache remembers a plugin's answer for the last command/output pair,
+// so Match and Suggest share a single run
+type responseCache struct {
+	mu     sync.Mutex
+	cmd    string
+	output string
+	resp   *ExternalResponse
+}
+
+// get returns the cached answer for cmd/output or computes it with run
+func (c *responseCache) get(name, cmd, output string, run func() (*ExternalResponse, error)) *ExternalResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.resp != nil && c.cmd == cmd && c.output == output {
+		return c.resp
+	}
+
+	resp, err := run()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Plugin %s failed: %v", name, err))
+	}
+	c.cmd, c.output, c.resp = cmd, output, resp
+	return resp
+}
+
+func (p *ExternalPlugin) Name() string {
+	name := filepath.Base(p.Path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *ExternalPlugin) Match(cmd string, output string) bool {
+	resp := p.response(cmd, output)
+	return resp != nil && resp.Match
+}
+
+// Suggest returns the suggestion the executable gave for cmd/output
+func (p *ExternalPlugin) Suggest(cmd string, output string) string {
+	resp := p.response(cmd, output)
+	if resp == nil || !resp.Match {
+		return ""
+	}
+	return strings.TrimSpace(resp.Suggestion)
+}
+
+// Score returns the confidence the executable reported, or DefaultScore
+func (p *ExternalPlugin) Score(cmd string, output string) float64 {
+	resp := p.response(cmd, output)
+	if resp == nil || resp.Confidence <= 0 {
+		return DefaultScore
+	}
+	return resp.Confidence
+}
+
+// response runs the executable, reusing the previous answer for the same input
+func (p *ExternalPlugin) response(cmd, output string) *ExternalResponse {
+	return p.cache.get(p.Name(), cmd, output, func() (*ExternalResponse, error) {
+		return p.run(cmd, output)
+	})
+}
+
+// run executes the plugin once and decodes its answer
+func (p *ExternalPlugin) run(cmd, output string) (*ExternalResponse, error) {
+	request, err := json.Marshal(ExternalRequest{Command: cmd, Output: output})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = defaultExternalTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	process := exec.CommandContext(ctx, p.Path)
+	process.Stdin = bytes.NewReader(request)
+	process.Stdout = &stdout
+	process.Stderr = &stderr
+	// Don't wait for children of a killed plugin that still hold stdout
+	process.WaitDelay = time.Second
+	if err := process.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w: %s", p.Path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp ExternalResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp, nil
+}
+
+// LoadExternalPlugins returns a plugin for every executable file, .wasm module,
+// .lua script and .yaml rule pack in dir, in name order. A missing directory
+// yields no plugins.
+func LoadExternalPlugins(dir string, timeout time.Duration) []Plugin {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warn(fmt.Sprintf("Failed to read plugins directory %s: %v", dir, err))
+		}
+		return nil
+	}
+
+	var plugins []Plugin
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		plugin, err := LoadExternalPlugin(filepath.Join(dir, entry.Name()), timeout)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to load %v", err))
+			continue
+		}
+		if plugin != nil {
+			plugins = append(plugins, plugin)
+			logger.Debug(fmt.Sprintf("Loaded plugin %s from %s", plugin.Name(), entry.Name()))
+		}
+	}
+	return plugins
+}
+
+// LoadExternalPlugin loads one file from PLUGINS_DIR by its type: a WASM
+// module, Lua script, rule pack or executable. It returns nil without an
+// error for files that are none of these
+func LoadExternalPlugin(path string, timeout time.Duration) (Plugin, error) {
+	name := filepath.Base(path)
+	switch ext := filepath.Ext(name); {
+	case ext == ".wasm":
+		plugin, err := NewWasmPlugin(path, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("WASM plugin %s: %w", name, err)
+		}
+		return plugin, nil
+	case ext == ".lua":
+		plugin, err := NewLuaPlugin(path, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("Lua plugin %s: %w", name, err)
+		}
+		return plugin, nil
+	case ext == ".yaml" || ext == ".yml":
+		plugin, err := NewRulePackPlugin(path)
+		if err != nil {
+			return nil, fmt.Errorf("rule pack %s: %w", name, err)
+		}
+		return plugin, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+		return nil, nil
+	}
+	return &ExternalPlugin{Path: path, Timeout: timeout}, nil
+}
@@ -0,0 +1,268 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// FindPlugin handles find expressions and the xargs pipelines they feed
+type FindPlugin struct{}
+
+var (
+	findPathsPrecede  = regexp.MustCompile("paths must precede expression: [`']([^']+)'")
+	findGlobalOption  = regexp.MustCompile("global option (-\\w+) after the argument")
+	findPatternOption = map[string]bool{"-name": true, "-iname": true, "-path": true, "-ipath": true, "-wholename": true, "-lname": true}
+)
+
+func (p *FindPlugin) Name() string {
+	return "find"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *FindPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "find", "xargs") && !strings.Contains(cmd, "| xargs") {
+		return false
+	}
+
+	findErrors := []string{
+		"paths must precede expression",
+		"possible unquoted pattern",
+		"missing argument to `-exec'",
+		"you have specified the global option",
+		"find: ",
+		"argument line too long",
+		"unmatched single quote",
+		"unmatched double quote",
+	}
+
+	return containsAny(output, findErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *FindPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *FindPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+	find, pipeline := p.splitPipeline(cmd)
+
+	// xargs splits on whitespace and quotes unless both sides use NUL
+	if strings.Contains(outputLower, "unmatched single quote") || strings.Contains(outputLower, "unmatched double quote") ||
+		strings.Contains(outputLower, "argument line too long") {
+		return p.withNulSeparators(cmd)
+	}
+
+	// The shell expanded an unquoted -name pattern into several words
+	if findPathsPrecede.MatchString(output) || strings.Contains(outputLower, "possible unquoted pattern") {
+		if quoted := p.quotePatterns(find); quoted != find {
+			return quoted + pipeline
+		}
+	}
+	if m := findPathsPrecede.FindStringSubmatch(output); m != nil {
+		fields := strings.Fields(find)
+		for i, field := range fields {
+			if field == m[1] && i > 1 {
+				rest := append(append([]string{}, fields[1:i]...), fields[i+1:]...)
+				return strings.Join(append([]string{fields[0], m[1]}, rest...), " ") + pipeline
+			}
+		}
+	}
+
+	if strings.Contains(output, "missing argument to `-exec'") {
+		return strings.TrimSpace(find) + ` \;` + pipeline
+	}
+
+	// -maxdepth and friends belong right after the starting points
+	if m := findGlobalOption.FindStringSubmatch(output); m != nil {
+		if moved := p.moveGlobalOption(find, m[1]); moved != "" {
+			return moved + pipeline
+		}
+	}
+
+	// Unreadable directories (/proc, other users' homes) are noise: skip them
+	if strings.Contains(outputLower, "permission denied") && !strings.Contains(find, "-readable") {
+		if pruned := p.pruneUnreadable(find); pruned != "" {
+			return pruned + pipeline
+		}
+	}
+
+	return ""
+}
+
+// splitPipeline returns the find command and the rest of the pipeline
+// (including its leading " | ")
+func (p *FindPlugin) splitPipeline(cmd string) (string, string) {
+	if idx := strings.Index(cmd, " | "); idx >= 0 {
+		return cmd[:idx], cmd[idx:]
+	}
+	return cmd, ""
+}
+
+// quotePatterns single-quotes glob patterns given to -name, -path and friends
+func (p *FindPlugin) quotePatterns(find string) string {
+	fields := strings.Fields(find)
+	for i := 1; i < len(fields); i++ {
+		value := fields[i]
+		if findPatternOption[fields[i-1]] && strings.ContainsAny(value, "*?[") && !strings.ContainsAny(value[:1], `'"`) {
+			fields[i] = "'" + value + "'"
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// moveGlobalOption moves option and its value to just after the starting points
+func (p *FindPlugin) moveGlobalOption(find, option string) string {
+	fields := strings.Fields(find)
+	idx := -1
+	for i, field := range fields {
+		if field == option && i+1 < len(fields) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ""
+	}
+	moved := fields[idx : idx+2]
+	rest := append(append([]string{}, fields[:idx]...), fields[idx+2:]...)
+
+	// The starting points are the words before the first expression
+	insert := 1
+	for insert < len(rest) && !strings.HasPrefix(rest[insert], "-") && rest[insert] != "(" && rest[insert] != "!" {
+		insert++
+	}
+	result := append(append(append([]string{}, rest[:insert]...), moved...), rest[insert:]...)
+	return strings.Join(result, " ")
+}
+
+// findActions print or act on the files; without one, find prints them
+var findActions = map[string]bool{
+	"-print": true, "-print0": true, "-printf": true, "-ls": true, "-fprint": true, "-fprint0": true, "-fprintf": true, "-fls": true,
+	"-exec": true, "-execdir": true, "-ok": true, "-okdir": true, "-delete": true, "-quit": true, "-prune": true,
+}
+
+// findGlobalOptions apply to the whole search and come before the expression
+var findGlobalOptions = map[string]int{"-maxdepth": 1, "-mindepth": 1, "-depth": 0, "-xdev": 0, "-mount": 0, "-ignore_readdir_race": 0}
+
+// pruneUnreadable skips the directories find cannot read instead of reporting
+// them: find / -name x becomes find / ! -readable -prune -o -name x -print
+func (p *FindPlugin) pruneUnreadable(find string) string {
+	fields := strings.Fields(find)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	// Starting points, then global options
+	insert := 1
+	for insert < len(fields) && !strings.HasPrefix(fields[insert], "-") && fields[insert] != "(" && fields[insert] != "!" {
+		insert++
+	}
+	for insert < len(fields) {
+		values, ok := findGlobalOptions[fields[insert]]
+		if !ok {
+			break
+		}
+		insert += 1 + values
+	}
+	if insert > len(fields) {
+		return ""
+	}
+
+	expression := fields[insert:]
+	hasAction := false
+	for _, field := range expression {
+		if findActions[field] {
+			hasAction = true
+		}
+	}
+	if !hasAction {
+		if indexOf(expression, "-o") >= 0 || indexOf(expression, "-or") >= 0 {
+			expression = append(append([]string{"("}, expression...), ")")
+		}
+		expression = append(expression, "-print")
+	}
+
+	result := append(append([]string{}, fields[:insert]...), "!", "-readable", "-prune", "-o")
+	return strings.Join(append(result, expression...), " ")
+}
+
+// withNulSeparators makes find print NUL-terminated names and xargs read them
+func (p *FindPlugin) withNulSeparators(cmd string) string {
+	find, pipeline := p.splitPipeline(cmd)
+	if !strings.Contains(pipeline, "xargs") {
+		return ""
+	}
+	if !isCommand(find, "find") {
+		// Not find: split on newlines only (GNU xargs)
+		if strings.Contains(pipeline, "xargs -d") || strings.Contains(pipeline, "xargs -0") {
+			return ""
+		}
+		return find + strings.Replace(pipeline, "xargs", `xargs -d '\n'`, 1)
+	}
+
+	if !strings.Contains(find, "-print0") {
+		find = strings.Replace(strings.TrimSpace(find), " -print ", " ", 1)
+		find = strings.TrimSuffix(find, " -print") + " -print0"
+	}
+	if !strings.Contains(pipeline, "xargs -0") {
+		pipeline = strings.Replace(pipeline, "xargs", "xargs -0", 1)
+	}
+	if fixed := find + pipeline; fixed != cmd {
+		return fixed
+	}
+	return ""
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *FindPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		return ""
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *FindPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in GNU findutils: find expressions, xargs and shell quoting.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with GNU find and xargs
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Quote -name/-path patterns so the shell does not expand them
+3. Starting paths come first, then global options like -maxdepth, then the expression
+4. Use -print0 with xargs -0 for file names containing spaces or quotes
+5. Skip unreadable directories with ! -readable -prune -o; do not redirect output
+
+EXAMPLES:
+- Input: "find . -name *.txt" + "find: paths must precede expression: `+"`b.txt'"+`"
+- Output: "find . -name '*.txt'"
+
+- Input: "find . -exec rm {}" + "find: missing argument to `+"`-exec'"+`"
+- Output: "find . -exec rm {} \;"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -1,12 +1,34 @@
 package plugins
 
 import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
 	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/configedit"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
 )
 
 // GitPlugin handles Git command errors
 type GitPlugin struct{}
 
+// gitAliasAfter is how many earlier occurrences of the same typo make
+// GIT_SUGGEST_ALIASES offer an alias for it
+const gitAliasAfter = 2
+
+// gitLookupTimeout bounds the git commands that list branches and remotes
+const gitLookupTimeout = 3 * time.Second
+
+var (
+	gitMissingRef    = regexp.MustCompile(`pathspec '([^']+)' did not match|invalid reference: (\S+)`)
+	gitMissingRemote = regexp.MustCompile(`'([^']+)' does not appear to be a git repository`)
+)
+
 func (p *GitPlugin) Name() string {
 	return "git"
 }
@@ -33,7 +55,8 @@ func (p *GitPlugin) Match(cmd string, output string) bool {
 	return containsAny(output, errorPatterns)
 }
 
-func (p *GitPlugin) Suggest(cmd string, output string) string {
+// typoCorrection returns the subcommand a mistyped git subcommand stands for
+func (p *GitPlugin) typoCorrection(subcommand string) (string, bool) {
 	// Common git command typos
 	commandCorrections := map[string]string{
 		"checout":  "checkout",
@@ -69,6 +92,12 @@ func (p *GitPlugin) Suggest(cmd string, output string) string {
 		"stas":     "stash",
 	}
 
+	commandCorrections = mergeCorrections(p.Name(), correctionCommands, commandCorrections)
+	correction, ok := commandCorrections[subcommand]
+	return correction, ok
+}
+
+func (p *GitPlugin) Suggest(cmd string, output string) string {
 	// Parse the git command
 	parts := strings.Fields(cmd)
 	if len(parts) < 2 {
@@ -78,7 +107,7 @@ func (p *GitPlugin) Suggest(cmd string, output string) string {
 	gitCommand := parts[1]
 
 	// Check for direct command corrections
-	if correction, exists := commandCorrections[gitCommand]; exists {
+	if correction, exists := p.typoCorrection(gitCommand); exists {
 		return strings.Replace(cmd, "git "+gitCommand, "git "+correction, 1)
 	}
 
@@ -87,6 +116,18 @@ func (p *GitPlugin) Suggest(cmd string, output string) string {
 		return "git init"
 	}
 
+	if m := gitMissingRemote.FindStringSubmatch(output); m != nil {
+		if fix := p.correctRemote(parts, m[1]); fix != "" {
+			return fix
+		}
+	}
+
+	if m := gitMissingRef.FindStringSubmatch(output); m != nil && (gitCommand == "checkout" || gitCommand == "switch") {
+		if fix := p.correctBranch(cmd, parts, m[1]+m[2]); fix != "" {
+			return fix
+		}
+	}
+
 	if strings.Contains(output, "pathspec") && strings.Contains(output, "did not match") {
 		// Suggest git branch to show available branches
 		return "git branch -a"
@@ -104,3 +145,201 @@ func (p *GitPlugin) Suggest(cmd string, output string) string {
 
 	return ""
 }
+
+// AutoApply reports whether suggestion fixes a subcommand typo git itself
+// rejected, which GIT_AUTO_CORRECT runs without asking
+func (p *GitPlugin) AutoApply(cmd string, output string, suggestion string) bool {
+	cfg := config.Current()
+	if cfg == nil || !cfg.GitAutoCorrect || !strings.Contains(output, "is not a git command") {
+		return false
+	}
+	parts := strings.Fields(cmd)
+	if len(parts) < 2 {
+		return false
+	}
+	correction, ok := p.typoCorrection(parts[1])
+	return ok && correction != parts[1] && suggestion == strings.Replace(cmd, "git "+parts[1], "git "+correction, 1)
+}
+
+// SuggestConfig proposes ~/.gitconfig changes for errors caused by missing settings
+func (p *GitPlugin) SuggestConfig(cmd string, output string) *configedit.Edit {
+	if edit := p.suggestAlias(cmd, output); edit != nil {
+		return edit
+	}
+
+	// git pull refuses to run until a reconcile strategy is configured
+	if strings.Contains(output, "divergent branches") {
+		return &configedit.Edit{
+			Path:        "~/.gitconfig",
+			Section:     "pull",
+			Key:         "rebase",
+			Value:       "false",
+			Separator:   " = ",
+			Description: "Make git pull merge divergent branches by default",
+		}
+	}
+
+	return nil
+}
+
+// suggestAlias offers a git alias for a subcommand typo made repeatedly, so
+// it works the next time (GIT_SUGGEST_ALIASES)
+func (p *GitPlugin) suggestAlias(cmd string, output string) *configedit.Edit {
+	cfg := config.Current()
+	if cfg == nil || !cfg.GitSuggestAliases || !strings.Contains(output, "is not a git command") {
+		return nil
+	}
+	parts := strings.Fields(cmd)
+	if len(parts) < 2 {
+		return nil
+	}
+	typo := parts[1]
+	correction, ok := p.typoCorrection(typo)
+	if !ok || correction == typo {
+		return nil
+	}
+
+	store := history.NewFromConfig()
+	if store == nil {
+		return nil
+	}
+	entries, err := store.List(0)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to read history: %v", err))
+		return nil
+	}
+	seen := 0
+	for _, entry := range entries {
+		if fields := strings.Fields(entry.Command); entry.Source == p.Name() && len(fields) > 1 && fields[0] == "git" && fields[1] == typo {
+			seen += entry.Occurrences()
+		}
+	}
+	if seen < gitAliasAfter {
+		return nil
+	}
+
+	return &configedit.Edit{
+		Path:        "~/.gitconfig",
+		Section:     "alias",
+		Key:         typo,
+		Value:       correction,
+		Separator:   " = ",
+		Description: fmt.Sprintf("You typed 'git %s' %d times; add an alias so it runs 'git %s'", typo, seen+1, correction),
+	}
+}
+
+// correctBranch points a checkout or switch at the branch of this repository
+// closest to name. Remote branches are checked out as tracking branches; if
+// nothing is close, the branch may only exist upstream, so fetch first
+func (p *GitPlugin) correctBranch(cmd string, parts []string, name string) string {
+	refs, err := gitLines("for-each-ref", "--format=%(refname:short)", "refs/heads", "refs/remotes")
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to list git branches: %v", err))
+		return ""
+	}
+
+	remotes := remoteNames()
+	var local, remote []string
+	for _, ref := range refs {
+		prefix, _, _ := strings.Cut(ref, "/")
+		switch {
+		case strings.HasSuffix(ref, "/HEAD") || contains(remotes, ref):
+			// A remote's symbolic HEAD, shortened to origin/HEAD or origin
+		case contains(remotes, prefix):
+			remote = append(remote, ref)
+		default:
+			local = append(local, ref)
+		}
+	}
+
+	arg := indexOf(parts, name)
+	if arg < 0 {
+		return ""
+	}
+	if branch := closestBranch(name, local); branch != "" {
+		parts[arg] = branch
+		return strings.Join(parts, " ")
+	}
+
+	// Compare remote branches by their name without the remote
+	short := make([]string, len(remote))
+	for i, ref := range remote {
+		short[i] = ref[strings.Index(ref, "/")+1:]
+	}
+	if branch := closestBranch(name, short); branch != "" {
+		parts[arg] = "--track " + remote[indexOf(short, branch)]
+		return strings.Join(parts, " ")
+	}
+
+	if len(remotes) > 0 {
+		return "git fetch && " + cmd
+	}
+	return ""
+}
+
+// correctRemote replaces a remote that does not exist with the closest
+// configured one, or the only one
+func (p *GitPlugin) correctRemote(parts []string, name string) string {
+	remotes := remoteNames()
+	arg := indexOf(parts, name)
+	if arg < 0 || len(remotes) == 0 {
+		return ""
+	}
+
+	remote := closestBranch(name, remotes)
+	if remote == "" && len(remotes) == 1 {
+		remote = remotes[0]
+	}
+	if remote == "" {
+		return ""
+	}
+	parts[arg] = remote
+	return strings.Join(parts, " ")
+}
+
+// closestBranch returns the branch nearest to name by edit distance, or else
+// the shortest branch that starts with name or that name starts with
+// ("develop" finds "dev")
+func closestBranch(name string, branches []string) string {
+	if match := closestMatch(name, branches); match != "" {
+		return match
+	}
+	best := ""
+	for _, branch := range branches {
+		if (strings.HasPrefix(branch, name) || strings.HasPrefix(name, branch)) && (best == "" || len(branch) < len(best)) {
+			best = branch
+		}
+	}
+	return best
+}
+
+// remoteNames returns the remotes of the repository in the working directory
+func remoteNames() []string {
+	remotes, err := gitLines("remote")
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to list git remotes: %v", err))
+	}
+	return remotes
+}
+
+// gitLines runs git in the working directory and returns its output lines
+func gitLines(args ...string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitLookupTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// indexOf returns the index of value in list, or -1
+func indexOf(list []string, value string) int {
+	for i, item := range list {
+		if item == value {
+			return i
+		}
+	}
+	return -1
+}
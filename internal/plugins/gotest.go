@@ -0,0 +1,267 @@
+package plugins
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// GoTestPlugin handles `go test` runs that fail before any test executes:
+// build and setup failures, module problems, cgo and build constraints
+type GoTestPlugin struct {
+	Dir string // module directory; "" means the working directory
+}
+
+var (
+	goTestNoModule     = regexp.MustCompile(`no required module provides package (\S+?);`)
+	goTestConstraints  = regexp.MustCompile(`build constraints exclude all Go files in (\S+)`)
+	goTestBuildTag     = regexp.MustCompile(`^//go:build\s+(\w+)`)
+	goTestCompileError = regexp.MustCompile(`\.go:\d+(?::\d+)?: `)
+	goTestToolchain    = regexp.MustCompile(`requires go >= [\d.]+`)
+)
+
+func (p *GoTestPlugin) Name() string {
+	return "gotest"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *GoTestPlugin) Match(cmd string, output string) bool {
+	fields := commandFields(cmd)
+	if !isCommand(cmd, "go") || len(fields) < 2 || fields[1] != "test" {
+		return false
+	}
+
+	goTestErrors := []string{
+		"no test files",
+		"matched no packages",
+		"requires cgo",
+		"c compiler \"gcc\" not found",
+		"no required module provides package",
+		"missing go.sum entry",
+		"updates to go.mod needed",
+		"inconsistent vendoring",
+		"build constraints exclude all go files",
+		"[build failed]",
+		"[setup failed]",
+		"go.mod file not found",
+		"requires go >=",
+	}
+
+	return containsAny(output, goTestErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *GoTestPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues. Each is a single
+// command, as fixes run without a shell
+func (p *GoTestPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// The race detector is implemented in C
+	if strings.Contains(outputLower, `c compiler "gcc" not found`) {
+		return "sudo apt install build-essential"
+	}
+	if strings.Contains(outputLower, "requires cgo") {
+		return p.withEnv(cmd, "CGO_ENABLED=1")
+	}
+
+	if strings.Contains(outputLower, "go.mod file not found") {
+		if module := p.moduleName(); module != "" {
+			return "go mod init " + module
+		}
+		return ""
+	}
+	// Newer toolchains are downloaded on demand unless GOTOOLCHAIN=local
+	if goTestToolchain.MatchString(output) {
+		return p.withEnv(cmd, "GOTOOLCHAIN=auto")
+	}
+	if strings.Contains(outputLower, "inconsistent vendoring") {
+		return "go mod vendor"
+	}
+	if m := goTestNoModule.FindStringSubmatch(output); m != nil {
+		return "go get " + m[1]
+	}
+	if strings.Contains(outputLower, "missing go.sum entry") || strings.Contains(outputLower, "updates to go.mod needed") {
+		return "go mod tidy"
+	}
+
+	if m := goTestConstraints.FindStringSubmatch(output); m != nil {
+		if tag := p.buildTag(m[1]); tag != "" && !strings.Contains(cmd, "-tags") {
+			return p.withFlag(cmd, "-tags="+tag)
+		}
+		return ""
+	}
+
+	// `go test` only covers the current directory
+	if (strings.Contains(outputLower, "no test files") || strings.Contains(outputLower, "matched no packages")) &&
+		strings.TrimSpace(strings.Join(commandFields(cmd), " ")) == "go test" {
+		return "go test ./..."
+	}
+
+	return ""
+}
+
+// buildTag returns the tag required by the Go files in dir
+func (p *GoTestPlugin) buildTag(dir string) string {
+	if !filepath.IsAbs(dir) && p.Dir != "" {
+		dir = filepath.Join(p.Dir, dir)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return ""
+	}
+
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(line, "package ") {
+				break
+			}
+			// Negated tags (//go:build !windows) do not match and cannot be fixed with -tags
+			if m := goTestBuildTag.FindStringSubmatch(line); m != nil {
+				file.Close()
+				return m[1]
+			}
+		}
+		file.Close()
+	}
+	return ""
+}
+
+// withEnv runs cmd through env with an environment assignment, replacing an
+// existing one. It returns "" when cmd already has it
+func (p *GoTestPlugin) withEnv(cmd, env string) string {
+	name := env[:strings.Index(env, "=")+1]
+	fields := strings.Fields(cmd)
+	if len(fields) > 0 && fields[0] == "env" {
+		fields = fields[1:]
+	}
+	// Assignments before the command only work in a shell; env sets them
+	// without one
+	var assignments []string
+	for len(fields) > 0 && strings.Contains(fields[0], "=") && !strings.HasPrefix(fields[0], "-") {
+		assignments, fields = append(assignments, fields[0]), fields[1:]
+	}
+	replaced := false
+	for i, assignment := range assignments {
+		if strings.HasPrefix(assignment, name) {
+			if assignment == env {
+				return ""
+			}
+			assignments[i], replaced = env, true
+		}
+	}
+	if !replaced {
+		assignments = append(assignments, env)
+	}
+	return strings.Join(append(append([]string{"env"}, assignments...), fields...), " ")
+}
+
+// moduleName returns the module path `go mod init` is given for the module
+// directory: its name, as go mod init would infer it from nothing
+func (p *GoTestPlugin) moduleName() string {
+	dir := p.Dir
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		dir = wd
+	}
+	name := filepath.Base(dir)
+	if name == "." || name == string(filepath.Separator) || strings.ContainsAny(name, " \t") {
+		return ""
+	}
+	return name
+}
+
+// withFlag inserts flag right after `go test`
+func (p *GoTestPlugin) withFlag(cmd, flag string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if field == "test" && i > 0 && isCommand(fields[i-1], "go") {
+			rest := append([]string{flag}, fields[i+1:]...)
+			return strings.Join(append(fields[:i+1], rest...), " ")
+		}
+	}
+	return ""
+}
+
+// relevantOutput keeps compiler errors and go command messages, dropping
+// test logs that would only distract the AI
+func (p *GoTestPlugin) relevantOutput(output string) string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if goTestCompileError.MatchString(line) || strings.HasPrefix(line, "go: ") || strings.HasPrefix(line, "FAIL") {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return output
+	}
+	return strings.Join(lines, "\n")
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *GoTestPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(p.relevantOutput(output)))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "go vet ./... # report build errors without running tests"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *GoTestPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Go developer familiar with the go command, modules, build tags and cgo.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Go toolchain with modules
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. It runs without a shell: no &&, pipes or redirections; set variables with env
+3. The tests did not run; fix the build or setup problem, not test assertions
+4. Use go get for missing packages and go mod tidy for go.mod/go.sum mismatches
+5. -race needs CGO_ENABLED=1 and a C compiler; files behind build tags need -tags
+6. Keep the user's flags and package patterns
+
+EXAMPLES:
+- Input: "go test -race ./..." + "go: -race requires cgo; enable cgo by setting CGO_ENABLED=1"
+- Output: "env CGO_ENABLED=1 go test -race ./..."
+
+- Input: "go test ./..." + "no required module provides package github.com/stretchr/testify/assert; to add it:"
+- Output: "go get github.com/stretchr/testify/assert"
+
+Provide the corrected command:`, cmd, output)
+}
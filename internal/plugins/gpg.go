@@ -0,0 +1,190 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// GPGPlugin handles GnuPG errors, including the ones that surface through apt
+// (missing or expired repository keys) and git (commit signing)
+type GPGPlugin struct{}
+
+var (
+	gpgMissingKey = regexp.MustCompile(`(?:NO_PUBKEY|EXPKEYSIG|KEYEXPIRED) ([0-9A-F]{8,40})`)
+	gpgExpiredKey = regexp.MustCompile(`key (?:0x)?([0-9A-Fa-f]{8,40})\b[^\n]*expired`)
+)
+
+// gpgKeyserver is queried for repository signing keys
+const gpgKeyserver = "hkps://keyserver.ubuntu.com"
+
+// gpgLookupTimeout bounds listing the user's secret keys
+const gpgLookupTimeout = 2 * time.Second
+
+func (p *GPGPlugin) Name() string {
+	return "gpg"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *GPGPlugin) Match(cmd string, output string) bool {
+	// These only come from gpg, whatever command ran it
+	gpgOutputErrors := []string{
+		"no_pubkey",
+		"expkeysig",
+		"inappropriate ioctl for device",
+		"gpg failed to sign the data",
+	}
+	if containsAny(output, gpgOutputErrors) {
+		return true
+	}
+
+	if !isCommand(cmd, "gpg", "gpg2") {
+		return false
+	}
+
+	gpgErrors := []string{
+		"no public key",
+		"secret key not available",
+		"no secret key",
+		"expired",
+		"unusable public key",
+	}
+
+	return containsAny(output, gpgErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *GPGPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues. Each is a single
+// command, as fixes run without a shell
+func (p *GPGPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// pinentry cannot prompt without knowing the terminal: point the agent
+	// at this one
+	if strings.Contains(outputLower, "inappropriate ioctl for device") {
+		return "gpg-connect-agent updatestartuptty /bye"
+	}
+
+	// apt repository keys: fetch them straight into a keyring apt trusts
+	if m := gpgMissingKey.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("sudo gpg --no-default-keyring --keyring /etc/apt/trusted.gpg.d/%s.gpg --keyserver %s --recv-keys %s",
+			m[1], gpgKeyserver, m[1])
+	}
+
+	if strings.Contains(outputLower, "secret key not available") || strings.Contains(outputLower, "no secret key") {
+		// git signs with user.signingkey; point it at a key that exists
+		if isCommand(cmd, "git") {
+			if key := p.secretKey(); key != "" {
+				return "git config --global user.signingkey " + key
+			}
+		}
+		return "gpg --list-secret-keys --keyid-format=long"
+	}
+
+	if m := gpgExpiredKey.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("gpg --keyserver %s --refresh-keys %s", gpgKeyserver, m[1])
+	}
+
+	if strings.Contains(outputLower, "no public key") {
+		if key := p.signingKey(output); key != "" {
+			return fmt.Sprintf("gpg --keyserver %s --recv-keys %s", gpgKeyserver, key)
+		}
+	}
+
+	return ""
+}
+
+// secretKey returns the id of the first secret key in the user's keyring,
+// or "" when there is none
+func (p *GPGPlugin) secretKey() string {
+	ctx, cancel := context.WithTimeout(context.Background(), gpgLookupTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "gpg", "--list-secret-keys", "--with-colons").Output()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("gpg --list-secret-keys failed: %v", err))
+		return ""
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if fields := strings.Split(line, ":"); fields[0] == "sec" && len(fields) > 4 {
+			return fields[4]
+		}
+	}
+	return ""
+}
+
+// signingKey extracts the key id from gpg --verify output
+// ("using RSA key 0123ABCD..." or "key ID 0123ABCD")
+func (p *GPGPlugin) signingKey(output string) string {
+	for _, marker := range []string{" key ", "key ID "} {
+		idx := strings.LastIndex(output, marker)
+		if idx < 0 {
+			continue
+		}
+		fields := strings.Fields(output[idx+len(marker):])
+		if len(fields) > 0 && gpgMissingKey.MatchString("NO_PUBKEY "+fields[0]) {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *GPGPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "gpg --list-keys --keyid-format=long"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *GPGPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in GnuPG, apt repository signing keys and git commit signing.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with GnuPG 2
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. It runs without a shell: no &&, pipes, redirections, $(...) or exports
+3. For apt NO_PUBKEY errors, fetch the key into a keyring in /etc/apt/trusted.gpg.d; apt-key is deprecated
+4. "Inappropriate ioctl for device" means pinentry does not know the terminal
+5. Refresh expired keys from a keyserver instead of disabling signature checks
+6. Never suggest --allow-unauthenticated or trusted=yes
+
+EXAMPLES:
+- Input: "sudo apt update" + "NO_PUBKEY 7EA0A9C3F273FCD8"
+- Output: "sudo gpg --no-default-keyring --keyring /etc/apt/trusted.gpg.d/7EA0A9C3F273FCD8.gpg --keyserver hkps://keyserver.ubuntu.com --recv-keys 7EA0A9C3F273FCD8"
+
+- Input: "git commit -S -m 'release'" + "gpg: signing failed: Inappropriate ioctl for device"
+- Output: "gpg-connect-agent updatestartuptty /bye"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,234 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// JavaPlugin handles java and javac errors: classpath problems, class file
+// version mismatches, heap exhaustion and missing JDKs
+type JavaPlugin struct {
+	Dir string // project directory searched for compiled classes; "" means the working directory
+}
+
+var (
+	javaMainClass      = regexp.MustCompile(`Could not find or load main class (\S+)`)
+	javaClassVersion   = regexp.MustCompile(`class file version (\d+)\.\d+\), this version of the Java Runtime only recognizes class file versions up to (\d+)`)
+	javaReleaseVersion = regexp.MustCompile(`(?:invalid target release|release version|invalid source release):? (\d+)`)
+	javaXmx            = regexp.MustCompile(`^-Xmx(\d+)([kKmMgG]?)$`)
+	javaAptHint        = regexp.MustCompile(`sudo apt install (openjdk-\S+)`)
+)
+
+// javaClassDirs are the build output directories of Maven and Gradle
+var javaClassDirs = []string{"target/classes", "build/classes/java/main", "out/production", "bin"}
+
+func (p *JavaPlugin) Name() string {
+	return "java"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *JavaPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "java", "javac") {
+		return false
+	}
+
+	javaErrors := []string{
+		"could not find or load main class",
+		"unsupportedclassversionerror",
+		"invalid target release",
+		"invalid source release",
+		"not supported",
+		"outofmemoryerror",
+		"command not found",
+		"not found, but can be installed",
+	}
+
+	return containsAny(output, javaErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *JavaPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *JavaPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	if strings.Contains(outputLower, "command not found") || strings.Contains(outputLower, "not found, but can be installed") {
+		if m := javaAptHint.FindStringSubmatch(output); m != nil {
+			return fmt.Sprintf("sudo apt install %s && %s", m[1], cmd)
+		}
+		return "sudo apt install default-jdk && " + cmd
+	}
+
+	// Class file version = Java version + 44
+	if m := javaClassVersion.FindStringSubmatch(output); m != nil {
+		if version, err := strconv.Atoi(m[1]); err == nil {
+			return fmt.Sprintf("sudo apt install openjdk-%d-jdk && %s", version-44, cmd)
+		}
+	}
+	if m := javaReleaseVersion.FindStringSubmatch(output); m != nil && isCommand(cmd, "javac") {
+		return fmt.Sprintf("sudo apt install openjdk-%s-jdk && %s", m[1], cmd)
+	}
+
+	if strings.Contains(output, "OutOfMemoryError") {
+		if strings.Contains(output, "Metaspace") {
+			return p.withJVMOption(cmd, "-XX:MaxMetaspaceSize=", "-XX:MaxMetaspaceSize=512m")
+		}
+		return p.withHeap(cmd)
+	}
+
+	if m := javaMainClass.FindStringSubmatch(output); m != nil {
+		return p.fixMainClass(cmd, m[1])
+	}
+
+	return ""
+}
+
+// fixMainClass corrects how the main class is named or where it is looked up
+func (p *JavaPlugin) fixMainClass(cmd, class string) string {
+	// `java Main.class` or `java com/example/Main`: java wants a class name
+	if strings.HasSuffix(class, ".class") || strings.Contains(class, "/") {
+		name := strings.TrimSuffix(class, ".class")
+		dir, file := filepath.Split(name)
+		if dir != "" && !p.isPackageDir(dir) {
+			return p.replaceArg(cmd, class, "-cp "+strings.TrimSuffix(dir, "/")+" "+file)
+		}
+		return p.replaceArg(cmd, class, strings.ReplaceAll(name, "/", "."))
+	}
+
+	// Only a source file exists; compile it first
+	simple := class[strings.LastIndex(class, ".")+1:]
+	if p.exists(simple+".java") && !p.exists(simple+".class") {
+		return fmt.Sprintf("javac %s.java && %s", simple, cmd)
+	}
+
+	// Compiled by Maven or Gradle into a classes directory
+	if !strings.Contains(cmd, "-cp ") && !strings.Contains(cmd, "-classpath ") && !strings.Contains(cmd, "--class-path ") {
+		classFile := strings.ReplaceAll(class, ".", "/") + ".class"
+		for _, dir := range javaClassDirs {
+			if p.exists(filepath.Join(dir, classFile)) {
+				return p.withJVMOption(cmd, "-cp ", "-cp "+dir)
+			}
+		}
+	}
+
+	return ""
+}
+
+// isPackageDir reports whether dir looks like a Java package path (e.g. com/example/)
+func (p *JavaPlugin) isPackageDir(dir string) bool {
+	first := strings.Split(strings.Trim(dir, "/"), "/")[0]
+	switch first {
+	case "com", "org", "net", "io", "dev", "edu":
+		return true
+	}
+	return false
+}
+
+// withHeap doubles an existing -Xmx or sets a 2 GB heap
+func (p *JavaPlugin) withHeap(cmd string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if m := javaXmx.FindStringSubmatch(field); m != nil {
+			size, _ := strconv.Atoi(m[1])
+			fields[i] = fmt.Sprintf("-Xmx%d%s", size*2, m[2])
+			return strings.Join(fields, " ")
+		}
+	}
+	return p.withJVMOption(cmd, "-Xmx", "-Xmx2g")
+}
+
+// withJVMOption inserts option right after java unless an option with prefix is present
+func (p *JavaPlugin) withJVMOption(cmd, prefix, option string) string {
+	fields := strings.Fields(cmd)
+	for _, field := range fields {
+		if strings.HasPrefix(field, prefix) {
+			return ""
+		}
+	}
+	for i, field := range fields {
+		if isCommand(field, "java") {
+			rest := append([]string{option}, fields[i+1:]...)
+			return strings.Join(append(fields[:i+1], rest...), " ")
+		}
+	}
+	return ""
+}
+
+func (p *JavaPlugin) replaceArg(cmd, old, replacement string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if field == old {
+			fields[i] = replacement
+			return strings.Join(fields, " ")
+		}
+	}
+	return ""
+}
+
+func (p *JavaPlugin) exists(name string) bool {
+	dir := p.Dir
+	if dir == "" {
+		dir = "."
+	}
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *JavaPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "java -version && javac -version"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *JavaPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Java developer familiar with the JDK tools, classpaths, Maven and Gradle.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with OpenJDK
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. java takes a fully qualified class name, not a .class file or path; set the classpath with -cp
+3. Class file version minus 44 is the required Java version (61 = Java 17, 65 = Java 21)
+4. For OutOfMemoryError, raise -Xmx (heap) or -XX:MaxMetaspaceSize (metaspace)
+5. Install JDKs with the openjdk-<version>-jdk packages
+
+EXAMPLES:
+- Input: "java Main.class" + "Error: Could not find or load main class Main.class"
+- Output: "java Main"
+
+- Input: "java -jar app.jar" + "UnsupportedClassVersionError: ... (class file version 65.0), this version of the Java Runtime only recognizes class file versions up to 55.0"
+- Output: "sudo apt install openjdk-21-jdk && java -jar app.jar"
+
+Provide the corrected command:`, cmd, output)
+}
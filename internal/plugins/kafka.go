@@ -0,0 +1,213 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// KafkaPlugin handles errors from the Kafka command line tools
+// (kafka-topics, kafka-console-consumer and friends)
+type KafkaPlugin struct{}
+
+var (
+	kafkaUnknownTopic  = regexp.MustCompile(`Topic '?[\w.-]+'? does not exist|=UNKNOWN_TOPIC_OR_PARTITION\}`)
+	kafkaBuildHint     = regexp.MustCompile(`Classpath is empty\. Please build the project first e\.g\. by running '([^']+)'`)
+	kafkaZookeeperFlag = regexp.MustCompile(`--zookeeper[ =](\S+)`)
+)
+
+func (p *KafkaPlugin) Name() string {
+	return "kafka"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *KafkaPlugin) Match(cmd string, output string) bool {
+	if !p.isKafka(cmd) {
+		return false
+	}
+
+	kafkaErrors := []string{
+		"could not be established",
+		"broker may not be available",
+		"unknown_topic_or_partition",
+		"does not exist",
+		"zookeeper is not a recognized option",
+		"java_home",
+		"classpath is empty",
+		"could not find or load main class",
+		"java: not found",
+		"java: command not found",
+	}
+
+	return containsAny(output, kafkaErrors)
+}
+
+// isKafka reports whether cmd runs one of the kafka-* scripts
+func (p *KafkaPlugin) isKafka(cmd string) bool {
+	fields := commandFields(cmd)
+	return len(fields) > 0 && strings.HasPrefix(filepath.Base(fields[0]), "kafka-")
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *KafkaPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues. Each is a single
+// command, as fixes run without a shell
+func (p *KafkaPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// Running from a source checkout
+	if m := kafkaBuildHint.FindStringSubmatch(output); m != nil {
+		return m[1]
+	}
+	if strings.Contains(outputLower, "java: not found") || strings.Contains(outputLower, "java: command not found") ||
+		strings.Contains(outputLower, "no 'java' command") {
+		return "sudo apt install default-jre"
+	}
+	if strings.Contains(outputLower, "java_home") {
+		if home := p.javaHome(); home != "" {
+			return fmt.Sprintf("env JAVA_HOME=%s %s", home, cmd)
+		}
+		return "sudo apt install default-jre"
+	}
+
+	// Kafka 3 removed --zookeeper; the tools talk to the brokers
+	if m := kafkaZookeeperFlag.FindStringSubmatch(cmd); m != nil && strings.Contains(outputLower, "zookeeper") {
+		return strings.Replace(cmd, m[0], "--bootstrap-server "+p.brokerAddress(m[1]), 1)
+	}
+
+	if kafkaUnknownTopic.MatchString(output) {
+		return fmt.Sprintf("%s --bootstrap-server %s --list", p.script(cmd, "topics"), p.bootstrapServer(cmd))
+	}
+
+	if strings.Contains(outputLower, "could not be established") || strings.Contains(outputLower, "broker may not be available") {
+		server := p.bootstrapServer(cmd)
+		host, port, err := net.SplitHostPort(server)
+		if err != nil {
+			return ""
+		}
+		// The ZooKeeper port is a common mix-up
+		if port == "2181" {
+			return strings.Replace(cmd, server, p.brokerAddress(server), 1)
+		}
+		// Check that the broker is listening
+		return fmt.Sprintf("nc -zv %s %s", host, port)
+	}
+
+	return ""
+}
+
+// javaHome returns the installation the java on PATH belongs to, or "" when
+// there is none
+func (p *KafkaPlugin) javaHome() string {
+	java, err := exec.LookPath("java")
+	if err != nil {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(java); err == nil {
+		java = resolved
+	}
+	home := filepath.Dir(filepath.Dir(java))
+	if strings.ContainsAny(home, " \t") {
+		return ""
+	}
+	return home
+}
+
+// brokerAddress turns a ZooKeeper address into the broker on the same host
+func (p *KafkaPlugin) brokerAddress(zookeeper string) string {
+	host := strings.SplitN(strings.Split(zookeeper, ",")[0], "/", 2)[0]
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return net.JoinHostPort(host, "9092")
+}
+
+// bootstrapServer returns the broker address cmd connects to
+func (p *KafkaPlugin) bootstrapServer(cmd string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		for _, flag := range []string{"--bootstrap-server", "--broker-list"} {
+			if field == flag && i+1 < len(fields) {
+				return fields[i+1]
+			}
+			if strings.HasPrefix(field, flag+"=") {
+				return strings.TrimPrefix(field, flag+"=")
+			}
+		}
+	}
+	return "localhost:9092"
+}
+
+// script returns the path of the kafka-<tool> script next to the one cmd ran,
+// keeping its directory and .sh suffix
+func (p *KafkaPlugin) script(cmd, tool string) string {
+	program := commandFields(cmd)[0]
+	name := "kafka-" + tool
+	if strings.HasSuffix(program, ".sh") {
+		name += ".sh"
+	}
+	if dir := filepath.Dir(program); dir != "." || strings.HasPrefix(program, "./") {
+		return dir + "/" + name
+	}
+	return name
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *KafkaPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		// Check the broker connection
+		return "kafka-broker-api-versions.sh --bootstrap-server localhost:9092"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *KafkaPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Apache Kafka administrator familiar with the kafka-* command line tools.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Kafka command line tools talking to a Kafka cluster
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. It runs without a shell: no &&, pipes, redirections, $(...) or exports
+3. Use --bootstrap-server with the broker port (usually 9092), never the ZooKeeper port 2181
+4. --zookeeper was removed in Kafka 3.0; replace it with --bootstrap-server
+5. For unknown topics, list the existing topics with kafka-topics --list
+6. The tools need Java; install a JRE when it is missing
+
+EXAMPLES:
+- Input: "kafka-topics.sh --zookeeper localhost:2181 --list" + "zookeeper is not a recognized option"
+- Output: "kafka-topics.sh --bootstrap-server localhost:9092 --list"
+
+- Input: "kafka-console-consumer.sh --bootstrap-server localhost:9092 --topic ordrs" + "{ordrs=UNKNOWN_TOPIC_OR_PARTITION}"
+- Output: "kafka-topics.sh --bootstrap-server localhost:9092 --list"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,253 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/cache"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// lookupTimeout bounds a package registry lookup (npm, PyPI) so a slow
+// network does not hold up the suggestion
+const lookupTimeout = 3 * time.Second
+
+// lookupTTL is how long registry answers are reused
+const lookupTTL = 24 * time.Hour
+
+// maxLookupSize bounds a registry response
+const maxLookupSize = 16 << 20
+
+var lookupClient = &http.Client{Timeout: lookupTimeout}
+
+// lookupEntry is a cached registry answer
+type lookupEntry struct {
+	Value    json.RawMessage `json:"value"`
+	Fetched  time.Time       `json:"fetched"`
+	Plugin   string          `json:"plugin,omitempty"`
+	Hits     int             `json:"hits,omitempty"`
+	Fetches  int             `json:"fetches,omitempty"`
+	LastUsed time.Time       `json:"last_used,omitempty"`
+}
+
+// lastUsed returns when the entry was last fetched or answered a lookup
+func (e lookupEntry) lastUsed() time.Time {
+	if e.LastUsed.After(e.Fetched) {
+		return e.LastUsed
+	}
+	return e.Fetched
+}
+
+// lookupCachePath returns the file registry answers are cached in, or "" when
+// there is no cache directory
+func lookupCachePath() string {
+	cfg := config.Current()
+	if cfg == nil || cfg.CacheDir == "" {
+		return ""
+	}
+	return filepath.Join(cfg.CacheDir, "lookups.json")
+}
+
+// cachedLookup returns the answer plugin stored under key if it is less than
+// lookupTTL old, and otherwise calls fetch and stores its result
+func cachedLookup[T any](plugin, key string, fetch func(ctx context.Context) (T, error)) (T, error) {
+	path := lookupCachePath()
+	entries := map[string]lookupEntry{}
+	if path != "" {
+		if err := state.ReadJSON(path, &entries); err != nil {
+			logger.Debug(fmt.Sprintf("Failed to read lookup cache: %v", err))
+		}
+		if entry, ok := entries[key]; ok && time.Since(entry.Fetched) < lookupTTL {
+			var value T
+			if err := json.Unmarshal(entry.Value, &value); err == nil {
+				recordLookupHit(path, key)
+				return value, nil
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+	value, err := fetch(ctx)
+	if err != nil || path == "" {
+		return value, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return value, nil
+	}
+	err = state.UpdateJSON(path, &entries, func() error {
+		now := time.Now()
+		previous := entries[key]
+		for k, entry := range entries {
+			if now.Sub(entry.Fetched) >= lookupTTL {
+				delete(entries, k)
+			}
+		}
+		entries[key] = lookupEntry{Value: encoded, Fetched: now, Plugin: plugin, Hits: previous.Hits, Fetches: previous.Fetches + 1}
+		return nil
+	})
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to update lookup cache: %v", err))
+	}
+	return value, nil
+}
+
+// recordLookupHit counts a lookup answered from the cache
+func recordLookupHit(path, key string) {
+	entries := map[string]lookupEntry{}
+	err := state.UpdateJSON(path, &entries, func() error {
+		if entry, ok := entries[key]; ok {
+			entry.Hits++
+			entry.LastUsed = time.Now()
+			entries[key] = entry
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to update lookup cache: %v", err))
+	}
+}
+
+// CachedLookup describes a registry answer in the lookup cache
+type CachedLookup struct {
+	Key      string // registry URL
+	Plugin   string
+	Hits     int
+	Fetched  time.Time
+	LastUsed time.Time
+}
+
+// LookupCacheEntries returns the cached registry answers, most recently
+// used first
+func LookupCacheEntries() ([]CachedLookup, error) {
+	path := lookupCachePath()
+	if path == "" {
+		return nil, nil
+	}
+	entries := map[string]lookupEntry{}
+	if err := state.ReadJSON(path, &entries); err != nil {
+		return nil, err
+	}
+
+	lookups := make([]CachedLookup, 0, len(entries))
+	for key, entry := range entries {
+		lookups = append(lookups, CachedLookup{Key: key, Plugin: entry.Plugin, Hits: entry.Hits, Fetched: entry.Fetched, LastUsed: entry.lastUsed()})
+	}
+	sort.Slice(lookups, func(i, j int) bool {
+		return lookups[i].LastUsed.After(lookups[j].LastUsed)
+	})
+	return lookups, nil
+}
+
+// LookupCacheStats summarizes the package registry lookup cache. Every
+// fetch counts as a lookup that missed
+func LookupCacheStats() (cache.Stats, error) {
+	var stats cache.Stats
+	path := lookupCachePath()
+	if path == "" {
+		return stats, nil
+	}
+	entries := map[string]lookupEntry{}
+	if err := state.ReadJSON(path, &entries); err != nil {
+		return stats, err
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		stats.Size = info.Size()
+	}
+	for _, entry := range entries {
+		stats.Add(entry.Plugin, entry.Fetched)
+		stats.Hits += entry.Hits
+		stats.Lookups += entry.Hits + max(entry.Fetches, 1)
+	}
+	return stats, nil
+}
+
+// ClearLookupCache removes the registry answers selected by filter and
+// returns how many were removed
+func ClearLookupCache(filter cache.Filter) (int, error) {
+	path := lookupCachePath()
+	if path == "" {
+		return 0, nil
+	}
+	removed := 0
+	entries := map[string]lookupEntry{}
+	err := state.UpdateJSON(path, &entries, func() error {
+		now := time.Now()
+		for key, entry := range entries {
+			if filter.Matches(entry.Plugin, entry.lastUsed(), now) {
+				delete(entries, key)
+				removed++
+			}
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// PruneLookupCache drops the least recently used registry answers until the
+// cache file is at most maxSize bytes, and returns how many were dropped
+func PruneLookupCache(maxSize int64) (int, error) {
+	path := lookupCachePath()
+	if path == "" {
+		return 0, nil
+	}
+	removed := 0
+	entries := map[string]lookupEntry{}
+	err := state.UpdateJSON(path, &entries, func() error {
+		keys := make([]string, 0, len(entries))
+		for key := range entries {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return entries[keys[i]].lastUsed().After(entries[keys[j]].lastUsed())
+		})
+		keep := cache.KeepWithin(len(keys), maxSize, func(i int) interface{} {
+			return map[string]lookupEntry{keys[i]: entries[keys[i]]}
+		})
+		for _, key := range keys[keep:] {
+			delete(entries, key)
+		}
+		removed = len(keys) - keep
+		return nil
+	})
+	return removed, err
+}
+
+// getJSON fetches url and decodes it into v. It returns false without an
+// error when the registry answers 404
+func getJSON(ctx context.Context, url string, v interface{}) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := lookupClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxLookupSize)).Decode(v); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", url, err)
+	}
+	return true, nil
+}
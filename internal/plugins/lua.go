@@ -0,0 +1,226 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/fuzzy"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// LuaPlugin runs a Lua script from PLUGINS_DIR. The script defines two global
+// functions:
+//
+//	match(command, output)    true if the plugin handles the error
+//	suggest(command, output)  the corrected command, or nil
+//
+// Only the base, string, table and math libraries are available, plus a
+// logaid table with helpers:
+//
+//	logaid.closest(word, candidates)  closest candidate within typo distance, or nil
+//	logaid.distance(a, b)             edit distance between two strings
+//	logaid.fields(command)            command words, without a leading sudo
+//	logaid.config(key)                configuration value, or nil if unset or secret
+//	logaid.log(message)               debug log message
+//
+// Each command/output pair runs in a fresh state, bounded by PLUGIN_TIMEOUT
+type LuaPlugin struct {
+	Path    string
+	Timeout time.Duration // 0 means defaultExternalTimeout
+
+	proto *lua.FunctionProto
+	cache responseCache
+}
+
+// luaUnsafeGlobals are removed from the base library: they reach the file system
+var luaUnsafeGlobals = []string{"dofile", "loadfile", "load", "loadstring", "require", "module"}
+
+// NewLuaPlugin compiles the script at path
+func NewLuaPlugin(path string, timeout time.Duration) (*LuaPlugin, error) {
+	source, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer source.Close()
+
+	chunk, err := parse.Parse(source, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	proto, err := lua.Compile(chunk, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s: %w", path, err)
+	}
+	return &LuaPlugin{Path: path, Timeout: timeout, proto: proto}, nil
+}
+
+func (p *LuaPlugin) Name() string {
+	return strings.TrimSuffix(filepath.Base(p.Path), ".lua")
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *LuaPlugin) Match(cmd string, output string) bool {
+	resp := p.response(cmd, output)
+	return resp != nil && resp.Match
+}
+
+// Suggest returns the suggestion the script gave for cmd/output
+func (p *LuaPlugin) Suggest(cmd string, output string) string {
+	resp := p.response(cmd, output)
+	if resp == nil || !resp.Match {
+		return ""
+	}
+	return strings.TrimSpace(resp.Suggestion)
+}
+
+// response runs the script, reusing the previous answer for the same input
+func (p *LuaPlugin) response(cmd, output string) *ExternalResponse {
+	return p.cache.get(p.Name(), cmd, output, func() (*ExternalResponse, error) {
+		return p.run(cmd, output)
+	})
+}
+
+// run loads the script into a new state and calls match, then suggest
+func (p *LuaPlugin) run(cmd, output string) (*ExternalResponse, error) {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = defaultExternalTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	L := p.newState()
+	defer L.Close()
+	L.SetContext(ctx)
+
+	L.Push(L.NewFunctionFromProto(p.proto))
+	if err := L.PCall(0, 0, nil); err != nil {
+		return nil, fmt.Errorf("failed to load script: %w", err)
+	}
+
+	matched, err := p.call(L, "match", cmd, output)
+	if err != nil {
+		return nil, err
+	}
+	if !lua.LVAsBool(matched) {
+		return &ExternalResponse{}, nil
+	}
+
+	suggestion, err := p.call(L, "suggest", cmd, output)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion.Type() != lua.LTString {
+		return &ExternalResponse{}, nil
+	}
+	return &ExternalResponse{Match: true, Suggestion: suggestion.String()}, nil
+}
+
+// call invokes the global function name with cmd and output and returns its result
+func (p *LuaPlugin) call(L *lua.LState, name, cmd, output string) (lua.LValue, error) {
+	fn, ok := L.GetGlobal(name).(*lua.LFunction)
+	if !ok {
+		return nil, fmt.Errorf("script does not define %s", name)
+	}
+	if err := L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, lua.LString(cmd), lua.LString(output)); err != nil {
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+	result := L.Get(-1)
+	L.Pop(1)
+	return result, nil
+}
+
+// newState creates a Lua state with the safe standard libraries and the logaid helpers
+func (p *LuaPlugin) newState() *lua.LState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	for _, name := range luaUnsafeGlobals {
+		L.SetGlobal(name, lua.LNil)
+	}
+
+	name := p.Name()
+	// print would write into LogAid's own output
+	L.SetGlobal("print", L.NewFunction(func(L *lua.LState) int {
+		logger.Debug(fmt.Sprintf("Lua plugin %s: %s", name, L.ToString(1)))
+		return 0
+	}))
+	L.SetGlobal("logaid", L.SetFuncs(L.NewTable(), map[string]lua.LGFunction{
+		"closest":  luaClosest,
+		"distance": luaDistance,
+		"fields":   luaFields,
+		"config":   luaConfig(name),
+		"log": func(L *lua.LState) int {
+			logger.Debug(fmt.Sprintf("Lua plugin %s: %s", name, L.CheckString(1)))
+			return 0
+		},
+	}))
+	return L
+}
+
+// luaClosest implements logaid.closest
+func luaClosest(L *lua.LState) int {
+	word := L.CheckString(1)
+	var candidates []string
+	L.CheckTable(2).ForEach(func(_, value lua.LValue) {
+		if s, ok := value.(lua.LString); ok {
+			candidates = append(candidates, string(s))
+		}
+	})
+	if match := closestMatch(word, candidates); match != "" {
+		L.Push(lua.LString(match))
+	} else {
+		L.Push(lua.LNil)
+	}
+	return 1
+}
+
+// luaDistance implements logaid.distance
+func luaDistance(L *lua.LState) int {
+	L.Push(lua.LNumber(fuzzy.Distance(L.CheckString(1), L.CheckString(2))))
+	return 1
+}
+
+// luaFields implements logaid.fields
+func luaFields(L *lua.LState) int {
+	fields := L.NewTable()
+	for _, field := range commandFields(L.CheckString(1)) {
+		fields.Append(lua.LString(field))
+	}
+	L.Push(fields)
+	return 1
+}
+
+// luaConfig implements logaid.config for the plugin called name, which
+// reads only its own PLUGIN_<NAME>_<KEY> settings
+func luaConfig(name string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		key := L.CheckString(1)
+		if cfg := config.Current(); cfg != nil {
+			if value, ok := cfg.PluginSetting(name, key); ok {
+				L.Push(lua.LString(value))
+				return 1
+			}
+		}
+		L.Push(lua.LNil)
+		return 1
+	}
+}
@@ -0,0 +1,288 @@
+package plugins
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// MakePlugin handles make errors: unknown targets, Makefile syntax and missing tools
+type MakePlugin struct {
+	Dir string // directory holding the Makefile; "" means the working directory
+}
+
+var (
+	makeNoRule           = regexp.MustCompile(`No rule to make target '([^']+)'(, needed by)?`)
+	makeMissingSeparator = regexp.MustCompile(`(\S+):(\d+): \*\*\* missing separator`)
+	makeToolNotFound     = regexp.MustCompile(`(?m)(?:^|\s)([\w.+-]+): (?:command not found|not found|No such file or directory)`)
+)
+
+// makeToolPackages maps build tools that recipes commonly call to the
+// Debian/Ubuntu package providing them
+var makeToolPackages = map[string]string{
+	"make":       "build-essential",
+	"cc":         "build-essential",
+	"gcc":        "build-essential",
+	"g++":        "build-essential",
+	"c++":        "build-essential",
+	"clang":      "clang",
+	"cmake":      "cmake",
+	"ninja":      "ninja-build",
+	"pkg-config": "pkg-config",
+	"autoconf":   "autoconf",
+	"automake":   "automake",
+	"autoreconf": "autoconf",
+	"libtool":    "libtool",
+	"libtoolize": "libtool",
+	"bison":      "bison",
+	"yacc":       "bison",
+	"flex":       "flex",
+	"lex":        "flex",
+	"m4":         "m4",
+	"protoc":     "protobuf-compiler",
+	"python":     "python-is-python3",
+	"python3":    "python3",
+	"go":         "golang-go",
+	"cargo":      "cargo",
+	"node":       "nodejs",
+	"npm":        "npm",
+	"zip":        "zip",
+	"unzip":      "unzip",
+	"curl":       "curl",
+	"wget":       "wget",
+	"git":        "git",
+	"docker":     "docker.io",
+	"jq":         "jq",
+}
+
+func (p *MakePlugin) Name() string {
+	return "make"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *MakePlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "make", "gmake") {
+		return false
+	}
+
+	makeErrors := []string{
+		"no rule to make target",
+		"missing separator",
+		"command not found",
+		": not found",
+		"no targets specified and no makefile found",
+		"recipe for target",
+		"error 127",
+	}
+
+	return containsAny(output, makeErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *MakePlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues. Each is a single
+// command, as fixes run without a shell; the next step of a longer fix is
+// suggested when make fails again
+func (p *MakePlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// Recipes must be indented with a tab, not spaces. The sed script has no
+	// spaces, so it stays one argument without quotes
+	if m := makeMissingSeparator.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf(`sed -i %ss/^\s\+/\t/ %s`, m[2], m[1])
+	}
+
+	if m := makeNoRule.FindStringSubmatch(output); m != nil {
+		// "needed by" means a prerequisite file is missing, not a typo
+		if m[2] != "" {
+			return ""
+		}
+		if target := closestMatch(m[1], p.targets(cmd)); target != "" && target != m[1] {
+			return p.replaceTarget(cmd, m[1], target)
+		}
+		return ""
+	}
+
+	if strings.Contains(outputLower, "no targets specified and no makefile found") {
+		if p.exists("CMakeLists.txt") {
+			if p.exists(filepath.Join("build", "CMakeCache.txt")) {
+				return "cmake --build build"
+			}
+			return "cmake -B build"
+		}
+		if p.exists("configure") {
+			return "./configure"
+		}
+		if p.exists("configure.ac") {
+			return "autoreconf -fi"
+		}
+		return ""
+	}
+
+	// make itself, or a tool called from a recipe, is not installed
+	for _, m := range makeToolNotFound.FindAllStringSubmatch(output, -1) {
+		if pkg, ok := makeToolPackages[m[1]]; ok {
+			return "sudo apt install " + pkg
+		}
+	}
+
+	return ""
+}
+
+// replaceTarget swaps a misspelled target in cmd
+func (p *MakePlugin) replaceTarget(cmd, wrong, target string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if field == wrong {
+			fields[i] = target
+			return strings.Join(fields, " ")
+		}
+	}
+	return cmd
+}
+
+// makefileDir returns the directory make runs in, honouring -C
+func (p *MakePlugin) makefileDir(cmd string) string {
+	dir := p.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	fields := commandFields(cmd)
+	for i, field := range fields {
+		if field == "-C" && i+1 < len(fields) {
+			return filepath.Join(dir, fields[i+1])
+		}
+		if strings.HasPrefix(field, "-C") && len(field) > 2 {
+			return filepath.Join(dir, field[2:])
+		}
+	}
+	return dir
+}
+
+// makefilePath returns the Makefile make reads, honouring -f
+func (p *MakePlugin) makefilePath(cmd string) string {
+	dir := p.makefileDir(cmd)
+
+	fields := commandFields(cmd)
+	for i, field := range fields {
+		if (field == "-f" || field == "--file") && i+1 < len(fields) {
+			return filepath.Join(dir, fields[i+1])
+		}
+	}
+
+	for _, name := range []string{"GNUmakefile", "makefile", "Makefile"} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func (p *MakePlugin) exists(name string) bool {
+	dir := p.Dir
+	if dir == "" {
+		dir = "."
+	}
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// targets returns the explicit targets defined in the Makefile
+func (p *MakePlugin) targets(cmd string) []string {
+	path := p.makefilePath(cmd)
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var targets []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '\t' || line[0] == ' ' || line[0] == '#' {
+			continue
+		}
+
+		colon := strings.Index(line, ":")
+		// Skip variable assignments (:=, ::=) and lines without a rule
+		if colon <= 0 || strings.HasPrefix(line[colon:], ":=") || strings.HasPrefix(line[colon:], "::=") ||
+			strings.ContainsAny(line[:colon], "=$") {
+			continue
+		}
+
+		for _, target := range strings.Fields(line[:colon]) {
+			if strings.HasPrefix(target, ".") || strings.Contains(target, "%") || seen[target] {
+				continue
+			}
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *MakePlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		return ""
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *MakePlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in GNU make and C/C++ build systems.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with GNU make
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. It runs without a shell: one command, no &&, pipes, redirections or comments
+3. For misspelled targets, suggest the closest existing target
+4. For missing tools called by recipes, install the package that provides them
+5. For missing prerequisite files, suggest the command that generates them
+6. Recipe lines must start with a tab character
+
+EXAMPLES:
+- Input: "make biuld" + "make: *** No rule to make target 'biuld'.  Stop."
+- Output: "make build"
+
+- Input: "make" + "/bin/sh: 1: cmake: not found"
+- Output: "sudo apt install cmake"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,364 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// NotFoundPlugin handles "command not found" for any program: misspelled
+// commands are corrected against the executables on $PATH, missing ones are
+// installed from the package the distro's command-not-found database names
+type NotFoundPlugin struct {
+	Path string // directories searched for executables; "" means $PATH
+
+	mu       sync.Mutex
+	packages map[string]string // install commands found per missing command
+}
+
+// commandDatabaseTimeout bounds a lookup in the distro's package database
+const commandDatabaseTimeout = 3 * time.Second
+
+// commandDatabase is a distro tool that knows which package provides a
+// command, using the package index on disk
+type commandDatabase struct {
+	tool    string
+	args    func(name string) []string
+	pattern *regexp.Regexp // first group is the package
+	install string         // install command for the package
+}
+
+// commandDatabases are asked in order until one names a package
+var commandDatabases = []commandDatabase{
+	{
+		// Ubuntu's handler, which the shell only runs interactively
+		tool:    "command-not-found",
+		args:    func(name string) []string { return []string{"--ignore-installed", "--no-failure-msg", name} },
+		pattern: regexp.MustCompile(`sudo apt install ([\w.+:-]+)`),
+		install: "sudo apt install %s",
+	},
+	{
+		// Debian/Ubuntu Contents indexes
+		tool: "apt-file",
+		args: func(name string) []string {
+			return []string{"search", "--regexp", "^/(usr/)?s?bin/" + regexp.QuoteMeta(name) + "$"}
+		},
+		pattern: regexp.MustCompile(`(?m)^([\w.+-]+): /`),
+		install: "sudo apt install %s",
+	},
+	{
+		// Fedora, from the cached repository metadata only
+		tool:    "dnf",
+		args:    func(name string) []string { return []string{"--cacheonly", "--quiet", "provides", "/usr/bin/" + name} },
+		pattern: regexp.MustCompile(`(?m)^([\w.+-]+?)-\d[^\s-]*-[^\s-]+ +: `),
+		install: "sudo dnf install %s",
+	},
+	{
+		// Arch
+		tool:    "pkgfile",
+		args:    func(name string) []string { return []string{"--binaries", name} },
+		pattern: regexp.MustCompile(`(?m)^\w+/([\w.+-]+)`),
+		install: "sudo pacman -S %s",
+	},
+}
+
+// notFoundPatterns extract the missing command from each shell's message
+var notFoundPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`Command '([^']+)' not found`),                   // Ubuntu command-not-found
+	regexp.MustCompile(`command not found: (\S+)`),                      // zsh
+	regexp.MustCompile(`Unknown command:? '?([^'\s]+)`),                 // fish
+	regexp.MustCompile(`(?m)(?:^|\s)([^\s:]+): (?:command )?not found`), // bash, dash
+}
+
+var (
+	notFoundInstall    = regexp.MustCompile(`sudo (?:apt|apt-get|snap|dnf|yum|zypper) install [\w.+:-]+`)
+	notFoundPackageKit = regexp.MustCompile(`Install package '([^']+)' to provide command`)
+	notFoundPkgfile    = regexp.MustCompile(`may be found in the following packages:\s+\w+/([\w.+-]+)`)
+	notFoundDidYouMean = regexp.MustCompile(`command '([^']+)' from (?:deb|snap) `)
+)
+
+// notFoundPackages maps commands to the Debian/Ubuntu package providing them,
+// for systems without a command-not-found handler
+var notFoundPackages = map[string]string{
+	"ifconfig":           "net-tools",
+	"netstat":            "net-tools",
+	"route":              "net-tools",
+	"dig":                "dnsutils",
+	"nslookup":           "dnsutils",
+	"ping":               "iputils-ping",
+	"traceroute":         "traceroute",
+	"ip":                 "iproute2",
+	"ss":                 "iproute2",
+	"nc":                 "netcat-openbsd",
+	"telnet":             "telnet",
+	"killall":            "psmisc",
+	"pstree":             "psmisc",
+	"ps":                 "procps",
+	"free":               "procps",
+	"crontab":            "cron",
+	"lsb_release":        "lsb-release",
+	"add-apt-repository": "software-properties-common",
+	"envsubst":           "gettext-base",
+	"sponge":             "moreutils",
+	"mkpasswd":           "whois",
+	"rg":                 "ripgrep",
+	"fd":                 "fd-find",
+	"ag":                 "silversearcher-ag",
+	"http":               "httpie",
+	"convert":            "imagemagick",
+	"7z":                 "p7zip-full",
+	"gcc":                "build-essential",
+	"g++":                "build-essential",
+	"make":               "build-essential",
+	"python":             "python-is-python3",
+	"pip":                "python3-pip",
+	"pip3":               "python3-pip",
+	"node":               "nodejs",
+	"java":               "default-jre",
+	"javac":              "default-jdk",
+	"go":                 "golang-go",
+	"redis-cli":          "redis-tools",
+	"mysql":              "mysql-client",
+	"psql":               "postgresql-client",
+	"docker":             "docker.io",
+	"htop":               "htop",
+	"tree":               "tree",
+	"jq":                 "jq",
+	"curl":               "curl",
+	"wget":               "wget",
+	"unzip":              "unzip",
+	"xclip":              "xclip",
+}
+
+func (p *NotFoundPlugin) Name() string {
+	return "notfound"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *NotFoundPlugin) Match(cmd string, output string) bool {
+	return p.missingCommand(cmd, output) != ""
+}
+
+// missingCommand returns the command the shell could not find, provided it
+// is one of the words of cmd rather than something a script ran
+func (p *NotFoundPlugin) missingCommand(cmd, output string) string {
+	fields := commandFields(cmd)
+	for _, pattern := range notFoundPatterns {
+		m := pattern.FindStringSubmatch(output)
+		if m == nil {
+			continue
+		}
+		for _, field := range fields {
+			if field == m[1] {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
+
+// Score is high when the missing command has a known package or a close
+// installed match, so it beats tool plugins that only saw "not found"
+func (p *NotFoundPlugin) Score(cmd string, output string) float64 {
+	if p.getQuickFix(cmd, output) != "" {
+		return 0.9
+	}
+	return genericScore
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *NotFoundPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *NotFoundPlugin) getQuickFix(cmd string, output string) string {
+	name := p.missingCommand(cmd, output)
+	if name == "" {
+		return ""
+	}
+
+	// The distro's handler already looked the command up
+	if m := notFoundInstall.FindString(output); m != "" {
+		return m + " && " + cmd
+	}
+	if m := notFoundPackageKit.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("sudo dnf install %s && %s", m[1], cmd)
+	}
+	if m := notFoundPkgfile.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("sudo pacman -S %s && %s", m[1], cmd)
+	}
+
+	// Known packages come before spelling: htop is not a typo of top
+	if install := p.providingPackage(name); install != "" {
+		return install + " && " + cmd
+	}
+	if pkg, ok := notFoundPackages[name]; ok {
+		return fmt.Sprintf("sudo apt install %s && %s", pkg, cmd)
+	}
+
+	executables := p.executables()
+	if fixed := closestMatch(name, executables); fixed != "" && fixed != name {
+		return p.replaceCommand(cmd, name, fixed)
+	}
+	for _, m := range notFoundDidYouMean.FindAllStringSubmatch(output, -1) {
+		for _, executable := range executables {
+			if executable == m[1] {
+				return p.replaceCommand(cmd, name, m[1])
+			}
+		}
+	}
+
+	return ""
+}
+
+// providingPackage returns the command installing the package that provides
+// name according to the distro's package database, or "" if no database
+// knows it. Answers are remembered, since Score and Suggest both ask
+func (p *NotFoundPlugin) providingPackage(name string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if install, ok := p.packages[name]; ok {
+		return install
+	}
+
+	install := ""
+	for _, db := range commandDatabases {
+		tool := p.lookPath(db.tool)
+		if tool == "" {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), commandDatabaseTimeout)
+		// Some tools exit non-zero even when they print an answer
+		out, err := exec.CommandContext(ctx, tool, db.args(name)...).CombinedOutput()
+		cancel()
+		if m := db.pattern.FindStringSubmatch(string(out)); m != nil {
+			install = fmt.Sprintf(db.install, m[1])
+			break
+		}
+		if err != nil {
+			logger.Debug(fmt.Sprintf("%s lookup for %s failed: %v", db.tool, name, err))
+		}
+	}
+
+	if p.packages == nil {
+		p.packages = make(map[string]string)
+	}
+	p.packages[name] = install
+	return install
+}
+
+// lookPath finds a tool on the search path. On the real $PATH it also looks
+// in /usr/lib, where Ubuntu installs command-not-found
+func (p *NotFoundPlugin) lookPath(tool string) string {
+	path := p.Path
+	if path == "" {
+		path = os.Getenv("PATH") + string(os.PathListSeparator) + "/usr/lib"
+	}
+	for _, dir := range filepath.SplitList(path) {
+		candidate := filepath.Join(dir, tool)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// executables lists the programs on the search path, earlier directories first
+func (p *NotFoundPlugin) executables() []string {
+	path := p.Path
+	if path == "" {
+		path = os.Getenv("PATH")
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(path) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || seen[entry.Name()] {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || info.Mode()&0111 == 0 {
+				continue
+			}
+			seen[entry.Name()] = true
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// replaceCommand swaps the misspelled word in cmd for the correct command
+func (p *NotFoundPlugin) replaceCommand(cmd, name, fixed string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if field == name {
+			fields[i] = fixed
+			return strings.Join(fields, " ")
+		}
+	}
+	return ""
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *NotFoundPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "apt-cache search <command> # find the package providing a command"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *NotFoundPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Linux administrator familiar with shells, $PATH and distribution packages.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux (Debian/Ubuntu, Fedora or Arch)
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. If the command is misspelled, correct the spelling and keep the arguments
+3. If the command is not installed, install the package providing it, then run the command
+4. Package names often differ from commands (dig is in dnsutils, ifconfig in net-tools)
+5. Use the package manager the error message mentions
+
+EXAMPLES:
+- Input: "gti status" + "bash: gti: command not found"
+- Output: "git status"
+
+- Input: "dig example.com" + "bash: dig: command not found"
+- Output: "sudo apt install dnsutils && dig example.com"
+
+Provide the corrected command:`, cmd, output)
+}
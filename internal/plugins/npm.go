@@ -3,13 +3,23 @@ package plugins
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/configedit"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
 )
 
 // NpmPlugin handles NPM command errors with AI-powered suggestions
-type NpmPlugin struct{}
+type NpmPlugin struct {
+	Registry string // npm registry URL, https://registry.npmjs.org if empty
+}
+
+// npmMissingPackage finds the package name in npm's 404 errors
+var npmMissingPackage = regexp.MustCompile(`404 Not Found - GET https?://\S+?/(@[^/\s]+/[^/\s]+|[^/@\s]+)(?:\s|$)|'(@?[^@'\s]+)@[^']*' is not in (?:this|the npm) registry`)
 
 func (p *NpmPlugin) Name() string {
 	return "npm"
@@ -57,6 +67,21 @@ func (p *NpmPlugin) Suggest(cmd string, output string) string {
 	return p.getAISuggestion(cmd, output)
 }
 
+// SuggestConfig proposes an ~/.npmrc prefix so global installs no longer need sudo
+func (p *NpmPlugin) SuggestConfig(cmd string, output string) *configedit.Edit {
+	outputLower := strings.ToLower(output)
+	if !strings.Contains(outputLower, "eacces") || !strings.Contains(cmd, "-g") || strings.Contains(cmd, "sudo") {
+		return nil
+	}
+
+	return &configedit.Edit{
+		Path:        "~/.npmrc",
+		Key:         "prefix",
+		Value:       "${HOME}/.npm-global",
+		Description: "Install global packages into ~/.npm-global instead of using sudo (add ~/.npm-global/bin to your PATH)",
+	}
+}
+
 // getQuickFix provides immediate fixes for common issues
 func (p *NpmPlugin) getQuickFix(cmd string, output string) string {
 	outputLower := strings.ToLower(output)
@@ -138,6 +163,7 @@ func (p *NpmPlugin) correctNpmCommand(cmd string) string {
 		"chche":     "cache",
 	}
 
+	corrections = mergeCorrections(p.Name(), correctionCommands, corrections)
 	parts := strings.Fields(cmd)
 	if len(parts) >= 2 {
 		command := parts[1]
@@ -150,85 +176,87 @@ func (p *NpmPlugin) correctNpmCommand(cmd string) string {
 	return cmd
 }
 
+// npmPackageCorrections maps common npm package name typos, shared with the
+// other JavaScript package managers
+var npmPackageCorrections = map[string]string{
+	// Popular packages with common typos
+	"expres":       "express",
+	"exprees":      "express",
+	"expresss":     "express",
+	"lodas":        "lodash",
+	"lodsh":        "lodash",
+	"lodassh":      "lodash",
+	"reac":         "react",
+	"react":        "react",
+	"reactt":       "react",
+	"axio":         "axios",
+	"axois":        "axios",
+	"axioss":       "axios",
+	"momen":        "moment",
+	"momnet":       "moment",
+	"momentt":      "moment",
+	"nod-fetch":    "node-fetch",
+	"node-fech":    "node-fetch",
+	"nodefetch":    "node-fetch",
+	"cheerio":      "cheerio",
+	"cherio":       "cheerio",
+	"cheeio":       "cheerio",
+	"socket.i":     "socket.io",
+	"socketio":     "socket.io",
+	"socket-io":    "socket.io",
+	"uuid":         "uuid",
+	"uui":          "uuid",
+	"uuuid":        "uuid",
+	"bcryp":        "bcrypt",
+	"bcrypt":       "bcrypt",
+	"bcryptjs":     "bcryptjs",
+	"jsonwebtoken": "jsonwebtoken",
+	"jwt":          "jsonwebtoken",
+	"mongoose":     "mongoose",
+	"mongose":      "mongoose",
+	"mungoose":     "mongoose",
+	"sequelize":    "sequelize",
+	"sequlize":     "sequelize",
+	"sequeize":     "sequelize",
+	"cors":         "cors",
+	"cor":          "cors",
+	"corss":        "cors",
+	"helmet":       "helmet",
+	"helmt":        "helmet",
+	"helnet":       "helmet",
+	"morgan":       "morgan",
+	"morga":        "morgan",
+	"morganr":      "morgan",
+	"nodemon":      "nodemon",
+	"nodmon":       "nodemon",
+	"nodemn":       "nodemon",
+	"pm2":          "pm2",
+	"pm":           "pm2",
+	"dotenv":       "dotenv",
+	"dotev":        "dotenv",
+	"dontenv":      "dotenv",
+	"chalk":        "chalk",
+	"chlk":         "chalk",
+	"chalck":       "chalk",
+	"commander":    "commander",
+	"comander":     "commander",
+	"comandr":      "commander",
+	"inquirer":     "inquirer",
+	"inquierer":    "inquirer",
+	"inquirr":      "inquirer",
+	"fs-extra":     "fs-extra",
+	"fs-ext":       "fs-extra",
+	"fsextra":      "fs-extra",
+	"glob":         "glob",
+	"globb":        "glob",
+	"globo":        "glob",
+	"rimraf":       "rimraf",
+	"rimaf":        "rimraf",
+	"rmraf":        "rimraf",
+}
+
 // correctPackageName fixes common package name typos
 func (p *NpmPlugin) correctPackageName(cmd string, output string) string {
-	packageCorrections := map[string]string{
-		// Popular packages with common typos
-		"expres":       "express",
-		"exprees":      "express",
-		"expresss":     "express",
-		"lodas":        "lodash",
-		"lodsh":        "lodash",
-		"lodassh":      "lodash",
-		"reac":         "react",
-		"react":        "react",
-		"reactt":       "react",
-		"axio":         "axios",
-		"axois":        "axios",
-		"axioss":       "axios",
-		"momen":        "moment",
-		"momnet":       "moment",
-		"momentt":      "moment",
-		"nod-fetch":    "node-fetch",
-		"node-fech":    "node-fetch",
-		"nodefetch":    "node-fetch",
-		"cheerio":      "cheerio",
-		"cherio":       "cheerio",
-		"cheeio":       "cheerio",
-		"socket.i":     "socket.io",
-		"socketio":     "socket.io",
-		"socket-io":    "socket.io",
-		"uuid":         "uuid",
-		"uui":          "uuid",
-		"uuuid":        "uuid",
-		"bcryp":        "bcrypt",
-		"bcrypt":       "bcrypt",
-		"bcryptjs":     "bcryptjs",
-		"jsonwebtoken": "jsonwebtoken",
-		"jwt":          "jsonwebtoken",
-		"mongoose":     "mongoose",
-		"mongose":      "mongoose",
-		"mungoose":     "mongoose",
-		"sequelize":    "sequelize",
-		"sequlize":     "sequelize",
-		"sequeize":     "sequelize",
-		"cors":         "cors",
-		"cor":          "cors",
-		"corss":        "cors",
-		"helmet":       "helmet",
-		"helmt":        "helmet",
-		"helnet":       "helmet",
-		"morgan":       "morgan",
-		"morga":        "morgan",
-		"morganr":      "morgan",
-		"nodemon":      "nodemon",
-		"nodmon":       "nodemon",
-		"nodemn":       "nodemon",
-		"pm2":          "pm2",
-		"pm":           "pm2",
-		"dotenv":       "dotenv",
-		"dotev":        "dotenv",
-		"dontenv":      "dotenv",
-		"chalk":        "chalk",
-		"chlk":         "chalk",
-		"chalck":       "chalk",
-		"commander":    "commander",
-		"comander":     "commander",
-		"comandr":      "commander",
-		"inquirer":     "inquirer",
-		"inquierer":    "inquirer",
-		"inquirr":      "inquirer",
-		"fs-extra":     "fs-extra",
-		"fs-ext":       "fs-extra",
-		"fsextra":      "fs-extra",
-		"glob":         "glob",
-		"globb":        "glob",
-		"globo":        "glob",
-		"rimraf":       "rimraf",
-		"rimaf":        "rimraf",
-		"rmraf":        "rimraf",
-	}
-
 	// Try to extract package name and correct it
 	parts := strings.Fields(cmd)
 	for i, part := range parts {
@@ -237,7 +265,22 @@ func (p *NpmPlugin) correctPackageName(cmd string, output string) string {
 				packageName := parts[i+1]
 				// Remove flags and get clean package name
 				cleanPackage := strings.Split(packageName, "@")[0]
-				if correction, exists := packageCorrections[cleanPackage]; exists {
+				if m := npmMissingPackage.FindStringSubmatch(output); m != nil {
+					// The error names the package when several are installed
+					missing := m[1] + m[2]
+					for j := i + 1; j < len(parts); j++ {
+						if parts[j] == missing || strings.HasPrefix(parts[j], missing+"@") {
+							i, packageName, cleanPackage = j-1, parts[j], missing
+							break
+						}
+					}
+				}
+
+				correction := mergeCorrections(p.Name(), correctionPackages, npmPackageCorrections)[cleanPackage]
+				if p.suggestAlternatives() {
+					correction = p.registryCorrection(cleanPackage, correction)
+				}
+				if correction != "" {
 					parts[i+1] = strings.Replace(packageName, cleanPackage, correction, 1)
 					return strings.Join(parts, " ")
 				}
@@ -248,6 +291,79 @@ func (p *NpmPlugin) correctPackageName(cmd string, output string) string {
 	return cmd
 }
 
+// suggestAlternatives reports whether NPM_SUGGEST_ALTERNATIVES allows
+// registry lookups
+func (p *NpmPlugin) suggestAlternatives() bool {
+	cfg := config.Current()
+	return cfg != nil && cfg.NPMSuggestAlternatives
+}
+
+// registryCorrection checks a correction from the typo map against the npm
+// registry and, when there is none or it does not exist, returns the best
+// search hit for name. Without network access the typo map answer stands
+func (p *NpmPlugin) registryCorrection(name, correction string) string {
+	if correction != "" {
+		exists, err := p.packageExists(correction)
+		if err != nil || exists {
+			return correction
+		}
+	}
+
+	hits, err := p.searchPackages(name)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("npm registry search failed: %v", err))
+		return ""
+	}
+	if match := closestMatch(name, hits); match != "" {
+		return match
+	}
+	if len(hits) > 0 {
+		return hits[0]
+	}
+	return ""
+}
+
+// registry returns the npm registry URL without a trailing slash
+func (p *NpmPlugin) registry() string {
+	if p.Registry != "" {
+		return strings.TrimSuffix(p.Registry, "/")
+	}
+	return "https://registry.npmjs.org"
+}
+
+// packageExists asks the registry whether a package is published
+func (p *NpmPlugin) packageExists(name string) (bool, error) {
+	location := p.registry() + "/" + strings.Replace(url.PathEscape(name), "%40", "@", 1) + "/latest"
+	return cachedLookup("npm", location, func(ctx context.Context) (bool, error) {
+		var latest struct {
+			Name string `json:"name"`
+		}
+		return getJSON(ctx, location, &latest)
+	})
+}
+
+// searchPackages returns the names of the top registry search hits for text
+func (p *NpmPlugin) searchPackages(text string) ([]string, error) {
+	location := p.registry() + "/-/v1/search?size=5&text=" + url.QueryEscape(text)
+	return cachedLookup("npm", location, func(ctx context.Context) ([]string, error) {
+		var result struct {
+			Objects []struct {
+				Package struct {
+					Name string `json:"name"`
+				} `json:"package"`
+			} `json:"objects"`
+		}
+		if _, err := getJSON(ctx, location, &result); err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, object := range result.Objects {
+			names = append(names, object.Package.Name)
+		}
+		return names, nil
+	})
+}
+
 // suggestScriptCommand suggests npm run scripts
 func (p *NpmPlugin) suggestScriptCommand(cmd string, output string) string {
 	// Common script names
@@ -275,10 +391,10 @@ func (p *NpmPlugin) suggestScriptCommand(cmd string, output string) string {
 
 // getAISuggestion uses AI to generate intelligent suggestions
 func (p *NpmPlugin) getAISuggestion(cmd string, output string) string {
-	prompt := p.buildAIPrompt(cmd, output)
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
 
 	ctx := context.Background()
-	suggestion, err := ai.GetSuggestion(ctx, prompt)
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
 	if err != nil {
 		// Fallback to generic suggestion
 		return "npm --help # Check the correct NPM command syntax"
@@ -0,0 +1,190 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// PacmanPlugin handles pacman and AUR helper (yay, paru) errors on Arch Linux
+type PacmanPlugin struct{}
+
+var pacmanTargetNotFound = regexp.MustCompile(`target not found: (\S+)`)
+
+func (p *PacmanPlugin) Name() string {
+	return "pacman"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *PacmanPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "pacman", "yay", "paru") {
+		return false
+	}
+
+	pacmanErrors := []string{
+		"target not found",
+		"unable to lock database",
+		"you cannot perform this operation unless you are root",
+		"avoid running yay as root",
+		"running paru as root",
+		"invalid or corrupted package",
+		"unknown trust",
+		"could not be looked up remotely",
+		"failed retrieving file",
+		"could not satisfy dependencies",
+		"failed to commit transaction",
+		"failed to synchronize all databases",
+	}
+
+	return containsAny(output, pacmanErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *PacmanPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *PacmanPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+	helper := isCommand(cmd, "yay", "paru")
+
+	// AUR helpers build as the user and escalate themselves
+	if strings.Contains(outputLower, "avoid running yay as root") || strings.Contains(outputLower, "running paru as root") {
+		return strings.Join(commandFields(cmd), " ")
+	}
+
+	if strings.Contains(outputLower, "you cannot perform this operation unless you are root") && !strings.HasPrefix(cmd, "sudo ") {
+		return "sudo " + cmd
+	}
+
+	// A stale db.lck is left behind when pacman is killed; only remove it
+	// when no pacman process is running
+	if strings.Contains(outputLower, "unable to lock database") {
+		return "! pgrep -x pacman && sudo rm -f /var/lib/pacman/db.lck && " + cmd
+	}
+
+	// Outdated archlinux-keyring is the usual cause of signature errors
+	if strings.Contains(outputLower, "invalid or corrupted package") || strings.Contains(outputLower, "unknown trust") ||
+		strings.Contains(outputLower, "could not be looked up remotely") {
+		return "sudo pacman -Sy --needed archlinux-keyring && sudo pacman -Su && " + cmd
+	}
+
+	// Mirrors dropped the old package versions: sync and upgrade everything,
+	// since Arch does not support partial upgrades
+	if strings.Contains(outputLower, "failed retrieving file") || strings.Contains(outputLower, "could not satisfy dependencies") {
+		if p.isPartialUpgrade(cmd) {
+			return strings.Replace(cmd, " -Sy ", " -Syu ", 1)
+		}
+		return "sudo pacman -Syu && " + cmd
+	}
+
+	if m := pacmanTargetNotFound.FindStringSubmatch(output); m != nil {
+		target := m[1]
+		if correction := p.getPackageCorrection(target); correction != "" {
+			return strings.Replace(cmd, target, correction, 1)
+		}
+		// Not in the official repositories; it may be in the AUR
+		if !helper {
+			return "yay -S " + target
+		}
+	}
+
+	return ""
+}
+
+// isPartialUpgrade reports whether cmd syncs the databases without upgrading
+func (p *PacmanPlugin) isPartialUpgrade(cmd string) bool {
+	return strings.Contains(cmd+" ", " -Sy ")
+}
+
+// getPackageCorrection maps package names from other distributions to Arch
+func (p *PacmanPlugin) getPackageCorrection(packageName string) string {
+	corrections := map[string]string{
+		"build-essential":   "base-devel",
+		"python3":           "python",
+		"python3-pip":       "python-pip",
+		"python3-venv":      "python",
+		"pip":               "python-pip",
+		"g++":               "gcc",
+		"openjdk":           "jdk-openjdk",
+		"default-jdk":       "jdk-openjdk",
+		"java":              "jre-openjdk",
+		"mysql-server":      "mariadb",
+		"mysql":             "mariadb",
+		"postgresql-client": "postgresql",
+		"redis-tools":       "redis",
+		"redis-cli":         "redis",
+		"openssh-client":    "openssh",
+		"openssh-server":    "openssh",
+		"dnsutils":          "bind",
+		"libssl-dev":        "openssl",
+		"docker.io":         "docker",
+		"docker-ce":         "docker",
+		"nodejs-npm":        "npm",
+		"golang":            "go",
+		"vim-gtk":           "gvim",
+		"netcat":            "openbsd-netcat",
+		"fd-find":           "fd",
+		"chromium-browser":  "chromium",
+	}
+
+	corrections = mergeCorrections(p.Name(), correctionPackages, corrections)
+	correction := corrections[strings.ToLower(packageName)]
+	if correction == packageName {
+		return ""
+	}
+	return correction
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *PacmanPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "sudo pacman -Syu && pacman -Ss <package-name>"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *PacmanPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Arch Linux system administrator specializing in pacman and AUR helpers.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Arch Linux with pacman (AUR via yay or paru)
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Use Arch package names, not Debian/Fedora ones
+3. Never suggest partial upgrades (-Sy without -u)
+4. Packages missing from the official repositories may be in the AUR: suggest yay -S without sudo
+5. Handle common issues: keyring/signature errors, stale db.lck, outdated mirrors, dependency conflicts
+
+EXAMPLES:
+- Input: "sudo pacman -S build-essential" + "error: target not found: build-essential"
+- Output: "sudo pacman -S base-devel"
+
+- Input: "sudo pacman -S visual-studio-code-bin" + "error: target not found: visual-studio-code-bin"
+- Output: "yay -S visual-studio-code-bin"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,201 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// PermissionPlugin handles EACCES/"Permission denied" errors from any command
+// that no tool-specific plugin fixed
+type PermissionPlugin struct {
+	Home string // home directory for ownership checks; "" means the current user's
+}
+
+// permissionPaths extract the path from common permission error formats
+var permissionPaths = []*regexp.Regexp{
+	regexp.MustCompile(`EACCES: permission denied, \w+ '([^']+)'`),                              // Node.js
+	regexp.MustCompile(`\[Errno 13\] Permission denied: '([^']+)'`),                             // Python
+	regexp.MustCompile(`(?:open|mkdir|stat|remove|rename|create) ([^\s:]+): permission denied`), // Go
+	regexp.MustCompile(`['"]([^'"]+)['"]: Permission denied`),                                   // coreutils
+	regexp.MustCompile(`(?m)(?:^|\s)([~.]?/[^\s:]+|[\w.-]+/[^\s:]*): Permission denied`),        // shells
+}
+
+// permissionGroups maps device and socket paths to the group that grants access
+var permissionGroups = []struct {
+	prefix string
+	group  string
+}{
+	{"/var/run/docker.sock", "docker"},
+	{"/run/docker.sock", "docker"},
+	{"/dev/ttyUSB", "dialout"},
+	{"/dev/ttyACM", "dialout"},
+	{"/dev/ttyS", "dialout"},
+	{"/dev/kvm", "kvm"},
+	{"/dev/video", "video"},
+	{"/dev/snd", "audio"},
+	{"/var/run/libvirt", "libvirt"},
+	{"/dev/bus/usb", "plugdev"},
+}
+
+func (p *PermissionPlugin) Name() string {
+	return "permission"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *PermissionPlugin) Match(cmd string, output string) bool {
+	outputLower := strings.ToLower(output)
+
+	// "Permission denied (publickey)" is an SSH authentication failure
+	if strings.Contains(outputLower, "permission denied (") {
+		return false
+	}
+
+	permissionErrors := []string{
+		"permission denied",
+		"eacces",
+		"operation not permitted",
+	}
+
+	return containsAny(output, permissionErrors)
+}
+
+// Score ranks this generic plugin below the tool-specific ones
+func (p *PermissionPlugin) Score(cmd string, output string) float64 {
+	return genericScore
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *PermissionPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *PermissionPlugin) getQuickFix(cmd string, output string) string {
+	path := p.deniedPath(output)
+
+	// Sockets and devices are opened through group membership, not sudo
+	if group := p.groupFor(cmd, path, output); group != "" {
+		return fmt.Sprintf("sudo usermod -aG %s $USER && newgrp %s", group, group)
+	}
+
+	fields := commandFields(cmd)
+	sudo := strings.HasPrefix(cmd, "sudo ")
+
+	// The program itself is not executable
+	if path != "" && len(fields) > 0 && fields[0] == path && !sudo {
+		return fmt.Sprintf("chmod +x %s && %s", path, cmd)
+	}
+
+	// Files in the user's own tree left behind by an earlier sudo run
+	if path != "" && p.isUserPath(path) {
+		return fmt.Sprintf("sudo chown -R $USER %s && %s", path, cmd)
+	}
+
+	if !sudo {
+		return "sudo " + cmd
+	}
+
+	return ""
+}
+
+// deniedPath returns the path named in the permission error, if any
+func (p *PermissionPlugin) deniedPath(output string) string {
+	for _, re := range permissionPaths {
+		if m := re.FindStringSubmatch(output); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// groupFor returns the group granting access to the denied path or to a
+// device the command opens
+func (p *PermissionPlugin) groupFor(cmd, path, output string) string {
+	if strings.Contains(strings.ToLower(output), "docker daemon socket") {
+		return "docker"
+	}
+	for _, candidate := range append([]string{path}, strings.Fields(cmd)...) {
+		for _, g := range permissionGroups {
+			if candidate != "" && strings.HasPrefix(candidate, g.prefix) {
+				return g.group
+			}
+		}
+	}
+	return ""
+}
+
+// isUserPath reports whether path lives under the user's home directory or
+// the working directory, where chown is preferable to running as root
+func (p *PermissionPlugin) isUserPath(path string) bool {
+	if strings.HasPrefix(path, "~") || !filepath.IsAbs(path) {
+		return true
+	}
+
+	home := p.Home
+	if home == "" {
+		var err error
+		if home, err = os.UserHomeDir(); err != nil {
+			return false
+		}
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(home, "/")+"/")
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *PermissionPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		path := p.deniedPath(output)
+		if path == "" {
+			path = "<path>"
+		}
+		return fmt.Sprintf("ls -ld %s && lsattr -d %s # check ownership, mode and immutable flags", path, path)
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *PermissionPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Linux system administrator specializing in file ownership, permissions and access control.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with standard Unix permissions, groups and sudo
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Prefer fixing ownership (chown) of files in the user's home over running the command as root
+3. For sockets and devices (docker.sock, /dev/ttyUSB0, /dev/kvm), add the user to the owning group
+4. Use chmod +x for scripts that are not executable
+5. Never suggest chmod 777 or recursive changes to system directories
+
+EXAMPLES:
+- Input: "npm install -g typescript" + "EACCES: permission denied, mkdir '/usr/local/lib/node_modules'"
+- Output: "sudo npm install -g typescript"
+
+- Input: "docker ps" + "permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock"
+- Output: "sudo usermod -aG docker $USER && newgrp docker"
+
+Provide the corrected command:`, cmd, output)
+}
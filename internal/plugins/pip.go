@@ -3,13 +3,32 @@ package plugins
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
 )
 
 // PipPlugin handles Python pip command errors with AI-powered suggestions
-type PipPlugin struct{}
+type PipPlugin struct {
+	Index string // PyPI URL, https://pypi.org if empty
+}
+
+// pipConstraint splits the first version specifier off a requirement
+var pipConstraint = regexp.MustCompile(`^(==|>=|<=|~=|!=|>|<)\s*([0-9][\w.*]*)`)
+
+// pipStableVersion matches final releases, leaving out pre- and dev releases
+var pipStableVersion = regexp.MustCompile(`^\d+(\.\d+)*$`)
+
+// pipFromVersions finds the versions pip lists when none satisfies a requirement
+var pipFromVersions = regexp.MustCompile(`\(from versions: ([^)]*)\)`)
 
 func (p *PipPlugin) Name() string {
 	return "pip"
@@ -17,8 +36,8 @@ func (p *PipPlugin) Name() string {
 
 // Match checks if this plugin should handle the command/output
 func (p *PipPlugin) Match(cmd string, output string) bool {
-	// Check if command uses pip
-	if !strings.Contains(strings.ToLower(cmd), "pip") {
+	// Check if command uses pip; pipenv has its own plugin
+	if !strings.Contains(strings.ToLower(cmd), "pip") || isCommand(cmd, "pipenv") {
 		return false
 	}
 
@@ -78,7 +97,7 @@ func (p *PipPlugin) getQuickFix(cmd string, output string) string {
 
 	// Handle package name corrections
 	if strings.Contains(outputLower, "could not find a version") || strings.Contains(outputLower, "no matching distribution") {
-		return p.correctPackageName(cmd)
+		return p.correctPackageName(cmd, output)
 	}
 
 	// Handle pip vs pip3
@@ -89,86 +108,89 @@ func (p *PipPlugin) getQuickFix(cmd string, output string) string {
 	return ""
 }
 
-// correctPackageName fixes common Python package name typos
-func (p *PipPlugin) correctPackageName(cmd string) string {
-	packageCorrections := map[string]string{
-		// Popular Python packages with common typos
-		"beautifulsoup":   "beautifulsoup4",
-		"bs4":             "beautifulsoup4",
-		"beautiful-soup":  "beautifulsoup4",
-		"request":         "requests",
-		"requets":         "requests",
-		"reqeusts":        "requests",
-		"numpy":           "numpy",
-		"numpi":           "numpy",
-		"numpyy":          "numpy",
-		"pandas":          "pandas",
-		"panda":           "pandas",
-		"pandass":         "pandas",
-		"matplotlib":      "matplotlib",
-		"matplot":         "matplotlib",
-		"matplotlb":       "matplotlib",
-		"scipy":           "scipy",
-		"scipi":           "scipy",
-		"scypy":           "scipy",
-		"scikit-learn":    "scikit-learn",
-		"sklearn":         "scikit-learn",
-		"scikit":          "scikit-learn",
-		"tensorflow":      "tensorflow",
-		"tensorflw":       "tensorflow",
-		"tensoflow":       "tensorflow",
-		"torch":           "torch",
-		"pytorch":         "torch",
-		"pyyaml":          "pyyaml",
-		"yaml":            "pyyaml",
-		"yml":             "pyyaml",
-		"flask":           "flask",
-		"flsk":            "flask",
-		"flaskk":          "flask",
-		"django":          "django",
-		"djnago":          "django",
-		"djangoo":         "django",
-		"fastapi":         "fastapi",
-		"fastap":          "fastapi",
-		"fast-api":        "fastapi",
-		"sqlalchemy":      "sqlalchemy",
-		"sqlalchmy":       "sqlalchemy",
-		"sql-alchemy":     "sqlalchemy",
-		"pillow":          "pillow",
-		"pil":             "pillow",
-		"pillw":           "pillow",
-		"opencv-python":   "opencv-python",
-		"opencv":          "opencv-python",
-		"cv2":             "opencv-python",
-		"jupyter":         "jupyter",
-		"jupytr":          "jupyter",
-		"jupyterr":        "jupyter",
-		"ipython":         "ipython",
-		"ipythoon":        "ipython",
-		"py-python":       "ipython",
-		"pytz":            "pytz",
-		"pyttz":           "pytz",
-		"timezone":        "pytz",
-		"dateutil":        "python-dateutil",
-		"python-dateutil": "python-dateutil",
-		"date-util":       "python-dateutil",
-		"click":           "click",
-		"clik":            "click",
-		"clickk":          "click",
-		"setuptools":      "setuptools",
-		"setup-tools":     "setuptools",
-		"setuptool":       "setuptools",
-		"wheel":           "wheel",
-		"whel":            "wheel",
-		"wheell":          "wheel",
-		"virtualenv":      "virtualenv",
-		"virtual-env":     "virtualenv",
-		"venv":            "virtualenv",
-		"pipenv":          "pipenv",
-		"pip-env":         "pipenv",
-		"pipenev":         "pipenv",
-	}
+// pipPackageCorrections maps common Python package name typos, shared with
+// the other Python package managers
+var pipPackageCorrections = map[string]string{
+	// Popular Python packages with common typos
+	"beautifulsoup":   "beautifulsoup4",
+	"bs4":             "beautifulsoup4",
+	"beautiful-soup":  "beautifulsoup4",
+	"request":         "requests",
+	"requets":         "requests",
+	"reqeusts":        "requests",
+	"numpy":           "numpy",
+	"numpi":           "numpy",
+	"numpyy":          "numpy",
+	"pandas":          "pandas",
+	"panda":           "pandas",
+	"pandass":         "pandas",
+	"matplotlib":      "matplotlib",
+	"matplot":         "matplotlib",
+	"matplotlb":       "matplotlib",
+	"scipy":           "scipy",
+	"scipi":           "scipy",
+	"scypy":           "scipy",
+	"scikit-learn":    "scikit-learn",
+	"sklearn":         "scikit-learn",
+	"scikit":          "scikit-learn",
+	"tensorflow":      "tensorflow",
+	"tensorflw":       "tensorflow",
+	"tensoflow":       "tensorflow",
+	"torch":           "torch",
+	"pytorch":         "torch",
+	"pyyaml":          "pyyaml",
+	"yaml":            "pyyaml",
+	"yml":             "pyyaml",
+	"flask":           "flask",
+	"flsk":            "flask",
+	"flaskk":          "flask",
+	"django":          "django",
+	"djnago":          "django",
+	"djangoo":         "django",
+	"fastapi":         "fastapi",
+	"fastap":          "fastapi",
+	"fast-api":        "fastapi",
+	"sqlalchemy":      "sqlalchemy",
+	"sqlalchmy":       "sqlalchemy",
+	"sql-alchemy":     "sqlalchemy",
+	"pillow":          "pillow",
+	"pil":             "pillow",
+	"pillw":           "pillow",
+	"opencv-python":   "opencv-python",
+	"opencv":          "opencv-python",
+	"cv2":             "opencv-python",
+	"jupyter":         "jupyter",
+	"jupytr":          "jupyter",
+	"jupyterr":        "jupyter",
+	"ipython":         "ipython",
+	"ipythoon":        "ipython",
+	"py-python":       "ipython",
+	"pytz":            "pytz",
+	"pyttz":           "pytz",
+	"timezone":        "pytz",
+	"dateutil":        "python-dateutil",
+	"python-dateutil": "python-dateutil",
+	"date-util":       "python-dateutil",
+	"click":           "click",
+	"clik":            "click",
+	"clickk":          "click",
+	"setuptools":      "setuptools",
+	"setup-tools":     "setuptools",
+	"setuptool":       "setuptools",
+	"wheel":           "wheel",
+	"whel":            "wheel",
+	"wheell":          "wheel",
+	"virtualenv":      "virtualenv",
+	"virtual-env":     "virtualenv",
+	"venv":            "virtualenv",
+	"pipenv":          "pipenv",
+	"pip-env":         "pipenv",
+	"pipenev":         "pipenv",
+}
 
+// correctPackageName fixes common Python package name typos and, with
+// PIP_SUGGEST_VERSIONS, checks the name and requested version against PyPI
+func (p *PipPlugin) correctPackageName(cmd string, output string) string {
 	// Try to extract package name and correct it
 	parts := strings.Fields(cmd)
 	for i, part := range parts {
@@ -182,8 +204,16 @@ func (p *PipPlugin) correctPackageName(cmd string) string {
 				cleanPackage = strings.Split(cleanPackage, ">")[0]
 				cleanPackage = strings.Split(cleanPackage, "<")[0]
 				cleanPackage = strings.Split(cleanPackage, "!=")[0]
+				cleanPackage = strings.Split(cleanPackage, "~=")[0]
 
-				if correction, exists := packageCorrections[cleanPackage]; exists {
+				if p.suggestVersions() {
+					if requirement, ok := p.indexRequirement(packageName, cleanPackage, output); ok {
+						parts[i+1] = requirement
+						return strings.Join(parts, " ")
+					}
+				}
+
+				if correction, exists := mergeCorrections(p.Name(), correctionPackages, pipPackageCorrections)[cleanPackage]; exists {
 					parts[i+1] = strings.Replace(packageName, cleanPackage, correction, 1)
 					return strings.Join(parts, " ")
 				}
@@ -194,12 +224,150 @@ func (p *PipPlugin) correctPackageName(cmd string) string {
 	return cmd
 }
 
+// suggestVersions reports whether PIP_SUGGEST_VERSIONS allows PyPI lookups
+func (p *PipPlugin) suggestVersions() bool {
+	cfg := config.Current()
+	return cfg != nil && cfg.PipSuggestVersions
+}
+
+// indexRequirement rewrites a requirement so that it names a project on PyPI
+// and, if it asked for a version, the closest release that exists. It returns
+// false when PyPI cannot be reached, leaving the typo map to answer
+func (p *PipPlugin) indexRequirement(requirement, name, output string) (string, bool) {
+	candidates := []string{name}
+	if correction, exists := mergeCorrections(p.Name(), correctionPackages, pipPackageCorrections)[name]; exists && correction != name {
+		candidates = []string{correction, name}
+	}
+
+	for _, candidate := range candidates {
+		releases, found, err := p.releases(candidate)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("PyPI lookup failed: %v", err))
+			if m := pipFromVersions.FindStringSubmatch(output); m != nil && candidate == name {
+				// pip already listed what the index has
+				releases, found = strings.Split(m[1], ", "), m[1] != "none"
+			} else {
+				return "", false
+			}
+		}
+		if !found {
+			continue
+		}
+
+		constraint := strings.TrimPrefix(requirement, name)
+		if version := closestPipVersion(releases, constraint); version != "" {
+			return candidate + "==" + version, true
+		}
+		return candidate + constraint, true
+	}
+	// Neither the name nor its correction is published
+	return requirement, true
+}
+
+// releases returns the versions of a PyPI project that still have files,
+// and false if the project does not exist
+func (p *PipPlugin) releases(name string) ([]string, bool, error) {
+	index := "https://pypi.org"
+	if p.Index != "" {
+		index = strings.TrimSuffix(p.Index, "/")
+	}
+	location := index + "/pypi/" + url.PathEscape(name) + "/json"
+
+	type answer struct {
+		Found    bool     `json:"found"`
+		Releases []string `json:"releases"`
+	}
+	result, err := cachedLookup("pip", location, func(ctx context.Context) (answer, error) {
+		var project struct {
+			Releases map[string][]struct {
+				Yanked bool `json:"yanked"`
+			} `json:"releases"`
+		}
+		found, err := getJSON(ctx, location, &project)
+		if err != nil || !found {
+			return answer{}, err
+		}
+
+		result := answer{Found: true}
+		for version, files := range project.Releases {
+			for _, file := range files {
+				if !file.Yanked {
+					result.Releases = append(result.Releases, version)
+					break
+				}
+			}
+		}
+		return result, nil
+	})
+	return result.Releases, result.Found, err
+}
+
+// closestPipVersion picks the release to suggest for a failed version
+// constraint such as "==2.99": the newest release not above the requested
+// version, the oldest release if all are above it, and the newest release
+// for lower bounds. It returns "" when there is no constraint or release
+func closestPipVersion(releases []string, constraint string) string {
+	m := pipConstraint.FindStringSubmatch(strings.TrimSpace(constraint))
+	if m == nil {
+		return ""
+	}
+	op, want := m[1], strings.TrimSuffix(m[2], ".*")
+
+	var stable []string
+	for _, version := range releases {
+		if pipStableVersion.MatchString(version) {
+			stable = append(stable, version)
+		}
+	}
+	if len(stable) == 0 {
+		return ""
+	}
+	sort.Slice(stable, func(i, j int) bool {
+		return compareVersions(stable[i], stable[j]) < 0
+	})
+
+	switch op {
+	case ">", ">=", "!=":
+		return stable[len(stable)-1]
+	}
+	best := stable[0]
+	for _, version := range stable {
+		if compareVersions(version, want) > 0 {
+			break
+		}
+		best = version
+	}
+	return best
+}
+
+// compareVersions compares dotted numeric versions, treating missing
+// components as zero
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 // getAISuggestion uses AI to generate intelligent suggestions
 func (p *PipPlugin) getAISuggestion(cmd string, output string) string {
-	prompt := p.buildAIPrompt(cmd, output)
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
 
 	ctx := context.Background()
-	suggestion, err := ai.GetSuggestion(ctx, prompt)
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
 	if err != nil {
 		// Fallback to generic suggestion
 		return "pip3 --help # Check the correct pip command syntax"
@@ -285,7 +453,7 @@ func (p *SystemctlPlugin) Match(cmd string, output string) bool {
 		"job failed",
 	}
 
-	return containsAny(output, systemctlErrors)
+	return containsAny(output, systemctlErrors) || systemctlUnitNotFound.MatchString(output)
 }
 
 // Suggest generates an AI-powered suggestion for the error
@@ -311,7 +479,7 @@ func (p *SystemctlPlugin) getQuickFix(cmd string, output string) string {
 	}
 
 	// Handle service name corrections
-	if strings.Contains(outputLower, "unit not found") || strings.Contains(outputLower, "could not find") {
+	if strings.Contains(outputLower, "unit not found") || strings.Contains(outputLower, "could not find") || systemctlUnitNotFound.MatchString(output) {
 		return p.correctServiceName(cmd)
 	}
 
@@ -327,65 +495,173 @@ func (p *SystemctlPlugin) getQuickFix(cmd string, output string) string {
 	return ""
 }
 
-// correctServiceName fixes common service name typos
+// systemctlTimeout bounds the unit listing used to correct service names
+const systemctlTimeout = 3 * time.Second
+
+// systemctlUnitNotFound matches systemctl's errors for a unit that does not exist
+var systemctlUnitNotFound = regexp.MustCompile(`(?i)unit \S+ (?:not found|could not be found)`)
+
+// serviceAliases groups names distributions give the same service. The first
+// name is suggested when the installed units cannot be listed
+var serviceAliases = [][]string{
+	{"apache2", "httpd", "apache"},
+	{"nginx", "ngnix"},
+	{"docker", "dockerd"},
+	{"mysql", "mysqld"},
+	{"mariadb"},
+	{"postgresql", "postgres"},
+	{"redis-server", "redis", "redis-srv"},
+	{"ssh", "sshd", "openssh"},
+	{"networking", "network", "net", "NetworkManager"},
+	{"ufw", "firewall", "firewalld"},
+	{"cron", "crond", "cronie"},
+	{"avahi-daemon", "avahi"},
+	{"cups", "printer"},
+}
+
+// correctServiceName replaces the unit in cmd with the closest unit installed
+// on this machine
 func (p *SystemctlPlugin) correctServiceName(cmd string) string {
-	serviceCorrections := map[string]string{
-		"apache":     "apache2",
-		"httpd":      "apache2",
-		"nginx":      "nginx",
-		"ngnix":      "nginx",
-		"docker":     "docker",
-		"dockerd":    "docker",
-		"mysql":      "mysql",
-		"mariadb":    "mariadb",
-		"postgresql": "postgresql",
-		"postgres":   "postgresql",
-		"redis":      "redis-server",
-		"redis-srv":  "redis-server",
-		"ssh":        "ssh",
-		"sshd":       "ssh",
-		"openssh":    "ssh",
-		"network":    "networking",
-		"net":        "networking",
-		"firewall":   "ufw",
-		"iptables":   "iptables",
-		"cron":       "cron",
-		"crond":      "cron",
-		"systemd":    "systemd",
-		"dbus":       "dbus",
-		"avahi":      "avahi-daemon",
-		"bluetooth":  "bluetooth",
-		"cups":       "cups",
-		"printer":    "cups",
+	parts := strings.Fields(cmd)
+	index := serviceArgIndex(parts)
+	if index < 0 {
+		return cmd
 	}
+	serviceName := parts[index]
+	cleanService := strings.TrimSuffix(serviceName, ".service")
 
-	parts := strings.Fields(cmd)
-	if len(parts) >= 3 {
-		serviceName := parts[2]
-		// Remove .service suffix if present
-		cleanService := strings.TrimSuffix(serviceName, ".service")
-
-		if correction, exists := serviceCorrections[cleanService]; exists {
-			parts[2] = correction + ".service"
-			return strings.Join(parts, " ")
+	// Services the user named in corrections/systemctl.yaml
+	if correction, ok := mergeCorrections(p.Name(), correctionServices, nil)[cleanService]; ok {
+		parts[index] = strings.TrimSuffix(correction, ".service") + ".service"
+		return strings.Join(parts, " ")
+	}
+
+	units, err := installedUnits(contains(parts, "--user"))
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to list systemd units: %v", err))
+	}
+
+	if correction := closestUnit(cleanService, units); correction != "" {
+		parts[index] = correction + ".service"
+		return strings.Join(parts, " ")
+	}
+
+	// If no match, try with the .service suffix
+	if !strings.HasSuffix(serviceName, ".service") {
+		parts[index] = cleanService + ".service"
+		return strings.Join(parts, " ")
+	}
+
+	return cmd
+}
+
+// closestUnit finds the installed unit meant by name: a unit another
+// distribution names differently (httpd for apache2), then the nearest typo,
+// then the shortest unit starting with name (redis-server for redis). Without
+// a unit list it falls back to serviceAliases
+func closestUnit(name string, units []string) string {
+	installed := map[string]bool{}
+	for _, unit := range units {
+		installed[unit] = true
+	}
+
+	for _, group := range serviceAliases {
+		if !contains(group, name) {
+			continue
+		}
+		if units == nil {
+			return group[0]
+		}
+		for _, alias := range group {
+			if installed[alias] {
+				return alias
+			}
 		}
+	}
+	if units == nil {
+		return ""
+	}
 
-		// If no exact match, try without .service suffix
-		if !strings.HasSuffix(serviceName, ".service") {
-			parts[2] = cleanService + ".service"
-			return strings.Join(parts, " ")
+	if match := closestMatch(name, units); match != "" {
+		return match
+	}
+	best := ""
+	for _, unit := range units {
+		if strings.HasPrefix(unit, name) && (best == "" || len(unit) < len(best)) {
+			best = unit
 		}
 	}
+	return best
+}
 
-	return cmd
+// installedUnits lists the service units systemd knows about, without the
+// .service suffix. Template units are left out
+func installedUnits(user bool) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), systemctlTimeout)
+	defer cancel()
+
+	scope := "--system"
+	if user {
+		scope = "--user"
+	}
+	seen := map[string]bool{}
+	var units []string
+	for _, list := range []string{"list-unit-files", "list-units"} {
+		args := []string{scope, list, "--type=service", "--no-legend", "--no-pager", "--plain"}
+		if list == "list-units" {
+			args = append(args, "--all")
+		}
+		out, err := exec.CommandContext(ctx, "systemctl", args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("systemctl %s failed: %w", list, err)
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || !strings.HasSuffix(fields[0], ".service") {
+				continue
+			}
+			unit := strings.TrimSuffix(fields[0], ".service")
+			if !strings.HasSuffix(unit, "@") && !seen[unit] {
+				seen[unit] = true
+				units = append(units, unit)
+			}
+		}
+	}
+	return units, nil
+}
+
+// serviceArgIndex returns the index of the unit in a systemctl command line,
+// the first argument after the operation, or -1
+func serviceArgIndex(parts []string) int {
+	operation := false
+	for i, part := range parts {
+		if part == "sudo" || part == "systemctl" || strings.HasPrefix(part, "-") {
+			continue
+		}
+		if operation {
+			return i
+		}
+		operation = true
+	}
+	return -1
+}
+
+// contains reports whether list contains value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
 }
 
 // getAISuggestion uses AI to generate intelligent suggestions
 func (p *SystemctlPlugin) getAISuggestion(cmd string, output string) string {
-	prompt := p.buildAIPrompt(cmd, output)
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
 
 	ctx := context.Background()
-	suggestion, err := ai.GetSuggestion(ctx, prompt)
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
 	if err != nil {
 		// Fallback to generic suggestion
 		return "systemctl --help # Check the correct systemctl command syntax"
@@ -2,9 +2,13 @@ package plugins
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/configedit"
+	"github.com/ayushsharma-1/LogAid/internal/fuzzy"
 	"github.com/ayushsharma-1/LogAid/internal/logger"
 )
 
@@ -15,21 +19,72 @@ type Plugin interface {
 	Name() string                             // Plugin identifier
 }
 
+// ConfigSuggester is implemented by plugins whose fix is a configuration file
+// change (e.g. ~/.npmrc or ~/.gitconfig) rather than a shell command
+type ConfigSuggester interface {
+	SuggestConfig(cmd string, output string) *configedit.Edit
+}
+
+// AutoApplier is implemented by plugins that can tell when their suggestion
+// is unambiguous enough to run without asking (e.g. GIT_AUTO_CORRECT)
+type AutoApplier interface {
+	AutoApply(cmd string, output string, suggestion string) bool
+}
+
+// Scorer is implemented by plugins that can say how sure they are of a match,
+// from 0 to 1 (e.g. 0.95 for an exact typo map hit, 0.4 for a generic error
+// keyword). The engine tries matching plugins from the highest score down
+type Scorer interface {
+	Score(cmd string, output string) float64
+}
+
+// DefaultScore is the score of plugins that do not implement Scorer
+const DefaultScore = 0.5
+
+// genericScore ranks plugins that recognise an error but not the tool
+const genericScore = 0.3
+
+// Score returns how sure plugin is of matching cmd/output
+func Score(plugin Plugin, cmd string, output string) float64 {
+	scorer, ok := plugin.(Scorer)
+	if !ok {
+		return DefaultScore
+	}
+	score := scorer.Score(cmd, output)
+	if score < 0 {
+		return 0
+	}
+	if score > 1 {
+		return 1
+	}
+	return score
+}
+
 // LoadAllPlugins loads all enabled plugins
 func LoadAllPlugins() []Plugin {
+	cfg := config.Current()
 	var plugins []Plugin
 
-	if config.AppConfig == nil {
+	if cfg == nil {
 		return plugins
 	}
 
-	enabledPlugins := strings.Split(config.AppConfig.EnablePlugins, ",")
+	if err := LoadCorrections(CorrectionsDir()); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to load corrections: %v", err))
+	}
+
+	enabledPlugins := strings.Split(cfg.EnablePlugins, ",")
 	enabledMap := make(map[string]bool)
 	for _, plugin := range enabledPlugins {
 		enabledMap[strings.TrimSpace(plugin)] = true
 	}
 
-	// Load built-in plugins
+	// Load built-in plugins. gpg goes first: its errors surface through apt and git
+	if enabledMap["gpg"] {
+		plugins = append(plugins, &GPGPlugin{})
+		logger.Debug("Loaded gpg plugin")
+	}
+
 	if enabledMap["apt"] {
 		plugins = append(plugins, &AptPlugin{})
 		logger.Debug("Loaded apt plugin")
@@ -60,6 +115,128 @@ func LoadAllPlugins() []Plugin {
 		logger.Debug("Loaded systemctl plugin")
 	}
 
+	if enabledMap["bun"] {
+		plugins = append(plugins, &BunPlugin{})
+		logger.Debug("Loaded bun plugin")
+	}
+
+	if enabledMap["poetry"] {
+		plugins = append(plugins, &PoetryPlugin{})
+		logger.Debug("Loaded poetry plugin")
+	}
+
+	if enabledMap["pacman"] {
+		plugins = append(plugins, &PacmanPlugin{})
+		logger.Debug("Loaded pacman plugin")
+	}
+
+	if enabledMap["zypper"] {
+		plugins = append(plugins, &ZypperPlugin{})
+		logger.Debug("Loaded zypper plugin")
+	}
+
+	if enabledMap["apk"] {
+		plugins = append(plugins, &ApkPlugin{})
+		logger.Debug("Loaded apk plugin")
+	}
+
+	if enabledMap["ssh"] {
+		plugins = append(plugins, &SSHPlugin{})
+		logger.Debug("Loaded ssh plugin")
+	}
+
+	if enabledMap["make"] {
+		plugins = append(plugins, &MakePlugin{})
+		logger.Debug("Loaded make plugin")
+	}
+
+	if enabledMap["archive"] {
+		plugins = append(plugins, &ArchivePlugin{})
+		logger.Debug("Loaded archive plugin")
+	}
+
+	if enabledMap["apache"] {
+		plugins = append(plugins, &ApachePlugin{})
+		logger.Debug("Loaded apache plugin")
+	}
+
+	if enabledMap["psql"] {
+		plugins = append(plugins, &PsqlPlugin{})
+		logger.Debug("Loaded psql plugin")
+	}
+
+	if enabledMap["svn"] {
+		plugins = append(plugins, &SvnPlugin{})
+		logger.Debug("Loaded svn plugin")
+	}
+
+	if enabledMap["java"] {
+		plugins = append(plugins, &JavaPlugin{})
+		logger.Debug("Loaded java plugin")
+	}
+
+	if enabledMap["cc"] {
+		plugins = append(plugins, &CCPlugin{})
+		logger.Debug("Loaded cc plugin")
+	}
+
+	if enabledMap["tsc"] {
+		plugins = append(plugins, &TscPlugin{})
+		logger.Debug("Loaded tsc plugin")
+	}
+
+	if enabledMap["pytest"] {
+		plugins = append(plugins, &PytestPlugin{})
+		logger.Debug("Loaded pytest plugin")
+	}
+
+	if enabledMap["gotest"] {
+		plugins = append(plugins, &GoTestPlugin{})
+		logger.Debug("Loaded gotest plugin")
+	}
+
+	if enabledMap["sed"] {
+		plugins = append(plugins, &SedPlugin{})
+		logger.Debug("Loaded sed plugin")
+	}
+
+	if enabledMap["find"] {
+		plugins = append(plugins, &FindPlugin{})
+		logger.Debug("Loaded find plugin")
+	}
+
+	if enabledMap["kafka"] {
+		plugins = append(plugins, &KafkaPlugin{})
+		logger.Debug("Loaded kafka plugin")
+	}
+
+	if enabledMap["rustup"] {
+		plugins = append(plugins, &RustupPlugin{})
+		logger.Debug("Loaded rustup plugin")
+	}
+
+	// External plugins from PLUGINS_DIR run before the generic ones so they can override them
+	if cfg.PluginsDir != "" {
+		timeout := time.Duration(cfg.PluginTimeout) * time.Second
+		plugins = append(plugins, LoadExternalPlugins(cfg.PluginsDir, timeout)...)
+	}
+
+	// Generic plugins match any command, so they run after the tool-specific ones
+	if enabledMap["shell"] {
+		plugins = append(plugins, &ShellPlugin{})
+		logger.Debug("Loaded shell plugin")
+	}
+
+	if enabledMap["notfound"] {
+		plugins = append(plugins, &NotFoundPlugin{})
+		logger.Debug("Loaded notfound plugin")
+	}
+
+	if enabledMap["permission"] {
+		plugins = append(plugins, &PermissionPlugin{})
+		logger.Debug("Loaded permission plugin")
+	}
+
 	logger.Info(fmt.Sprintf("Loaded %d plugins", len(plugins)))
 	return plugins
 }
@@ -74,3 +251,42 @@ func containsAny(text string, patterns []string) bool {
 	}
 	return false
 }
+
+// commandFields splits cmd into words, dropping a leading sudo
+func commandFields(cmd string) []string {
+	fields := strings.Fields(cmd)
+	if len(fields) > 0 && fields[0] == "sudo" {
+		fields = fields[1:]
+	}
+	return fields
+}
+
+// isCommand reports whether cmd runs one of the given programs
+func isCommand(cmd string, names ...string) bool {
+	fields := commandFields(cmd)
+	if len(fields) == 0 {
+		return false
+	}
+	program := filepath.Base(fields[0])
+	for _, name := range names {
+		if program == name {
+			return true
+		}
+	}
+	return false
+}
+
+// closestMatch returns the candidate nearest to word within its typo
+// tolerance, or "" when none is close enough
+func closestMatch(word string, candidates []string) string {
+	best, bestDistance := "", fuzzy.MaxDistance(word)+1
+	for _, candidate := range candidates {
+		if candidate == word {
+			return candidate
+		}
+		if d := fuzzy.Distance(word, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
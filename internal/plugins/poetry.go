@@ -0,0 +1,189 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// PoetryPlugin handles Poetry and Pipenv errors with AI-powered suggestions
+type PoetryPlugin struct{}
+
+var (
+	poetryNoMatchingVersion = regexp.MustCompile(`(?i)could not find a matching version of package (\S+)`)
+	poetryLockHint          = regexp.MustCompile("Run `(poetry lock[^`]*)`")
+	poetryPythonRequirement = regexp.MustCompile(`(?i)not supported by the project \(([^)]+)\)`)
+	pipenvPythonNotFound    = regexp.MustCompile(`(?i)python (\d+\.\d+)\S* was not found on your system`)
+	pythonVersion           = regexp.MustCompile(`(\d+)\.(\d+)`)
+	optionalArgs            = regexp.MustCompile(`\[[^\]]*\]`)
+)
+
+func (p *PoetryPlugin) Name() string {
+	return "poetry"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *PoetryPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "poetry", "pipenv") {
+		return false
+	}
+
+	poetryErrors := []string{
+		"could not find a matching version",
+		"poetry.lock was last generated",
+		"lock file is not consistent",
+		"pipfile.lock",
+		"is not supported by the project",
+		"was not found on your system",
+		"failed to create virtual environment",
+		"virtualenvcreationerror",
+		"ensurepip is not",
+		"no module named venv",
+		"solverproblemerror",
+		"version solving failed",
+		"resolutionfailure",
+	}
+
+	return containsAny(output, poetryErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *PoetryPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *PoetryPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+	pipenv := isCommand(cmd, "pipenv")
+
+	// The venv module is split out of python3 on Debian/Ubuntu
+	if strings.Contains(outputLower, "ensurepip is not") || strings.Contains(outputLower, "no module named venv") {
+		return "sudo apt install python3-venv && " + cmd
+	}
+
+	// Lock file out of date with pyproject.toml / Pipfile
+	if strings.Contains(outputLower, "poetry.lock was last generated") || strings.Contains(outputLower, "lock file is not consistent") {
+		lock := "poetry lock"
+		if m := poetryLockHint.FindStringSubmatch(output); m != nil {
+			// Drop optional flags shown in usage brackets, e.g. [--no-update]
+			lock = strings.Join(strings.Fields(optionalArgs.ReplaceAllString(m[1], "")), " ")
+		}
+		return p.chain(lock, cmd)
+	}
+	if pipenv && strings.Contains(outputLower, "pipfile.lock") && strings.Contains(outputLower, "out of date") {
+		return p.chain("pipenv lock", cmd)
+	}
+
+	// Active interpreter outside the project's python constraint
+	if m := poetryPythonRequirement.FindStringSubmatch(output); m != nil {
+		if v := pythonVersion.FindString(m[1]); v != "" {
+			return p.chain("poetry env use python"+v, cmd)
+		}
+	}
+	if m := pipenvPythonNotFound.FindStringSubmatch(output); m != nil && pipenv {
+		return "pipenv --python " + m[1]
+	}
+
+	if m := poetryNoMatchingVersion.FindStringSubmatch(output); m != nil {
+		return p.correctPackage(cmd, strings.Trim(m[1], `"'.`))
+	}
+
+	// A broken environment is cheaper to recreate than to repair
+	if strings.Contains(outputLower, "failed to create virtual environment") || strings.Contains(outputLower, "virtualenvcreationerror") {
+		if pipenv {
+			return "pipenv --rm && pipenv install"
+		}
+		return "poetry env remove --all && poetry install"
+	}
+
+	return ""
+}
+
+// correctPackage fixes a package name typo, or drops a version constraint
+// that no release satisfies
+func (p *PoetryPlugin) correctPackage(cmd string, name string) string {
+	fields := commandFields(cmd)
+	for i, field := range fields {
+		clean := field
+		if idx := strings.IndexAny(field, "@=<>!~^"); idx > 0 {
+			clean = field[:idx]
+		}
+		if !strings.EqualFold(clean, name) {
+			continue
+		}
+
+		if correction, ok := mergeCorrections(p.Name(), correctionPackages, pipPackageCorrections)[strings.ToLower(clean)]; ok && correction != clean {
+			fields[i] = correction + field[len(clean):]
+		} else if clean != field {
+			fields[i] = clean
+		} else {
+			return ""
+		}
+		return strings.Join(fields, " ")
+	}
+	return ""
+}
+
+// chain runs fix before retrying cmd, unless cmd is the fix itself
+func (p *PoetryPlugin) chain(fix, cmd string) string {
+	if strings.TrimSpace(cmd) == fix {
+		return fix
+	}
+	return fix + " && " + cmd
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *PoetryPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		if isCommand(cmd, "pipenv") {
+			return "pipenv lock && pipenv sync"
+		}
+		return "poetry lock && poetry install"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *PoetryPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in Python dependency management with Poetry and Pipenv.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Python project managed by Poetry (pyproject.toml, poetry.lock) or Pipenv (Pipfile, Pipfile.lock)
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Use the same tool the user ran (poetry or pipenv), never plain pip
+3. Handle common issues: outdated lock files, unsupported Python versions, version solving failures, broken virtualenvs
+4. If package doesn't exist, suggest the closest alternative
+5. For version conflicts, relax the constraint that cannot be satisfied
+
+EXAMPLES:
+- Input: "poetry install" + "pyproject.toml changed significantly since poetry.lock was last generated. Run `+"`poetry lock`"+` to fix the lock file."
+- Output: "poetry lock && poetry install"
+
+- Input: "poetry install" + "The currently activated Python version 3.8.10 is not supported by the project (^3.10)."
+- Output: "poetry env use python3.10 && poetry install"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,183 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// PsqlPlugin handles psql and PostgreSQL client tool errors
+type PsqlPlugin struct{}
+
+var (
+	psqlMissingRole     = regexp.MustCompile(`role "([^"]+)" does not exist`)
+	psqlMissingDatabase = regexp.MustCompile(`database "([^"]+)" does not exist`)
+	psqlPasswordFailed  = regexp.MustCompile(`password authentication failed for user "([^"]+)"`)
+	psqlPeerFailed      = regexp.MustCompile(`Peer authentication failed for user "([^"]+)"`)
+	psqlServerAddress   = regexp.MustCompile(`connection to server at "([^"]+)"(?: \([^)]*\))?, port (\d+) failed`)
+)
+
+func (p *PsqlPlugin) Name() string {
+	return "psql"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *PsqlPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "psql", "pg_dump", "pg_dumpall", "pg_restore", "createdb", "createuser", "dropdb") {
+		return false
+	}
+
+	psqlErrors := []string{
+		"command not found",
+		"does not exist",
+		"password authentication failed",
+		"peer authentication failed",
+		"connection refused",
+		"is the server running",
+		"no such file or directory",
+	}
+
+	return containsAny(output, psqlErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *PsqlPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *PsqlPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	if strings.Contains(outputLower, "command not found") {
+		return "sudo apt install postgresql-client && " + cmd
+	}
+
+	// Roles are created by the postgres superuser, named after the OS user by default
+	if m := psqlMissingRole.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("sudo -u postgres createuser --createdb %s && %s", m[1], cmd)
+	}
+
+	if m := psqlMissingDatabase.FindStringSubmatch(output); m != nil {
+		if isCommand(cmd, "createdb") {
+			return ""
+		}
+		return fmt.Sprintf("createdb %s%s && %s", p.connectionFlags(cmd), m[1], cmd)
+	}
+
+	// Peer authentication only applies to the Unix socket; TCP uses passwords
+	if psqlPeerFailed.MatchString(output) && !p.hasFlag(cmd, "-h", "--host") {
+		return p.withHost(cmd, "localhost")
+	}
+
+	if m := psqlPasswordFailed.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf(`sudo -u postgres psql -c "\password %s"`, m[1])
+	}
+
+	if strings.Contains(outputLower, "connection refused") || strings.Contains(outputLower, "is the server running") {
+		host, port := "localhost", "5432"
+		if m := psqlServerAddress.FindStringSubmatch(output); m != nil {
+			host, port = m[1], m[2]
+		}
+		if host == "localhost" || host == "127.0.0.1" || host == "::1" {
+			return "sudo systemctl start postgresql && " + cmd
+		}
+		return fmt.Sprintf("pg_isready -h %s -p %s", host, port)
+	}
+
+	return ""
+}
+
+// connectionFlags returns the -h/-p/-U flags of cmd so createdb reaches the same server
+func (p *PsqlPlugin) connectionFlags(cmd string) string {
+	fields := commandFields(cmd)
+	var flags []string
+	for i := 1; i < len(fields); i++ {
+		switch field := fields[i]; {
+		case (field == "-h" || field == "-p" || field == "-U") && i+1 < len(fields):
+			flags = append(flags, field, fields[i+1])
+			i++
+		case strings.HasPrefix(field, "--host=") || strings.HasPrefix(field, "--port=") || strings.HasPrefix(field, "--username="):
+			flags = append(flags, field)
+		}
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return strings.Join(flags, " ") + " "
+}
+
+func (p *PsqlPlugin) hasFlag(cmd string, names ...string) bool {
+	for _, field := range commandFields(cmd) {
+		for _, name := range names {
+			if field == name || strings.HasPrefix(field, name+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withHost inserts -h host right after the program name
+func (p *PsqlPlugin) withHost(cmd, host string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if field != "sudo" {
+			rest := append([]string{"-h", host}, fields[i+1:]...)
+			return strings.Join(append(fields[:i+1], rest...), " ")
+		}
+	}
+	return cmd
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *PsqlPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "pg_isready && sudo systemctl status postgresql"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *PsqlPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert PostgreSQL database administrator.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with PostgreSQL client tools (psql, pg_dump, createdb)
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Create missing roles with "sudo -u postgres createuser" and missing databases with createdb
+3. Peer authentication fails for other users over the Unix socket; connect with -h localhost instead
+4. For refused connections, check or start the server before changing the command
+5. Never suggest trust authentication or disabling passwords in pg_hba.conf
+
+EXAMPLES:
+- Input: "psql" + "psql: error: connection to server on socket \"/var/run/postgresql/.s.PGSQL.5432\" failed: FATAL:  role \"alice\" does not exist"
+- Output: "sudo -u postgres createuser --createdb alice && psql"
+
+- Input: "psql -U app appdb" + "FATAL:  Peer authentication failed for user \"app\""
+- Output: "psql -h localhost -U app appdb"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,244 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// PytestPlugin handles pytest runs that fail before or around the tests:
+// missing fixtures, conftest import errors and unregistered marks. Plain
+// assertion failures are left alone.
+type PytestPlugin struct {
+	Dir string // project root holding pytest.ini; "" means the working directory
+}
+
+var (
+	pytestFixtureNotFound = regexp.MustCompile(`fixture '([^']+)' not found`)
+	pytestFixtureFile     = regexp.MustCompile(`file (\S+\.py), line (\d+)`)
+	pytestAvailable       = regexp.MustCompile(`available fixtures: ([^\n]+)`)
+	pytestNoModule        = regexp.MustCompile(`No module named '([^'.]+)`)
+	pytestUnknownMark     = regexp.MustCompile(`(?:(\S+\.py):(\d+): )?PytestUnknownMarkWarning: Unknown pytest\.mark\.(\w+)`)
+	pytestStrictMark      = regexp.MustCompile(`'(\w+)' not found in ` + "`markers`" + ` configuration option`)
+	pytestMarkersOption   = regexp.MustCompile(`(?m)^markers\s*=`)
+)
+
+// pytestPluginFixtures maps fixtures to the pytest plugin that provides them
+var pytestPluginFixtures = map[string]string{
+	"mocker":            "pytest-mock",
+	"benchmark":         "pytest-benchmark",
+	"httpx_mock":        "pytest-httpx",
+	"requests_mock":     "requests-mock",
+	"event_loop":        "pytest-asyncio",
+	"freezer":           "pytest-freezer",
+	"snapshot":          "syrupy",
+	"httpserver":        "pytest-httpserver",
+	"faker":             "faker",
+	"client":            "pytest-django",
+	"rf":                "pytest-django",
+	"admin_client":      "pytest-django",
+	"django_user_model": "pytest-django",
+	"settings":          "pytest-django",
+	"aiohttp_client":    "pytest-aiohttp",
+	"celery_app":        "celery[pytest]",
+	"datadir":           "pytest-datadir",
+	"xprocess":          "pytest-xprocess",
+}
+
+// pytestBuiltinMarks are the marks pytest knows without registration
+var pytestBuiltinMarks = []string{"skip", "skipif", "xfail", "parametrize", "usefixtures", "filterwarnings"}
+
+func (p *PytestPlugin) Name() string {
+	return "pytest"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *PytestPlugin) Match(cmd string, output string) bool {
+	if !p.isPytest(cmd) {
+		return false
+	}
+
+	pytestErrors := []string{
+		"fixture '",
+		"importerror while loading conftest",
+		"importerror while importing test module",
+		"pytestunknownmarkwarning",
+		"not found in `markers` configuration option",
+		"command not found",
+		"no module named pytest",
+	}
+
+	return containsAny(output, pytestErrors)
+}
+
+// isPytest reports whether cmd runs pytest directly or as a module
+func (p *PytestPlugin) isPytest(cmd string) bool {
+	if isCommand(cmd, "pytest", "py.test") {
+		return true
+	}
+	return isCommand(cmd, "python", "python3") && strings.Contains(cmd, "-m pytest")
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *PytestPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *PytestPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	if strings.Contains(outputLower, "command not found") || strings.Contains(outputLower, "no module named pytest") {
+		return "pip install pytest && " + cmd
+	}
+
+	if m := pytestFixtureNotFound.FindStringSubmatch(output); m != nil {
+		return p.fixFixture(cmd, output, m[1])
+	}
+
+	if strings.Contains(outputLower, "importerror while loading conftest") || strings.Contains(outputLower, "importerror while importing test module") {
+		if m := pytestNoModule.FindStringSubmatch(output); m != nil {
+			return p.fixImport(cmd, m[1])
+		}
+		return ""
+	}
+
+	if m := pytestStrictMark.FindStringSubmatch(output); m != nil {
+		return p.registerMark(cmd, m[1])
+	}
+	if m := pytestUnknownMark.FindStringSubmatch(output); m != nil {
+		// "parametrise" is a typo, "slow" is a custom mark to register
+		if builtin := closestMatch(m[3], pytestBuiltinMarks); builtin != "" && m[1] != "" {
+			return fmt.Sprintf("sed -i '%ss/mark\\.%s/mark.%s/' %s && %s", m[2], m[3], builtin, m[1], cmd)
+		}
+		return p.registerMark(cmd, m[3])
+	}
+
+	return ""
+}
+
+// fixFixture installs the plugin providing fixture or corrects a misspelling
+func (p *PytestPlugin) fixFixture(cmd, output, fixture string) string {
+	if pkg, ok := pytestPluginFixtures[fixture]; ok {
+		return fmt.Sprintf("pip install %s && %s", pkg, cmd)
+	}
+
+	m := pytestAvailable.FindStringSubmatch(output)
+	loc := pytestFixtureFile.FindStringSubmatch(output)
+	if m == nil || loc == nil {
+		return ""
+	}
+	var available []string
+	for _, name := range strings.Split(m[1], ",") {
+		available = append(available, strings.TrimSpace(name))
+	}
+	if fixed := closestMatch(fixture, available); fixed != "" && fixed != fixture {
+		return fmt.Sprintf(`sed -i '%ss/\b%s\b/%s/' %s && %s`, loc[2], fixture, fixed, loc[1], cmd)
+	}
+	return ""
+}
+
+// fixImport handles a module the tests cannot import: local packages need the
+// project root on sys.path, anything else is installed
+func (p *PytestPlugin) fixImport(cmd, module string) string {
+	if p.exists(filepath.Join("src", module)) {
+		return "PYTHONPATH=src " + cmd
+	}
+	if p.exists(module) || p.exists(module+".py") {
+		if isCommand(cmd, "python", "python3") {
+			return "PYTHONPATH=. " + cmd
+		}
+		// python -m pytest puts the working directory on sys.path
+		fields := commandFields(cmd)
+		fields[0] = "pytest"
+		return "python -m " + strings.Join(fields, " ")
+	}
+
+	pkg := module
+	if correction, ok := mergeCorrections(p.Name(), correctionPackages, pipPackageCorrections)[strings.ToLower(module)]; ok {
+		pkg = correction
+	}
+	return fmt.Sprintf("pip install %s && %s", pkg, cmd)
+}
+
+// registerMark adds mark to the markers option of pytest.ini
+func (p *PytestPlugin) registerMark(cmd, mark string) string {
+	if !p.exists("pytest.ini") {
+		if p.exists("pyproject.toml") || p.exists("setup.cfg") || p.exists("tox.ini") {
+			return ""
+		}
+		return fmt.Sprintf(`printf '[pytest]\nmarkers =\n    %s\n' > pytest.ini && %s`, mark, cmd)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(p.dir(), "pytest.ini"))
+	if pytestMarkersOption.Match(data) {
+		return fmt.Sprintf(`sed -i '/^markers\s*=/a\    %s' pytest.ini && %s`, mark, cmd)
+	}
+	return fmt.Sprintf(`sed -i '/^\[pytest\]/a markers =\n    %s' pytest.ini && %s`, mark, cmd)
+}
+
+func (p *PytestPlugin) dir() string {
+	if p.Dir == "" {
+		return "."
+	}
+	return p.Dir
+}
+
+func (p *PytestPlugin) exists(name string) bool {
+	_, err := os.Stat(filepath.Join(p.dir(), name))
+	return err == nil
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *PytestPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "pytest --collect-only -q # check that tests and conftest.py import"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *PytestPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Python developer familiar with pytest, its plugins and configuration.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Python project tested with pytest
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Fixtures like mocker or benchmark come from plugins (pytest-mock, pytest-benchmark); install the plugin
+3. For conftest import errors of local packages, run "python -m pytest" or set PYTHONPATH
+4. Register custom marks under markers in pytest.ini instead of disabling --strict-markers
+5. Do not try to fix failing assertions; only fix errors that stop tests from running
+
+EXAMPLES:
+- Input: "pytest" + "E       fixture 'mocker' not found"
+- Output: "pip install pytest-mock && pytest"
+
+- Input: "pytest --strict-markers" + "'slow' not found in `+"`markers`"+` configuration option"
+- Output: "printf '[pytest]\nmarkers =\n    slow\n' > pytest.ini && pytest --strict-markers"
+
+Provide the corrected command:`, cmd, output)
+}
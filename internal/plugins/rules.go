@@ -0,0 +1,197 @@
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// RulePack is a YAML file of regex rules for one tool:
+//
+//	name: terraform
+//	commands: [terraform, tofu]
+//	rules:
+//	  - output: 'Could not load plugin.*provider "([\w/.-]+)"'
+//	    suggest: terraform init -upgrade && $command
+//
+// Rules are tried in order; the first one whose patterns match wins
+type RulePack struct {
+	Name     string   `yaml:"name"`
+	Commands []string `yaml:"commands"` // empty matches any command
+	Rules    []Rule   `yaml:"rules"`
+}
+
+// Rule maps an error to a suggestion. The suggestion can refer to $command,
+// to capture groups of the output pattern by number ($1, ${2}) and to named
+// groups of either pattern (${version}); $$ is a literal dollar sign
+type Rule struct {
+	Description string `yaml:"description"`
+	Command     string `yaml:"command"` // optional pattern the command must match
+	Output      string `yaml:"output"`
+	Suggest     string `yaml:"suggest"`
+
+	command *regexp.Regexp
+	output  *regexp.Regexp
+}
+
+// ParseRulePack decodes a rule pack and compiles its patterns
+func ParseRulePack(data []byte) (*RulePack, error) {
+	var pack RulePack
+	if err := yaml.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("failed to parse rule pack: %w", err)
+	}
+	if len(pack.Rules) == 0 {
+		return nil, fmt.Errorf("rule pack has no rules")
+	}
+
+	for i := range pack.Rules {
+		rule := &pack.Rules[i]
+		if rule.Output == "" || rule.Suggest == "" {
+			return nil, fmt.Errorf("rule %d needs output and suggest", i+1)
+		}
+		var err error
+		if rule.output, err = regexp.Compile(rule.Output); err != nil {
+			return nil, fmt.Errorf("rule %d: invalid output pattern: %w", i+1, err)
+		}
+		if rule.Command != "" {
+			if rule.command, err = regexp.Compile(rule.Command); err != nil {
+				return nil, fmt.Errorf("rule %d: invalid command pattern: %w", i+1, err)
+			}
+		}
+	}
+	return &pack, nil
+}
+
+// Apply returns the suggestion of the first rule that matches cmd/output
+func (pack *RulePack) Apply(cmd, output string) string {
+	if len(pack.Commands) > 0 && !isCommand(cmd, pack.Commands...) {
+		return ""
+	}
+
+	for _, rule := range pack.Rules {
+		m := rule.output.FindStringSubmatch(output)
+		if m == nil {
+			continue
+		}
+		vars := map[string]string{"command": cmd, "$": "$"}
+		for i, group := range m {
+			vars[strconv.Itoa(i)] = group
+		}
+		addNamedGroups(vars, rule.output, m)
+
+		if rule.command != nil {
+			cm := rule.command.FindStringSubmatch(cmd)
+			if cm == nil {
+				continue
+			}
+			addNamedGroups(vars, rule.command, cm)
+		}
+
+		return os.Expand(rule.Suggest, func(name string) string {
+			return vars[name]
+		})
+	}
+	return ""
+}
+
+// addNamedGroups records the named capture groups of a match in vars
+func addNamedGroups(vars map[string]string, re *regexp.Regexp, m []string) {
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			vars[name] = m[i]
+		}
+	}
+}
+
+// RulePackPlugin serves a rule pack file from PLUGINS_DIR and re-reads it
+// whenever the file changes, so edits apply without restarting LogAid
+type RulePackPlugin struct {
+	Path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	pack    *RulePack
+}
+
+// NewRulePackPlugin loads the rule pack at path
+func NewRulePackPlugin(path string) (*RulePackPlugin, error) {
+	p := &RulePackPlugin{Path: path}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *RulePackPlugin) Name() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pack.Name != "" {
+		return p.pack.Name
+	}
+	name := filepath.Base(p.Path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *RulePackPlugin) Match(cmd string, output string) bool {
+	return p.Suggest(cmd, output) != ""
+}
+
+// Suggest returns the suggestion of the first matching rule
+func (p *RulePackPlugin) Suggest(cmd string, output string) string {
+	return p.current().Apply(cmd, output)
+}
+
+// current returns the rule pack, reloading it if the file has changed.
+// A pack that no longer parses keeps the previous rules
+func (p *RulePackPlugin) current() *RulePack {
+	if info, err := os.Stat(p.Path); err == nil && !info.ModTime().Equal(p.loadedAt()) {
+		if err := p.load(); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to reload rule pack %s: %v", p.Path, err))
+		} else {
+			logger.Debug(fmt.Sprintf("Reloaded rule pack %s", p.Path))
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pack
+}
+
+// loadedAt returns the modification time of the loaded file
+func (p *RulePackPlugin) loadedAt() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.modTime
+}
+
+// load reads and parses the rule pack file
+func (p *RulePackPlugin) load() error {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", p.Path, err)
+	}
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", p.Path, err)
+	}
+	pack, err := ParseRulePack(data)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Remember the broken version too, so it is reported once rather than on every command
+	p.modTime = info.ModTime()
+	if err != nil {
+		return err
+	}
+	p.pack = pack
+	return nil
+}
@@ -0,0 +1,177 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// RustupPlugin handles rustup toolchain management errors, whether they come
+// from rustup itself or from the cargo/rustc proxies it installs
+type RustupPlugin struct{}
+
+var (
+	rustupToolchainMissing = regexp.MustCompile(`toolchain '([^']+)' is not installed`)
+	rustupComponentHint    = regexp.MustCompile("rustup component add [\\w-]+(?: --toolchain \\S+)?")
+	rustupComponentMissing = regexp.MustCompile(`'([\w-]+)' is not installed for the toolchain '([^']+)'`)
+	rustupNoSuchCommand    = regexp.MustCompile("no such (?:sub)?command: `([\\w-]+)`")
+	rustupUnavailable      = regexp.MustCompile(`component '([\w-]+)'(?: for target '[^']+')? is unavailable for download for channel '([^']+)'`)
+	rustupConflict         = regexp.MustCompile(`failed to install component: '[^']+', detected conflict|could not rename component file`)
+)
+
+// rustupComponents maps the commands components provide to the component name
+var rustupComponents = map[string]string{
+	"clippy":        "clippy",
+	"cargo-clippy":  "clippy",
+	"clippy-driver": "clippy",
+	"fmt":           "rustfmt",
+	"cargo-fmt":     "rustfmt",
+	"rustfmt":       "rustfmt",
+	"rust-analyzer": "rust-analyzer",
+	"miri":          "miri",
+	"cargo-miri":    "miri",
+	"llvm-cov":      "llvm-tools-preview",
+}
+
+func (p *RustupPlugin) Name() string {
+	return "rustup"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *RustupPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "rustup", "cargo", "rustc", "rustfmt", "rust-analyzer") {
+		return false
+	}
+
+	rustupErrors := []string{
+		"is not installed",
+		"no override and no default toolchain set",
+		"no default is configured",
+		"no such command: `clippy`",
+		"no such command: `fmt`",
+		"no such command: `miri`",
+		"is unavailable for download",
+		"detected conflict",
+		"could not rename component file",
+	}
+
+	return containsAny(output, rustupErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *RustupPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *RustupPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	if strings.Contains(outputLower, "no override and no default toolchain set") || strings.Contains(outputLower, "no default is configured") {
+		return "rustup default stable && " + cmd
+	}
+
+	// Components first: their message also says "is not installed"
+	if m := rustupComponentHint.FindString(output); m != "" {
+		return m + " && " + cmd
+	}
+	if m := rustupComponentMissing.FindStringSubmatch(output); m != nil {
+		if component, ok := rustupComponents[m[1]]; ok {
+			return fmt.Sprintf("rustup component add %s --toolchain %s && %s", component, m[2], cmd)
+		}
+	}
+	if m := rustupNoSuchCommand.FindStringSubmatch(output); m != nil {
+		if component, ok := rustupComponents[m[1]]; ok {
+			return fmt.Sprintf("rustup component add %s && %s", component, cmd)
+		}
+	}
+
+	// Covers `cargo +nightly` and rust-toolchain.toml overrides
+	if m := rustupToolchainMissing.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("rustup toolchain install %s && %s", m[1], cmd)
+	}
+
+	// Nightlies sometimes ship without a component; take the newest one that has it
+	if m := rustupUnavailable.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("rustup toolchain install %s --allow-downgrade --component %s", m[2], m[1])
+	}
+
+	// A half-finished update left files behind
+	if rustupConflict.MatchString(output) {
+		if toolchain := p.toolchain(cmd); toolchain != "" {
+			return fmt.Sprintf("rustup toolchain uninstall %s && rustup toolchain install %s", toolchain, toolchain)
+		}
+	}
+
+	return ""
+}
+
+// toolchain returns the toolchain cmd installs or updates, defaulting to stable
+func (p *RustupPlugin) toolchain(cmd string) string {
+	fields := commandFields(cmd)
+	if len(fields) < 2 || !isCommand(cmd, "rustup") {
+		return ""
+	}
+	args := fields[2:]
+	if fields[1] == "toolchain" && len(fields) > 2 {
+		args = fields[3:]
+	}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+	return "stable"
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *RustupPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "rustup show # list installed toolchains and the active one"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *RustupPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert Rust developer familiar with rustup toolchains, components and cargo.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Rust installed with rustup
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Install missing toolchains with rustup toolchain install <name>
+3. clippy, rustfmt and rust-analyzer are components: rustup component add <name>
+4. Without a default toolchain, run rustup default stable
+5. Keep the user's +toolchain and cargo arguments
+
+EXAMPLES:
+- Input: "cargo +nightly build" + "error: toolchain 'nightly-x86_64-unknown-linux-gnu' is not installed"
+- Output: "rustup toolchain install nightly-x86_64-unknown-linux-gnu && cargo +nightly build"
+
+- Input: "cargo clippy" + "error: no such command: `+"`clippy`"+`"
+- Output: "rustup component add clippy && cargo clippy"
+
+Provide the corrected command:`, cmd, output)
+}
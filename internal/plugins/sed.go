@@ -0,0 +1,252 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// SedPlugin handles sed and awk one-liners: broken s commands, GNU/BSD
+// differences in -i and awk programs the shell or a typo mangled
+type SedPlugin struct{}
+
+var (
+	sedQuotedArg    = regexp.MustCompile(`'([^']*)'|"([^"]*)"`)
+	sedBSDError     = regexp.MustCompile(`sed: \d+: "[^"]*": `)
+	sedFlags        = regexp.MustCompile(`^[gipIMe0-9]*$`)
+	sedEmptyInPlace = regexp.MustCompile(`-i (?:''|"")`)
+)
+
+// sedDelimiters are tried in order when a pattern contains the delimiter
+const sedDelimiters = "|#,@:%"
+
+func (p *SedPlugin) Name() string {
+	return "sed"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *SedPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "sed", "gsed", "awk", "gawk", "mawk", "nawk") {
+		return false
+	}
+
+	sedErrors := []string{
+		"unterminated `s' command",
+		"unknown option to `s'",
+		"unterminated substitute",
+		"invalid command code",
+		"extra characters at the end",
+		"can't read",
+		"syntax error",
+		"unexpected newline or end of string",
+		"non-terminated string",
+		"runaway string",
+	}
+
+	return containsAny(output, sedErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *SedPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *SedPlugin) getQuickFix(cmd string, output string) string {
+	if isCommand(cmd, "awk", "gawk", "mawk", "nawk") {
+		return p.fixAwk(cmd)
+	}
+	outputLower := strings.ToLower(output)
+
+	// GNU sed reads `-i ''` as -i followed by an empty script
+	if strings.Contains(outputLower, "can't read") && sedEmptyInPlace.MatchString(cmd) {
+		return sedEmptyInPlace.ReplaceAllString(cmd, "-i")
+	}
+	// BSD sed takes the script after -i as the backup suffix
+	if sedBSDError.MatchString(output) {
+		fields := strings.Fields(cmd)
+		for i, field := range fields {
+			if field == "-i" && i+1 < len(fields) && fields[i+1] != "''" && fields[i+1] != `""` {
+				return strings.Join(append(fields[:i+1], append([]string{"''"}, fields[i+1:]...)...), " ")
+			}
+		}
+	}
+
+	arg, script := p.script(cmd)
+	if arg == "" || len(script) < 2 || script[0] != 's' {
+		return ""
+	}
+	delim := script[1]
+	parts := p.splitUnescaped(script[2:], delim)
+
+	// s/foo/bar is missing its closing delimiter
+	if strings.Contains(output, "unterminated `s' command") && len(parts) == 2 {
+		return strings.Replace(cmd, arg, strings.Replace(arg, script, script+string(delim), 1), 1)
+	}
+
+	// Unescaped delimiters in paths: split the parts between pattern and
+	// replacement and switch to a delimiter neither contains
+	if strings.Contains(output, "unknown option to `s'") && len(parts) > 3 && sedFlags.MatchString(parts[len(parts)-1]) {
+		body := parts[:len(parts)-1]
+		split := p.pathSplit(body)
+		if split < 0 {
+			return ""
+		}
+		pattern := strings.Join(body[:split], string(delim))
+		replacement := strings.Join(body[split:], string(delim))
+		for _, d := range sedDelimiters {
+			if !strings.ContainsRune(script, d) {
+				fixed := fmt.Sprintf("s%c%s%c%s%c%s", d, pattern, d, replacement, d, parts[len(parts)-1])
+				return strings.Replace(cmd, arg, strings.Replace(arg, script, fixed, 1), 1)
+			}
+		}
+	}
+
+	return ""
+}
+
+// pathSplit returns where the replacement starts in the parts of an s command
+// whose paths were not escaped: at the second absolute path (s//usr//opt/),
+// or halfway for relative paths of equal depth. -1 means it is ambiguous
+func (p *SedPlugin) pathSplit(body []string) int {
+	if body[0] == "" {
+		split := -1
+		for i := 1; i < len(body); i++ {
+			if body[i] == "" {
+				if split >= 0 {
+					return -1
+				}
+				split = i
+			}
+		}
+		return split
+	}
+	if len(body)%2 != 0 {
+		return -1
+	}
+	return len(body) / 2
+}
+
+// fixAwk repairs the awk program: shell-expanded fields, missing braces
+// around actions and unbalanced braces
+func (p *SedPlugin) fixAwk(cmd string) string {
+	arg, program := p.script(cmd)
+	if arg == "" {
+		return ""
+	}
+
+	fixed := program
+	if strings.Count(fixed, "{") == 0 && (strings.HasPrefix(fixed, "print") || strings.HasPrefix(fixed, "printf")) {
+		fixed = "{" + fixed + "}"
+	}
+	if open := strings.Count(fixed, "{") - strings.Count(fixed, "}"); open > 0 {
+		fixed += strings.Repeat("}", open)
+	}
+
+	// In double quotes the shell expands $1 before awk sees it
+	quoted := "'" + fixed + "'"
+	if strings.HasPrefix(arg, `"`) && strings.Contains(fixed, "$") && !strings.Contains(fixed, "'") {
+		return strings.Replace(cmd, arg, quoted, 1)
+	}
+	if fixed == program {
+		return ""
+	}
+	return strings.Replace(cmd, arg, strings.Replace(arg, program, fixed, 1), 1)
+}
+
+// script returns the sed script or awk program as written in cmd (with any
+// quotes) and its unquoted text
+func (p *SedPlugin) script(cmd string) (string, string) {
+	for _, loc := range sedQuotedArg.FindAllStringSubmatchIndex(cmd, -1) {
+		// Skip option values such as -F':' or -v x="y"
+		before := strings.TrimRight(cmd[:loc[0]], " ")
+		if strings.HasSuffix(before, "-F") || strings.HasSuffix(before, "-v") || strings.HasSuffix(before, "=") {
+			continue
+		}
+		if text := cmd[loc[0]+1 : loc[1]-1]; text != "" {
+			return cmd[loc[0]:loc[1]], text
+		}
+	}
+
+	fields := commandFields(cmd)
+	for i := 1; i < len(fields); i++ {
+		switch {
+		case fields[i] == "-e" || fields[i] == "-f" || fields[i] == "-F" || fields[i] == "-v":
+			if fields[i] == "-e" && i+1 < len(fields) {
+				return fields[i+1], fields[i+1]
+			}
+			i++
+		case !strings.HasPrefix(fields[i], "-"):
+			return fields[i], fields[i]
+		}
+	}
+	return "", ""
+}
+
+// splitUnescaped splits s on delim, ignoring backslash-escaped delimiters
+func (p *SedPlugin) splitUnescaped(s string, delim byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case delim:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *SedPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "sed --debug -n '<script>' <file> # show how GNU sed parses the script"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *SedPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in sed and awk one-liners on both GNU and BSD/macOS systems.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: GNU sed/gawk on Linux or BSD sed/awk on macOS
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Use a different s delimiter (s|a|b|) when the pattern or replacement contains /
+3. GNU sed takes -i without an argument; BSD sed needs -i ''
+4. Single-quote awk programs so the shell does not expand $1, and put actions in { }
+5. Keep the user's files and options unchanged
+
+EXAMPLES:
+- Input: "sed -i 's//usr/local//opt/g' config" + "sed: -e expression #1, char 19: unknown option to `+"`s'"+`"
+- Output: "sed -i 's|/usr/local|/opt|g' config"
+
+- Input: "awk 'print $1' access.log" + "awk: cmd. line:1: print $1\nawk: cmd. line:1: ^ syntax error"
+- Output: "awk '{print $1}' access.log"
+
+Provide the corrected command:`, cmd, output)
+}
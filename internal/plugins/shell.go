@@ -0,0 +1,231 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// ShellPlugin handles shell-level errors that are not tied to any tool:
+// syntax errors, bad substitutions, broken shebangs and overlong argument lists
+type ShellPlugin struct {
+	Dir string // directory scripts are resolved against; "" means the working directory
+}
+
+var (
+	shellUnexpectedToken = regexp.MustCompile("syntax error near unexpected token [`']([^']+)'")
+	shellBadInterpreter  = regexp.MustCompile(`(\S+): (/\S+?)(\^M|\r)?: bad interpreter`)
+)
+
+func (p *ShellPlugin) Name() string {
+	return "shell"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *ShellPlugin) Match(cmd string, output string) bool {
+	shellErrors := []string{
+		"syntax error near unexpected token",
+		"bad substitution",
+		"bad interpreter",
+		"exec format error",
+		"argument list too long",
+	}
+
+	return containsAny(output, shellErrors)
+}
+
+// Score ranks this generic plugin below the tool-specific ones
+func (p *ShellPlugin) Score(cmd string, output string) float64 {
+	return genericScore
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *ShellPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *ShellPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// Unquoted (, ), <, > and friends are parsed by the shell
+	if m := shellUnexpectedToken.FindStringSubmatch(output); m != nil {
+		special := m[1]
+		if special == "newline" {
+			special = "<>"
+		}
+		return p.quoteFields(cmd, special)
+	}
+
+	// Bash-only expansions run by sh (dash)
+	if strings.Contains(outputLower, "bad substitution") && isCommand(cmd, "sh", "dash") {
+		fields := strings.Fields(cmd)
+		for i, field := range fields {
+			if isCommand(field, "sh", "dash") {
+				fields[i] = "bash"
+				return strings.Join(fields, " ")
+			}
+		}
+	}
+
+	if m := shellBadInterpreter.FindStringSubmatch(output); m != nil {
+		script := strings.TrimPrefix(m[1], "bash: ")
+		// Saved with Windows line endings: the interpreter is "/bin/bash\r"
+		if m[3] != "" {
+			return fmt.Sprintf(`sed -i 's/\r$//' %s && %s`, script, cmd)
+		}
+		interpreter := filepath.Base(m[2])
+		if interpreter == "python" {
+			interpreter = "python3"
+		}
+		return fmt.Sprintf("sed -i '1s|^#!.*|#!/usr/bin/env %s|' %s && %s", interpreter, script, cmd)
+	}
+
+	if strings.Contains(outputLower, "exec format error") {
+		script := p.scriptPath(cmd)
+		if script == "" {
+			return ""
+		}
+		head := p.readHead(script)
+		if bytes.HasPrefix(head, []byte("\x7fELF")) {
+			return fmt.Sprintf("file %s # built for a different CPU architecture", script)
+		}
+		if head != nil && !bytes.HasPrefix(head, []byte("#!")) {
+			return fmt.Sprintf("sed -i '1i #!/usr/bin/env bash' %s && %s", script, cmd)
+		}
+		return ""
+	}
+
+	if strings.Contains(outputLower, "argument list too long") {
+		return p.withXargs(cmd)
+	}
+
+	return ""
+}
+
+// quoteFields single-quotes the words from the first one containing a special
+// character up to the next flag, so a message like `fix(parser): x` stays one argument
+func (p *ShellPlugin) quoteFields(cmd, special string) string {
+	fields := strings.Fields(cmd)
+	start := -1
+	for i, field := range fields {
+		if i > 0 && !strings.HasPrefix(field, "'") && !strings.HasPrefix(field, `"`) && strings.ContainsAny(field, special) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+
+	end := start + 1
+	for end < len(fields) && !strings.HasPrefix(fields[end], "-") {
+		end++
+	}
+	quoted := strings.ReplaceAll(strings.Join(fields[start:end], " "), "'", `'\''`)
+
+	result := append(append([]string{}, fields[:start]...), "'"+quoted+"'")
+	return strings.Join(append(result, fields[end:]...), " ")
+}
+
+// scriptPath returns the script or binary cmd executes
+func (p *ShellPlugin) scriptPath(cmd string) string {
+	fields := commandFields(cmd)
+	if len(fields) == 0 || !strings.Contains(fields[0], "/") {
+		return ""
+	}
+	return fields[0]
+}
+
+// readHead returns the first bytes of path, or nil if it cannot be read
+func (p *ShellPlugin) readHead(path string) []byte {
+	if !filepath.IsAbs(path) && p.Dir != "" {
+		path = filepath.Join(p.Dir, path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	head := make([]byte, 4)
+	n, _ := file.Read(head)
+	return head[:n]
+}
+
+// withXargs rewrites `cmd args *.glob` to pass the matches through find and xargs
+func (p *ShellPlugin) withXargs(cmd string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if i == 0 || !strings.ContainsAny(field, "*?") {
+			continue
+		}
+		dir, pattern := filepath.Split(field)
+		if dir == "" {
+			dir = "."
+		}
+		rest := append(append([]string{}, fields[:i]...), fields[i+1:]...)
+		// cp and mv take the destination last, which xargs would break
+		if isCommand(cmd, "cp", "mv") && i < len(fields)-1 {
+			dest := rest[len(rest)-1]
+			rest = append(rest[:len(rest)-1:len(rest)-1], "-t", dest)
+		}
+		return fmt.Sprintf("find %s -maxdepth 1 -name '%s' -print0 | xargs -0 %s", strings.TrimSuffix(dir, "/"), pattern, strings.Join(rest, " "))
+	}
+	return ""
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *ShellPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "shellcheck <script> # find quoting and syntax problems"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *ShellPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in POSIX sh and bash quoting, expansion and script execution.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Linux with bash as the interactive shell and dash as /bin/sh
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Quote arguments containing ( ) < > ; & | with single quotes
+3. Run scripts that use bash features with bash, not sh
+4. Fix CRLF line endings and missing or wrong shebangs in the script
+5. Use find -print0 | xargs -0 when a glob expands to too many arguments
+
+EXAMPLES:
+- Input: "git commit -m fix(parser): handle tabs" + "bash: syntax error near unexpected token `+"`('"+`"
+- Output: "git commit -m 'fix(parser): handle tabs'"
+
+- Input: "rm logs/*.log" + "bash: /usr/bin/rm: Argument list too long"
+- Output: "find logs -maxdepth 1 -name '*.log' -print0 | xargs -0 rm"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,326 @@
+package plugins
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// SSHPlugin handles ssh, scp and sftp connection and authentication errors
+type SSHPlugin struct {
+	ConfigPath string // ssh client config; "" means ~/.ssh/config
+}
+
+var (
+	sshKeyTooOpen      = regexp.MustCompile(`Permissions \d+ for '([^']+)' are too open`)
+	sshBadOption       = regexp.MustCompile(`(\S+): line (\d+): Bad configuration option: (\S+)`)
+	sshUnresolvedHost  = regexp.MustCompile(`Could not resolve hostname ([^:\s]+)`)
+	sshAuthMethods     = regexp.MustCompile(`Permission denied \(([^)]+)\)`)
+	sshChangedHostname = regexp.MustCompile(`Host key for (\S+) has changed`)
+)
+
+// sshFlagsWithValue are the options of each program that consume the next argument
+var sshFlagsWithValue = map[string]string{
+	"ssh":         "BbcDEeFIiJLlmOoPpQRSWw",
+	"scp":         "cDFiJlOoPS",
+	"sftp":        "BbcDFiJlOoPRSs",
+	"ssh-copy-id": "iFopt",
+}
+
+// sshConfigOptions are the ssh_config keywords, used to correct typos
+var sshConfigOptions = []string{
+	"Host", "Match", "Include", "HostName", "User", "Port", "IdentityFile",
+	"IdentitiesOnly", "IdentityAgent", "CertificateFile", "AddKeysToAgent",
+	"UseKeychain", "ForwardAgent", "ForwardX11", "ForwardX11Trusted",
+	"ProxyJump", "ProxyCommand", "LocalForward", "RemoteForward",
+	"DynamicForward", "ServerAliveInterval", "ServerAliveCountMax",
+	"StrictHostKeyChecking", "UserKnownHostsFile", "GlobalKnownHostsFile",
+	"HashKnownHosts", "CheckHostIP", "Compression", "ConnectTimeout",
+	"ConnectionAttempts", "PreferredAuthentications", "PubkeyAuthentication",
+	"PasswordAuthentication", "KbdInteractiveAuthentication",
+	"GSSAPIAuthentication", "PubkeyAcceptedAlgorithms", "HostKeyAlgorithms",
+	"KexAlgorithms", "Ciphers", "MACs", "ControlMaster", "ControlPath",
+	"ControlPersist", "LogLevel", "SendEnv", "SetEnv", "RequestTTY",
+	"RemoteCommand", "LocalCommand", "PermitLocalCommand", "BatchMode",
+	"TCPKeepAlive", "AddressFamily", "BindAddress", "ExitOnForwardFailure",
+	"GatewayPorts", "VisualHostKey", "NumberOfPasswordPrompts",
+}
+
+func (p *SSHPlugin) Name() string {
+	return "ssh"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *SSHPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "ssh", "scp", "sftp", "ssh-copy-id") {
+		return false
+	}
+
+	sshErrors := []string{
+		"permission denied (",
+		"host key verification failed",
+		"remote host identification has changed",
+		"unprotected private key file",
+		"connection refused",
+		"connection timed out",
+		"operation timed out",
+		"no route to host",
+		"could not resolve hostname",
+		"bad configuration option",
+		"no ecdsa host key is known",
+		"no ed25519 host key is known",
+		"no rsa host key is known",
+	}
+
+	return containsAny(output, sshErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *SSHPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *SSHPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+	user, host, port := p.target(cmd)
+
+	// ssh ignores private keys other users can read
+	if m := sshKeyTooOpen.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("chmod 600 %s && %s", m[1], cmd)
+	}
+
+	if m := sshBadOption.FindStringSubmatch(output); m != nil {
+		if option := closestMatch(strings.ToLower(m[3]), p.lowerOptions()); option != "" {
+			return fmt.Sprintf("sed -i '%ss/%s/%s/I' %s && %s", m[2], m[3], p.canonicalOption(option), m[1], cmd)
+		}
+	}
+
+	// A changed host key can mean a reinstalled server or an attack, so only
+	// the stale entry is removed and the new key is verified on reconnect
+	if strings.Contains(outputLower, "remote host identification has changed") {
+		if m := sshChangedHostname.FindStringSubmatch(output); m != nil {
+			host = strings.Trim(m[1], `"`)
+		}
+		if host != "" {
+			return fmt.Sprintf("ssh-keygen -R %s && %s", p.knownHostsName(host, port), cmd)
+		}
+	}
+
+	// Unknown host with strict checking (e.g. BatchMode): accept new keys only
+	if strings.Contains(outputLower, "host key is known") && strings.Contains(outputLower, "strict checking") {
+		return p.withOption(cmd, "StrictHostKeyChecking=accept-new")
+	}
+
+	if m := sshAuthMethods.FindStringSubmatch(output); m != nil && host != "" {
+		if strings.Contains(m[1], "password") && isCommand(cmd, "ssh") {
+			// Password login works, so the key just has to be installed
+			return fmt.Sprintf("ssh-copy-id %s && %s", p.joinTarget(user, host, port), cmd)
+		}
+	}
+
+	if m := sshUnresolvedHost.FindStringSubmatch(output); m != nil {
+		if alias := closestMatch(m[1], p.configuredHosts()); alias != "" && alias != m[1] {
+			return strings.Replace(cmd, m[1], alias, 1)
+		}
+	}
+
+	if host != "" && port == "" {
+		port = "22"
+	}
+	if strings.Contains(outputLower, "connection refused") && host != "" {
+		return fmt.Sprintf("nc -zv %s %s # check whether sshd is running and listening on this port", host, port)
+	}
+	if (strings.Contains(outputLower, "timed out") || strings.Contains(outputLower, "no route to host")) && host != "" {
+		return fmt.Sprintf("ping -c 3 %s && nc -zv -w 5 %s %s", host, host, port)
+	}
+
+	return ""
+}
+
+// target extracts the user, host and port the command connects to
+func (p *SSHPlugin) target(cmd string) (user, host, port string) {
+	fields := commandFields(cmd)
+	if len(fields) == 0 {
+		return "", "", ""
+	}
+	program := filepath.Base(fields[0])
+	valueFlags := sshFlagsWithValue[program]
+	portFlag := "P"
+	if program == "ssh" || program == "ssh-copy-id" {
+		portFlag = "p"
+	}
+	scp := program == "scp"
+
+	for i := 1; i < len(fields); i++ {
+		field := fields[i]
+		if strings.HasPrefix(field, "-") && len(field) > 1 {
+			flag := field[1:2]
+			if strings.Contains(valueFlags, flag) {
+				value := field[2:]
+				if value == "" && i+1 < len(fields) {
+					i++
+					value = fields[i]
+				}
+				switch flag {
+				case portFlag:
+					port = value
+				case "l":
+					user = value
+				}
+			}
+			continue
+		}
+
+		if scp {
+			idx := strings.Index(field, ":")
+			if idx <= 0 {
+				continue
+			}
+			field = field[:idx]
+		}
+		if at := strings.LastIndex(field, "@"); at >= 0 {
+			user, field = field[:at], field[at+1:]
+		}
+		host = field
+		break
+	}
+	return user, host, port
+}
+
+// joinTarget formats user@host with -p when the port is not the default
+func (p *SSHPlugin) joinTarget(user, host, port string) string {
+	target := host
+	if user != "" {
+		target = user + "@" + host
+	}
+	if port != "" && port != "22" {
+		target = "-p " + port + " " + target
+	}
+	return target
+}
+
+// knownHostsName is how host appears in known_hosts
+func (p *SSHPlugin) knownHostsName(host, port string) string {
+	if port != "" && port != "22" {
+		return fmt.Sprintf(`"[%s]:%s"`, host, port)
+	}
+	return host
+}
+
+// withOption inserts -o option right after the program name
+func (p *SSHPlugin) withOption(cmd, option string) string {
+	fields := strings.Fields(cmd)
+	for i, field := range fields {
+		if isCommand(field, "ssh", "scp", "sftp") {
+			rest := append([]string{"-o", option}, fields[i+1:]...)
+			return strings.Join(append(fields[:i+1], rest...), " ")
+		}
+	}
+	return cmd
+}
+
+func (p *SSHPlugin) lowerOptions() []string {
+	lower := make([]string, len(sshConfigOptions))
+	for i, option := range sshConfigOptions {
+		lower[i] = strings.ToLower(option)
+	}
+	return lower
+}
+
+func (p *SSHPlugin) canonicalOption(lower string) string {
+	for _, option := range sshConfigOptions {
+		if strings.ToLower(option) == lower {
+			return option
+		}
+	}
+	return lower
+}
+
+// configuredHosts returns the Host aliases defined in the ssh client config
+func (p *SSHPlugin) configuredHosts() []string {
+	path := p.ConfigPath
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(home, ".ssh", "config")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var hosts []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "host") {
+			continue
+		}
+		for _, alias := range fields[1:] {
+			if !strings.ContainsAny(alias, "*?!") {
+				hosts = append(hosts, alias)
+			}
+		}
+	}
+	return hosts
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *SSHPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "ssh -v " + strings.Join(commandFields(cmd)[1:], " ") + " # verbose output shows which step fails"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *SSHPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in OpenSSH client configuration and troubleshooting.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: OpenSSH client (ssh, scp, sftp) with ~/.ssh/config and ~/.ssh/known_hosts
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Never disable host key checking (no StrictHostKeyChecking=no, no UserKnownHostsFile=/dev/null)
+3. For changed host keys, remove only the stale entry with ssh-keygen -R
+4. For publickey failures, suggest the right key (-i), user, or ssh-copy-id
+5. For connection failures, suggest a diagnostic command (nc -zv, ping) or the right port
+
+EXAMPLES:
+- Input: "ssh deploy@10.0.0.5" + "deploy@10.0.0.5: Permission denied (publickey,password)."
+- Output: "ssh-copy-id deploy@10.0.0.5 && ssh deploy@10.0.0.5"
+
+- Input: "ssh web1" + "WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED!"
+- Output: "ssh-keygen -R web1 && ssh web1"
+
+Provide the corrected command:`, cmd, output)
+}
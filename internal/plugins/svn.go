@@ -0,0 +1,149 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// SvnPlugin handles Subversion errors
+type SvnPlugin struct{}
+
+var (
+	svnInConflict     = regexp.MustCompile(`'([^']+)' remains in (?:tree[- ])?conflict`)
+	svnTreeConflict   = regexp.MustCompile(`(?m)^\s*C\s+(\S+)\s*$`)
+	svnNotWorkingCopy = regexp.MustCompile(`'([^']+)' is not a working copy`)
+	svnServerHost     = regexp.MustCompile(`(?:https?|svn(?:\+ssh)?)://([^/:\s>'"]+)`)
+)
+
+func (p *SvnPlugin) Name() string {
+	return "svn"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *SvnPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "svn") {
+		return false
+	}
+
+	svnErrors := []string{
+		"command not found",
+		"is not a working copy",
+		"run 'svn cleanup'",
+		"e155004",
+		"authentication failed",
+		"authorization failed",
+		"no more credentials",
+		"tree conflict",
+		"remains in conflict",
+		"summary of conflicts",
+		"needs to be upgraded",
+		"e155036",
+	}
+
+	return containsAny(output, svnErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *SvnPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *SvnPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	if strings.Contains(outputLower, "command not found") {
+		return "sudo apt install subversion && " + cmd
+	}
+
+	// Interrupted operations leave the working copy locked
+	if strings.Contains(outputLower, "run 'svn cleanup'") || strings.Contains(outputLower, "e155004") {
+		return "svn cleanup && " + cmd
+	}
+
+	if strings.Contains(outputLower, "needs to be upgraded") || strings.Contains(outputLower, "e155036") {
+		return "svn upgrade && " + cmd
+	}
+
+	// The conflicted file must be fixed by hand before it is marked resolved
+	if m := svnInConflict.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("svn resolve --accept working %s && %s", m[1], cmd)
+	}
+	if strings.Contains(outputLower, "tree conflict") || strings.Contains(outputLower, "summary of conflicts") {
+		if m := svnTreeConflict.FindStringSubmatch(output); m != nil {
+			return "svn resolve --accept working " + m[1]
+		}
+		return "svn status | grep '^.\\{0,6\\}C'"
+	}
+
+	// Cached credentials are stale; remove them so svn prompts again
+	if strings.Contains(outputLower, "authentication failed") || strings.Contains(outputLower, "authorization failed") ||
+		strings.Contains(outputLower, "no more credentials") {
+		if m := svnServerHost.FindStringSubmatch(cmd + "\n" + output); m != nil {
+			return fmt.Sprintf("svn auth --remove %s && %s", m[1], cmd)
+		}
+		if !strings.Contains(cmd, "--username") {
+			return cmd + " --username $USER"
+		}
+		return ""
+	}
+
+	if m := svnNotWorkingCopy.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("svn checkout <repository-url> %s", m[1])
+	}
+
+	return ""
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *SvnPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "svn info && svn status"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *SvnPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert in Apache Subversion (svn) version control.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Subversion 1.9+ command-line client
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Use svn cleanup for locked working copies and svn upgrade for old formats
+3. Resolve conflicts with svn resolve only after the file has been fixed
+4. For authentication failures, clear cached credentials (svn auth --remove) or pass --username
+5. Never suggest deleting the .svn directory
+
+EXAMPLES:
+- Input: "svn update" + "svn: E155004: Run 'svn cleanup' to remove locks"
+- Output: "svn cleanup && svn update"
+
+- Input: "svn commit -m fix" + "svn: E155015: Aborting commit: '/src/app.c' remains in conflict"
+- Output: "svn resolve --accept working /src/app.c && svn commit -m fix"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,273 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// TscPlugin handles TypeScript compiler errors that come from the project
+// setup rather than the code: missing type declarations, tsconfig options and
+// outdated compilers
+type TscPlugin struct {
+	Dir string // project directory; "" means the working directory
+}
+
+var (
+	tscMissingModule = regexp.MustCompile(`(?:Cannot find module|Could not find a declaration file for module) '([^']+)'`)
+	tscUnknownOption = regexp.MustCompile(`(?:(\S*tsconfig[\w.]*\.json)\(\d+,\d+\): )?error TS5023: Unknown compiler option '(?:--)?([^']+)'`)
+)
+
+// tscCompilerOptions are the tsconfig compilerOptions, used to correct typos
+// and to tell typos from options the installed compiler is too old for
+var tscCompilerOptions = []string{
+	"allowJs", "allowSyntheticDefaultImports", "allowUnreachableCode", "allowUnusedLabels",
+	"allowImportingTsExtensions", "alwaysStrict", "baseUrl", "checkJs", "composite",
+	"declaration", "declarationDir", "declarationMap", "downlevelIteration", "emitDecoratorMetadata",
+	"esModuleInterop", "exactOptionalPropertyTypes", "experimentalDecorators",
+	"forceConsistentCasingInFileNames", "importHelpers", "incremental", "isolatedModules",
+	"jsx", "jsxImportSource", "lib", "module", "moduleDetection", "moduleResolution",
+	"noEmit", "noEmitOnError", "noFallthroughCasesInSwitch", "noImplicitAny",
+	"noImplicitOverride", "noImplicitReturns", "noImplicitThis", "noPropertyAccessFromIndexSignature",
+	"noUncheckedIndexedAccess", "noUnusedLocals", "noUnusedParameters", "outDir", "outFile",
+	"paths", "removeComments", "resolveJsonModule", "rootDir", "rootDirs", "skipLibCheck",
+	"sourceMap", "strict", "strictBindCallApply", "strictFunctionTypes", "strictNullChecks",
+	"strictPropertyInitialization", "target", "tsBuildInfoFile", "typeRoots", "types",
+	"useDefineForClassFields", "useUnknownInCatchVariables", "verbatimModuleSyntax",
+	"erasableSyntaxOnly", "rewriteRelativeImportExtensions", "noUncheckedSideEffectImports",
+	"isolatedDeclarations", "customConditions", "resolvePackageJsonExports", "resolvePackageJsonImports",
+}
+
+func (p *TscPlugin) Name() string {
+	return "tsc"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *TscPlugin) Match(cmd string, output string) bool {
+	if !p.isTsc(cmd) {
+		return false
+	}
+
+	tscErrors := []string{
+		"cannot find module",
+		"could not find a declaration file for module",
+		"unknown compiler option",
+		"command not found",
+		"this is not the tsc command you are looking for",
+	}
+
+	return containsAny(output, tscErrors)
+}
+
+// isTsc reports whether cmd runs tsc directly or through a package runner
+// (npx tsc, pnpm exec tsc, yarn tsc)
+func (p *TscPlugin) isTsc(cmd string) bool {
+	if isCommand(cmd, "tsc") {
+		return true
+	}
+	if !isCommand(cmd, "npx", "pnpx", "bunx", "pnpm", "yarn") {
+		return false
+	}
+	fields := commandFields(cmd)
+	for i := 1; i < len(fields) && i <= 2; i++ {
+		if fields[i] == "tsc" {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *TscPlugin) Suggest(cmd string, output string) string {
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *TscPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// tsc is not installed, or npx resolved the unrelated "tsc" package
+	if strings.Contains(outputLower, "command not found") || strings.Contains(outputLower, "this is not the tsc command") {
+		return fmt.Sprintf("%s typescript && npx tsc%s", p.addDev(), strings.TrimPrefix(p.tscArgs(cmd), "tsc"))
+	}
+
+	if m := tscUnknownOption.FindStringSubmatch(output); m != nil {
+		option := m[2]
+		fixed := closestMatch(option, tscCompilerOptions)
+		switch {
+		case fixed == option:
+			// A real option the installed compiler predates
+			return fmt.Sprintf("%s typescript@latest && %s", p.addDev(), cmd)
+		case fixed == "":
+			return ""
+		case strings.Contains(cmd, "--"+option):
+			return strings.Replace(cmd, "--"+option, "--"+fixed, 1)
+		}
+		file := m[1]
+		if file == "" {
+			file = "tsconfig.json"
+		}
+		return fmt.Sprintf(`sed -i 's/"%s"/"%s"/' %s && %s`, option, fixed, file, cmd)
+	}
+
+	for _, m := range tscMissingModule.FindAllStringSubmatch(output, -1) {
+		if strings.HasPrefix(m[1], ".") || strings.HasPrefix(m[1], "/") {
+			continue
+		}
+		pkg := p.packageName(m[1])
+		if p.isInstalled(pkg) || strings.Contains(output, "TS7016") {
+			return fmt.Sprintf("%s %s && %s", p.addDev(), p.typesPackage(pkg), cmd)
+		}
+		return fmt.Sprintf("%s %s && %s", p.add(), pkg, cmd)
+	}
+
+	return ""
+}
+
+// tscArgs returns the command from tsc onwards, dropping npx
+func (p *TscPlugin) tscArgs(cmd string) string {
+	fields := commandFields(cmd)
+	for i, field := range fields {
+		if field == "tsc" || filepath.Base(field) == "tsc" {
+			return strings.Join(append([]string{"tsc"}, fields[i+1:]...), " ")
+		}
+	}
+	return "tsc"
+}
+
+// packageName strips subpaths: "lodash/fp" is in "lodash", "@scope/pkg/x" in "@scope/pkg"
+func (p *TscPlugin) packageName(module string) string {
+	parts := strings.Split(module, "/")
+	if strings.HasPrefix(module, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// typesPackage returns the DefinitelyTyped package for pkg
+func (p *TscPlugin) typesPackage(pkg string) string {
+	if strings.HasPrefix(pkg, "@") {
+		return "@types/" + strings.Replace(strings.TrimPrefix(pkg, "@"), "/", "__", 1)
+	}
+	return "@types/" + pkg
+}
+
+func (p *TscPlugin) isInstalled(pkg string) bool {
+	_, err := os.Stat(filepath.Join(p.dir(), "node_modules", pkg))
+	return err == nil
+}
+
+func (p *TscPlugin) dir() string {
+	if p.Dir == "" {
+		return "."
+	}
+	return p.Dir
+}
+
+func (p *TscPlugin) exists(name string) bool {
+	_, err := os.Stat(filepath.Join(p.dir(), name))
+	return err == nil
+}
+
+// isWorkspaceRoot reports whether the project is the root of a monorepo
+func (p *TscPlugin) isWorkspaceRoot() bool {
+	if p.exists("pnpm-workspace.yaml") {
+		return true
+	}
+	data, err := os.ReadFile(filepath.Join(p.dir(), "package.json"))
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	return json.Unmarshal(data, &pkg) == nil && len(pkg.Workspaces) > 0
+}
+
+// add returns the install command of the project's package manager.
+// pnpm and Yarn refuse to add packages to a workspace root without -w/-W
+func (p *TscPlugin) add() string {
+	root := p.isWorkspaceRoot()
+	switch {
+	case p.exists("pnpm-lock.yaml"):
+		if root {
+			return "pnpm add -w"
+		}
+		return "pnpm add"
+	case p.exists("yarn.lock"):
+		if root {
+			return "yarn add -W"
+		}
+		return "yarn add"
+	case p.exists("bun.lockb") || p.exists("bun.lock"):
+		return "bun add"
+	}
+	return "npm install"
+}
+
+// addDev is add for devDependencies
+func (p *TscPlugin) addDev() string {
+	switch add := p.add(); {
+	case strings.HasPrefix(add, "npm"):
+		return "npm install --save-dev"
+	case strings.HasPrefix(add, "bun"):
+		return "bun add -d"
+	default:
+		return strings.Replace(add, " add", " add -D", 1)
+	}
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *TscPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "npx tsc --showConfig # print the effective tsconfig"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *TscPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert TypeScript developer familiar with tsc, tsconfig.json and Node.js package managers.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: Node.js project using npm, pnpm, Yarn or Bun
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. For missing type declarations, install @types/<package> as a dev dependency (scoped packages become @types/scope__name)
+3. Use the project's package manager and install TypeScript locally, running it with npx
+4. Fix misspelled compilerOptions in tsconfig.json; upgrade TypeScript when an option is newer than the compiler
+5. Only report errors in the project setup; do not rewrite the user's code
+
+EXAMPLES:
+- Input: "npx tsc" + "error TS7016: Could not find a declaration file for module 'express'."
+- Output: "npm install --save-dev @types/express && npx tsc"
+
+- Input: "tsc" + "tsconfig.json(4,5): error TS5023: Unknown compiler option 'strictNullCheck'."
+- Output: "sed -i 's/\"strictNullCheck\"/\"strictNullChecks\"/' tsconfig.json && tsc"
+
+Provide the corrected command:`, cmd, output)
+}
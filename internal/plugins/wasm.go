@@ -0,0 +1,194 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// WasmPlugin runs a WebAssembly module from PLUGINS_DIR in a sandbox with no
+// file system or network access. The module must export:
+//
+//	memory
+//	logaid_alloc(size u32) u32                 buffer for the host to write into
+//	logaid_match(ptr, len u32) u32             1 if the request matches
+//	logaid_suggest(ptr, len u32) u64           suggestion as ptr<<32 | len
+//
+// where ptr/len point at an ExternalRequest encoded as JSON. It may import
+// from the "logaid" module:
+//
+//	config(keyPtr, keyLen, bufPtr, bufCap u32) i32   value length, -1 if unset
+//	log(ptr, len u32)                                 debug log message
+//
+// Each command/output pair runs in a fresh instance, bounded by PLUGIN_TIMEOUT
+type WasmPlugin struct {
+	Path    string
+	Timeout time.Duration // 0 means defaultExternalTimeout
+
+	compiled wazero.CompiledModule
+	cache    responseCache
+}
+
+var (
+	wasmRuntime     wazero.Runtime
+	wasmRuntimeErr  error
+	wasmRuntimeOnce sync.Once
+)
+
+// sharedWasmRuntime creates the runtime with WASI and the logaid host module
+func sharedWasmRuntime() (wazero.Runtime, error) {
+	wasmRuntimeOnce.Do(func() {
+		ctx := context.Background()
+		runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+		wasi_snapshot_preview1.MustInstantiate(ctx, runtime)
+
+		_, err := runtime.NewHostModuleBuilder("logaid").
+			NewFunctionBuilder().WithFunc(wasmConfig).Export("config").
+			NewFunctionBuilder().WithFunc(wasmLog).Export("log").
+			Instantiate(ctx)
+		if err != nil {
+			wasmRuntimeErr = fmt.Errorf("failed to instantiate host module: %w", err)
+			return
+		}
+		wasmRuntime = runtime
+	})
+	return wasmRuntime, wasmRuntimeErr
+}
+
+// wasmConfig implements logaid.config: it copies the plugin's
+// PLUGIN_<NAME>_<KEY> setting into the guest buffer and returns its length,
+// or -1 when it is unset
+func wasmConfig(ctx context.Context, m api.Module, keyPtr, keyLen, bufPtr, bufCap uint32) int32 {
+	key, ok := m.Memory().Read(keyPtr, keyLen)
+	if !ok {
+		return -1
+	}
+	cfg := config.Current()
+	if cfg == nil {
+		return -1
+	}
+	value, ok := cfg.PluginSetting(m.Name(), string(key))
+	if !ok {
+		return -1
+	}
+	if uint32(len(value)) <= bufCap {
+		m.Memory().Write(bufPtr, []byte(value))
+	}
+	return int32(len(value))
+}
+
+// wasmLog implements logaid.log
+func wasmLog(ctx context.Context, m api.Module, ptr, length uint32) {
+	if message, ok := m.Memory().Read(ptr, length); ok {
+		logger.Debug(fmt.Sprintf("WASM plugin %s: %s", m.Name(), message))
+	}
+}
+
+// NewWasmPlugin compiles the module at path
+func NewWasmPlugin(path string, timeout time.Duration) (*WasmPlugin, error) {
+	runtime, err := sharedWasmRuntime()
+	if err != nil {
+		return nil, err
+	}
+	binary, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	compiled, err := runtime.CompileModule(context.Background(), binary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s: %w", path, err)
+	}
+	for _, export := range []string{"logaid_alloc", "logaid_match", "logaid_suggest"} {
+		if _, ok := compiled.ExportedFunctions()[export]; !ok {
+			return nil, fmt.Errorf("%s does not export %s", path, export)
+		}
+	}
+	return &WasmPlugin{Path: path, Timeout: timeout, compiled: compiled}, nil
+}
+
+func (p *WasmPlugin) Name() string {
+	return strings.TrimSuffix(filepath.Base(p.Path), ".wasm")
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *WasmPlugin) Match(cmd string, output string) bool {
+	resp := p.response(cmd, output)
+	return resp != nil && resp.Match
+}
+
+// Suggest returns the suggestion the module gave for cmd/output
+func (p *WasmPlugin) Suggest(cmd string, output string) string {
+	resp := p.response(cmd, output)
+	if resp == nil || !resp.Match {
+		return ""
+	}
+	return strings.TrimSpace(resp.Suggestion)
+}
+
+// response runs the module, reusing the previous answer for the same input
+func (p *WasmPlugin) response(cmd, output string) *ExternalResponse {
+	return p.cache.get(p.Name(), cmd, output, func() (*ExternalResponse, error) {
+		return p.run(cmd, output)
+	})
+}
+
+// run instantiates the module and calls logaid_match, then logaid_suggest
+func (p *WasmPlugin) run(cmd, output string) (*ExternalResponse, error) {
+	request, err := json.Marshal(ExternalRequest{Command: cmd, Output: output})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = defaultExternalTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Reactor modules (TinyGo, Rust, Go wasip1 c-shared) initialise in _initialize
+	moduleConfig := wazero.NewModuleConfig().WithName(p.Name()).WithStartFunctions("_initialize")
+	module, err := wasmRuntime.InstantiateModule(ctx, p.compiled, moduleConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate: %w", err)
+	}
+	defer module.Close(ctx)
+
+	results, err := module.ExportedFunction("logaid_alloc").Call(ctx, uint64(len(request)))
+	if err != nil {
+		return nil, fmt.Errorf("logaid_alloc failed: %w", err)
+	}
+	ptr := uint32(results[0])
+	if !module.Memory().Write(ptr, request) {
+		return nil, fmt.Errorf("logaid_alloc returned an out of range buffer")
+	}
+
+	results, err = module.ExportedFunction("logaid_match").Call(ctx, uint64(ptr), uint64(len(request)))
+	if err != nil {
+		return nil, fmt.Errorf("logaid_match failed: %w", err)
+	}
+	if uint32(results[0]) == 0 {
+		return &ExternalResponse{}, nil
+	}
+
+	results, err = module.ExportedFunction("logaid_suggest").Call(ctx, uint64(ptr), uint64(len(request)))
+	if err != nil {
+		return nil, fmt.Errorf("logaid_suggest failed: %w", err)
+	}
+	suggestion, ok := module.Memory().Read(uint32(results[0]>>32), uint32(results[0]))
+	if !ok {
+		return nil, fmt.Errorf("logaid_suggest returned an out of range string")
+	}
+	return &ExternalResponse{Match: true, Suggestion: string(suggestion)}, nil
+}
@@ -0,0 +1,193 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// ZypperPlugin handles zypper package manager errors on openSUSE and SLES
+type ZypperPlugin struct{}
+
+var (
+	zypperNotFound = regexp.MustCompile(`(?:No provider of '([^']+)' found|'([^']+)' not found in package names)`)
+	zypperLockPID  = regexp.MustCompile(`locked by the application with pid (\d+) \(([^)]+)\)`)
+)
+
+func (p *ZypperPlugin) Name() string {
+	return "zypper"
+}
+
+// Match checks if this plugin should handle the command/output
+func (p *ZypperPlugin) Match(cmd string, output string) bool {
+	if !isCommand(cmd, "zypper") {
+		return false
+	}
+
+	zypperErrors := []string{
+		"no provider of",
+		"not found in package names",
+		"system management is locked",
+		"root privileges are required",
+		"repository '",
+		"download (curl) error",
+		"problem retrieving files",
+		"valid metadata not found",
+		"signature verification failed",
+		"gpg check failed",
+		"vendor change",
+		"problem:",
+	}
+
+	return containsAny(output, zypperErrors)
+}
+
+// Suggest generates an AI-powered suggestion for the error
+func (p *ZypperPlugin) Suggest(cmd string, output string) string {
+	// First try manual corrections for speed
+	if quickFix := p.getQuickFix(cmd, output); quickFix != "" {
+		return quickFix
+	}
+
+	// Use AI for complex suggestions
+	return p.getAISuggestion(cmd, output)
+}
+
+// getQuickFix provides immediate fixes for common issues
+func (p *ZypperPlugin) getQuickFix(cmd string, output string) string {
+	outputLower := strings.ToLower(output)
+
+	// Handle lock errors: PackageKit can be stopped, anything else is waited for
+	if m := zypperLockPID.FindStringSubmatch(output); m != nil {
+		if strings.Contains(m[2], "packagekit") {
+			return "sudo systemctl stop packagekit && " + cmd
+		}
+		return fmt.Sprintf("while [ -e /proc/%s ]; do sleep 1; done; %s", m[1], cmd)
+	}
+
+	// Handle permission errors
+	if strings.Contains(outputLower, "root privileges are required") && !strings.HasPrefix(cmd, "sudo ") {
+		return "sudo " + cmd
+	}
+
+	// Handle untrusted repository keys
+	if strings.Contains(outputLower, "signature verification failed") || strings.Contains(outputLower, "gpg check failed") {
+		return "sudo zypper --gpg-auto-import-keys refresh && " + cmd
+	}
+
+	// Handle stale or unreachable repository metadata
+	if strings.Contains(outputLower, "download (curl) error") || strings.Contains(outputLower, "problem retrieving files") ||
+		strings.Contains(outputLower, "valid metadata not found") {
+		return "sudo zypper refresh && " + cmd
+	}
+
+	// Handle packages moving between vendors (e.g. Packman and the main repository)
+	if strings.Contains(outputLower, "vendor change") && !strings.Contains(cmd, "--allow-vendor-change") {
+		return cmd + " --allow-vendor-change"
+	}
+
+	// Common package name corrections
+	if m := zypperNotFound.FindStringSubmatch(output); m != nil {
+		packageName := m[1]
+		if packageName == "" {
+			packageName = m[2]
+		}
+		if correction := p.getPackageCorrection(packageName); correction != "" {
+			return strings.Replace(cmd, packageName, correction, 1)
+		}
+		return "zypper search " + packageName
+	}
+
+	return ""
+}
+
+// getPackageCorrection maps Debian/Fedora package names to their SUSE equivalents
+func (p *ZypperPlugin) getPackageCorrection(packageName string) string {
+	corrections := map[string]string{
+		"build-essential":   "-t pattern devel_basis",
+		"g++":               "gcc-c++",
+		"libssl-dev":        "libopenssl-devel",
+		"openssl-devel":     "libopenssl-devel",
+		"zlib1g-dev":        "zlib-devel",
+		"python3-dev":       "python3-devel",
+		"python3-venv":      "python3",
+		"httpd":             "apache2",
+		"mysql-server":      "mariadb",
+		"mysql":             "mariadb",
+		"redis-tools":       "redis",
+		"redis-cli":         "redis",
+		"default-jdk":       "java-21-openjdk-devel",
+		"openjdk":           "java-21-openjdk",
+		"docker.io":         "docker",
+		"docker-ce":         "docker",
+		"dnsutils":          "bind-utils",
+		"netcat":            "netcat-openbsd",
+		"golang":            "go",
+		"postgresql-client": "postgresql",
+		"vim-gtk":           "gvim",
+		"fd-find":           "fd",
+		"openssh-client":    "openssh-clients",
+		"chromium-browser":  "chromium",
+	}
+
+	corrections = mergeCorrections(p.Name(), correctionPackages, corrections)
+	lower := strings.ToLower(packageName)
+	if correction, exists := corrections[lower]; exists && correction != packageName {
+		return correction
+	}
+
+	// Debian development packages are -devel on SUSE
+	if strings.HasSuffix(lower, "-dev") {
+		return strings.TrimSuffix(packageName, "-dev") + "-devel"
+	}
+
+	return ""
+}
+
+// getAISuggestion uses AI to generate intelligent suggestions
+func (p *ZypperPlugin) getAISuggestion(cmd string, output string) string {
+	prompt := p.buildAIPrompt(cmd, ai.TruncateOutput(output))
+
+	ctx := context.Background()
+	suggestion, err := ai.GetSuggestionFor(ctx, p.Name(), prompt)
+	if err != nil {
+		// Fallback to generic suggestion
+		return "sudo zypper refresh && zypper search <package-name>"
+	}
+
+	return suggestion
+}
+
+// buildAIPrompt creates a detailed prompt for the AI
+func (p *ZypperPlugin) buildAIPrompt(cmd string, output string) string {
+	return fmt.Sprintf(`
+You are an expert SUSE Linux system administrator specializing in zypper package management on openSUSE and SLES.
+
+CONTEXT:
+- User executed command: %s
+- Command output/error: %s
+- System: openSUSE/SLES with zypper package manager
+- Goal: Provide the EXACT corrected command to fix the issue
+
+TASK:
+Analyze the command and error, then provide a single, executable command that will resolve the issue.
+
+RULES:
+1. Return ONLY the corrected command, no explanations
+2. Use SUSE package names (-devel suffix, gcc-c++, patterns via -t pattern)
+3. Include sudo if needed for permissions
+4. Handle common issues: repository refresh failures, GPG keys, vendor change conflicts, locks
+5. If package doesn't exist, suggest the closest alternative
+
+EXAMPLES:
+- Input: "sudo zypper install libssl-dev" + "No provider of 'libssl-dev' found."
+- Output: "sudo zypper install libopenssl-devel"
+
+- Input: "sudo zypper dup" + "Problem: ... (with vendor change)"
+- Output: "sudo zypper dup --allow-vendor-change"
+
+Provide the corrected command:`, cmd, output)
+}
@@ -0,0 +1,213 @@
+// Package privacy deletes the command lines and output LogAid stores: the
+// history, the suggestion and registry caches, the log file, recorded
+// sessions, the AI audit log and what was learned from accepted fixes.
+// Purge removes all of it; Retain drops what is older than RETENTION_DAYS.
+//
+// The exec audit log is only removed by Purge when asked to. It is never
+// trimmed by age because every record carries the hash of the previous one,
+// and dropping the oldest would break 'logaid audit --verify'.
+package privacy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/cache"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/learning"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/ayushsharma-1/LogAid/internal/session"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// Stored data, as named by Target.Name
+const (
+	History     = "history"
+	Suggestions = "suggestion cache"
+	Lookups     = "registry lookup cache"
+	Log         = "log file"
+	Sessions    = "sessions"
+	AIAudit     = "AI audit log"
+	ExecAudit   = "exec audit log"
+	Learning    = "learned outcomes"
+	Model       = "personal model"
+)
+
+// Target is a file or directory holding commands or their output
+type Target struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Removed bool   `json:"removed"` // it existed and was deleted
+}
+
+// Targets returns what Purge deletes. The exec audit log is only included
+// with includeExecAudit
+func Targets(includeExecAudit bool) []Target {
+	cfg := config.Current()
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	cacheDir := cfg.CacheDir
+	if cacheDir == "" {
+		cacheDir = filepath.Join(config.Dir(), "cache")
+	}
+
+	targets := []Target{
+		{Name: History, Path: cfg.HistoryFile},
+		{Name: Suggestions, Path: filepath.Join(cacheDir, "suggestions.json")},
+		{Name: Lookups, Path: filepath.Join(cacheDir, "lookups.json")},
+		{Name: Log, Path: logPath()},
+		{Name: Sessions, Path: cfg.SessionsDir},
+		{Name: AIAudit, Path: cfg.AIAuditLogFile},
+		{Name: Learning, Path: filepath.Join(config.Dir(), "learning.json")},
+		{Name: Model, Path: filepath.Join(config.Dir(), "model.json")},
+	}
+	if includeExecAudit {
+		targets = append(targets, Target{Name: ExecAudit, Path: cfg.ExecAuditLogFile})
+	}
+
+	kept := targets[:0]
+	for _, target := range targets {
+		if target.Path != "" {
+			kept = append(kept, target)
+		}
+	}
+	return kept
+}
+
+// logPath returns the log file this process writes to, or LOG_FILE
+func logPath() string {
+	cfg := config.Current()
+	if path := logger.Path(); path != "" {
+		return path
+	}
+	if cfg != nil {
+		return cfg.LogFile
+	}
+	return ""
+}
+
+// Purge deletes every target and returns them with Removed set on those
+// that existed. The log file is emptied rather than deleted so running
+// processes keep logging to it, and only the session files LogAid saved are
+// deleted from SESSIONS_DIR
+func Purge(includeExecAudit bool) ([]Target, error) {
+	targets := Targets(includeExecAudit)
+	for i := range targets {
+		target := &targets[i]
+		if !exists(target.Path) {
+			continue
+		}
+		var err error
+		switch target.Name {
+		case Log:
+			err = logger.Prune(target.Path, time.Now())
+		case Sessions:
+			_, err = session.Purge(target.Path)
+		default:
+			err = os.Remove(target.Path)
+		}
+		if err != nil {
+			return targets, fmt.Errorf("failed to remove %s: %w", target.Name, err)
+		}
+		target.Removed = true
+	}
+	return targets, nil
+}
+
+// Retain deletes what was stored before cutoff and returns how many entries
+// were removed from each target. The log file and the AI audit log are
+// trimmed without counting their lines
+func Retain(cutoff time.Time) (map[string]int, error) {
+	removed := map[string]int{}
+	age := time.Since(cutoff)
+	for _, target := range Targets(false) {
+		if !exists(target.Path) {
+			continue
+		}
+		var n int
+		var err error
+		switch target.Name {
+		case History:
+			n, err = history.New(target.Path, maxHistoryEntries()).Prune(cutoff)
+		case Suggestions:
+			n, err = cache.New(target.Path, 0, cache.DefaultSimilarity).Clear(cache.Filter{OlderThan: age})
+		case Lookups:
+			n, err = plugins.ClearLookupCache(cache.Filter{OlderThan: age})
+		case Sessions:
+			n, err = session.Prune(target.Path, cutoff)
+		case Learning:
+			n, err = learning.New(target.Path).Prune(cutoff)
+		case Log:
+			err = logger.Prune(target.Path, cutoff)
+		case AIAudit:
+			err = ai.PruneAuditLog(target.Path, cutoff)
+		default:
+			// The personal model keeps counts per word, not commands
+			continue
+		}
+		if err != nil {
+			return removed, fmt.Errorf("failed to apply retention to %s: %w", target.Name, err)
+		}
+		removed[target.Name] = n
+	}
+	return removed, nil
+}
+
+func maxHistoryEntries() int {
+	cfg := config.Current()
+	if cfg == nil {
+		return 0
+	}
+	return cfg.MaxHistoryEntries
+}
+
+// retentionState is the on-disk layout of ~/.logaid/retention.json
+type retentionState struct {
+	LastRun time.Time `json:"last_run"`
+}
+
+// Enforce applies RETENTION_DAYS. It does nothing when the setting is 0 and
+// runs at most once a day
+func Enforce() error {
+	cfg := config.Current()
+	if cfg == nil || cfg.RetentionDays <= 0 {
+		return nil
+	}
+	path := filepath.Join(config.Dir(), "retention.json")
+	now := time.Now()
+	var last retentionState
+	if err := state.ReadJSON(path, &last); err != nil || now.Sub(last.LastRun) < 24*time.Hour {
+		return err
+	}
+	// Claim the run so concurrent invocations do not repeat it
+	due := false
+	err := state.UpdateJSON(path, &last, func() error {
+		due = now.Sub(last.LastRun) >= 24*time.Hour
+		if due {
+			last.LastRun = now
+		}
+		return nil
+	})
+	if err != nil || !due {
+		return err
+	}
+
+	cutoff := now.AddDate(0, 0, -cfg.RetentionDays)
+	removed, err := Retain(cutoff)
+	if err != nil {
+		return err
+	}
+	logger.Debug(fmt.Sprintf("Retention of %d days removed %v", cfg.RetentionDays, removed))
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,88 @@
+// Package redact scrubs secrets and personal details from text before it is
+// shared, e.g. in 'logaid report', or written to the log and history
+package redact
+
+import (
+	"os"
+	"os/user"
+	"regexp"
+	"strings"
+)
+
+// Mask replaces a redacted value
+const Mask = "[REDACTED]"
+
+// rule is a secret pattern. When the pattern has a group, only the group is
+// masked so the surrounding context (e.g. the variable name) stays readable
+type rule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+var rules = []rule{
+	{"private key", regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{"Slack webhook", regexp.MustCompile(`https://hooks\.slack\.com/services/[A-Za-z0-9/]+`)},
+	{"API key", regexp.MustCompile(`\b(?:sk|pk|rk)-(?:[A-Za-z0-9_-]*-)?[A-Za-z0-9]{20,}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\b`)},
+	{"authorization header", regexp.MustCompile(`(?i)\b(?:authorization:\s*(?:bearer|basic|token)|bearer)\s+([A-Za-z0-9._~+/=-]{8,})`)},
+	{"URL credentials", regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:([^/\s@]+)@`)},
+	{"secret assignment", regexp.MustCompile(`(?i)\b[A-Z0-9_.-]*(?:password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|credentials?)[A-Z0-9_.-]*\s*[:=]\s*["']?([^\s"',;]+)`)},
+	{"secret flag", regexp.MustCompile(`(?i)--(?:password|passwd|token|secret|api-key|access-key)[= ]\s*["']?([^\s"']+)`)},
+	{"MySQL password", regexp.MustCompile(`\b(?:mysql|mysqldump|mysqladmin|mariadb)\b[^\n]*?\s-p["']?([^\s"']+)`)},
+	{"sshpass password", regexp.MustCompile(`\bsshpass\s+-p\s*["']?([^\s"']+)`)},
+	{"curl credentials", regexp.MustCompile(`\b(?:curl|wget)\b[^\n]*?\s(?:-u|--user)[= ]\s*["']?[^\s:"']+:([^\s"']+)`)},
+}
+
+// String returns s with secrets masked, the home directory shortened to ~ and
+// the user and host names replaced
+func String(s string) string {
+	return personal(Secrets(s))
+}
+
+// Secrets returns s with secrets masked, keeping paths and names
+func Secrets(s string) string {
+	for _, r := range rules {
+		s = replace(r.pattern, s)
+	}
+	return s
+}
+
+// replace masks every match of pattern, or only its first group if it has one
+func replace(pattern *regexp.Regexp, s string) string {
+	if pattern.NumSubexp() == 0 {
+		return pattern.ReplaceAllString(s, Mask)
+	}
+	return pattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := pattern.FindStringSubmatchIndex(match)
+		if len(groups) < 4 || groups[2] < 0 {
+			return Mask
+		}
+		return match[:groups[2]] + Mask + match[groups[3]:]
+	})
+}
+
+// personal replaces the home directory, user name and host name, which
+// identify the machine the report came from
+func personal(s string) string {
+	if home, err := os.UserHomeDir(); err == nil && len(home) > 1 {
+		s = strings.ReplaceAll(s, home, "~")
+	}
+	if host, err := os.Hostname(); err == nil && len(host) > 2 {
+		s = replaceWord(s, host, "<host>")
+	}
+	if u, err := user.Current(); err == nil && len(u.Username) > 2 && u.Username != "root" {
+		s = replaceWord(s, u.Username, "<user>")
+	}
+	return s
+}
+
+// replaceWord replaces old where it is not part of a longer word
+func replaceWord(s, old, new string) string {
+	pattern := regexp.MustCompile(`(^|[^A-Za-z0-9_-])` + regexp.QuoteMeta(old) + `($|[^A-Za-z0-9_-])`)
+	return pattern.ReplaceAllString(s, "${1}"+new+"${2}")
+}
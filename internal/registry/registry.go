@@ -0,0 +1,364 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// maxDownloadSize bounds the index and every plugin file
+const maxDownloadSize = 64 << 20
+
+// Index lists the plugins a registry offers
+type Index struct {
+	Plugins []Entry `json:"plugins"`
+}
+
+// Entry is one installable plugin: a rule pack, Lua script, WASM module or
+// executable, possibly built for several platforms
+type Entry struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Version     string     `json:"version"`
+	Files       []Artifact `json:"files"`
+}
+
+// Artifact is a file installed into PLUGINS_DIR
+type Artifact struct {
+	Name       string `json:"name"`               // file name in PLUGINS_DIR
+	URL        string `json:"url"`                // absolute, or relative to the index
+	SHA256     string `json:"sha256"`             // hex digest, required
+	Platform   string `json:"platform,omitempty"` // GOOS/GOARCH; empty for any
+	Executable bool   `json:"executable,omitempty"`
+}
+
+// Installed records a plugin installed from the registry
+type Installed struct {
+	Version string    `json:"version"`
+	Files   []string  `json:"files"`
+	Updated time.Time `json:"updated"`
+}
+
+// Registry installs plugins from an index into a plugins directory. Source is
+// the index URL (https:// or file path), or a git repository (ending in .git
+// or prefixed with git+) with index.json at its root
+type Registry struct {
+	Source    string
+	Dir       string // PLUGINS_DIR
+	Manifest  string // JSON file recording installed plugins
+	Client    *http.Client
+	CloneRoot string // where git registries are cloned
+}
+
+// New creates a registry for source that installs into dir
+func New(source, dir string) *Registry {
+	return &Registry{
+		Source:    source,
+		Dir:       dir,
+		Manifest:  filepath.Join(config.Dir(), "plugins.json"),
+		Client:    &http.Client{Timeout: 30 * time.Second},
+		CloneRoot: filepath.Join(config.Dir(), "registry"),
+	}
+}
+
+// NewFromConfig creates the registry for PLUGIN_REGISTRY and PLUGINS_DIR
+func NewFromConfig() (*Registry, error) {
+	cfg := config.Current()
+	if cfg == nil || cfg.PluginRegistry == "" {
+		return nil, fmt.Errorf("PLUGIN_REGISTRY is not set")
+	}
+	if cfg.PluginsDir == "" {
+		return nil, fmt.Errorf("PLUGINS_DIR is not set")
+	}
+	return New(cfg.PluginRegistry, cfg.PluginsDir), nil
+}
+
+// Index fetches the plugin index
+func (r *Registry) Index(ctx context.Context) (*Index, error) {
+	base, err := r.base(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.fetch(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin index: %w", err)
+	}
+	sort.Slice(index.Plugins, func(i, j int) bool {
+		return index.Plugins[i].Name < index.Plugins[j].Name
+	})
+	return &index, nil
+}
+
+// Find returns the entry called name
+func (index *Index) Find(name string) (*Entry, bool) {
+	for i := range index.Plugins {
+		if index.Plugins[i].Name == name {
+			return &index.Plugins[i], true
+		}
+	}
+	return nil, false
+}
+
+// Installed returns the plugins installed from the registry by name
+func (r *Registry) Installed() (map[string]Installed, error) {
+	installed := map[string]Installed{}
+	if err := state.ReadJSON(r.Manifest, &installed); err != nil {
+		return nil, err
+	}
+	return installed, nil
+}
+
+// Install downloads the plugin called name, verifies its checksums and writes
+// it into Dir. Nothing is written unless every file verifies
+func (r *Registry) Install(ctx context.Context, name string) (*Entry, error) {
+	index, err := r.Index(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := index.Find(name)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s is not in the registry", name)
+	}
+	if err := r.install(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Update reinstalls the given installed plugins (all of them if names is
+// empty) whose registry version changed, and returns the updated entries
+func (r *Registry) Update(ctx context.Context, names []string) ([]Entry, error) {
+	installed, err := r.Installed()
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		for name := range installed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	index, err := r.Index(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []Entry
+	for _, name := range names {
+		current, ok := installed[name]
+		if !ok {
+			return updated, fmt.Errorf("plugin %s is not installed", name)
+		}
+		entry, ok := index.Find(name)
+		if !ok || entry.Version == current.Version {
+			continue
+		}
+		if err := r.install(ctx, entry); err != nil {
+			return updated, err
+		}
+		updated = append(updated, *entry)
+	}
+	return updated, nil
+}
+
+// Remove deletes the files of an installed plugin
+func (r *Registry) Remove(name string) error {
+	installed := map[string]Installed{}
+	return state.UpdateJSON(r.Manifest, &installed, func() error {
+		plugin, ok := installed[name]
+		if !ok {
+			return fmt.Errorf("plugin %s is not installed", name)
+		}
+		for _, file := range plugin.Files {
+			if err := os.Remove(filepath.Join(r.Dir, file)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", file, err)
+			}
+		}
+		delete(installed, name)
+		return nil
+	})
+}
+
+// install downloads and verifies every artifact of entry for this platform,
+// then writes them and records the plugin in the manifest
+func (r *Registry) install(ctx context.Context, entry *Entry) error {
+	artifacts := entry.artifacts(runtime.GOOS + "/" + runtime.GOARCH)
+	if len(artifacts) == 0 {
+		return fmt.Errorf("plugin %s is not available for %s/%s", entry.Name, runtime.GOOS, runtime.GOARCH)
+	}
+
+	base, err := r.base(ctx)
+	if err != nil {
+		return err
+	}
+
+	contents := make([][]byte, len(artifacts))
+	for i, artifact := range artifacts {
+		if artifact.Name == "" || artifact.Name != filepath.Base(artifact.Name) || strings.HasPrefix(artifact.Name, ".") {
+			return fmt.Errorf("plugin %s has an invalid file name %q", entry.Name, artifact.Name)
+		}
+		location, err := resolve(base, artifact.URL)
+		if err != nil {
+			return err
+		}
+		data, err := r.fetch(ctx, location)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		if artifact.SHA256 == "" || !strings.EqualFold(hex.EncodeToString(sum[:]), artifact.SHA256) {
+			return fmt.Errorf("checksum mismatch for %s: got %x, want %s", artifact.Name, sum, artifact.SHA256)
+		}
+		contents[i] = data
+	}
+
+	installed := map[string]Installed{}
+	return state.UpdateJSON(r.Manifest, &installed, func() error {
+		// Files the previous version had and this one does not
+		for _, old := range installed[entry.Name].Files {
+			if !hasArtifact(artifacts, old) {
+				os.Remove(filepath.Join(r.Dir, old))
+			}
+		}
+
+		var files []string
+		for i, artifact := range artifacts {
+			perm := os.FileMode(0644)
+			if artifact.Executable {
+				perm = 0755
+			}
+			if err := state.WriteFileAtomic(filepath.Join(r.Dir, artifact.Name), contents[i], perm); err != nil {
+				return err
+			}
+			files = append(files, artifact.Name)
+		}
+		installed[entry.Name] = Installed{Version: entry.Version, Files: files, Updated: time.Now()}
+		return nil
+	})
+}
+
+// artifacts returns the files of entry to install on platform
+func (e *Entry) artifacts(platform string) []Artifact {
+	var artifacts []Artifact
+	for _, artifact := range e.Files {
+		if artifact.Platform == "" || artifact.Platform == platform {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return artifacts
+}
+
+// hasArtifact reports whether artifacts contains a file called name
+func hasArtifact(artifacts []Artifact, name string) bool {
+	for _, artifact := range artifacts {
+		if artifact.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// base returns the location of index.json, cloning or pulling git registries
+func (r *Registry) base(ctx context.Context) (string, error) {
+	repo := strings.TrimPrefix(r.Source, "git+")
+	if repo == r.Source && !strings.HasSuffix(r.Source, ".git") {
+		return r.Source, nil
+	}
+
+	dir := filepath.Join(r.CloneRoot, strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(repo))
+	var cmd *exec.Cmd
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		cmd = exec.CommandContext(ctx, "git", "-C", dir, "pull", "--ff-only", "--quiet")
+	} else {
+		cmd = exec.CommandContext(ctx, "git", "clone", "--depth", "1", "--quiet", repo, dir)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to fetch registry %s: %w: %s", repo, err, strings.TrimSpace(string(out)))
+	}
+	return filepath.Join(dir, "index.json"), nil
+}
+
+// resolve turns an artifact URL relative to the index into a location
+func resolve(base, ref string) (string, error) {
+	if ref == "" {
+		return "", fmt.Errorf("artifact has no url")
+	}
+	if isHTTP(base) {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return "", fmt.Errorf("invalid registry url: %w", err)
+		}
+		refURL, err := url.Parse(ref)
+		if err != nil {
+			return "", fmt.Errorf("invalid artifact url: %w", err)
+		}
+		return baseURL.ResolveReference(refURL).String(), nil
+	}
+	if isHTTP(ref) || filepath.IsAbs(ref) {
+		return ref, nil
+	}
+	return filepath.Join(filepath.Dir(base), filepath.FromSlash(ref)), nil
+}
+
+// fetch reads an http(s) URL or a local file
+func (r *Registry) fetch(ctx context.Context, location string) ([]byte, error) {
+	if !isHTTP(location) {
+		data, err := os.ReadFile(strings.TrimPrefix(location, "file://"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", location, err)
+		}
+		return data, nil
+	}
+	if strings.HasPrefix(location, "http://") {
+		return nil, fmt.Errorf("refusing to download %s over plain http", location)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", location, resp.Status)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(resp.Body, maxDownloadSize+1)); err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", location, err)
+	}
+	if buf.Len() > maxDownloadSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", location, maxDownloadSize)
+	}
+	return buf.Bytes(), nil
+}
+
+// isHTTP reports whether location is an http or https URL
+func isHTTP(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
@@ -0,0 +1,240 @@
+// Package report builds a shareable description of a failure — the command,
+// its output, the environment and the fixes tried — with secrets scrubbed
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/redact"
+	"github.com/ayushsharma-1/LogAid/internal/session"
+)
+
+// maxOutputLines is how much of the output a report keeps, from the end
+const maxOutputLines = 200
+
+// Attempt is a fix LogAid suggested for the failure
+type Attempt struct {
+	Suggestion string `json:"suggestion"`
+	Source     string `json:"source"`
+	Outcome    string `json:"outcome"` // applied, failed, rejected, proposed or the decision
+}
+
+// Field is a line of the environment section
+type Field struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Report describes a failure
+type Report struct {
+	Command     string    `json:"command"`
+	Time        time.Time `json:"time"`
+	ExitCode    *int      `json:"exit_code,omitempty"`
+	Output      string    `json:"output"`
+	Truncated   bool      `json:"truncated,omitempty"` // Output only holds the last lines
+	Attempts    []Attempt `json:"attempts"`
+	Explanation string    `json:"explanation,omitempty"`
+	Environment []Field   `json:"environment"`
+}
+
+// FromHistory describes the failure of entry, with every suggestion made for
+// the same command in entries as an attempt
+func FromHistory(entry history.Entry, entries []history.Entry) *Report {
+	r := &Report{Command: entry.Command, Time: entry.Time, Output: entry.Output, Explanation: entry.Explanation}
+	for _, e := range entries {
+		if e.Command == entry.Command && e.ID <= entry.ID {
+			r.Attempts = append(r.Attempts, Attempt{Suggestion: e.Suggestion, Source: e.Source, Outcome: e.Status})
+		}
+	}
+	if len(r.Attempts) == 0 && entry.Suggestion != "" {
+		r.Attempts = []Attempt{{Suggestion: entry.Suggestion, Source: entry.Source, Outcome: entry.Status}}
+	}
+	return r
+}
+
+// FromSession describes the failure recorded in a session
+func FromSession(s *session.Session) *Report {
+	r := &Report{Command: s.Command, Time: s.Started}
+	var output strings.Builder
+	for i, event := range s.Events {
+		switch event.Type {
+		case session.EventOutput:
+			if event.Stream != session.StreamLog {
+				output.WriteString(event.Data)
+			}
+		case session.EventExit:
+			r.ExitCode = event.Code
+		case session.EventSuggestion:
+			r.Attempts = append(r.Attempts, Attempt{Suggestion: event.Data, Source: event.Source, Outcome: outcome(s.Events[i+1:])})
+		}
+	}
+	r.Output = output.String()
+	return r
+}
+
+// outcome describes what happened to a suggestion from the events after it
+func outcome(events []session.Event) string {
+	decision := "proposed"
+	for _, event := range events {
+		switch event.Type {
+		case session.EventDecision:
+			decision = event.Data
+		case session.EventFix:
+			if event.Code != nil && *event.Code != 0 {
+				return history.StatusFailed
+			}
+			return history.StatusApplied
+		case session.EventSuggestion:
+			return decision
+		}
+	}
+	return decision
+}
+
+// AddEnvironment describes the machine and LogAid setup, without anything
+// that identifies the user
+func (r *Report) AddEnvironment(version string) {
+	cfg := config.Current()
+	r.Environment = append(r.Environment,
+		Field{"LogAid", version},
+		Field{"OS", runtime.GOOS + "/" + runtime.GOARCH},
+	)
+	if shell := os.Getenv("SHELL"); shell != "" {
+		r.Environment = append(r.Environment, Field{"Shell", filepath.Base(shell)})
+	}
+	if cfg != nil && cfg.AIProvider != "" {
+		r.Environment = append(r.Environment, Field{"AI provider", cfg.AIProvider})
+	}
+}
+
+// Redact scrubs secrets and personal details from everything in the report
+// and keeps only the end of long output
+func (r *Report) Redact() {
+	r.Command = redact.String(r.Command)
+	r.Output = redact.String(r.Output)
+	r.Explanation = redact.String(r.Explanation)
+	for i := range r.Attempts {
+		r.Attempts[i].Suggestion = redact.String(r.Attempts[i].Suggestion)
+	}
+
+	lines := strings.Split(strings.TrimRight(r.Output, "\n"), "\n")
+	if len(lines) > maxOutputLines {
+		r.Output = strings.Join(lines[len(lines)-maxOutputLines:], "\n")
+		r.Truncated = true
+	}
+}
+
+// Markdown renders the report for a GitHub issue
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Failure: `%s`\n\n", strings.ReplaceAll(r.Command, "`", "'"))
+	if summary := r.Summary(); summary != "" {
+		b.WriteString(summary + "\n\n")
+	}
+
+	b.WriteString("### Output\n\n")
+	if r.Truncated {
+		fmt.Fprintf(&b, "_Last %d lines._\n\n", maxOutputLines)
+	}
+	fence := "```"
+	for strings.Contains(r.Output, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(&b, "%s\n%s\n%s\n\n", fence, strings.TrimRight(r.Output, "\n"), fence)
+
+	if len(r.Attempts) > 0 {
+		b.WriteString("### Attempted fixes\n\n")
+		b.WriteString("| # | Suggestion | Source | Outcome |\n|---|---|---|---|\n")
+		for i, attempt := range r.Attempts {
+			fmt.Fprintf(&b, "| %d | `%s` | %s | %s |\n", i+1, cell(attempt.Suggestion), cell(attempt.Source), cell(attempt.Outcome))
+		}
+		b.WriteString("\n")
+	}
+
+	if r.Explanation != "" {
+		b.WriteString("### Explanation\n\n" + r.Explanation + "\n\n")
+	}
+
+	b.WriteString("### Environment\n\n")
+	for _, field := range r.Environment {
+		fmt.Fprintf(&b, "- **%s:** %s\n", field.Name, field.Value)
+	}
+	b.WriteString("\n_Generated by `logaid report`; secrets were redacted automatically._\n")
+	return b.String()
+}
+
+// Summary says when the command failed and how
+func (r *Report) Summary() string {
+	var parts []string
+	if !r.Time.IsZero() {
+		parts = append(parts, "Failed on "+r.Time.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+	if r.ExitCode != nil {
+		parts = append(parts, fmt.Sprintf("exit status %d", *r.ExitCode))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, ", ") + "."
+}
+
+// cell escapes a value for a markdown table cell
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "`", "'")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>LogAid report: {{.Command}}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; }
+pre { background: #f4f4f4; padding: 1em; overflow-x: auto; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+</style>
+</head>
+<body>
+<h2>Failure: <code>{{.Command}}</code></h2>
+{{with .Summary}}<p>{{.}}</p>{{end}}
+<h3>Output</h3>
+{{if .Truncated}}<p><em>Last lines only.</em></p>{{end}}
+<pre>{{.Output}}</pre>
+{{if .Attempts}}<h3>Attempted fixes</h3>
+<table>
+<tr><th>#</th><th>Suggestion</th><th>Source</th><th>Outcome</th></tr>
+{{range $i, $a := .Attempts}}<tr><td>{{inc $i}}</td><td><code>{{$a.Suggestion}}</code></td><td>{{$a.Source}}</td><td>{{$a.Outcome}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Explanation}}<h3>Explanation</h3>
+<p>{{.Explanation}}</p>{{end}}
+<h3>Environment</h3>
+<ul>
+{{range .Environment}}<li><strong>{{.Name}}:</strong> {{.Value}}</li>
+{{end}}</ul>
+<p><em>Generated by logaid report; secrets were redacted automatically.</em></p>
+</body>
+</html>
+`))
+
+// HTML renders the report as a standalone page
+func (r *Report) HTML() (string, error) {
+	var b bytes.Buffer
+	if err := htmlTemplate.Execute(&b, r); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,125 @@
+package risk
+
+import "strings"
+
+// simpleCommand is one command of a command line, with its quotes removed
+type simpleCommand struct {
+	args      []string
+	overwrite []string // files its output is redirected to with > or >|
+}
+
+// parse splits a shell command line into the commands it runs. It follows
+// quotes, escapes, separators and redirections, which is enough to classify
+// a fix without running it; expansions are left as they are
+func parse(line string) []simpleCommand {
+	var commands []simpleCommand
+	var current simpleCommand
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	// What the next word is: an argument, a file overwritten, or the target
+	// of another redirection, which is skipped
+	const (
+		argument = iota
+		overwritten
+		skipped
+	)
+	next := argument
+
+	flushWord := func() {
+		if !inWord {
+			return
+		}
+		switch next {
+		case overwritten:
+			current.overwrite = append(current.overwrite, word.String())
+		case argument:
+			current.args = append(current.args, word.String())
+		}
+		word.Reset()
+		inWord, next = false, argument
+	}
+	flushCommand := func() {
+		flushWord()
+		if len(current.args) > 0 || len(current.overwrite) > 0 {
+			commands = append(commands, current)
+		}
+		current, next = simpleCommand{}, argument
+	}
+
+	runes := []rune(line)
+	peek := func(i int) rune {
+		if i < len(runes) {
+			return runes[i]
+		}
+		return 0
+	}
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			if r == '\\' && quote == '"' && strings.ContainsRune("\"\\$`", peek(i+1)) {
+				i++
+				r = runes[i]
+			}
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == '#' && !inWord:
+			// A comment runs to the end of the line
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case r == ' ' || r == '\t':
+			flushWord()
+		case strings.ContainsRune(";&|\n(){}`", r):
+			flushCommand()
+		case r == '>' || r == '<':
+			// A file descriptor before the operator is not a word: 2>err.log
+			if inWord && isDigits(word.String()) {
+				word.Reset()
+				inWord = false
+			}
+			flushWord()
+			next = skipped
+			switch {
+			case r == '<':
+				if peek(i+1) == '<' {
+					i++ // a here-document's delimiter
+				}
+			case peek(i+1) == '>':
+				i++ // appends
+			case peek(i+1) == '&':
+				i++ // duplicates a file descriptor: 2>&1
+			case peek(i+1) == '|':
+				i++
+				next = overwritten
+			default:
+				next = overwritten
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	flushCommand()
+	return commands
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,400 @@
+// Package risk classifies suggested fixes by what running them could break,
+// so that a typo correction is confirmed with one key while a fix that
+// deletes files has to be typed back
+package risk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// Level is how much damage running a command can do
+type Level int
+
+// Risk levels, from least to most dangerous
+const (
+	Safe        Level = iota // reads or changes nothing outside the project
+	Moderate                 // changes the system: packages, services, permissions
+	Destructive              // deletes or overwrites data that cannot be recovered
+)
+
+// String returns the level's name, as used in notification rules
+func (l Level) String() string {
+	switch l {
+	case Moderate:
+		return "moderate"
+	case Destructive:
+		return "destructive"
+	}
+	return "safe"
+}
+
+// Assessment is the level of a command and why it was given
+type Assessment struct {
+	Level  Level
+	Reason string // empty for safe commands
+}
+
+// raise keeps the more dangerous of a and the level and reason given
+func (a *Assessment) raise(level Level, reason string) {
+	if level > a.Level {
+		a.Level, a.Reason = level, reason
+	}
+}
+
+// Patterns checked against the whole command line
+var (
+	diskWritePattern   = regexp.MustCompile(`>\s*/dev/(?:sd|hd|vd|xvd|nvme|mmcblk|disk)`)
+	sqlDropPattern     = regexp.MustCompile(`(?i)\b(?:drop\s+(?:database|schema|table)|truncate\s+table)\b`)
+	pipeToShellPattern = regexp.MustCompile(`\b(?:curl|wget)\b[^|]*\|\s*(?:sudo\s+)?(?:ba|z|da)?sh\b`)
+	functionPattern    = regexp.MustCompile(`([^\s(){};|&]+)\s*\(\)\s*\{([^}]*)\}`)
+)
+
+// maxDepth is how deeply scripts run by sh -c or eval are followed
+const maxDepth = 4
+
+// shells run the script given to -c, which is classified like a command line
+var shells = map[string]bool{"sh": true, "bash": true, "zsh": true, "dash": true, "ksh": true, "fish": true}
+
+// systemDirs hold files whose loss breaks the system, e.g. /etc/passwd
+var systemDirs = []string{"/etc/", "/boot/", "/usr/", "/bin/", "/sbin/", "/lib/", "/lib64/", "/var/lib/"}
+
+// wrappers run the command that follows them, with their own flags first
+var wrappers = map[string]bool{"sudo": true, "doas": true, "env": true, "nohup": true, "time": true, "nice": true, "xargs": true, "command": true}
+
+// destructiveCommands delete or overwrite data whatever their arguments
+var destructiveCommands = map[string]string{
+	"rm":       "deletes files",
+	"shred":    "overwrites files",
+	"dd":       "writes raw data to files or disks",
+	"truncate": "cuts files short",
+	"wipefs":   "erases file system signatures",
+	"fdisk":    "changes partition tables",
+	"sfdisk":   "changes partition tables",
+	"gdisk":    "changes partition tables",
+	"parted":   "changes partition tables",
+	"mkswap":   "formats a disk",
+}
+
+// systemPackageManagers change what is installed on the system
+var systemPackageManagers = map[string]bool{
+	"apt": true, "apt-get": true, "aptitude": true, "dnf": true, "yum": true, "microdnf": true, "zypper": true,
+	"pacman": true, "apk": true, "brew": true, "snap": true, "flatpak": true, "port": true, "dpkg": true, "rpm": true,
+}
+
+// projectPackageManagers change a project's dependencies or the user's tools
+var projectPackageManagers = map[string]bool{
+	"pip": true, "pip3": true, "npm": true, "yarn": true, "pnpm": true, "bun": true, "gem": true,
+	"cargo": true, "poetry": true, "composer": true, "go": true, "rustup": true,
+}
+
+// packageVerbs change what a package manager has installed
+var packageVerbs = map[string]bool{
+	"install": true, "add": true, "remove": true, "uninstall": true, "purge": true, "erase": true, "del": true,
+	"upgrade": true, "dist-upgrade": true, "full-upgrade": true, "update": true, "autoremove": true, "reinstall": true,
+	"in": true, "rm": true, "-S": true, "-Sy": true, "-Syu": true, "-R": true, "-Rs": true, "-Rns": true, "-i": true, "-e": true, "-U": true,
+}
+
+// Classify returns how dangerous running command is. A command line is as
+// dangerous as the most dangerous command in it, including the scripts it
+// runs with sh -c or eval
+func Classify(command string) Assessment {
+	return classify(command, 0)
+}
+
+// classify is Classify for a script nested depth shells deep
+func classify(line string, depth int) Assessment {
+	var a Assessment
+	if depth > maxDepth {
+		a.raise(Moderate, "nests scripts too deeply to check")
+		return a
+	}
+	if diskWritePattern.MatchString(line) {
+		a.raise(Destructive, "writes directly to a disk")
+	}
+	if sqlDropPattern.MatchString(line) {
+		a.raise(Destructive, "drops database objects")
+	}
+	if pipeToShellPattern.MatchString(line) {
+		a.raise(Moderate, "runs a downloaded script")
+	}
+	if forkBomb(line) {
+		a.raise(Destructive, "starts a fork bomb")
+	}
+	for _, listed := range blacklist() {
+		if strings.Contains(line, listed) {
+			a.raise(Destructive, fmt.Sprintf("matches %q in BLACKLIST_COMMANDS", listed))
+		}
+	}
+	for _, c := range parse(line) {
+		level, reason := classifySimple(c, depth)
+		a.raise(level, reason)
+	}
+	return a
+}
+
+// classifySimple classifies a single command, its arguments and the files
+// it overwrites
+func classifySimple(c simpleCommand, depth int) (Level, string) {
+	var a Assessment
+	for _, path := range c.overwrite {
+		a.raise(overwriteLevel(path))
+	}
+
+	args, sudo := c.args, false
+	for len(args) > 0 {
+		name := args[0]
+		if strings.Contains(name, "=") && !strings.HasPrefix(name, "-") {
+			args = args[1:] // an environment assignment
+			continue
+		}
+		if !wrappers[name] {
+			break
+		}
+		sudo = sudo || name == "sudo" || name == "doas"
+		args = args[1:]
+		for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+			args = args[1:]
+		}
+	}
+
+	if len(args) > 0 {
+		name := args[0]
+		switch {
+		case name == "eval":
+			inner := classify(strings.Join(args[1:], " "), depth+1)
+			a.raise(inner.Level, inner.Reason)
+		case shells[name]:
+			if script, ok := shellScript(args[1:]); ok {
+				inner := classify(script, depth+1)
+				a.raise(inner.Level, inner.Reason)
+			}
+		case name == "tee" && !hasAny(args, "-a", "--append"):
+			for _, path := range args[1:] {
+				if !strings.HasPrefix(path, "-") {
+					a.raise(overwriteLevel(path))
+				}
+			}
+		default:
+			a.raise(classifyCommand(name, args[1:]))
+		}
+	}
+
+	if sudo && a.Level == Safe && requireSudoConfirmation() {
+		a.raise(Moderate, "runs as root")
+	}
+	return a.Level, a.Reason
+}
+
+// shellScript returns the script a shell runs with -c, e.g. for
+// sh -c 'script' or bash -lc 'script'
+func shellScript(args []string) (string, bool) {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") {
+			return "", false
+		}
+		if strings.ContainsRune(arg[1:], 'c') && i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
+
+// overwriteLevel classifies replacing the contents of the file at path. A
+// new file loses nothing, an existing one is moderate and a system file is
+// destructive
+func overwriteLevel(path string) (Level, string) {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if strings.HasPrefix(path, "/dev/") {
+		return Safe, "" // disks are caught by diskWritePattern
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return Safe, ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		for _, dir := range systemDirs {
+			if strings.HasPrefix(abs, dir) {
+				return Destructive, fmt.Sprintf("overwrites %s", abs)
+			}
+		}
+	}
+	return Moderate, fmt.Sprintf("overwrites %s", path)
+}
+
+// forkBomb reports whether line defines a function that pipes itself into
+// itself, such as :(){ :|:& };:
+func forkBomb(line string) bool {
+	for _, match := range functionPattern.FindAllStringSubmatch(line, -1) {
+		name, body := match[1], strings.Join(strings.Fields(match[2]), "")
+		if strings.Contains(body, name+"|"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyCommand classifies the program name run with args
+func classifyCommand(name string, args []string) (Level, string) {
+	if reason, ok := destructiveCommands[name]; ok {
+		return Destructive, fmt.Sprintf("%s %s", name, reason)
+	}
+	if strings.HasPrefix(name, "mkfs") {
+		return Destructive, fmt.Sprintf("%s formats a disk", name)
+	}
+
+	switch name {
+	case "chmod", "chown", "chgrp":
+		if hasAny(args, "-R", "--recursive") || hasFlagLetter(args, 'R') {
+			return Destructive, fmt.Sprintf("%s -R changes every file below a directory", name)
+		}
+		return Moderate, fmt.Sprintf("%s changes permissions", name)
+	case "find":
+		if hasAny(args, "-delete") || (hasAny(args, "-exec", "-execdir") && hasAny(args, "rm", "shred")) {
+			return Destructive, "find deletes the files it finds"
+		}
+	case "git":
+		return classifyGit(args)
+	case "docker", "podman":
+		switch {
+		case hasAny(args, "prune") || (hasAny(args, "volume") && hasAny(args, "rm")):
+			return Destructive, fmt.Sprintf("%s deletes containers, images or volumes", name)
+		case hasAny(args, "rm", "rmi", "stop", "kill"):
+			return Moderate, fmt.Sprintf("%s removes or stops containers", name)
+		}
+	case "kubectl":
+		if hasAny(args, "delete") {
+			return Destructive, "kubectl deletes cluster resources"
+		}
+		if hasAny(args, "apply", "scale", "rollout", "drain") {
+			return Moderate, "kubectl changes cluster resources"
+		}
+	case "systemctl", "service", "launchctl":
+		if !hasAny(args, "status", "show", "list-units", "is-active", "is-enabled", "cat") {
+			return Moderate, fmt.Sprintf("%s manages services", name)
+		}
+	case "kill", "pkill", "killall":
+		return Moderate, fmt.Sprintf("%s stops processes", name)
+	case "mv":
+		return Moderate, "mv can overwrite files"
+	case "sed", "perl":
+		if hasAny(args, "--in-place") || hasPrefix(args, "-i") {
+			return Moderate, fmt.Sprintf("%s edits files in place", name)
+		}
+	}
+
+	if len(args) > 0 && packageVerbs[args[0]] {
+		if systemPackageManagers[name] {
+			return Moderate, fmt.Sprintf("%s changes installed packages", name)
+		}
+		if projectPackageManagers[name] && (name != "go" || args[0] == "install") {
+			return Moderate, fmt.Sprintf("%s changes installed packages", name)
+		}
+	}
+	if systemPackageManagers[name] && hasAny(args, "-i", "-e", "-U", "--install", "--remove", "--purge") {
+		return Moderate, fmt.Sprintf("%s changes installed packages", name)
+	}
+	return Safe, ""
+}
+
+// classifyGit classifies git subcommands that lose commits or changes
+func classifyGit(args []string) (Level, string) {
+	if len(args) == 0 {
+		return Safe, ""
+	}
+	switch args[0] {
+	case "reset":
+		if hasAny(args, "--hard") {
+			return Destructive, "git reset --hard discards uncommitted changes"
+		}
+	case "clean":
+		return Destructive, "git clean deletes untracked files"
+	case "push":
+		if hasAny(args, "-f", "--force", "--force-with-lease") || hasPrefix(args, "+") {
+			return Destructive, "git push --force rewrites remote history"
+		}
+		return Moderate, "git push publishes commits"
+	case "branch":
+		if hasAny(args, "-D") {
+			return Destructive, "git branch -D deletes unmerged branches"
+		}
+	case "stash":
+		if hasAny(args, "drop", "clear") {
+			return Destructive, "git stash drop deletes stashed changes"
+		}
+	case "checkout", "restore":
+		if hasAny(args, "--", ".", "-f", "--force") {
+			return Destructive, fmt.Sprintf("git %s discards uncommitted changes", args[0])
+		}
+	}
+	return Safe, ""
+}
+
+// Enabled reports whether DANGEROUS_COMMANDS_CHECK makes how a fix is
+// confirmed depend on its risk
+func Enabled() bool {
+	cfg := config.Current()
+	return cfg == nil || cfg.DangerousCommandsCheck
+}
+
+// blacklist returns the commands BLACKLIST_COMMANDS always treats as
+// destructive
+func blacklist() []string {
+	cfg := config.Current()
+	if cfg == nil {
+		return nil
+	}
+	var commands []string
+	for _, command := range strings.Split(cfg.BlacklistCommands, ",") {
+		if command = strings.TrimSpace(command); command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// requireSudoConfirmation reports whether REQUIRE_SUDO_CONFIRMATION makes
+// commands run as root at least moderate
+func requireSudoConfirmation() bool {
+	cfg := config.Current()
+	return cfg == nil || cfg.RequireSudoConfirmation
+}
+
+func hasAny(args []string, values ...string) bool {
+	for _, arg := range args {
+		for _, value := range values {
+			if arg == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasPrefix(args []string, prefix string) bool {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFlagLetter reports whether a combined short flag such as -Rf has letter
+func hasFlagLetter(args []string, letter rune) bool {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && strings.ContainsRune(arg[1:], letter) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Player shows a recorded session again
+type Player struct {
+	Out     io.Writer
+	Speed   float64       // 2 plays twice as fast; 0 shows everything at once
+	MaxIdle time.Duration // longest pause between events, 0 for no limit
+	// Step, when set, is called before each event that is not output and
+	// blocks until the user wants to continue
+	Step func(event Event) error
+}
+
+// markerColor dims the markers between the output
+var markerColor = color.New(color.Faint)
+
+// Play writes the session's output and marks what LogAid did, until the
+// session ends or ctx is done
+func (p *Player) Play(ctx context.Context, s *Session) error {
+	last := 0.0
+	for _, event := range s.Events {
+		if err := p.wait(ctx, event.Time-last); err != nil {
+			return err
+		}
+		last = event.Time
+
+		if event.Type == EventOutput {
+			if _, err := io.WriteString(p.Out, event.Data); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := markerColor.Fprintf(p.Out, "── %s ──\n", event.Describe()); err != nil {
+			return err
+		}
+		if p.Step != nil {
+			if err := p.Step(event); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// wait pauses for the time between two events
+func (p *Player) wait(ctx context.Context, seconds float64) error {
+	if p.Speed <= 0 || seconds <= 0 {
+		return ctx.Err()
+	}
+	delay := time.Duration(seconds / p.Speed * float64(time.Second))
+	if p.MaxIdle > 0 && delay > p.MaxIdle {
+		delay = p.MaxIdle
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Describe returns a one-line description of an event
+func (e Event) Describe() string {
+	code := 0
+	if e.Code != nil {
+		code = *e.Code
+	}
+	switch e.Type {
+	case EventError:
+		return "error detected"
+	case EventSuggestion:
+		return fmt.Sprintf("suggestion from %s: %s", e.Source, e.Data)
+	case EventDecision:
+		return "decision: " + e.Data
+	case EventFix:
+		return fmt.Sprintf("fix exited with status %d", code)
+	case EventExit:
+		return fmt.Sprintf("command exited with status %d", code)
+	case EventOutput:
+		return fmt.Sprintf("%d bytes of %s", len(e.Data), e.Stream)
+	}
+	return e.Type
+}
@@ -0,0 +1,274 @@
+// Package session records what happened while LogAid monitored a command —
+// its output, the errors found, the suggestions and what was decided — so
+// 'logaid replay' can show it again, with the original timing
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// formatVersion is the version of the session file format
+const formatVersion = 1
+
+// fileName matches the files Save writes, and the temporary files it leaves
+// behind if interrupted, so Prune and Purge never touch anything else the
+// user keeps in SESSIONS_DIR
+var fileName = regexp.MustCompile(`^\.?\d{8}-\d{6}-[A-Za-z0-9._-]+\.json(\.tmp-\d+)?$`)
+
+// Event types
+const (
+	EventOutput     = "output"         // a chunk written to Stream
+	EventError      = "error_detected" // Data is the output the error was found in
+	EventSuggestion = "suggestion"     // Data is the suggestion, Source who made it
+	EventDecision   = "decision"       // Data is accepted, auto-confirmed, rejected or suggested
+	EventFix        = "fix"            // Code is the exit code of the suggested command
+	EventExit       = "exit"           // Code is the exit code of the command
+)
+
+// Streams of output events
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+	StreamLog    = "log" // LogAid's own messages and prompts
+)
+
+// Event is one thing that happened during a session
+type Event struct {
+	Time   float64 `json:"t"` // seconds since the session started
+	Type   string  `json:"type"`
+	Stream string  `json:"stream,omitempty"`
+	Data   string  `json:"data,omitempty"`
+	Source string  `json:"source,omitempty"`
+	Code   *int    `json:"code,omitempty"`
+}
+
+// Session is a recorded 'logaid exec'
+type Session struct {
+	Version  int       `json:"version"`
+	ID       string    `json:"id"`
+	Command  string    `json:"command"`
+	Started  time.Time `json:"started"`
+	Duration float64   `json:"duration"` // seconds
+	Events   []Event   `json:"events"`
+}
+
+// Find returns the event of type eventType, or nil
+func (s *Session) Find(eventType string) *Event {
+	for i := range s.Events {
+		if s.Events[i].Type == eventType {
+			return &s.Events[i]
+		}
+	}
+	return nil
+}
+
+// Recorder collects the events of a session. A nil Recorder records nothing
+type Recorder struct {
+	mu      sync.Mutex
+	session Session
+}
+
+// NewRecorder starts recording a session of command
+func NewRecorder(command string) *Recorder {
+	now := time.Now()
+	return &Recorder{session: Session{
+		Version: formatVersion,
+		ID:      newID(now, command),
+		Command: command,
+		Started: now,
+	}}
+}
+
+// newID names a session after when it started and the program it ran
+func newID(started time.Time, command string) string {
+	program := "session"
+	if fields := strings.Fields(command); len(fields) > 0 {
+		program = filepath.Base(fields[0])
+	}
+	return started.Format("20060102-150405") + "-" + sanitize(program)
+}
+
+// sanitize keeps the characters that are safe in a file name
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// Record adds an event at the current time
+func (r *Recorder) Record(event Event) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event.Time = time.Since(r.session.Started).Seconds()
+	r.session.Events = append(r.session.Events, event)
+}
+
+// Exit records the exit code of a command or fix
+func (r *Recorder) Exit(eventType string, code int) {
+	r.Record(Event{Type: eventType, Code: &code})
+}
+
+// Writer returns a writer that records what is written to w as output of
+// stream
+func (r *Recorder) Writer(stream string, w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+	return &streamWriter{recorder: r, stream: stream, w: w}
+}
+
+type streamWriter struct {
+	recorder *Recorder
+	stream   string
+	w        io.Writer
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	s.recorder.Record(Event{Type: EventOutput, Stream: s.stream, Data: string(p)})
+	return s.w.Write(p)
+}
+
+// Session returns the session recorded so far
+func (r *Recorder) Session() *Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := r.session
+	session.Duration = time.Since(session.Started).Seconds()
+	session.Events = append([]Event(nil), r.session.Events...)
+	return &session
+}
+
+// Save writes the session to dir and returns the file's path
+func (r *Recorder) Save(dir string) (string, error) {
+	session := r.Session()
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode session: %w", err)
+	}
+	path := filepath.Join(dir, session.ID+".json")
+	if err := state.WriteFileAtomic(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to save session: %w", err)
+	}
+	return path, nil
+}
+
+// Dir returns the configured SESSIONS_DIR
+func Dir() string {
+	cfg := config.Current()
+	if cfg == nil {
+		return ""
+	}
+	return cfg.SessionsDir
+}
+
+// Load reads a session by ID from dir, or from a file path
+func Load(dir, idOrPath string) (*Session, error) {
+	path := idOrPath
+	if _, err := os.Stat(path); err != nil {
+		path = filepath.Join(dir, strings.TrimSuffix(idOrPath, ".json")+".json")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session %s: %w", idOrPath, err)
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %w", idOrPath, err)
+	}
+	if session.Version > formatVersion {
+		return nil, fmt.Errorf("session %s was recorded by a newer LogAid (format %d)", idOrPath, session.Version)
+	}
+	return &session, nil
+}
+
+// List returns the sessions saved in dir, newest first
+func List(dir string) ([]*Session, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	var sessions []*Session
+	for _, path := range paths {
+		session, err := Load(dir, path)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Started.After(sessions[j].Started)
+	})
+	return sessions, nil
+}
+
+// Prune removes the sessions in dir last written before cutoff and returns
+// how many were removed
+func Prune(dir string, cutoff time.Time) (int, error) {
+	paths, err := files(dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove session: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Purge removes every session in dir and returns how many were removed.
+// Other files and the directory itself are left in place
+func Purge(dir string) (int, error) {
+	paths, err := files(dir)
+	if err != nil {
+		return 0, err
+	}
+	for i, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return i, fmt.Errorf("failed to remove session: %w", err)
+		}
+	}
+	return len(paths), nil
+}
+
+// files returns the paths of the session files in dir
+func files(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && fileName.MatchString(entry.Name()) {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,196 @@
+// Package telemetry exports OpenTelemetry traces and metrics over OTLP/HTTP
+// when OTEL_EXPORTER_OTLP_ENDPOINT is set, so the time spent in plugins, AI
+// calls and fixes shows up in an existing observability stack. Until Init
+// configures an exporter every span and measurement is a no-op.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// scope names the instrumentation library in exported spans and metrics
+const scope = "github.com/ayushsharma-1/LogAid"
+
+var (
+	mu             sync.Mutex
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+)
+
+// Init starts exporting to OTEL_EXPORTER_OTLP_ENDPOINT. It does nothing
+// when the endpoint is empty
+func Init(ctx context.Context, version string) error {
+	cfg := config.Current()
+	if cfg == nil || cfg.OTelEndpoint == "" {
+		return nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.OTelServiceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create telemetry resource: %w", err)
+	}
+	headers := ParseHeaders(cfg.OTelHeaders)
+
+	traceExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint(cfg.OTelEndpoint, "/v1/traces")), otlptracehttp.WithHeaders(headers))
+	if err != nil {
+		return fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(endpoint(cfg.OTelEndpoint, "/v1/metrics")), otlpmetrichttp.WithHeaders(headers))
+	if err != nil {
+		return fmt.Errorf("failed to create metric exporter: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res))
+	meterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)), sdkmetric.WithResource(res))
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	return nil
+}
+
+// endpoint appends the signal's path to the collector's base URL, like the
+// OTEL_EXPORTER_OTLP_ENDPOINT environment variable of other SDKs
+func endpoint(base, path string) string {
+	return strings.TrimSuffix(base, "/") + path
+}
+
+// ParseHeaders reads OTEL_EXPORTER_OTLP_HEADERS: "key=value,key2=value2"
+func ParseHeaders(spec string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if key = strings.TrimSpace(key); ok && key != "" {
+			headers[key] = strings.TrimSpace(value)
+		}
+	}
+	return headers
+}
+
+// Flush exports the spans and metrics recorded so far, e.g. before a
+// command exits
+func Flush(ctx context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracerProvider == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return errors.Join(tracerProvider.ForceFlush(ctx), meterProvider.ForceFlush(ctx))
+}
+
+// Shutdown flushes and stops the exporters
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracerProvider == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	err := errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	tracerProvider, meterProvider = nil, nil
+	return err
+}
+
+// Start starts a span called name as a child of the span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(scope).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends span, marking it failed when err is not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// meters holds the instruments, created once from the global meter provider
+// which forwards them to the exporter once Init has run
+type meters struct {
+	pluginDuration    metric.Float64Histogram
+	aiRequests        metric.Int64Counter
+	aiDuration        metric.Float64Histogram
+	aiTokens          metric.Int64Counter
+	suggestions       metric.Int64Counter
+	executions        metric.Int64Counter
+	executionDuration metric.Float64Histogram
+}
+
+var (
+	instruments     meters
+	instrumentsOnce sync.Once
+)
+
+// meter returns the instruments, creating them on first use
+func meter() *meters {
+	instrumentsOnce.Do(func() {
+		m := otel.Meter(scope)
+		instruments.pluginDuration, _ = m.Float64Histogram("logaid.plugin.duration", metric.WithUnit("ms"), metric.WithDescription("Time spent in plugin calls"))
+		instruments.aiRequests, _ = m.Int64Counter("logaid.ai.requests", metric.WithDescription("AI provider requests"))
+		instruments.aiDuration, _ = m.Float64Histogram("logaid.ai.duration", metric.WithUnit("ms"), metric.WithDescription("Latency of AI provider requests"))
+		instruments.aiTokens, _ = m.Int64Counter("logaid.ai.tokens", metric.WithDescription("Tokens used by AI requests"))
+		instruments.suggestions, _ = m.Int64Counter("logaid.suggestions", metric.WithDescription("Suggestions made, by source"))
+		instruments.executions, _ = m.Int64Counter("logaid.executions", metric.WithDescription("Suggested commands executed"))
+		instruments.executionDuration, _ = m.Float64Histogram("logaid.execution.duration", metric.WithUnit("ms"), metric.WithDescription("Run time of suggested commands"))
+	})
+	return &instruments
+}
+
+// milliseconds converts d for the duration histograms
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// PluginCall records the duration of a plugin method call
+func PluginCall(ctx context.Context, plugin, method string, d time.Duration, timedOut bool) {
+	meter().pluginDuration.Record(ctx, milliseconds(d), metric.WithAttributes(
+		attribute.String("plugin", plugin), attribute.String("method", method), attribute.Bool("timed_out", timedOut)))
+}
+
+// AIRequest records an AI provider request and the tokens it used
+func AIRequest(ctx context.Context, provider, model string, d time.Duration, promptTokens, completionTokens int, failed bool) {
+	attrs := []attribute.KeyValue{attribute.String("provider", provider), attribute.String("model", model)}
+	m := meter()
+	m.aiRequests.Add(ctx, 1, metric.WithAttributes(append(attrs, attribute.Bool("error", failed))...))
+	m.aiDuration.Record(ctx, milliseconds(d), metric.WithAttributes(attrs...))
+	m.aiTokens.Add(ctx, int64(promptTokens), metric.WithAttributes(append(attrs, attribute.String("type", "prompt"))...))
+	m.aiTokens.Add(ctx, int64(completionTokens), metric.WithAttributes(append(attrs, attribute.String("type", "completion"))...))
+}
+
+// Suggestion counts a suggestion made by source
+func Suggestion(ctx context.Context, source string) {
+	meter().suggestions.Add(ctx, 1, metric.WithAttributes(attribute.String("source", source)))
+}
+
+// Execution records a suggested command that was run
+func Execution(ctx context.Context, source string, d time.Duration, exitCode int) {
+	attrs := metric.WithAttributes(attribute.String("source", source), attribute.Bool("success", exitCode == 0))
+	m := meter()
+	m.executions.Add(ctx, 1, attrs)
+	m.executionDuration.Record(ctx, milliseconds(d), attrs)
+}
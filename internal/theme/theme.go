@@ -0,0 +1,164 @@
+// Package theme maps color names from the configuration (COLOR_ERROR, THEME,
+// ...) onto terminal colors
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Theme is the color spec of each kind of message. A spec is a color name
+// (red, bright-cyan), a hex color (#ff8800) or "none", optionally with
+// attributes: "bold red", "underline #5fafff"
+type Theme struct {
+	Error      string
+	Warning    string
+	Success    string
+	Suggestion string
+	Info       string
+	Debug      string
+}
+
+// Default is the theme used when THEME is not set
+const Default = "default"
+
+// themes are the built-in themes
+var themes = map[string]Theme{
+	Default: {Error: "red", Warning: "yellow", Success: "green", Suggestion: "cyan", Info: "cyan", Debug: "magenta"},
+	"high-contrast": {
+		Error: "bold bright-red", Warning: "bold bright-yellow", Success: "bold bright-green",
+		Suggestion: "bold bright-white", Info: "bright-cyan", Debug: "bright-magenta",
+	},
+	"solarized": {
+		Error: "#dc322f", Warning: "#b58900", Success: "#859900",
+		Suggestion: "#2aa198", Info: "#268bd2", Debug: "#6c71c4",
+	},
+	"mono": {Error: "bold", Warning: "bold", Success: "none", Suggestion: "underline", Info: "none", Debug: "dim"},
+}
+
+// Names returns the built-in themes, the default first
+func Names() []string {
+	names := []string{Default}
+	for name := range themes {
+		if name != Default {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// Get returns the built-in theme called name, with the non-empty specs of
+// overrides replacing its own
+func Get(name string, overrides Theme) (Theme, error) {
+	if name == "" {
+		name = Default
+	}
+	t, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q, use one of %s", name, strings.Join(Names(), ", "))
+	}
+	for _, o := range []struct{ spec, override *string }{
+		{&t.Error, &overrides.Error}, {&t.Warning, &overrides.Warning}, {&t.Success, &overrides.Success},
+		{&t.Suggestion, &overrides.Suggestion}, {&t.Info, &overrides.Info}, {&t.Debug, &overrides.Debug},
+	} {
+		if *o.override != "" {
+			*o.spec = *o.override
+		}
+	}
+	return t, nil
+}
+
+var names = map[string]color.Attribute{
+	"black": color.FgBlack, "red": color.FgRed, "green": color.FgGreen, "yellow": color.FgYellow,
+	"blue": color.FgBlue, "magenta": color.FgMagenta, "cyan": color.FgCyan, "white": color.FgWhite,
+	"gray": color.FgHiBlack, "grey": color.FgHiBlack,
+	"bright-black": color.FgHiBlack, "bright-red": color.FgHiRed, "bright-green": color.FgHiGreen,
+	"bright-yellow": color.FgHiYellow, "bright-blue": color.FgHiBlue, "bright-magenta": color.FgHiMagenta,
+	"bright-cyan": color.FgHiCyan, "bright-white": color.FgHiWhite,
+}
+
+var attributes = map[string]color.Attribute{
+	"bold": color.Bold, "dim": color.Faint, "italic": color.Italic, "underline": color.Underline,
+}
+
+// Parse turns a color spec into a color. Hex colors use truecolor when
+// COLORTERM says the terminal supports it and the closest of the 256 colors
+// otherwise
+func Parse(spec string) (*color.Color, error) {
+	c := color.New()
+	for _, word := range strings.Fields(strings.ToLower(spec)) {
+		word = strings.ReplaceAll(word, "_", "-")
+		if strings.HasPrefix(word, "hi-") {
+			word = "bright-" + strings.TrimPrefix(word, "hi-")
+		}
+		if attribute, ok := attributes[word]; ok {
+			c.Add(attribute)
+			continue
+		}
+		if attribute, ok := names[word]; ok {
+			c.Add(attribute)
+			continue
+		}
+		if strings.HasPrefix(word, "#") {
+			r, g, b, err := parseHex(word)
+			if err != nil {
+				return nil, err
+			}
+			if truecolor() {
+				c.AddRGB(r, g, b)
+			} else {
+				c.Add(38, 5, color.Attribute(to256(r, g, b)))
+			}
+			continue
+		}
+		if word != "none" {
+			return nil, fmt.Errorf("unknown color %q", word)
+		}
+	}
+	return c, nil
+}
+
+// parseHex reads #rrggbb or #rgb
+func parseHex(hex string) (r, g, b int, err error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	value, parseErr := strconv.ParseUint(digits, 16, 32)
+	if len(digits) != 6 || parseErr != nil {
+		return 0, 0, 0, fmt.Errorf("invalid hex color %q", hex)
+	}
+	return int(value >> 16 & 0xff), int(value >> 8 & 0xff), int(value & 0xff), nil
+}
+
+// truecolor reports whether the terminal supports 24-bit colors
+func truecolor() bool {
+	colorterm := os.Getenv("COLORTERM")
+	return colorterm == "truecolor" || colorterm == "24bit"
+}
+
+// to256 returns the closest color of the 6x6x6 cube of 256-color terminals
+func to256(r, g, b int) int {
+	level := func(v int) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
+
+// Disabled reports whether colors are turned off with NO_COLOR
+// (https://no-color.org)
+func Disabled() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
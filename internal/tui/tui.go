@@ -0,0 +1,428 @@
+// Package tui is the full-screen terminal UI started by 'logaid tui': the
+// output of a running command, the errors detected in it, ranked
+// suggestions to apply, edit or dismiss, and a history browser
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/risk"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxLines is how much command output the output pane keeps
+const maxLines = 5000
+
+// Views of the TUI
+const (
+	viewRun = iota
+	viewHistory
+)
+
+// Messages from background work
+type (
+	outputMsg string // a line of command, fix or log output
+	doneMsg   struct {
+		output   string // the output errors are looked for in
+		exitCode int
+		err      error
+	}
+	suggestionMsg struct {
+		suggestion *engine.Suggestion
+		err        error
+	}
+	appliedMsg   struct{ ok bool }
+	dismissedMsg struct{}
+	historyMsg   struct {
+		entries []history.Entry
+		err     error
+	}
+)
+
+// Model is the state of the TUI
+type Model struct {
+	engine  *engine.Engine
+	history *history.Store
+	args    []string // the command to run; empty to only browse the history
+	events  chan tea.Msg
+	out     *lineWriter
+
+	lines      []string
+	running    bool
+	busy       bool // applying or dismissing a suggestion
+	exitCode   int
+	fixed      bool
+	errors     []string // output lines that show an error
+	suggestion *engine.Suggestion
+	candidates []string
+	selected   int
+	editing    bool
+	input      []rune
+	confirming string // a destructive fix waiting for enter to be pressed again
+	status     string
+
+	view    int
+	entries []history.Entry // newest first
+	entry   int
+
+	width, height int
+}
+
+// New creates the TUI for running args with eng. Fixes and log messages
+// should be sent to Output. store may be nil when history is disabled
+func New(eng *engine.Engine, store *history.Store, args []string) *Model {
+	events := make(chan tea.Msg, 256)
+	m := &Model{
+		engine:  eng,
+		history: store,
+		args:    args,
+		events:  events,
+		out:     &lineWriter{events: events},
+		width:   100,
+		height:  30,
+	}
+	if len(args) == 0 {
+		m.view = viewHistory
+	}
+	return m
+}
+
+// Output returns a writer whose lines are shown in the output pane
+func (m *Model) Output() io.Writer {
+	return m.out
+}
+
+// Failed reports whether the command failed and no fix worked
+func (m *Model) Failed() bool {
+	return m.exitCode != 0 && !m.fixed
+}
+
+// Init starts the command, or loads the history when there is none
+func (m *Model) Init() tea.Cmd {
+	if len(m.args) == 0 {
+		return tea.Batch(m.waitForOutput(), m.loadHistory())
+	}
+	m.running = true
+	m.status = "Running " + strings.Join(m.args, " ")
+	return tea.Batch(m.waitForOutput(), m.run())
+}
+
+// Update handles a key press or the result of background work
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case outputMsg:
+		m.lines = append(m.lines, string(msg))
+		if len(m.lines) > maxLines {
+			m.lines = m.lines[len(m.lines)-maxLines:]
+		}
+		return m, m.waitForOutput()
+	case doneMsg:
+		return m, m.finished(msg)
+	case suggestionMsg:
+		m.busy = false
+		switch {
+		case msg.err != nil:
+			m.status = fmt.Sprintf("Failed to get a suggestion: %v", msg.err)
+		case msg.suggestion == nil:
+			m.status = "No suggestion found"
+		default:
+			m.suggestion = msg.suggestion
+			m.candidates = msg.suggestion.Candidates()
+			if len(m.candidates) == 0 {
+				m.candidates = []string{msg.suggestion.Text()}
+			}
+			m.selected = 0
+			m.status = fmt.Sprintf("%d suggestion(s) from %s", len(m.candidates), msg.suggestion.Source)
+		}
+	case appliedMsg:
+		m.busy = false
+		m.fixed = msg.ok
+		m.suggestion = nil
+		if msg.ok {
+			m.status = "Fix applied"
+		} else {
+			m.status = "Fix failed"
+		}
+	case dismissedMsg:
+		m.busy = false
+		m.suggestion = nil
+		m.status = "Suggestion dismissed"
+	case historyMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Failed to read history: %v", msg.err)
+			break
+		}
+		m.entries = msg.entries
+		m.entry = 0
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey applies the keybindings of the current view
+func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if m.editing {
+		return m, m.handleEditKey(msg)
+	}
+	if m.confirming != "" {
+		command := m.confirming
+		m.confirming = ""
+		if msg.String() == "enter" {
+			return m, m.apply(command)
+		}
+		m.status = "Cancelled"
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "h":
+		if m.view == viewHistory && len(m.args) > 0 {
+			m.view = viewRun
+			return m, nil
+		}
+		m.view = viewHistory
+		return m, m.loadHistory()
+	case "esc":
+		if len(m.args) > 0 {
+			m.view = viewRun
+		}
+		return m, nil
+	case "up", "k":
+		m.move(-1)
+		return m, nil
+	case "down", "j":
+		m.move(1)
+		return m, nil
+	}
+
+	if m.view != viewRun || m.suggestion == nil || m.busy {
+		return m, nil
+	}
+	switch msg.String() {
+	case "enter", "a":
+		return m, m.confirm(m.candidates[m.selected])
+	case "e":
+		if m.suggestion.ConfigEdit != nil {
+			m.status = "Configuration changes cannot be edited"
+			return m, nil
+		}
+		m.editing = true
+		m.input = []rune(m.candidates[m.selected])
+	case "d":
+		m.busy = true
+		return m, m.dismiss()
+	}
+	return m, nil
+}
+
+// handleEditKey edits the selected suggestion; enter applies it
+func (m *Model) handleEditKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.editing = false
+	case tea.KeyEnter:
+		m.editing = false
+		if command := strings.TrimSpace(string(m.input)); command != "" {
+			return m.confirm(command)
+		}
+	case tea.KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case tea.KeySpace:
+		m.input = append(m.input, ' ')
+	case tea.KeyRunes:
+		m.input = append(m.input, msg.Runes...)
+	}
+	return nil
+}
+
+// move changes the selected suggestion or history entry by delta
+func (m *Model) move(delta int) {
+	if m.view == viewHistory {
+		m.entry = clamp(m.entry+delta, len(m.entries))
+		return
+	}
+	m.selected = clamp(m.selected+delta, len(m.candidates))
+}
+
+// finished records the end of the command and looks for a fix
+func (m *Model) finished(msg doneMsg) tea.Cmd {
+	m.running = false
+	m.exitCode = msg.exitCode
+	m.status = fmt.Sprintf("Exited with status %d", msg.exitCode)
+	if msg.err != nil && msg.exitCode < 0 {
+		m.status = fmt.Sprintf("Failed to run: %v", msg.err)
+		return nil
+	}
+
+	for _, line := range strings.Split(msg.output, "\n") {
+		if m.engine.DetectError(line) {
+			m.errors = append(m.errors, strings.TrimSpace(line))
+		}
+	}
+	if !m.engine.DetectError(msg.output) {
+		return nil
+	}
+
+	m.busy = true
+	m.status = "Looking for a fix..."
+	eng, command, output := m.engine, strings.Join(m.args, " "), msg.output
+	return func() tea.Msg {
+		suggestion, err := eng.Analyze(context.Background(), command, output)
+		return suggestionMsg{suggestion: suggestion, err: err}
+	}
+}
+
+// run starts the command, sending its output to the output pane
+func (m *Model) run() tea.Cmd {
+	args, out := m.args, m.out
+	return func() tea.Msg {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = os.Environ()
+		cmd.Stdout = io.MultiWriter(out, &stdout)
+		cmd.Stderr = io.MultiWriter(out, &stderr)
+
+		err := cmd.Run()
+		out.Flush()
+
+		// Like 'logaid exec': errors are looked for in stderr of a failed
+		// command and in stdout of one that succeeded
+		output := stdout.String()
+		if err != nil && stderr.Len() > 0 {
+			output = stderr.String()
+		}
+		return doneMsg{output: output, exitCode: exitCode(err), err: err}
+	}
+}
+
+// confirm applies command, once enter is pressed a second time when it is
+// destructive
+func (m *Model) confirm(command string) tea.Cmd {
+	if m.suggestion.ConfigEdit == nil && risk.Enabled() {
+		if assessment := risk.Classify(command); assessment.Level == risk.Destructive {
+			m.confirming = command
+			m.status = fmt.Sprintf("Destructive: %s. Press enter again to run it, any other key to cancel", assessment.Reason)
+			return nil
+		}
+	}
+	return m.apply(command)
+}
+
+// apply accepts command for the current suggestion and runs it
+func (m *Model) apply(command string) tea.Cmd {
+	m.busy = true
+	m.status = "Applying " + command
+	eng, suggestion := m.engine, m.suggestion
+	return func() tea.Msg {
+		eng.Choose(suggestion, command)
+		return appliedMsg{ok: eng.Apply(suggestion)}
+	}
+}
+
+// dismiss rejects the current suggestion
+func (m *Model) dismiss() tea.Cmd {
+	eng, suggestion := m.engine, m.suggestion
+	return func() tea.Msg {
+		eng.Dismiss(suggestion)
+		return dismissedMsg{}
+	}
+}
+
+// loadHistory reads the suggestion history, newest first
+func (m *Model) loadHistory() tea.Cmd {
+	store := m.history
+	return func() tea.Msg {
+		if store == nil {
+			return historyMsg{err: errors.New("history is disabled (HISTORY_FILE is empty)")}
+		}
+		entries, err := store.List(0)
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+		return historyMsg{entries: entries, err: err}
+	}
+}
+
+// waitForOutput delivers the next output line
+func (m *Model) waitForOutput() tea.Cmd {
+	events := m.events
+	return func() tea.Msg {
+		return <-events
+	}
+}
+
+// lineWriter sends each complete line written to it as an outputMsg
+type lineWriter struct {
+	mu      sync.Mutex
+	events  chan<- tea.Msg
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.events <- outputMsg(strings.TrimRight(string(w.partial[:i]), "\r"))
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush sends a last line that did not end in a newline
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.partial) > 0 {
+		w.events <- outputMsg(string(w.partial))
+		w.partial = nil
+	}
+}
+
+// exitCode returns the exit status of a command that returned err, or -1
+// when it could not be started
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// clamp keeps i within 0..n-1
+func clamp(i, n int) int {
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
@@ -0,0 +1,189 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ayushsharma-1/LogAid/internal/risk"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	headingStyle  = lipgloss.NewStyle().Bold(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8"))
+)
+
+// View renders the current view
+func (m *Model) View() string {
+	// Title, status and help lines around the panes, plus their borders
+	bodyHeight := m.height - 5
+	if bodyHeight < 3 {
+		bodyHeight = 3
+	}
+	leftWidth := m.width*2/3 - 2
+	rightWidth := m.width - leftWidth - 4
+
+	var left, right []string
+	if m.view == viewHistory {
+		left, right = m.historyList(leftWidth, bodyHeight), m.historyDetail(rightWidth)
+	} else {
+		left, right = m.outputPane(bodyHeight), m.sidePanel()
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		pane(left, leftWidth, bodyHeight),
+		pane(right, rightWidth, bodyHeight),
+	)
+	return strings.Join([]string{m.title(), body, m.status, helpStyle.Render(m.help())}, "\n")
+}
+
+// title describes the command and its state
+func (m *Model) title() string {
+	if len(m.args) == 0 {
+		return titleStyle.Render("LogAid — history")
+	}
+	state := fmt.Sprintf("exit %d", m.exitCode)
+	switch {
+	case m.running:
+		state = "running"
+	case m.fixed:
+		state = "fixed"
+	}
+	return titleStyle.Render(fmt.Sprintf("LogAid — %s [%s]", strings.Join(m.args, " "), state))
+}
+
+// outputPane returns the last lines of output that fit in height
+func (m *Model) outputPane(height int) []string {
+	lines := m.lines
+	if len(lines) > height {
+		lines = lines[len(lines)-height:]
+	}
+	// Colors and tabs from the command would upset the layout
+	plain := make([]string, len(lines))
+	for i, line := range lines {
+		plain[i] = strings.ReplaceAll(ansi.Strip(line), "\t", "    ")
+	}
+	return plain
+}
+
+// sidePanel lists the detected errors and the ranked suggestions
+func (m *Model) sidePanel() []string {
+	lines := []string{headingStyle.Render("Errors")}
+	if len(m.errors) == 0 {
+		lines = append(lines, helpStyle.Render("none detected"))
+	}
+	for _, line := range m.errors {
+		lines = append(lines, errorStyle.Render("✗ "+line))
+	}
+
+	lines = append(lines, "", headingStyle.Render("Suggestions"))
+	if m.suggestion == nil {
+		return append(lines, helpStyle.Render("none"))
+	}
+	source := m.suggestion.Source
+	if m.suggestion.Confidence > 0 {
+		source += fmt.Sprintf(", confidence %.2f", m.suggestion.Confidence)
+	}
+	if m.suggestion.Note != "" {
+		source += ", " + m.suggestion.Note
+	}
+	lines = append(lines, helpStyle.Render("from "+source))
+	for i, candidate := range m.candidates {
+		line := fmt.Sprintf("%d. %s", i+1, candidate)
+		if level := risk.Classify(candidate).Level; level != risk.Safe && m.suggestion.ConfigEdit == nil {
+			line += fmt.Sprintf(" [%s]", level)
+		}
+		if i == m.selected {
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if edit := m.suggestion.ConfigEdit; edit != nil && edit.Description != "" {
+		lines = append(lines, "", edit.Description)
+	}
+	if m.editing {
+		lines = append(lines, "", "Edit: "+string(m.input)+"█")
+	}
+	return lines
+}
+
+// historyList lists the history entries around the selected one
+func (m *Model) historyList(width, height int) []string {
+	if len(m.entries) == 0 {
+		return []string{helpStyle.Render("No suggestions in history yet")}
+	}
+	start := 0
+	if m.entry >= height {
+		start = m.entry - height + 1
+	}
+	var lines []string
+	for i := start; i < len(m.entries) && i < start+height; i++ {
+		entry := m.entries[i]
+		line := fmt.Sprintf("%s %-8s %s", entry.Time.Format("01-02 15:04"), entry.Status, entry.Command)
+		line = ansi.Truncate(line, width, "…")
+		if i == m.entry {
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// historyDetail describes the selected history entry
+func (m *Model) historyDetail(width int) []string {
+	if len(m.entries) == 0 {
+		return nil
+	}
+	entry := m.entries[m.entry]
+	lines := []string{
+		headingStyle.Render("Command"), entry.Command, "",
+		headingStyle.Render("Suggestion"), entry.Suggestion,
+		helpStyle.Render(fmt.Sprintf("%s, from %s", entry.Status, entry.Source)),
+	}
+	if entry.Explanation != "" {
+		lines = append(lines, "", headingStyle.Render("Explanation"))
+		lines = append(lines, strings.Split(lipgloss.NewStyle().Width(width).Render(entry.Explanation), "\n")...)
+	}
+	if entry.Output != "" {
+		lines = append(lines, "", headingStyle.Render("Output"))
+		lines = append(lines, strings.Split(strings.TrimRight(entry.Output, "\n"), "\n")...)
+	}
+	return lines
+}
+
+// help lists the keys that do something in the current state
+func (m *Model) help() string {
+	switch {
+	case m.editing:
+		return "enter apply • esc cancel"
+	case m.confirming != "":
+		return "enter run • any other key cancel"
+	case m.view == viewHistory && len(m.args) > 0:
+		return "↑/↓ select • h/esc back • q quit"
+	case m.view == viewHistory:
+		return "↑/↓ select • q quit"
+	case m.suggestion != nil && !m.busy:
+		return "↑/↓ select • enter apply • e edit • d dismiss • h history • q quit"
+	}
+	return "h history • q quit"
+}
+
+// pane renders lines in a bordered box, cutting lines that do not fit
+func pane(lines []string, width, height int) string {
+	if width < 1 {
+		width = 1
+	}
+	fitted := make([]string, 0, height)
+	for _, line := range lines {
+		if len(fitted) == height {
+			break
+		}
+		fitted = append(fitted, ansi.Truncate(line, width, "…"))
+	}
+	return paneStyle.Width(width).Height(height).Render(strings.Join(fitted, "\n"))
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/ayushsharma-1/LogAid/cmd"
 	"github.com/ayushsharma-1/LogAid/internal/config"
@@ -12,8 +14,12 @@ import (
 func main() {
 	// Initialize configuration
 	if err := config.Init(); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to initialize config: %v\n", err)
-		os.Exit(1)
+		// The config and doctor commands still run so the problem can be fixed
+		var invalid config.ValidationErrors
+		if !errors.As(err, &invalid) || !cmd.ToleratesInvalidConfig(os.Args[1:]) {
+			fmt.Fprintf(os.Stderr, "Failed to initialize config: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
 	// Initialize logger
@@ -21,10 +27,18 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
+	// Logging settings may also come from config.yaml
+	if cfg := config.Current(); cfg != nil {
+		logger.SetLevel(cfg.LogLevel)
+		logger.SetMasking(cfg.MaskSecrets)
+		logger.SetAsync(cfg.LogAsync, time.Duration(cfg.LogFlushInterval)*time.Second)
+	}
+	defer logger.AppLogger.Close()
 
 	// Execute root command
 	if err := cmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		logger.AppLogger.Close()
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// TestAIAuditLog tests appending audit records and the retention policy
+func TestAIAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "ai.jsonl")
+
+	old := ai.AuditRecord{Time: time.Now().Add(-100 * 24 * time.Hour), Provider: "openai", Model: "gpt-4o", PromptSHA256: "old"}
+	if err := ai.WriteAuditRecord(path, old, 0); err != nil {
+		t.Fatalf("WriteAuditRecord() error = %v", err)
+	}
+
+	recent := ai.AuditRecord{
+		Time:         time.Now(),
+		Provider:     "gemini",
+		Model:        "gemini-2.0-flash-exp",
+		PromptSHA256: "new",
+		Response:     []string{"git status"},
+		LatencyMS:    120,
+		Usage:        ai.Usage{PromptTokens: 42, CompletionTokens: 3},
+	}
+	if err := ai.PruneAuditLog(path, time.Now().Add(-90*24*time.Hour)); err != nil {
+		t.Fatalf("PruneAuditLog() error = %v", err)
+	}
+	if err := ai.WriteAuditRecord(path, recent, 90); err != nil {
+		t.Fatalf("WriteAuditRecord() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("audit log has %d records, want 1 after retention:\n%s", len(lines), data)
+	}
+
+	var got ai.AuditRecord
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("Failed to parse audit record: %v", err)
+	}
+	if got.PromptSHA256 != "new" || got.PromptTokens != 42 || got.CompletionTokens != 3 || got.LatencyMS != 120 {
+		t.Errorf("audit record = %+v", got)
+	}
+	if !strings.Contains(lines[0], `"prompt_tokens":42`) {
+		t.Errorf("audit record should flatten token counts: %s", lines[0])
+	}
+}
+
+// TestAIAuditMasksSecrets tests that secrets in AI responses are masked in
+// the audit log, unless MASK_SECRETS is off
+func TestAIAuditMasksSecrets(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+
+	dir := t.TempDir()
+	fixtures := filepath.Join(dir, "fixtures.json")
+	content := `[{"pattern": "(?i)mysql", "response": "mysql -u app -ps3cr3t shop"}]`
+	if err := os.WriteFile(fixtures, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, enabled := range []bool{true, false} {
+		path := filepath.Join(dir, fmt.Sprintf("ai-%v.jsonl", enabled))
+		config.SetCurrent(&config.Config{
+			MockAIFixtures: fixtures,
+			AIAuditLog:     true,
+			AIAuditLogFile: path,
+			MaskSecrets:    enabled,
+		})
+		client := ai.NewAIClientFor("mock")
+		if _, err := client.GenerateSuggestion(context.Background(), "Command: mysql -u app shop\nError: Access denied"); err != nil {
+			t.Fatalf("GenerateSuggestion() error = %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read audit log: %v", err)
+		}
+		if leaked := strings.Contains(string(data), "s3cr3t"); leaked == enabled {
+			t.Errorf("audit log with MASK_SECRETS=%v = %s", enabled, data)
+		}
+	}
+}
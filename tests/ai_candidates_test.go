@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+)
+
+// TestAICandidates tests that multiple completions are requested and de-duplicated
+func TestAICandidates(t *testing.T) {
+	var requested int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ai.OpenAIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requested = req.N
+
+		fmt.Fprint(w, `{"choices": [
+			{"message": {"content": "sudo apt install redis-tools"}},
+			{"message": {"content": "sudo apt  install redis-tools"}},
+			{"message": {"content": "sudo apt install redis"}}
+		]}`)
+	}))
+	defer server.Close()
+
+	client := &ai.AIClient{Provider: "openai", APIKey: "key", Model: "gpt-4o", BaseURL: server.URL, Timeout: 5 * time.Second}
+	suggestions, err := client.GenerateSuggestions(context.Background(), "Command: sudo apt install rediscli", 3)
+	if err != nil {
+		t.Fatalf("GenerateSuggestions() error = %v", err)
+	}
+
+	if requested != 3 {
+		t.Errorf("request n = %d, want 3", requested)
+	}
+	if len(suggestions) != 2 || suggestions[0] != "sudo apt install redis-tools" || suggestions[1] != "sudo apt install redis" {
+		t.Errorf("GenerateSuggestions() = %q, want 2 distinct suggestions", suggestions)
+	}
+}
+
+// TestSuggestionPicker tests choosing among multiple candidates
+func TestSuggestionPicker(t *testing.T) {
+	suggestion := &engine.Suggestion{Command: "git status", Alternatives: []string{"git stash", "git show"}}
+
+	testCases := []struct {
+		answer   string
+		expected string
+		ok       bool
+	}{
+		{"y", "git status", true},
+		{"1", "git status", true},
+		{"3\n", "git show", true},
+		{"4", "", false},
+		{"", "", false},
+		{"n", "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := suggestion.Pick(tc.answer)
+		if got != tc.expected || ok != tc.ok {
+			t.Errorf("Pick(%q) = %q, %v; want %q, %v", tc.answer, got, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+// TestGeminiStructuredOutput tests that Gemini is asked for JSON and the
+// command is read from it
+func TestGeminiStructuredOutput(t *testing.T) {
+	var mimeType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ai.GeminiRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mimeType = req.GenerationConfig.ResponseMimeType
+		if req.GenerationConfig.ResponseSchema == nil {
+			t.Error("request has no response schema")
+		}
+
+		fmt.Fprint(w, `{"candidates": [{"content": {"parts": [
+			{"text": "{\"command\": \"git status\", \"explanation\": \"The subcommand was misspelled.\"}"}
+		]}}]}`)
+	}))
+	defer server.Close()
+
+	client := &ai.AIClient{Provider: "gemini", APIKey: "key", Model: "gemini-2.0-flash", BaseURL: server.URL, Timeout: 5 * time.Second}
+	suggestion, err := client.GenerateSuggestion(context.Background(), "Command: git stauts")
+	if err != nil {
+		t.Fatalf("GenerateSuggestion() error = %v", err)
+	}
+
+	if mimeType != "application/json" {
+		t.Errorf("responseMimeType = %q, want application/json", mimeType)
+	}
+	if suggestion != "git status" {
+		t.Errorf("GenerateSuggestion() = %q, want %q", suggestion, "git status")
+	}
+}
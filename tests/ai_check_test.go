@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// TestAIProviderCheck tests key validation, model listing and latency measurement
+func TestAIProviderCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good-key" {
+			http.Error(w, `{"error": "invalid api key"}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/models":
+			fmt.Fprint(w, `{"data": [{"id": "gpt-4o"}, {"id": "gpt-4o-mini"}]}`)
+		case "/v1/chat/completions":
+			fmt.Fprint(w, `{"choices": [{"message": {"role": "assistant", "content": "git status"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	newClient := func(key, model string) *ai.AIClient {
+		return &ai.AIClient{
+			Provider: "openai",
+			APIKey:   key,
+			Model:    model,
+			BaseURL:  server.URL + "/v1/chat/completions",
+			Timeout:  5 * time.Second,
+		}
+	}
+
+	t.Run("valid key", func(t *testing.T) {
+		result := ai.Check(context.Background(), newClient("good-key", "gpt-4o"))
+		if !result.Passed() {
+			t.Fatalf("Check() error = %v", result.Err)
+		}
+		if len(result.Models) != 2 || result.Warning != "" {
+			t.Errorf("Check() models = %v, warning = %q", result.Models, result.Warning)
+		}
+	})
+
+	t.Run("unknown model", func(t *testing.T) {
+		result := ai.Check(context.Background(), newClient("good-key", "gpt-9"))
+		if !result.Passed() || result.Warning == "" {
+			t.Errorf("Check() = %+v, want pass with model warning", result)
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		result := ai.Check(context.Background(), newClient("bad-key", "gpt-4o"))
+		if result.Passed() || !strings.Contains(result.Err.Error(), "401") {
+			t.Errorf("Check() error = %v, want 401 failure", result.Err)
+		}
+	})
+}
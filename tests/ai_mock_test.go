@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// TestMockAIProvider tests canned responses, fixtures and the echo fallback
+func TestMockAIProvider(t *testing.T) {
+	fixtures := filepath.Join(t.TempDir(), "fixtures.json")
+	content := `[{"pattern": "(?i)terraform", "response": "terraform init"}]`
+	if err := os.WriteFile(fixtures, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write fixtures: %v", err)
+	}
+
+	mock, err := ai.NewMockProvider(fixtures)
+	if err != nil {
+		t.Fatalf("NewMockProvider() error = %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		prompt   string
+		expected string
+	}{
+		{
+			name:     "fixture response",
+			prompt:   "Command: terraform plan\nError: Backend initialization required",
+			expected: "terraform init",
+		},
+		{
+			name:     "built-in response",
+			prompt:   "Command: git merge dev\nError: Automatic merge failed; fix conflicts",
+			expected: "git status",
+		},
+		{
+			name:     "engine prompt fallback",
+			prompt:   "Command: make buidl\nError: No rule to make target\nProvide a corrected command:",
+			expected: "make buidl",
+		},
+		{
+			name:     "plugin prompt fallback",
+			prompt:   "CONTEXT:\n- User executed command: npm instal\n- Command output/error: oops",
+			expected: "npm instal",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := mock.Respond(tc.prompt); got != tc.expected {
+				t.Errorf("Respond() = %q, want %q", got, tc.expected)
+			}
+		})
+	}
+
+	if _, err := ai.NewMockProvider(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("NewMockProvider() expected error for missing fixtures file")
+	}
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// TestAIRateLimiter tests the persisted token bucket with a fake clock
+func TestAIRateLimiter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	limiter := ai.NewRateLimiter(path, 6, 2)
+	limiter.Now = func() time.Time { return now }
+
+	// The burst is available immediately, then the bucket is empty
+	for i := 0; i < 2; i++ {
+		if !limiter.Allow() {
+			t.Fatalf("Allow() #%d = false, want burst of 2", i+1)
+		}
+	}
+	if limiter.Allow() {
+		t.Fatal("Allow() = true after burst was used")
+	}
+
+	// A second limiter (another process) shares the same bucket
+	other := ai.NewRateLimiter(path, 6, 2)
+	other.Now = func() time.Time { return now }
+	if other.Allow() {
+		t.Fatal("Allow() from another limiter = true, want shared empty bucket")
+	}
+
+	// 6 requests/minute refill one token every 10 seconds
+	now = now.Add(10 * time.Second)
+	if !limiter.Allow() {
+		t.Error("Allow() = false after refill interval")
+	}
+	if limiter.Allow() {
+		t.Error("Allow() = true, only one token should have been refilled")
+	}
+
+	var unlimited *ai.RateLimiter
+	if !unlimited.Allow() {
+		t.Error("nil limiter should always allow")
+	}
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestApachePlugin tests the Apache configtest plugin
+func TestApachePlugin(t *testing.T) {
+	root := t.TempDir()
+	plugin := &plugins.ApachePlugin{Root: root}
+	t.Setenv("EDITOR", "vim")
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "rewrite module on Debian",
+			command:     "sudo apache2ctl configtest",
+			output:      "AH00526: Syntax error on line 8 of /etc/apache2/sites-enabled/app.conf:\nInvalid command 'RewriteEngine', perhaps misspelled or defined by a module not included in the server configuration",
+			shouldMatch: true,
+			expectedFix: "sudo a2enmod rewrite",
+		},
+		{
+			name:        "proxy on Debian",
+			command:     "sudo apache2ctl -t",
+			output:      "Invalid command 'ProxyPass', perhaps misspelled or defined by a module not included in the server configuration",
+			shouldMatch: true,
+			expectedFix: "sudo a2enmod proxy proxy_http",
+		},
+		{
+			name:        "ssl on RHEL",
+			command:     "sudo apachectl configtest",
+			output:      "AH00526: Syntax error on line 5 of /etc/httpd/conf.d/site.conf:\nInvalid command 'SSLEngine', perhaps misspelled or defined by a module not included in the server configuration",
+			shouldMatch: true,
+			expectedFix: "sudo dnf install mod_ssl",
+		},
+		{
+			name:        "headers on RHEL",
+			command:     "sudo httpd -t",
+			output:      "Invalid command 'Header', perhaps misspelled or defined by a module not included in the server configuration",
+			shouldMatch: true,
+			expectedFix: "sudo vim /etc/httpd/conf.modules.d/99-headers.conf",
+		},
+		{
+			name:        "port conflict",
+			command:     "sudo apachectl start",
+			output:      "(98)Address already in use: AH00072: make_sock: could not bind to address [::]:80\nno listening sockets available, shutting down",
+			shouldMatch: true,
+			expectedFix: "sudo ss -ltnp sport = :80",
+		},
+		{
+			name:        "other syntax error",
+			command:     "sudo apache2ctl configtest",
+			output:      "AH00526: Syntax error on line 14 of /etc/apache2/sites-enabled/000-default.conf:\n</VirtualHost> without matching <VirtualHost> section",
+			shouldMatch: true,
+			expectedFix: "sudo vim +14 /etc/apache2/sites-enabled/000-default.conf",
+		},
+		{
+			name:        "module name typo",
+			command:     "sudo a2enmod rewrit",
+			output:      "ERROR: Module rewrit does not exist!",
+			shouldMatch: true,
+			expectedFix: "sudo a2enmod rewrite",
+		},
+		{
+			name:        "syntax ok",
+			command:     "sudo apache2ctl configtest",
+			output:      "Syntax OK",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+
+	// Fixes that take several steps suggest the next one each time
+	steps := []struct {
+		name   string
+		file   string // created before the step
+		output string
+		want   string
+	}{
+		{"wsgi not installed", "", "Invalid command 'WSGIScriptAlias', perhaps misspelled or defined by a module not included in the server configuration", "sudo apt install libapache2-mod-wsgi-py3"},
+		{"wsgi installed", "usr/lib/apache2/modules/mod_wsgi.so", "Invalid command 'WSGIScriptAlias', perhaps misspelled or defined by a module not included in the server configuration", "sudo a2enmod wsgi"},
+		{"ServerName not set", "", "AH00558: apache2: Could not reliably determine the server's fully qualified domain name, using 127.0.1.1. Set the 'ServerName' directive globally to suppress this message", "sudo vim /etc/apache2/conf-available/servername.conf"},
+		{"ServerName file written", "etc/apache2/conf-available/servername.conf", "AH00558: apache2: Could not reliably determine the server's fully qualified domain name, using 127.0.1.1. Set the 'ServerName' directive globally to suppress this message", "sudo a2enconf servername"},
+	}
+	for _, step := range steps {
+		if step.file != "" {
+			path := filepath.Join(root, step.file)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := plugin.Suggest("sudo apache2ctl configtest", step.output); got != step.want {
+			t.Errorf("%s: Suggest() = %q, want %q", step.name, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/api"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestAPI tests the HTTP endpoints of 'logaid serve'
+func TestAPI(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{})
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "sudo apt install nginx"}})
+	store := history.New(filepath.Join(t.TempDir(), "history.json"), 10)
+	if _, err := store.Add(history.Entry{Command: "gti status", Suggestion: "git status", Source: "git", Status: history.StatusApplied}); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(api.NewServer(eng, store, "secret").Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   string
+		status int
+		want   string // expected in the response body
+	}{
+		{"health needs no token", "GET", "/v1/health", "", "", http.StatusOK, `"status":"ok"`},
+		{"suggest without token", "POST", "/v1/suggest", "", `{"command":"apt install ngnix"}`, http.StatusUnauthorized, "invalid token"},
+		{"suggest with wrong token", "POST", "/v1/suggest", "nope", `{"command":"apt install ngnix"}`, http.StatusUnauthorized, "invalid token"},
+		{"suggest", "POST", "/v1/suggest", "secret", `{"command":"apt install ngnix","output":"E: Unable to locate package ngnix","context":"os: ubuntu"}`, http.StatusOK, `"suggestions":[{"rank":1,"command":"sudo apt install nginx"}]`},
+		{"suggest without command or output", "POST", "/v1/suggest", "secret", `{}`, http.StatusBadRequest, "required"},
+		{"suggest with invalid JSON", "POST", "/v1/suggest", "secret", `{`, http.StatusBadRequest, "invalid request"},
+		{"history", "GET", "/v1/history?limit=1", "secret", "", http.StatusOK, `"suggestion":"git status"`},
+		{"history with invalid limit", "GET", "/v1/history?limit=x", "secret", "", http.StatusBadRequest, "invalid limit"},
+		{"wrong method", "GET", "/v1/suggest", "secret", "", http.StatusMethodNotAllowed, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var body json.RawMessage
+			json.NewDecoder(resp.Body).Decode(&body)
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d (body %s)", resp.StatusCode, tt.status, body)
+			}
+			if !strings.Contains(string(body), tt.want) {
+				t.Errorf("body = %s, want it to contain %s", body, tt.want)
+			}
+		})
+	}
+}
+
+// TestAPIIsLoopback tests which addresses may be served without a token
+func TestAPIIsLoopback(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1:8765", true},
+		{"localhost:8765", true},
+		{"[::1]:8765", true},
+		{":8765", false},
+		{"0.0.0.0:8765", false},
+		{"192.168.1.10:8765", false},
+		{"invalid", false},
+	}
+	for _, tt := range tests {
+		if got := api.IsLoopback(tt.addr); got != tt.want {
+			t.Errorf("IsLoopback(%q) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
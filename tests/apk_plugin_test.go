@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestApkPlugin tests the Alpine apk plugin
+func TestApkPlugin(t *testing.T) {
+	plugin := &plugins.ApkPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "debian package name",
+			command:     "apk add build-essential",
+			output:      "ERROR: unable to select packages:\n  build-essential (no such package):\n    required by: world[build-essential]",
+			shouldMatch: true,
+			expectedFix: "apk add build-base",
+		},
+		{
+			name:        "second of several packages",
+			command:     "apk add --no-cache curl libssl-dev",
+			output:      "ERROR: unable to select packages:\n  libssl-dev (no such package):\n    required by: world[libssl-dev]",
+			shouldMatch: true,
+			expectedFix: "apk add --no-cache curl openssl-dev",
+		},
+		{
+			name:        "fedora devel package",
+			command:     "apk add libffi-devel",
+			output:      "ERROR: unable to select packages:\n  libffi-devel (no such package):\n    required by: world[libffi-devel]",
+			shouldMatch: true,
+			expectedFix: "apk add libffi-dev",
+		},
+		{
+			name:        "missing index",
+			command:     "apk add curl",
+			output:      "WARNING: Ignoring APKINDEX.2c4ac24e.tar.gz: No such file or directory\nERROR: unable to select packages:\n  curl (no such package):\n    required by: world[curl]",
+			shouldMatch: true,
+			expectedFix: "apk --update-cache add curl",
+		},
+		{
+			name:        "stale index",
+			command:     "apk add git",
+			output:      "ERROR: git-2.43.0-r0: package mentioned in index not found (try 'apk update')",
+			shouldMatch: true,
+			expectedFix: "apk --update-cache add git",
+		},
+		{
+			name:        "not root",
+			command:     "apk add htop",
+			output:      "ERROR: Unable to lock database: Permission denied\nERROR: Failed to open apk database: Permission denied",
+			shouldMatch: true,
+			expectedFix: "sudo apk add htop",
+		},
+		{
+			name:        "apt command",
+			command:     "apt install build-essential",
+			output:      "E: Unable to locate package build-essential",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package tests
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ayushsharma-1/LogAid/internal/config"
 	"github.com/ayushsharma-1/LogAid/internal/plugins"
 )
 
@@ -342,3 +345,107 @@ func TestAptPluginEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestAptPackageSearch tests APT_SEARCH_SUGGESTIONS against a fake apt-cache
+func TestAptPackageSearch(t *testing.T) {
+	bin := t.TempDir()
+	script := `#!/bin/sh
+case "$1" in
+pkgnames) printf 'ripgrep\nneovim\nfd-find\nhttpie\n' ;;
+search) [ "$3" = "fd" ] && printf 'fd-find - Simple, fast and user-friendly alternative to find\n' ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(bin, "apt-cache"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{APTSearchSuggestions: true})
+
+	plugin := &plugins.AptPlugin{}
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "typo ranked by edit distance",
+			command:     "sudo apt install ripgrpe",
+			output:      "E: Unable to locate package ripgrpe",
+			expectedFix: "sudo apt install ripgrep",
+		},
+		{
+			name:        "partial name from apt-cache search",
+			command:     "sudo apt install fd",
+			output:      "E: Unable to locate package fd",
+			expectedFix: "sudo apt install fd-find",
+		},
+		{
+			name:        "known package with stale lists",
+			command:     "sudo apt install httpie",
+			output:      "E: Unable to locate package httpie",
+			expectedFix: "sudo apt update && sudo apt install httpie",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+}
+
+// TestAptBackports tests APT_ENABLE_BACKPORTS against a fake apt-cache
+func TestAptBackports(t *testing.T) {
+	bin := t.TempDir()
+	script := `#!/bin/sh
+[ "$1" = "madison" ] && [ "$2" = "neovim" ] && printf '    neovim | 0.9.5-6~bpo12+1 | http://deb.debian.org/debian bookworm-backports/main amd64 Packages\n'
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(bin, "apt-cache"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+
+	plugin := &plugins.AptPlugin{}
+	testCases := []struct {
+		name        string
+		backports   bool
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "package only in backports",
+			backports:   true,
+			command:     "sudo apt install -y neovim",
+			output:      "E: Package 'neovim' has no installation candidate",
+			expectedFix: "sudo apt install -t bookworm-backports -y neovim",
+		},
+		{
+			name:        "disabled",
+			backports:   false,
+			command:     "sudo apt install neovim",
+			output:      "E: Package 'neovim' has no installation candidate",
+			expectedFix: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config.SetCurrent(&config.Config{APTEnableBackports: tc.backports})
+			suggestion := plugin.Suggest(tc.command, tc.output)
+			if tc.expectedFix == "" && strings.Contains(suggestion, "backports") || tc.expectedFix != "" && suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestArchivePlugin tests the tar/zip/unzip plugin
+func TestArchivePlugin(t *testing.T) {
+	plugin := &plugins.ArchivePlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "missing -f on extract",
+			command:     "tar -xz release.tar.gz",
+			output:      "tar: Refusing to read archive contents from terminal (missing -f option?)\ntar: Error is not recoverable: exiting now",
+			shouldMatch: true,
+			expectedFix: "tar -xzf release.tar.gz",
+		},
+		{
+			name:        "missing -f on create",
+			command:     "tar -cz src backup.tar.gz",
+			output:      "tar: Refusing to write archive contents to terminal (missing -f option?)",
+			shouldMatch: true,
+			expectedFix: "tar -czf backup.tar.gz src",
+		},
+		{
+			name:        "f not last in cluster",
+			command:     "tar -xfz site.tar.gz",
+			output:      "tar: z: Cannot open: No such file or directory\ntar: Error is not recoverable: exiting now",
+			shouldMatch: true,
+			expectedFix: "tar -xzf site.tar.gz",
+		},
+		{
+			name:        "gzip flag on xz archive",
+			command:     "tar xzf backup.tar.xz",
+			output:      "gzip: stdin: not in gzip format\ntar: Child returned status 1",
+			shouldMatch: true,
+			expectedFix: "tar xJf backup.tar.xz",
+		},
+		{
+			name:        "bzip2 archive",
+			command:     "sudo tar -xvzf data.tbz2 -C /opt",
+			output:      "gzip: stdin: not in gzip format",
+			shouldMatch: true,
+			expectedFix: "sudo tar -xvjf data.tbz2 -C /opt",
+		},
+		{
+			name:        "zstd archive",
+			command:     "tar -xzf image.tar.zst",
+			output:      "gzip: stdin: not in gzip format",
+			shouldMatch: true,
+			expectedFix: "tar -xf image.tar.zst --zstd",
+		},
+		{
+			name:        "unzip not installed",
+			command:     "unzip assets.zip",
+			output:      "bash: unzip: command not found",
+			shouldMatch: true,
+			expectedFix: "sudo apt install unzip && unzip assets.zip",
+		},
+		{
+			name:        "truncated tarball",
+			command:     "tar -xzf node.tar.gz",
+			output:      "gzip: stdin: unexpected end of file\ntar: Unexpected EOF in archive",
+			shouldMatch: true,
+			expectedFix: "gzip -t node.tar.gz # the archive looks truncated; download it again if this fails",
+		},
+		{
+			name:        "truncated zip",
+			command:     "unzip dist.zip",
+			output:      "End-of-central-directory signature not found.  Either this file is not\n  a zipfile, or it constitutes one disk of a multi-part archive.",
+			shouldMatch: true,
+			expectedFix: "unzip -t dist.zip # the archive looks truncated; download it again if this fails",
+		},
+		{
+			name:        "other command",
+			command:     "make dist",
+			output:      "zip: not found",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// TestAsyncLogging tests buffering log file writes and flushing them
+func TestAsyncLogging(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logaid.log")
+	t.Setenv("LOG_FILE", path)
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_ASYNC", "true")
+	t.Setenv("LOG_FLUSH_INTERVAL", "60")
+	saved := logger.AppLogger
+	if err := logger.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		logger.AppLogger.Close()
+		logger.AppLogger = saved
+	}()
+
+	logged := func(msg string) bool {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.Contains(string(data), msg)
+	}
+
+	logger.Info("buffered message")
+	if logged("buffered message") {
+		t.Error("message written before a flush in async mode")
+	}
+	logger.Flush()
+	if !logged("buffered message") {
+		t.Error("message missing after Flush()")
+	}
+
+	logger.Info("before error")
+	logger.Error("failed message")
+	if !logged("before error") || !logged("failed message") {
+		t.Error("Error() did not flush the buffer")
+	}
+
+	logger.SetAsync(true, 50*time.Millisecond)
+	logger.Info("periodic message")
+	deadline := time.Now().Add(2 * time.Second)
+	for !logged("periodic message") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !logged("periodic message") {
+		t.Error("message not flushed by the periodic flush")
+	}
+
+	logger.SetAsync(false, 0)
+	logger.Info("sync message")
+	if !logged("sync message") {
+		t.Error("message not written immediately in synchronous mode")
+	}
+
+	logger.SetAsync(true, time.Minute)
+	logger.Info("exit message")
+	logger.AppLogger.Close()
+	if !logged("exit message") {
+		t.Error("Close() did not flush the buffer")
+	}
+}
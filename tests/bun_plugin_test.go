@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestBunPlugin tests the Bun plugin with package.json scripts, package typos and Node fallbacks
+func TestBunPlugin(t *testing.T) {
+	dir := t.TempDir()
+	packageJSON := `{"scripts": {"dev": "vite", "build": "vite build", "start": "node server.js"}}`
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(packageJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	plugin := &plugins.BunPlugin{Dir: dir}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "script typo",
+			command:     "bun run biuld",
+			output:      `error: Script not found "biuld"`,
+			shouldMatch: true,
+			expectedFix: "bun run build",
+		},
+		{
+			name:        "subcommand typo",
+			command:     "bun instal",
+			output:      `error: Script not found "instal"`,
+			shouldMatch: true,
+			expectedFix: "bun install",
+		},
+		{
+			name:        "unknown script",
+			command:     "bun run deploy",
+			output:      `error: Script not found "deploy"`,
+			shouldMatch: true,
+			expectedFix: "bun run # List available scripts",
+		},
+		{
+			name:        "package 404",
+			command:     "bun add expresss",
+			output:      "error: package \"expresss\" not found registry.npmjs.org/expresss 404",
+			shouldMatch: true,
+			expectedFix: "bun add express",
+		},
+		{
+			name:        "package 404 with version",
+			command:     "bun add lodas@4",
+			output:      "error: GET https://registry.npmjs.org/lodas - 404",
+			shouldMatch: true,
+			expectedFix: "bun add lodash@4",
+		},
+		{
+			name:        "unimplemented node api",
+			command:     "bun run server.js",
+			output:      "NotImplementedError: node:inspector is not yet implemented in Bun.",
+			shouldMatch: true,
+			expectedFix: "node server.js",
+		},
+		{
+			name:        "unimplemented node api in script",
+			command:     "bun run start",
+			output:      "error: node:http2 createSecureServer is not yet implemented in Bun",
+			shouldMatch: true,
+			expectedFix: "npm run start",
+		},
+		{
+			name:        "npm command",
+			command:     "npm run biuld",
+			output:      `npm ERR! Missing script: "biuld"`,
+			shouldMatch: false,
+		},
+		{
+			name:        "successful install",
+			command:     "bun install",
+			output:      "Checked 42 installs across 50 packages (no changes) [12.00ms]",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
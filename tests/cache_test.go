@@ -0,0 +1,208 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/cache"
+)
+
+// TestSemanticCache tests exact hits and reuse of correction patterns across error signatures
+func TestSemanticCache(t *testing.T) {
+	c := cache.New(filepath.Join(t.TempDir(), "suggestions.json"), time.Hour, cache.DefaultSimilarity)
+
+	if err := c.Store("sudo apt install foo1", "E: Unable to locate package foo1", "sudo apt install foo1-dev", "AI"); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if err := c.Store("sudo apt install rediscli", "E: Package rediscli has no installation candidate", "sudo apt install redis-tools", "AI"); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		command  string
+		output   string
+		expected string
+		found    bool
+	}{
+		{
+			name:     "exact hit",
+			command:  "sudo apt install rediscli",
+			output:   "E: Package rediscli has no installation candidate",
+			expected: "sudo apt install redis-tools",
+			found:    true,
+		},
+		{
+			name:     "pattern reused for new package",
+			command:  "sudo apt install foo2",
+			output:   "E: Unable to locate package foo2",
+			expected: "sudo apt install foo2-dev",
+			found:    true,
+		},
+		{
+			name:    "fixed correction not reused for other package",
+			command: "sudo apt install rediscli2",
+			output:  "E: Package rediscli2 has no installation candidate",
+			found:   false,
+		},
+		{
+			name:    "different command shape",
+			command: "apt-get install foo3",
+			output:  "E: Unable to locate package foo3",
+			found:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, found := c.Lookup(tc.command, tc.output)
+			if found != tc.found || got != tc.expected {
+				t.Errorf("Lookup() = %q, %v; want %q, %v", got, found, tc.expected, tc.found)
+			}
+		})
+	}
+
+	// A miss leaves the file alone
+	before, err := os.ReadFile(c.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Lookup("docker ps", "Cannot connect to the Docker daemon")
+	if after, _ := os.ReadFile(c.Path()); string(after) != string(before) {
+		t.Error("Lookup() rewrote the cache on a miss")
+	}
+
+	// Forget removes only the correction stored for that exact failure
+	if err := c.Forget("sudo apt install foo9", "E: Unable to locate package foo9"); err != nil {
+		t.Fatalf("Forget() error = %v", err)
+	}
+	if _, found := c.Lookup("sudo apt install foo2", "E: Unable to locate package foo2"); !found {
+		t.Error("Forget() removed a pattern learned from another failure")
+	}
+	if err := c.Forget("sudo apt install foo1", "E: Unable to locate package foo1"); err != nil {
+		t.Fatalf("Forget() error = %v", err)
+	}
+	if _, found := c.Lookup("sudo apt install foo2", "E: Unable to locate package foo2"); found {
+		t.Error("Lookup() should miss after Forget()")
+	}
+}
+
+// TestErrorSignature tests normalization of volatile output details
+func TestErrorSignature(t *testing.T) {
+	a := cache.NewSignature("docker run ubntu", "Unable to find image 'ubntu:latest' locally\nError 404 at /var/lib/docker/a1b2c3d4e5")
+	b := cache.NewSignature("docker run alpne", "Unable to find image 'alpne:latest' locally\nError 500 at /tmp/other")
+
+	if a.Command != "docker run {{1}}" || a.Command != b.Command {
+		t.Errorf("command templates = %q, %q", a.Command, b.Command)
+	}
+	if a.Error != b.Error {
+		t.Errorf("error signatures differ: %q vs %q", a.Error, b.Error)
+	}
+	if a.Key() == b.Key() {
+		t.Errorf("signatures for different images share the key %q", a.Key())
+	}
+	if again := cache.NewSignature("docker run ubntu", "Unable to find image 'ubntu:latest' locally\nError 403 at /tmp/x"); again.Key() != a.Key() {
+		t.Errorf("Key() = %q, want %q for the same failure", again.Key(), a.Key())
+	}
+}
+
+// TestCacheManagement tests hit rates, selective clearing and pruning
+func TestCacheManagement(t *testing.T) {
+	newCache := func(t *testing.T) *cache.Cache {
+		c := cache.New(filepath.Join(t.TempDir(), "suggestions.json"), 0, cache.DefaultSimilarity)
+		c.Store("sudo apt install rediscli", "E: Unable to locate package rediscli", "sudo apt install redis-tools", "AI")
+		c.Store("git chekout main", "git: 'chekout' is not a git command", "git checkout main", "AI")
+		c.Lookup("sudo apt install rediscli", "E: Unable to locate package rediscli")
+		c.Lookup("docker ps", "Cannot connect to the Docker daemon")
+		return c
+	}
+
+	t.Run("stats", func(t *testing.T) {
+		stats, err := newCache(t).Stats()
+		if err != nil {
+			t.Fatalf("Stats() error = %v", err)
+		}
+		if stats.Entries != 2 || stats.Plugins["apt"] != 1 || stats.Plugins["git"] != 1 {
+			t.Errorf("Stats() = %+v, want one apt and one git entry", stats)
+		}
+		// Two stored misses and one hit; the miss nothing was stored for is
+		// not written down
+		if stats.HitRate() != 1.0/3 {
+			t.Errorf("HitRate() = %v, want 1/3", stats.HitRate())
+		}
+		if stats.Size == 0 {
+			t.Error("Stats() has no size")
+		}
+	})
+
+	tests := []struct {
+		name    string
+		filter  cache.Filter
+		removed int
+	}{
+		{"by plugin", cache.Filter{Plugin: "git"}, 1},
+		{"unknown plugin", cache.Filter{Plugin: "npm"}, 0},
+		{"recently used", cache.Filter{OlderThan: time.Hour}, 0},
+		{"everything", cache.Filter{}, 2},
+	}
+	for _, tt := range tests {
+		t.Run("clear "+tt.name, func(t *testing.T) {
+			c := newCache(t)
+			removed, err := c.Clear(tt.filter)
+			if err != nil {
+				t.Fatalf("Clear() error = %v", err)
+			}
+			if removed != tt.removed {
+				t.Errorf("Clear() removed %d, want %d", removed, tt.removed)
+			}
+			entries, _ := c.Entries()
+			if len(entries) != 2-tt.removed {
+				t.Errorf("%d entries left, want %d", len(entries), 2-tt.removed)
+			}
+		})
+	}
+
+	t.Run("prune", func(t *testing.T) {
+		c := newCache(t)
+		if removed, err := c.Prune(1 << 20); err != nil || removed != 0 {
+			t.Errorf("Prune(1MB) = %d, %v, want nothing removed", removed, err)
+		}
+		stats, _ := c.Stats()
+		limit := stats.Size * 3 / 4
+		if removed, err := c.Prune(limit); err != nil || removed != 1 {
+			t.Errorf("Prune(%d) = %d, %v, want 1 removed", limit, removed, err)
+		}
+		if stats, _ := c.Stats(); stats.Size > limit {
+			t.Errorf("cache is %d bytes after Prune(%d)", stats.Size, limit)
+		}
+		entries, _ := c.Entries()
+		if len(entries) != 1 || entries[0].Tool() != "apt" {
+			t.Errorf("Prune() kept %+v, want the recently used apt entry", entries)
+		}
+	})
+}
+
+// TestParseSize tests the size limits accepted by logaid cache prune
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"512", 512, false},
+		{"512B", 512, false},
+		{"4KB", 4096, false},
+		{"1.5mb", 3 << 19, false},
+		{"2GB", 2 << 30, false},
+		{"lots", 0, true},
+		{"-1MB", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := cache.ParseSize(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestCCPlugin tests the C/C++ compiler plugin
+func TestCCPlugin(t *testing.T) {
+	plugin := &plugins.CCPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "missing openssl header",
+			command:     "gcc client.c -o client",
+			output:      "client.c:3:10: fatal error: openssl/ssl.h: No such file or directory\n    3 | #include <openssl/ssl.h>\ncompilation terminated.",
+			shouldMatch: true,
+			expectedFix: "sudo apt install libssl-dev && gcc client.c -o client",
+		},
+		{
+			name:        "missing header with clang",
+			command:     "clang -c db.c",
+			output:      "db.c:1:10: fatal error: 'sqlite3.h' file not found",
+			shouldMatch: true,
+			expectedFix: "sudo apt install libsqlite3-dev && clang -c db.c",
+		},
+		{
+			name:        "python extension",
+			command:     "gcc -shared -fPIC ext.c -o ext.so",
+			output:      "ext.c:1:10: fatal error: Python.h: No such file or directory",
+			shouldMatch: true,
+			expectedFix: "sudo apt install python3-dev && gcc -shared -fPIC ext.c -o ext.so",
+		},
+		{
+			name:        "math library",
+			command:     "gcc calc.c -o calc",
+			output:      "/usr/bin/ld: /tmp/ccX1.o: in function `main':\ncalc.c:(.text+0x2a): undefined reference to `sqrt'\ncollect2: error: ld returned 1 exit status",
+			shouldMatch: true,
+			expectedFix: "gcc calc.c -o calc -lm",
+		},
+		{
+			name:        "openssl link",
+			command:     "gcc client.c -o client",
+			output:      "undefined reference to `SSL_CTX_new'",
+			shouldMatch: true,
+			expectedFix: "gcc client.c -o client -lssl -lcrypto",
+		},
+		{
+			name:        "C++ compiled with gcc",
+			command:     "gcc main.cpp -o main",
+			output:      "undefined reference to `std::ios_base::Init::Init()'",
+			shouldMatch: true,
+			expectedFix: "g++ main.cpp -o main",
+		},
+		{
+			name:        "compiler not installed",
+			command:     "gcc hello.c",
+			output:      "bash: gcc: command not found",
+			shouldMatch: true,
+			expectedFix: "sudo apt install build-essential && gcc hello.c",
+		},
+		{
+			name:        "own function missing",
+			command:     "gcc main.c -o main",
+			output:      "undefined reference to `parse_config'",
+			shouldMatch: true,
+		},
+		{
+			name:        "type error",
+			command:     "gcc main.c",
+			output:      "main.c:5:12: error: incompatible types when assigning to type 'int' from type 'char *'",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/ci"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+)
+
+// TestCIAnnotations tests the GitHub Actions annotations and job summary of
+// 'logaid ci'
+func TestCIAnnotations(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{MaskSecrets: true})
+
+	tests := []struct {
+		name       string
+		report     engine.Report
+		annotation string   // "" for none
+		summary    []string // expected in the job summary, nil for none
+	}{
+		{
+			name:   "succeeded",
+			report: engine.Report{Command: "make test"},
+		},
+		{
+			name: "failed with a fix",
+			report: engine.Report{
+				Command: "apt install ngnix", ExitCode: 100, ErrorDetected: true,
+				Output:     "Reading package lists...\nE: Unable to locate package ngnix\n",
+				Source:     "apt",
+				Suggestion: "sudo apt install nginx", Alternatives: []string{"apt search ngnix"},
+				Confidence: 0.9, Decision: engine.DecisionSuggested,
+			},
+			annotation: "::error title=LogAid%3A apt install ngnix failed::E: Unable to locate package ngnix%0ASuggested fix (from apt): sudo apt install nginx%0A  or: apt search ngnix",
+			summary: []string{
+				"### ❌ `apt install ngnix` failed with exit code 100",
+				"> E: Unable to locate package ngnix",
+				"**Suggested fix** from apt (confidence 0.90):\n\n```sh\nsudo apt install nginx\n```",
+				"- or `apt search ngnix`",
+				"_Suggested only; LogAid did not run it._",
+				"```text\nReading package lists...\nE: Unable to locate package ngnix\n```",
+			},
+		},
+		{
+			name: "compiler error",
+			report: engine.Report{
+				Command: "go build ./...", ExitCode: 1, ErrorDetected: true,
+				Output: "# app\nmain.go:12:5: undefined: foo (error)\n", Error: "no fix found",
+			},
+			annotation: "::error title=LogAid%3A go build ./... failed,file=main.go,line=12,col=5::main.go:12:5: undefined: foo (error)",
+			summary:    []string{"_No fix found: no fix found_"},
+		},
+		{
+			name: "fixed",
+			report: engine.Report{
+				Command: "gti status", ExitCode: 1, ErrorDetected: true, Fixed: true,
+				Output: "gti: command not found", Source: "notfound", Suggestion: "git status", FixExitCode: new(int),
+			},
+			annotation: "::warning title=LogAid%3A error in gti status::gti: command not found%0ASuggested fix (from notfound): git status",
+			summary:    []string{"### ✅ `gti status` failed and LogAid fixed it"},
+		},
+		{
+			name:       "not started",
+			report:     engine.Report{Command: "nope", ExitCode: -1},
+			annotation: "::error title=LogAid%3A nope failed::the command could not be started",
+			summary:    []string{"### ❌ `nope` could not be started", "_No fix found._"},
+		},
+		{
+			name: "secrets",
+			report: engine.Report{
+				Command: "mysql -u root -p'hunter2' app", ExitCode: 1, ErrorDetected: true,
+				Output: "ERROR 1045 (28000): Access denied",
+			},
+			annotation: "::error title=LogAid%3A mysql -u root -p'[REDACTED]' app failed::ERROR 1045 (28000): Access denied",
+			summary:    []string{"`mysql -u root -p'[REDACTED]' app`"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ci.Annotation(&tt.report); got != tt.annotation {
+				t.Errorf("Annotation() = %q, want %q", got, tt.annotation)
+			}
+			summary := ci.Summary(&tt.report)
+			if tt.summary == nil && summary != "" {
+				t.Errorf("Summary() = %q, want none", summary)
+			}
+			for _, want := range tt.summary {
+				if !strings.Contains(summary, want) {
+					t.Errorf("Summary() = %q, does not contain %q", summary, want)
+				}
+			}
+			if strings.Contains(summary, "hunter2") {
+				t.Errorf("Summary() = %q, contains the password", summary)
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv(ci.SummaryEnv, path)
+	for _, markdown := range []string{"### one", "### two"} {
+		if err := ci.WriteSummary(markdown); err != nil {
+			t.Fatalf("WriteSummary() error = %v", err)
+		}
+	}
+	if data, _ := os.ReadFile(path); string(data) != "### one\n### two\n" {
+		t.Errorf("job summary = %q, want both summaries appended", data)
+	}
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// TestConfigSetUnset tests that keys are written to and removed from
+// config.yaml with the right type, keeping the rest of the file
+func TestConfigSetUnset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("# my settings\nlog_level: info\n"), 0644)
+
+	if err := config.SetInFile(path, "log-level", "debug"); err != nil {
+		t.Fatalf("SetInFile() error = %v", err)
+	}
+	if err := config.SetInFile(path, "PLUGIN_TIMEOUT", "7"); err != nil {
+		t.Fatalf("SetInFile() error = %v", err)
+	}
+	if err := config.SetInFile(path, "PLUGIN_TIMEOUT", "soon"); err == nil {
+		t.Error("SetInFile() accepted a non-numeric PLUGIN_TIMEOUT")
+	}
+	if err := config.SetInFile(path, "NO_SUCH_KEY", "1"); err == nil {
+		t.Error("SetInFile() accepted an unknown key")
+	}
+
+	data, _ := os.ReadFile(path)
+	want := "# my settings\nlog_level: debug\nPLUGIN_TIMEOUT: 7\n"
+	if string(data) != want {
+		t.Errorf("config.yaml = %q, want %q", data, want)
+	}
+
+	if err := config.UnsetInFile(path, "LOG_LEVEL"); err != nil {
+		t.Fatalf("UnsetInFile() error = %v", err)
+	}
+	if err := config.UnsetInFile(path, "LOG_LEVEL"); err == nil {
+		t.Error("UnsetInFile() of a missing key succeeded")
+	}
+	data, _ = os.ReadFile(path)
+	if strings.Contains(string(data), "log_level") {
+		t.Errorf("config.yaml still has log_level: %q", data)
+	}
+}
+
+// TestConfigValidateFile tests that mistyped values are errors and unknown
+// keys are warnings
+func TestConfigValidateFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantErrors   []string
+		wantWarnings []string
+		wantParseErr bool
+	}{
+		{name: "valid", content: "LOG_LEVEL: debug\nENABLE_COLORS: false\nAI_TEMPERATURE: 0.2\n"},
+		{name: "empty", content: ""},
+		{name: "unknown key", content: "LOG_LEVL: debug\n", wantWarnings: []string{"LOG_LEVL"}},
+		{name: "wrong types", content: "ENABLE_COLORS: maybe\nMAX_SUGGESTIONS: many\n", wantErrors: []string{"ENABLE_COLORS", "MAX_SUGGESTIONS"}},
+		{name: "not an allowed value", content: "AI_PROVIDER: claude\n", wantErrors: []string{"AI_PROVIDER"}},
+		{name: "not yaml", content: "LOG_LEVEL: [\n", wantParseErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			os.WriteFile(path, []byte(tt.content), 0644)
+
+			problems, err := config.ValidateFile(path)
+			if (err != nil) != tt.wantParseErr {
+				t.Fatalf("ValidateFile() error = %v, wantParseErr %v", err, tt.wantParseErr)
+			}
+
+			var errs, warnings []string
+			for _, problem := range problems {
+				if problem.Warning {
+					warnings = append(warnings, problem.Key)
+				} else {
+					errs = append(errs, problem.Key)
+				}
+				if problem.Fix == "" {
+					t.Errorf("%s: no fix suggested", problem.Key)
+				}
+			}
+			if strings.Join(errs, ",") != strings.Join(tt.wantErrors, ",") {
+				t.Errorf("errors = %v, want %v", errs, tt.wantErrors)
+			}
+			if strings.Join(warnings, ",") != strings.Join(tt.wantWarnings, ",") {
+				t.Errorf("warnings = %v, want %v", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+// TestConfigValidateStartup tests that Init reports the offending keys
+func TestConfigValidateStartup(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		wantKeys []string
+	}{
+		{"valid", map[string]string{"LOG_LEVEL": "warn"}, nil},
+		{"wrong type", map[string]string{"PLUGIN_TIMEOUT": "soon", "ENABLE_COLORS": "sometimes"}, []string{"ENABLE_COLORS", "PLUGIN_TIMEOUT"}},
+		{"unknown provider", map[string]string{"AI_PROVIDER": "claude"}, []string{"AI_PROVIDER"}},
+		{"negative", map[string]string{"MAX_SUGGESTIONS": "-1"}, []string{"MAX_SUGGESTIONS"}},
+		{"exclusive options", map[string]string{"AI_CA_BUNDLE": "/nonexistent/ca.pem", "AI_INSECURE_SKIP_VERIFY": "true"}, []string{"AI_CA_BUNDLE", "AI_INSECURE_SKIP_VERIFY"}},
+		{"unwritable path", map[string]string{"CACHE_DIR": "/dev/null/cache"}, []string{"CACHE_DIR"}},
+	}
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			err := config.Init()
+			if tt.wantKeys == nil {
+				if err != nil {
+					t.Fatalf("Init() error = %v", err)
+				}
+				return
+			}
+
+			var invalid config.ValidationErrors
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Init() error = %v, want validation errors", err)
+			}
+			var keys []string
+			for _, problem := range invalid {
+				keys = append(keys, problem.Key)
+			}
+			if strings.Join(keys, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("Init() reported %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// TestConfigChanges tests describing what changed between configurations
+func TestConfigChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cfg *config.Config)
+		want   []string
+	}{
+		{"unchanged", func(cfg *config.Config) {}, nil},
+		{"log level and provider", func(cfg *config.Config) {
+			cfg.LogLevel = "debug"
+			cfg.AIProvider = "openai"
+		}, []string{"AI_PROVIDER: gemini -> openai", "LOG_LEVEL: info -> debug"}},
+		{"cleared value", func(cfg *config.Config) { cfg.EnablePlugins = "" }, []string{`ENABLE_PLUGINS: git,npm -> ""`}},
+		{"secret hidden", func(cfg *config.Config) { cfg.OpenAIAPIKey = "sk-new" }, []string{"OPENAI_API_KEY changed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := &config.Config{LogLevel: "info", AIProvider: "gemini", EnablePlugins: "git,npm", OpenAIAPIKey: "sk-old"}
+			updated := *old
+			tt.change(&updated)
+			got := config.Changes(old, &updated)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Changes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConfigReloadRemovedKey tests that a key removed from .env no longer
+// applies after a reload
+func TestConfigReloadRemovedKey(t *testing.T) {
+	if _, ok := os.LookupEnv("LOG_LEVEL"); ok {
+		t.Skip("LOG_LEVEL is set in the environment and takes precedence over .env")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { os.Unsetenv("LOG_LEVEL") })
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	envFile := filepath.Join(config.Dir(), ".env")
+	for _, step := range []struct{ env, want string }{
+		{"LOG_LEVEL=debug\n", "debug"},
+		{"# LOG_LEVEL removed\n", "info"},
+	} {
+		if err := os.WriteFile(envFile, []byte(step.env), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := config.Reload(); err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+		if config.Current().LogLevel != step.want {
+			t.Errorf("after writing %q LOG_LEVEL = %q, want %q", step.env, config.Current().LogLevel, step.want)
+		}
+	}
+}
+
+// TestConfigReloadInvalid tests that an invalid configuration is reported
+// and the previous one stays in effect
+func TestConfigReloadInvalid(t *testing.T) {
+	if _, ok := os.LookupEnv("PLUGIN_TIMEOUT"); ok {
+		t.Skip("PLUGIN_TIMEOUT is set in the environment and takes precedence over .env")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { os.Unsetenv("PLUGIN_TIMEOUT") })
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+	previous := config.Current()
+
+	if err := os.WriteFile(filepath.Join(config.Dir(), ".env"), []byte("PLUGIN_TIMEOUT=-1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Reload(); err == nil || !strings.Contains(err.Error(), "PLUGIN_TIMEOUT") {
+		t.Errorf("Reload() error = %v, want PLUGIN_TIMEOUT reported", err)
+	}
+	if config.Current() != previous {
+		t.Errorf("after an invalid reload PLUGIN_TIMEOUT = %d, want the previous configuration kept", config.Current().PluginTimeout)
+	}
+}
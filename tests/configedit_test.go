@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/configedit"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestConfigEditApplyAndRevert tests INI-section edits with backup and revert
+func TestConfigEditApplyAndRevert(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	gitconfig := filepath.Join(home, ".gitconfig")
+	original := "[user]\n\tname = Dev\n[pull]\n\trebase = true\n[core]\n\teditor = vim\n"
+	if err := os.WriteFile(gitconfig, []byte(original), 0644); err != nil {
+		t.Fatalf("Failed to write gitconfig: %v", err)
+	}
+
+	plugin := &plugins.GitPlugin{}
+	edit := plugin.SuggestConfig("git pull", "hint: You have divergent branches and need to specify how to reconcile them.")
+	if edit == nil {
+		t.Fatal("SuggestConfig() returned nil for divergent branches")
+	}
+
+	preview, err := edit.Preview()
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if !strings.Contains(preview, "- rebase = true") || !strings.Contains(preview, "+ rebase = false") {
+		t.Errorf("Preview() = %q, want replaced rebase line", preview)
+	}
+
+	if err := edit.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	updated, _ := os.ReadFile(gitconfig)
+	expected := "[user]\n\tname = Dev\n[pull]\n\trebase = false\n[core]\n\teditor = vim\n"
+	if string(updated) != expected {
+		t.Errorf("Apply() wrote %q, want %q", updated, expected)
+	}
+
+	if _, err := configedit.RevertLast(); err != nil {
+		t.Fatalf("RevertLast() error = %v", err)
+	}
+	restored, _ := os.ReadFile(gitconfig)
+	if string(restored) != original {
+		t.Errorf("RevertLast() restored %q, want %q", restored, original)
+	}
+
+	if _, err := configedit.RevertLast(); err == nil {
+		t.Error("RevertLast() expected error when nothing is left to revert")
+	}
+}
+
+// TestConfigEditCreatesFile tests flat key=value edits on a missing file
+func TestConfigEditCreatesFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	plugin := &plugins.NpmPlugin{}
+	edit := plugin.SuggestConfig("npm install -g typescript", "npm ERR! Error: EACCES: permission denied")
+	if edit == nil {
+		t.Fatal("SuggestConfig() returned nil for EACCES on global install")
+	}
+
+	if err := edit.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	npmrc := filepath.Join(home, ".npmrc")
+	content, _ := os.ReadFile(npmrc)
+	if string(content) != "prefix=${HOME}/.npm-global\n" {
+		t.Errorf("Apply() wrote %q", content)
+	}
+
+	if _, err := configedit.RevertLast(); err != nil {
+		t.Fatalf("RevertLast() error = %v", err)
+	}
+	if _, err := os.Stat(npmrc); !os.IsNotExist(err) {
+		t.Error("RevertLast() should remove a file created by Apply()")
+	}
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/container"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestContainerAdapt tests rewriting fixes for the environment of a container
+func TestContainerAdapt(t *testing.T) {
+	alpine := container.ParseEnv("os=alpine\nuid=0\npm=apk\n")
+	if alpine.OS != "alpine" || !alpine.Root || alpine.PackageManager != "apk" || alpine.Sudo || alpine.Systemd {
+		t.Fatalf("ParseEnv() = %+v", alpine)
+	}
+	debian := container.ParseEnv("os=debian\nuid=1000\npm=apt-get\nsudo=1\nsystemd=1\n")
+
+	tests := []struct {
+		name    string
+		env     container.Env
+		command string
+		want    string // "" when the command cannot work there
+	}{
+		{"sudo dropped as root", alpine, "sudo -E make install", "make install"},
+		{"package manager", alpine, "sudo apt install -y nginx curl", "apk add nginx curl"},
+		{"same package manager", alpine, "apk add --no-cache nginx", "apk add --no-cache nginx"},
+		{"other commands kept", alpine, "npm install express", "npm install express"},
+		{"systemctl without systemd", alpine, "sudo systemctl restart nginx", ""},
+		{"sudo kept for a user", debian, "sudo dnf install nginx", "sudo apt-get install -y nginx"},
+		{"systemctl with systemd", debian, "sudo systemctl restart nginx", "sudo systemctl restart nginx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.env.Adapt(tt.command)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("Adapt(%q) = %q, want an error", tt.command, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Adapt(%q) = %q, %v, want %q", tt.command, got, err, tt.want)
+			}
+		})
+	}
+
+	if got := alpine.Describe(); !strings.Contains(got, "apk") || !strings.Contains(got, "do not use sudo") || !strings.Contains(got, "do not use systemctl") {
+		t.Errorf("Describe() = %q", got)
+	}
+}
+
+// fakeDocker answers inspect for the container "web", the environment probe
+// for an Alpine image and fails "apt install ngnix"; every call is logged
+const fakeDocker = `#!/bin/sh
+echo "$@" >> "$DOCKER_LOG"
+case "$1" in
+inspect) echo "abc123 true" ;;
+exec)
+	shift; [ "$1" = "-i" ] && shift; shift
+	case "$1" in
+	sh) printf 'os=alpine\nuid=0\npm=apk\n' ;;
+	apt) echo "E: Unable to locate package ngnix" >&2; exit 100 ;;
+	esac ;;
+esac
+`
+
+// TestContainerExec tests monitoring a command run in a container and
+// running its fix there
+func TestContainerExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake docker is a shell script")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(fakeDocker), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	log := filepath.Join(dir, "docker.log")
+	t.Setenv("DOCKER_LOG", log)
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{ContainerRuntime: "auto", AssumeYes: true})
+
+	env, err := container.Open(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if env.Runtime != "docker" || env.ID != "abc123" || env.PackageManager != "apk" {
+		t.Fatalf("Open() = %+v", env)
+	}
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "apt", fix: "sudo apt install nginx"}})
+	eng.SetContainer(env)
+	var out bytes.Buffer
+	report, err := eng.Monitor(exec.Command("apt", "install", "ngnix"), &out)
+	if err != nil {
+		t.Fatalf("Monitor() error = %v, output %s", err, out.String())
+	}
+	if report.Command != "apt install ngnix" || report.Suggestion != "apk add nginx" || !report.Fixed {
+		t.Errorf("Monitor() = %+v, want the fix adapted to Alpine and applied", report)
+	}
+
+	data, _ := os.ReadFile(log)
+	for _, want := range []string{"exec abc123 apt install ngnix", "exec -i abc123 apk add nginx"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("docker calls %q, want %q", data, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestUserCorrections tests corrections files merged over the built-in maps
+func TestUserCorrections(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"npm.yaml":       "packages:\n  acme-utils: \"@acme/utils\"\n  lodas: lodash-es\n",
+		"git.yaml":       "commands:\n  sw: switch\n",
+		"systemctl.yaml": "services:\n  web: acme-web\n",
+		"apt.yml":        "packages:\n  acmectl: acme-tools\n",
+		"broken.yaml":    "packages: [\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := plugins.LoadCorrections(dir); err != nil {
+		t.Fatalf("LoadCorrections() error = %v", err)
+	}
+	defer plugins.LoadCorrections(t.TempDir())
+
+	testCases := []struct {
+		name        string
+		plugin      plugins.Plugin
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "org package alias",
+			plugin:      &plugins.NpmPlugin{},
+			command:     "npm install acme-utils",
+			output:      "npm ERR! 404 Not Found - GET https://registry.npmjs.org/acme-utils - Not found",
+			expectedFix: "npm install @acme/utils",
+		},
+		{
+			name:        "user entry overrides built-in",
+			plugin:      &plugins.NpmPlugin{},
+			command:     "npm install lodas",
+			output:      "npm ERR! 404 Not Found - GET https://registry.npmjs.org/lodas - Not found",
+			expectedFix: "npm install lodash-es",
+		},
+		{
+			name:        "built-in entries remain",
+			plugin:      &plugins.NpmPlugin{},
+			command:     "npm install expres",
+			output:      "npm ERR! 404 Not Found - GET https://registry.npmjs.org/expres - Not found",
+			expectedFix: "npm install express",
+		},
+		{
+			name:        "command typo",
+			plugin:      &plugins.GitPlugin{},
+			command:     "git sw main",
+			output:      "git: 'sw' is not a git command. See 'git --help'.",
+			expectedFix: "git switch main",
+		},
+		{
+			name:        "service name",
+			plugin:      &plugins.SystemctlPlugin{},
+			command:     "sudo systemctl restart web",
+			output:      "Failed to restart web.service: Unit web.service not found.",
+			expectedFix: "sudo systemctl restart acme-web.service",
+		},
+		{
+			name:        "yml extension",
+			plugin:      &plugins.AptPlugin{},
+			command:     "sudo apt install acmectl",
+			output:      "E: Unable to locate package acmectl",
+			expectedFix: "sudo apt install acme-tools",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if suggestion := tc.plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+}
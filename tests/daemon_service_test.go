@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/daemon"
+)
+
+// TestDaemonServiceUnit tests the systemd unit written by 'logaid daemon install'
+func TestDaemonServiceUnit(t *testing.T) {
+	tests := []struct {
+		name       string
+		executable string
+		socket     string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "paths fixed at install",
+			executable: "/usr/local/bin/logaid",
+			socket:     "/home/dev/.logaid/daemon.sock",
+			want: []string{
+				`ExecStart="/usr/local/bin/logaid" daemon`,
+				`Environment="DAEMON_SOCKET=/home/dev/.logaid/daemon.sock"`,
+				`Environment="LOG_FILE=/home/dev/.logaid/logs/logaid.log"`,
+				"Restart=on-failure",
+				"WantedBy=default.target",
+			},
+		},
+		{
+			name:       "spaces and specifiers escaped",
+			executable: "/opt/log aid/logaid",
+			socket:     "/run/100%/daemon.sock",
+			want:       []string{`ExecStart="/opt/log aid/logaid" daemon`, `DAEMON_SOCKET=/run/100%%/daemon.sock"`},
+		},
+		{name: "daemon disabled", executable: "/usr/local/bin/logaid", socket: "", wantErr: true},
+		{name: "relative executable", executable: "logaid", socket: "/tmp/daemon.sock", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := daemon.ServiceUnit(tt.executable, tt.socket, "/home/dev/.logaid/logs/logaid.log")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ServiceUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(unit, want) {
+					t.Errorf("unit is missing %q:\n%s", want, unit)
+				}
+			}
+		})
+	}
+}
+
+// TestDaemonServiceInstall tests writing and removing the unit, leaving
+// units LogAid did not write alone
+func TestDaemonServiceInstall(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	dir, err := daemon.ServiceDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(config, "systemd", "user"); dir != want {
+		t.Fatalf("ServiceDir() = %s, want %s", dir, want)
+	}
+
+	unit, err := daemon.ServiceUnit("/usr/local/bin/logaid", "/tmp/daemon.sock", "/tmp/logaid.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := daemon.InstallService(dir, unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !daemon.ServiceInstalled(dir) {
+		t.Fatal("ServiceInstalled() = false after install")
+	}
+	if _, err := daemon.InstallService(dir, unit); err != nil {
+		t.Errorf("installing again: %v", err)
+	}
+
+	removed, err := daemon.UninstallService(dir)
+	if err != nil || !removed {
+		t.Fatalf("UninstallService() = %v, %v", removed, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("%s still exists", path)
+	}
+
+	if err := os.WriteFile(path, []byte("[Service]\nExecStart=/bin/true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := daemon.InstallService(dir, unit); err == nil {
+		t.Error("InstallService() replaced a unit LogAid did not write")
+	}
+	if removed, _ := daemon.UninstallService(dir); removed {
+		t.Error("UninstallService() removed a unit LogAid did not write")
+	}
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/daemon"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestDaemon tests serving suggestions to a remote engine over the socket
+func TestDaemon(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{AssumeYes: true})
+	savedLogger := logger.AppLogger
+	t.Setenv("LOG_FILE", filepath.Join(t.TempDir(), "logaid.log"))
+	if err := logger.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		logger.AppLogger.Close()
+		logger.AppLogger = savedLogger
+	}()
+	logger.SetConsole(io.Discard)
+
+	path := filepath.Join(t.TempDir(), "daemon.sock")
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "true"}})
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	served := make(chan error, 1)
+	go func() { served <- daemon.NewServer(eng, path).Serve(ctx) }()
+
+	client := daemon.NewClient(path)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := client.Status(context.Background()); err == nil {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("daemon did not start: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	remote := engine.NewRemote(client)
+	report, err := remote.Monitor(exec.Command("sh", "-c", "echo 'error: boom' >&2; exit 3"), io.Discard)
+	if err != nil {
+		t.Fatalf("Monitor() error = %v", err)
+	}
+	if report.Plugin != "stub" || report.Suggestion != "true" || !report.Fixed {
+		t.Errorf("report = %+v, want the stub plugin's fix applied", report)
+	}
+
+	status, err := client.Status(context.Background())
+	if err != nil || status.Requests != 1 || status.Plugins != 1 {
+		t.Errorf("Status() = %+v, %v, want 1 request and 1 plugin", status, err)
+	}
+
+	if level, err := client.SetLogLevel(context.Background(), "DEBUG"); err != nil || level != "debug" {
+		t.Errorf("SetLogLevel(DEBUG) = %q, %v, want debug", level, err)
+	}
+	if _, err := client.SetLogLevel(context.Background(), "loud"); err == nil {
+		t.Error("SetLogLevel(loud) succeeded, want an unknown level error")
+	}
+	if level, err := client.SetLogLevel(context.Background(), ""); err != nil || level != "debug" {
+		t.Errorf("SetLogLevel(\"\") = %q, %v, want the current level debug", level, err)
+	}
+
+	if err := client.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("daemon did not stop")
+	}
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestDockerHubLookup tests image and tag corrections from Docker Hub
+func TestDockerHubLookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/repositories/library/grafana/", "/v2/repositories/library/nginx/":
+			fmt.Fprint(w, `{"name": "ok"}`)
+		case "/v2/search/repositories/":
+			if r.URL.Query().Get("query") == "grafna" {
+				fmt.Fprint(w, `{"results": [{"repo_name": "grafana/grafana"}, {"repo_name": "grafana/loki"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"results": []}`)
+		case "/v2/repositories/library/nginx/tags/":
+			fmt.Fprint(w, `{"results": [{"name": "1.27-alpine"}, {"name": "1.27"}, {"name": "latest"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{DockerHubSearch: true, DockerSuggestTags: true, CacheDir: t.TempDir()})
+
+	plugin := &plugins.DockerPlugin{Hub: server.URL}
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "image found by search",
+			command:     "docker run -d grafna",
+			output:      "Unable to find image 'grafna:latest' locally\ndocker: Error response from daemon: pull access denied for grafna, repository does not exist",
+			expectedFix: "docker run -d grafana/grafana:latest",
+		},
+		{
+			name:        "close tag",
+			command:     "docker pull nginx:1.72",
+			output:      "Error response from daemon: manifest for nginx:1.72 not found: manifest unknown: manifest unknown",
+			expectedFix: "docker pull nginx:1.27",
+		},
+		{
+			name:        "unrelated tag falls back to latest",
+			command:     "docker run nginx:stable-bookworm-perl",
+			output:      "Unable to find image 'nginx:stable-bookworm-perl' locally\ndocker: Error response from daemon: manifest for nginx:stable-bookworm-perl not found: manifest unknown",
+			expectedFix: "docker run nginx:latest",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/doctor"
+)
+
+// TestDoctor tests that each diagnostic reports the problem it is meant to find
+func TestDoctor(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(t *testing.T, cfg *config.Config, home string)
+		reachable bool
+		want      map[string]string
+	}{
+		{
+			name: "healthy environment",
+			setup: func(t *testing.T, cfg *config.Config, home string) {
+				os.WriteFile(filepath.Join(home, ".bashrc"), []byte("eval \"$(logaid)\"\n"), 0644)
+			},
+			reachable: true,
+			want: map[string]string{
+				"Configuration": doctor.StatusOK,
+				"API key":       doctor.StatusOK,
+				"Network":       doctor.StatusOK,
+				"Shell hook":    doctor.StatusOK,
+				"Plugins":       doctor.StatusOK,
+				"Directories":   doctor.StatusOK,
+			},
+		},
+		{
+			name: "missing key and unreachable provider",
+			setup: func(t *testing.T, cfg *config.Config, home string) {
+				cfg.GeminiAPIKey = ""
+			},
+			want: map[string]string{
+				"API key":    doctor.StatusFail,
+				"Network":    doctor.StatusFail,
+				"Shell hook": doctor.StatusWarn,
+			},
+		},
+		{
+			name: "unknown provider",
+			setup: func(t *testing.T, cfg *config.Config, home string) {
+				cfg.AIProvider = "claude"
+			},
+			want: map[string]string{"Configuration": doctor.StatusFail},
+		},
+		{
+			name: "broken rule pack and unknown plugin",
+			setup: func(t *testing.T, cfg *config.Config, home string) {
+				cfg.EnablePlugins = "git,nosuch"
+				os.WriteFile(filepath.Join(cfg.PluginsDir, "broken.yaml"), []byte("rules: [\n"), 0644)
+			},
+			reachable: true,
+			want:      map[string]string{"Plugins": doctor.StatusFail},
+		},
+		{
+			name: "unknown plugin",
+			setup: func(t *testing.T, cfg *config.Config, home string) {
+				cfg.EnablePlugins = "git,nosuch"
+			},
+			reachable: true,
+			want:      map[string]string{"Plugins": doctor.StatusWarn},
+		},
+		{
+			name: "unwritable cache directory",
+			setup: func(t *testing.T, cfg *config.Config, home string) {
+				file := filepath.Join(home, "not-a-dir")
+				os.WriteFile(file, nil, 0644)
+				cfg.CacheDir = filepath.Join(file, "cache")
+			},
+			reachable: true,
+			want:      map[string]string{"Directories": doctor.StatusFail},
+		},
+	}
+
+	originalConfig := config.Current()
+	defer func() { config.SetCurrent(originalConfig) }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			cfg := &config.Config{
+				AIProvider:    "gemini",
+				GeminiAPIKey:  "test-key",
+				LogLevel:      "info",
+				EnablePlugins: "git,npm",
+				LogFile:       filepath.Join(home, "logs", "logaid.log"),
+				CacheDir:      filepath.Join(home, "cache"),
+				HistoryFile:   filepath.Join(home, "history.json"),
+				PluginsDir:    filepath.Join(home, "plugins"),
+			}
+			os.MkdirAll(cfg.PluginsDir, 0755)
+			tt.setup(t, cfg, home)
+			config.SetCurrent(cfg)
+
+			d := &doctor.Doctor{
+				Home: home,
+				Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					if !tt.reachable {
+						return nil, errors.New("connection refused")
+					}
+					client, server := net.Pipe()
+					server.Close()
+					return client, nil
+				},
+			}
+
+			results := map[string]doctor.Result{}
+			for _, result := range d.Run(context.Background()) {
+				results[result.Name] = result
+			}
+			for name, status := range tt.want {
+				result, ok := results[name]
+				if !ok {
+					t.Fatalf("no %q check in results", name)
+				}
+				if result.Status != status {
+					t.Errorf("%s: status = %q (%s), want %q", name, result.Status, result.Detail, status)
+				}
+				if status != doctor.StatusOK && result.Fix == "" {
+					t.Errorf("%s: no remediation for %q", name, result.Detail)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// scenarios are real failing commands and the fix LogAid should propose
+var scenarios = []Scenario{
+	{
+		Name:             "git typo auto-fixed",
+		Command:          "git stauts",
+		Confirm:          true,
+		ExpectSuggestion: "git status",
+		ExpectFixed:      true,
+	},
+	{
+		Name:             "git typo declined",
+		Command:          "git checout main",
+		ExpectSuggestion: "git checkout main",
+		ExpectFixed:      false,
+	},
+	{
+		Name:             "apt package typo",
+		Command:          "apt-get install rediscli",
+		Images:           []string{"ubuntu"},
+		ExpectSuggestion: "apt-get install redis-tools",
+		ExpectFixed:      false,
+	},
+}
+
+// TestContainers runs every scenario inside disposable containers
+func TestContainers(t *testing.T) {
+	if ok, reason := Enabled(); !ok {
+		t.Skipf("Skipping container tests: %s", reason)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	binary, err := BuildBinary(ctx, t.TempDir())
+	if err != nil {
+		t.Fatalf("BuildBinary() error = %v", err)
+	}
+
+	for _, image := range Images {
+		image := image
+		t.Run(image.Name, func(t *testing.T) {
+			t.Parallel()
+
+			container, err := Start(ctx, image, binary)
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			defer container.Close()
+
+			for _, scenario := range scenarios {
+				if !scenario.RunsOn(image) {
+					continue
+				}
+				t.Run(scenario.Name, func(t *testing.T) {
+					result, err := container.RunLogAid(ctx, scenario)
+					if err != nil {
+						t.Fatalf("RunLogAid() error = %v", err)
+					}
+					if err := Check(scenario, result); err != nil {
+						t.Error(err)
+					}
+				})
+			}
+		})
+	}
+}
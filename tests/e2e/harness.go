@@ -0,0 +1,235 @@
+// Package e2e runs LogAid end to end inside disposable Docker containers.
+// It is enabled with E2E_TEST_CONTAINERS=true and requires a working docker CLI.
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Image is a distribution LogAid is tested on, with the commands that
+// prepare it (installing the tools the scenarios need)
+type Image struct {
+	Name  string
+	Ref   string
+	Setup []string
+}
+
+// Images are the distributions covered by the end-to-end tests
+var Images = []Image{
+	{
+		Name:  "ubuntu",
+		Ref:   "ubuntu:22.04",
+		Setup: []string{"apt-get update -qq && DEBIAN_FRONTEND=noninteractive apt-get install -y -qq git >/dev/null"},
+	},
+	{
+		Name:  "fedora",
+		Ref:   "fedora:40",
+		Setup: []string{"dnf install -y -q git >/dev/null"},
+	},
+	{
+		Name:  "alpine",
+		Ref:   "alpine:3.20",
+		Setup: []string{"apk add --no-cache -q git"},
+	},
+}
+
+// workDir is a git repository created in every container for the scenarios
+const workDir = "/work"
+
+// Scenario is a failing command run through `logaid exec` and the outcome
+// expected from it
+type Scenario struct {
+	Name    string
+	Command string
+	Images  []string          // image names to run on; empty means all
+	Env     map[string]string // extra environment for logaid
+	Confirm bool              // auto-confirm the suggestion
+
+	ExpectSuggestion string // text the proposed fix must contain
+	ExpectFixed      bool   // whether logaid should exit successfully
+}
+
+// RunsOn reports whether the scenario applies to the image
+func (s Scenario) RunsOn(image Image) bool {
+	if len(s.Images) == 0 {
+		return true
+	}
+	for _, name := range s.Images {
+		if name == image.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Result is the combined output and exit code of a command in a container
+type Result struct {
+	Output   string
+	ExitCode int
+}
+
+// Container is a running disposable container
+type Container struct {
+	ID    string
+	Image Image
+}
+
+// Enabled reports whether end-to-end tests were requested and docker is usable
+func Enabled() (bool, string) {
+	if os.Getenv("E2E_TEST_CONTAINERS") != "true" {
+		return false, "E2E_TEST_CONTAINERS is not set to true"
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		return false, "docker CLI not found"
+	}
+	if err := exec.Command("docker", "info").Run(); err != nil {
+		return false, "docker daemon not reachable"
+	}
+	return true, ""
+}
+
+// BuildBinary cross-compiles a static linux logaid binary into dir
+func BuildBinary(ctx context.Context, dir string) (string, error) {
+	root, err := moduleRoot()
+	if err != nil {
+		return "", err
+	}
+
+	binary := filepath.Join(dir, "logaid")
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", binary, ".")
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "GOOS=linux", "GOARCH="+runtime.GOARCH)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to build logaid: %w\n%s", err, out)
+	}
+	return binary, nil
+}
+
+// Start runs a container from image, installs the logaid binary and runs the
+// image setup commands. The caller must Close the container.
+func Start(ctx context.Context, image Image, binary string) (*Container, error) {
+	out, err := docker(ctx, "run", "-d", "--rm", "-w", workDir, image.Ref, "sleep", "infinity")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", image.Ref, err)
+	}
+	c := &Container{ID: strings.TrimSpace(out), Image: image}
+
+	if _, err := docker(ctx, "cp", binary, c.ID+":/usr/local/bin/logaid"); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to copy logaid into %s: %w", image.Ref, err)
+	}
+
+	setup := append(append([]string{}, image.Setup...),
+		fmt.Sprintf("mkdir -p %s && cd %s && git init -q && git config --global user.email e2e@logaid.dev && git config --global user.name e2e", workDir, workDir))
+	for _, command := range setup {
+		result, err := c.Exec(ctx, nil, command)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		if result.ExitCode != 0 {
+			c.Close()
+			return nil, fmt.Errorf("setup of %s failed (%s): %s", image.Ref, command, result.Output)
+		}
+	}
+
+	return c, nil
+}
+
+// Exec runs a shell command in the container
+func (c *Container) Exec(ctx context.Context, env map[string]string, command string) (Result, error) {
+	args := []string{"exec", "-w", workDir}
+	for _, key := range sortedKeys(env) {
+		args = append(args, "-e", key+"="+env[key])
+	}
+	args = append(args, c.ID, "sh", "-c", command)
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return Result{Output: output.String(), ExitCode: exitErr.ExitCode()}, nil
+	}
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to exec in %s: %w", c.Image.Ref, err)
+	}
+	return Result{Output: output.String()}, nil
+}
+
+// RunLogAid runs a scenario through `logaid exec` with the deterministic
+// mock AI provider
+func (c *Container) RunLogAid(ctx context.Context, scenario Scenario) (Result, error) {
+	env := map[string]string{
+		"AI_PROVIDER":            "mock",
+		"ENABLE_COLORS":          "false",
+		"PERSONAL_MODEL":         "false",
+		"LEARN_FIXES":            "false",
+		"FREQUENT_FIX_MIN_COUNT": "0",
+		"AUTO_CONFIRM":           fmt.Sprintf("%t", scenario.Confirm),
+	}
+	for key, value := range scenario.Env {
+		env[key] = value
+	}
+
+	return c.Exec(ctx, env, "logaid exec "+scenario.Command+" </dev/null")
+}
+
+// Close removes the container
+func (c *Container) Close() error {
+	_, err := docker(context.Background(), "rm", "-f", c.ID)
+	return err
+}
+
+// Check compares a scenario result with its expectations
+func Check(scenario Scenario, result Result) error {
+	if scenario.ExpectSuggestion != "" && !strings.Contains(result.Output, scenario.ExpectSuggestion) {
+		return fmt.Errorf("expected suggestion %q in output:\n%s", scenario.ExpectSuggestion, result.Output)
+	}
+	if fixed := result.ExitCode == 0; fixed != scenario.ExpectFixed {
+		return fmt.Errorf("exit code %d, expected fixed=%t; output:\n%s", result.ExitCode, scenario.ExpectFixed, result.Output)
+	}
+	return nil
+}
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// moduleRoot returns the directory containing go.mod
+func moduleRoot() (string, error) {
+	out, err := exec.Command("go", "env", "GOMOD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate go.mod: %w", err)
+	}
+	gomod := strings.TrimSpace(string(out))
+	if gomod == "" || gomod == os.DevNull {
+		return "", fmt.Errorf("not inside a Go module")
+	}
+	return filepath.Dir(gomod), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
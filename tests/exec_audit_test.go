@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/audit"
+)
+
+// TestExecAudit tests the hash chain of the executed commands audit log
+func TestExecAudit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "exec.jsonl")
+	for i, command := range []string{"git pull", "npm install", "sudo apt-get update"} {
+		record := audit.Record{Time: time.Now().UTC(), Command: command, Source: "git", Confirmation: "accepted", ExitCode: i}
+		if err := audit.Append(path, record); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	records, err := audit.Read(path)
+	if err != nil || len(records) != 3 {
+		t.Fatalf("Read() = %d records, %v, want 3", len(records), err)
+	}
+	if records[0].Prev != "" || records[1].Prev != records[0].Hash || records[2].ExitCode != 2 {
+		t.Errorf("Read() = %+v, want chained records", records)
+	}
+	if n, err := audit.Verify(records); err != nil || n != 3 {
+		t.Errorf("Verify() = %d, %v, want 3 records intact", n, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(strings.TrimSpace(string(data)), "\n")
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"modified", strings.Replace(string(data), "npm install", "npm ci", 1), "record 2"},
+		{"removed", lines[0] + lines[2] + "\n", "record 2"},
+		{"truncated start", lines[1] + lines[2] + "\n", "record 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := filepath.Join(t.TempDir(), "exec.jsonl")
+			if err := os.WriteFile(tampered, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			records, err := audit.Read(tampered)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := audit.Verify(records); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Verify() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
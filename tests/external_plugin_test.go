@@ -0,0 +1,303 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// writeExternalPlugin creates an executable shell script plugin in dir
+func writeExternalPlugin(t *testing.T, dir, name, script string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestExternalPlugins tests discovery and the JSON protocol of external plugins
+func TestExternalPlugins(t *testing.T) {
+	dir := t.TempDir()
+	writeExternalPlugin(t, dir, "terraform.sh", `
+if grep -q terrafrom; then
+  echo '{"match": true, "suggestion": "terraform plan", "confidence": 0.9}'
+else
+  echo '{"match": false}'
+fi
+`, 0755)
+	writeExternalPlugin(t, dir, "broken", "echo not json\n", 0755)
+	writeExternalPlugin(t, dir, "slow", "sleep 5\n", 0755)
+	writeExternalPlugin(t, dir, "README", "not a plugin\n", 0644)
+
+	loaded := plugins.LoadExternalPlugins(dir, 500*time.Millisecond)
+	var names []string
+	for _, plugin := range loaded {
+		names = append(names, plugin.Name())
+	}
+	if len(names) != 3 || names[0] != "broken" || names[1] != "slow" || names[2] != "terraform" {
+		t.Fatalf("LoadExternalPlugins() = %v, want [broken slow terraform]", names)
+	}
+
+	testCases := []struct {
+		name        string
+		plugin      plugins.Plugin
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "plugin matches",
+			plugin:      loaded[2],
+			command:     "terrafrom plan",
+			output:      "terrafrom: command not found",
+			shouldMatch: true,
+			expectedFix: "terraform plan",
+		},
+		{
+			name:        "plugin passes",
+			plugin:      loaded[2],
+			command:     "ls",
+			output:      "ls: cannot access 'x'",
+			shouldMatch: false,
+		},
+		{
+			name:        "invalid response",
+			plugin:      loaded[0],
+			command:     "terrafrom plan",
+			output:      "terrafrom: command not found",
+			shouldMatch: false,
+		},
+		{
+			name:        "timeout",
+			plugin:      loaded[1],
+			command:     "terrafrom plan",
+			output:      "terrafrom: command not found",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := tc.plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := tc.plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+
+	if confidence := plugins.Score(loaded[2], "terrafrom plan", "terrafrom: command not found"); confidence != 0.9 {
+		t.Errorf("Score() = %v, want 0.9", confidence)
+	}
+}
+
+// TestWasmPlugin tests loading and calling a WebAssembly plugin
+func TestWasmPlugin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building the WASM fixture is slow")
+	}
+
+	dir := t.TempDir()
+	build := exec.Command("go", "build", "-buildmode=c-shared", "-o", filepath.Join(dir, "terraform.wasm"), ".")
+	build.Dir = filepath.Join("testdata", "wasmplugin")
+	build.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Skipf("cannot build WASM fixture: %v\n%s", err, out)
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("PLUGIN_TERRAFORM_FLAGS", "-no-color")
+	t.Setenv("TERRAFORM_FLAGS", "-leaked")
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := plugins.LoadExternalPlugins(dir, 5*time.Second)
+	if len(loaded) != 1 || loaded[0].Name() != "terraform" {
+		t.Fatalf("LoadExternalPlugins() = %v, want the terraform WASM plugin", loaded)
+	}
+	plugin := loaded[0]
+
+	if !plugin.Match("terrafrom plan", "terrafrom: command not found") {
+		t.Fatal("Match() = false, want true")
+	}
+	if suggestion := plugin.Suggest("terrafrom plan", "terrafrom: command not found"); suggestion != "terraform plan -no-color" {
+		t.Errorf("Suggest() = %q, want %q", suggestion, "terraform plan -no-color")
+	}
+	if plugin.Match("ls", "ls: cannot access 'x'") {
+		t.Error("Match() = true for an unrelated command")
+	}
+}
+
+// TestPluginSetting tests that plugins read only their own PLUGIN_<NAME>_*
+// settings
+func TestPluginSetting(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("PLUGIN_TERRAFORM_FLAGS", "-no-color")
+	t.Setenv("PLUGIN_MY_LINT_LEVEL", "strict")
+	t.Setenv("NOTIFY_SLACK_WEBHOOK", "https://hooks.slack.com/services/T0/B0/x")
+	t.Setenv("DATABASE_URL", "postgres://admin:hunter2@db/app")
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Current()
+
+	tests := []struct {
+		plugin, key string
+		want        string
+		wantOK      bool
+	}{
+		{"terraform", "FLAGS", "-no-color", true},
+		{"terraform", "flags", "-no-color", true},
+		{"my-lint", "LEVEL", "strict", true},
+		{"kubectl", "FLAGS", "", false},
+		{"terraform", "", "", false},
+		{"terraform", "NOTIFY_SLACK_WEBHOOK", "", false},
+		{"terraform", "DATABASE_URL", "", false},
+		{"terraform", "HOME", "", false},
+		{"terraform", "PLUGIN_MY_LINT_LEVEL", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := cfg.PluginSetting(tt.plugin, tt.key); got != tt.want || ok != tt.wantOK {
+			t.Errorf("PluginSetting(%q, %q) = %q, %v; want %q, %v", tt.plugin, tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// TestLuaPlugin tests Lua scripted plugins and their sandbox
+func TestLuaPlugin(t *testing.T) {
+	dir := t.TempDir()
+	script := `
+local commands = {"terraform", "kubectl", "docker"}
+
+function match(command, output)
+  return output:find("command not found") ~= nil
+end
+
+function suggest(command, output)
+  local fields = logaid.fields(command)
+  local fixed = logaid.closest(fields[1], commands)
+  if fixed == nil then
+    return nil
+  end
+  if os ~= nil or io ~= nil or dofile ~= nil then
+    return "sandbox escaped"
+  end
+  fields[1] = fixed
+  return table.concat(fields, " ")
+end
+`
+	if err := os.WriteFile(filepath.Join(dir, "typos.lua"), []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "loop.lua"), []byte("function match() while true do end end\nfunction suggest() end\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "invalid.lua"), []byte("function match(\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := plugins.LoadExternalPlugins(dir, 500*time.Millisecond)
+	if len(loaded) != 2 || loaded[0].Name() != "loop" || loaded[1].Name() != "typos" {
+		t.Fatalf("LoadExternalPlugins() = %v, want [loop typos]", loaded)
+	}
+
+	testCases := []struct {
+		name        string
+		plugin      plugins.Plugin
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "fuzzy match helper",
+			plugin:      loaded[1],
+			command:     "sudo terrafrom plan -out tf.plan",
+			output:      "sudo: terrafrom: command not found",
+			shouldMatch: true,
+			expectedFix: "terraform plan -out tf.plan",
+		},
+		{
+			name:        "suggest returns nil",
+			plugin:      loaded[1],
+			command:     "frobnicate",
+			output:      "frobnicate: command not found",
+			shouldMatch: false,
+		},
+		{
+			name:        "match returns false",
+			plugin:      loaded[1],
+			command:     "terraform plan",
+			output:      "Error: No configuration files",
+			shouldMatch: false,
+		},
+		{
+			name:        "timeout",
+			plugin:      loaded[0],
+			command:     "terrafrom plan",
+			output:      "terrafrom: command not found",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := tc.plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := tc.plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
+
+// TestLuaPluginConfig tests that Lua plugins read only their own settings
+func TestLuaPluginConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("PLUGIN_TERRAFORM_FLAGS", "-no-color")
+	t.Setenv("DATABASE_URL", "postgres://admin:hunter2@db/app")
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	script := `
+function match(command, output)
+  return true
+end
+
+function suggest(command, output)
+  return table.concat({"terraform plan", tostring(logaid.config("FLAGS")),
+    tostring(logaid.config("DATABASE_URL")), tostring(logaid.config("HOME"))}, " ")
+end
+`
+	if err := os.WriteFile(filepath.Join(dir, "terraform.lua"), []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded := plugins.LoadExternalPlugins(dir, time.Second)
+	if len(loaded) != 1 {
+		t.Fatalf("LoadExternalPlugins() = %v, want the terraform Lua plugin", loaded)
+	}
+	if got, want := loaded[0].Suggest("terrafrom plan", "terrafrom: command not found"), "terraform plan -no-color nil nil"; got != want {
+		t.Errorf("Suggest() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/feedback"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestFeedbackDownRanking tests that suggestions rated harmful are only made
+// when nothing else is found
+func TestFeedbackDownRanking(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+
+	tests := []struct {
+		name    string
+		plugins []plugins.Plugin
+		harmful string
+		want    string
+	}{
+		{
+			name:    "no feedback",
+			plugins: []plugins.Plugin{&stubPlugin{name: "first", fix: "git push --force"}, &stubPlugin{name: "second", fix: "git pull --rebase"}},
+			want:    "git push --force",
+		},
+		{
+			name:    "harmful suggestion skipped",
+			plugins: []plugins.Plugin{&stubPlugin{name: "first", fix: "git push --force"}, &stubPlugin{name: "second", fix: "git pull --rebase"}},
+			harmful: "git push --force",
+			want:    "git pull --rebase",
+		},
+		{
+			name:    "harmful suggestion as last resort",
+			plugins: []plugins.Plugin{&stubPlugin{name: "first", fix: "git push --force"}},
+			harmful: "git push --force",
+			want:    "git push --force",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.SetCurrent(&config.Config{HistoryFile: filepath.Join(t.TempDir(), "history.json")})
+			store := history.NewFromConfig()
+			if tt.harmful != "" {
+				if _, err := store.Add(history.Entry{Command: "git push", Suggestion: tt.harmful, Feedback: history.FeedbackHarmful}); err != nil {
+					t.Fatalf("Add() error = %v", err)
+				}
+			}
+
+			eng := engine.New()
+			eng.SetPlugins(tt.plugins)
+			suggestion, _ := eng.Suggest(context.Background(), "git push", "error: failed to push some refs")
+			if suggestion == nil || suggestion.Command != tt.want {
+				t.Errorf("Suggest() = %+v, want %q", suggestion, tt.want)
+			}
+		})
+	}
+}
+
+// TestFeedbackUpload tests that uploaded feedback leaves out the commands'
+// arguments, the output and the note
+func TestFeedbackUpload(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode upload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	entry := history.Entry{
+		Command:    "sudo /usr/bin/apt install secret-package",
+		Output:     "E: Unable to locate package secret-package",
+		Suggestion: "DEBIAN_FRONTEND=noninteractive apt-get install secret-pkg",
+		Source:     "apt",
+		Feedback:   history.FeedbackHarmful,
+		Note:       "wrong package name for my-hostname",
+	}
+	if err := feedback.Upload(context.Background(), server.Client(), server.URL, feedback.NewReport(entry, "1.0.0")); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	want := map[string]string{"rating": "harmful", "source": "apt", "tool": "apt", "suggestion_tool": "apt-get", "version": "1.0.0"}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %q, want %q", key, got[key], value)
+		}
+	}
+	for _, value := range got {
+		if strings.Contains(value, "secret") || strings.Contains(value, "hostname") {
+			t.Errorf("upload %v leaks %q", got, value)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestFindPlugin tests the find/xargs plugin
+func TestFindPlugin(t *testing.T) {
+	plugin := &plugins.FindPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "unquoted name pattern",
+			command:     "find . -name *.txt -delete",
+			output:      "find: paths must precede expression: `b.txt'\nfind: possible unquoted pattern after predicate `-name'?",
+			shouldMatch: true,
+			expectedFix: "find . -name '*.txt' -delete",
+		},
+		{
+			name:        "path after expression",
+			command:     "find -name '*.go' src",
+			output:      "find: paths must precede expression: `src'",
+			shouldMatch: true,
+			expectedFix: "find src -name '*.go'",
+		},
+		{
+			name:        "exec without terminator",
+			command:     "find . -name '*.tmp' -exec rm {}",
+			output:      "find: missing argument to `-exec'",
+			shouldMatch: true,
+			expectedFix: `find . -name '*.tmp' -exec rm {} \;`,
+		},
+		{
+			name:        "maxdepth after expression",
+			command:     "find . -type f -maxdepth 1",
+			output:      "find: warning: you have specified the global option -maxdepth after the argument -type, but global options are not positional",
+			shouldMatch: true,
+			expectedFix: "find . -maxdepth 1 -type f",
+		},
+		{
+			name:        "permission noise",
+			command:     "find / -name nginx.conf | head",
+			output:      "find: '/proc/1/map_files': Permission denied\nfind: '/root': Permission denied",
+			shouldMatch: true,
+			expectedFix: "find / ! -readable -prune -o -name nginx.conf -print | head",
+		},
+		{
+			name:        "permission noise with alternatives",
+			command:     "find /var -maxdepth 2 -name *.log -o -name *.gz",
+			output:      "find: '/var/cache/private': Permission denied",
+			shouldMatch: true,
+			expectedFix: "find /var -maxdepth 2 ! -readable -prune -o ( -name *.log -o -name *.gz ) -print",
+		},
+		{
+			name:        "permission noise with an action",
+			command:     "find / -name core -delete",
+			output:      "find: '/root': Permission denied",
+			shouldMatch: true,
+			expectedFix: "find / ! -readable -prune -o -name core -delete",
+		},
+		{
+			name:        "xargs quote in file name",
+			command:     "find . -name '*.jpg' | xargs rm",
+			output:      "xargs: unmatched single quote; by default quotes are special to xargs unless you use the -0 option",
+			shouldMatch: true,
+			expectedFix: "find . -name '*.jpg' -print0 | xargs -0 rm",
+		},
+		{
+			name:        "xargs -0 without print0",
+			command:     "find . -type f | xargs -0 grep TODO",
+			output:      "xargs: argument line too long",
+			shouldMatch: true,
+			expectedFix: "find . -type f -print0 | xargs -0 grep TODO",
+		},
+		{
+			name:        "not find",
+			command:     "ls /root",
+			output:      "ls: cannot open directory '/root': Permission denied",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestFrequentFixes tests ranking the fixes applied to the same failure by
+// how often they worked
+func TestFrequentFixes(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	t.Setenv("HOME", t.TempDir())
+	config.SetCurrent(&config.Config{
+		HistoryFile:         filepath.Join(t.TempDir(), "history.json"),
+		FrequentFixMinCount: 3,
+	})
+
+	const command, output = "apt install node", "E: Unable to locate package node"
+	store := history.NewFromConfig()
+	record := func(command, output, suggestion, status string, times int) {
+		for i := 0; i < times; i++ {
+			if _, err := store.Add(history.Entry{Command: command, Output: output, Suggestion: suggestion, Status: status}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "apt", fix: "apt install nod"}})
+	ctx := context.Background()
+
+	record(command, output, "sudo apt install nodejs npm", history.StatusApplied, 2)
+	record(command, output, "apt install nod", history.StatusRejected, 3)
+	if suggestion, _ := eng.Suggest(ctx, command, output); suggestion == nil || suggestion.Source != "apt" {
+		t.Fatalf("Suggest() after 2 fixes = %+v, want the plugin's suggestion", suggestion)
+	}
+
+	record(command, output, "sudo apt install nodejs npm", history.StatusApplied, 10)
+	record(command, output, "sudo apt install nodejs", history.StatusApplied, 1)
+	record("apt install foo", "E: Unable to locate package foo", "apt install foo-utils", history.StatusApplied, 5)
+
+	resolutions, err := store.Resolutions(command, output)
+	if err != nil {
+		t.Fatalf("Resolutions() error = %v", err)
+	}
+	if len(resolutions) != 2 || resolutions[0].Command != "sudo apt install nodejs npm" || resolutions[0].Count != 12 {
+		t.Fatalf("Resolutions() = %+v", resolutions)
+	}
+
+	suggestion, err := eng.Suggest(ctx, command, output)
+	if err != nil || suggestion == nil {
+		t.Fatalf("Suggest() = %v, %v", suggestion, err)
+	}
+	if suggestion.Command != "sudo apt install nodejs npm" || suggestion.Source != "history" {
+		t.Errorf("Suggest() = %+v, want the most frequent fix", suggestion)
+	}
+	if !strings.Contains(suggestion.Note, "12 times") {
+		t.Errorf("Note = %q, want the number of times it worked", suggestion.Note)
+	}
+
+	if suggestion, _ := eng.Suggest(ctx, "apt install bar", "E: Unable to locate package bar"); suggestion == nil || suggestion.Source != "apt" {
+		t.Errorf("Suggest() for another package = %+v, want no fix from the history", suggestion)
+	}
+
+	updated := *config.Current()
+	updated.FrequentFixMinCount = 0
+	config.SetCurrent(&updated)
+	if suggestion, _ := eng.Suggest(ctx, command, output); suggestion == nil || suggestion.Source != "apt" {
+		t.Errorf("Suggest() with FREQUENT_FIX_MIN_COUNT=0 = %+v", suggestion)
+	}
+}
@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// gitRun runs git in dir and fails the test on error
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// TestGitRepositoryRefs tests branch and remote suggestions from the repository
+func TestGitRepositoryRefs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	upstream := filepath.Join(root, "upstream")
+	gitRun(t, root, "init", "--quiet", "-b", "main", upstream)
+	gitRun(t, upstream, "commit", "--quiet", "--allow-empty", "-m", "initial")
+	gitRun(t, upstream, "branch", "release-2.0")
+
+	work := filepath.Join(root, "work")
+	gitRun(t, root, "clone", "--quiet", upstream, work)
+	gitRun(t, work, "branch", "dev")
+	gitRun(t, work, "branch", "feature/login")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(work); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	plugin := &plugins.GitPlugin{}
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "local branch typo",
+			command:     "git checkout feature/lgoin",
+			output:      "error: pathspec 'feature/lgoin' did not match any file(s) known to git",
+			expectedFix: "git checkout feature/login",
+		},
+		{
+			name:        "shorter local branch",
+			command:     "git checkout develop",
+			output:      "error: pathspec 'develop' did not match any file(s) known to git",
+			expectedFix: "git checkout dev",
+		},
+		{
+			name:        "remote branch",
+			command:     "git switch release-2.1",
+			output:      "fatal: invalid reference: release-2.1",
+			expectedFix: "git switch --track origin/release-2.0",
+		},
+		{
+			name:        "unknown branch fetched first",
+			command:     "git checkout hotfix-login-timeout",
+			output:      "error: pathspec 'hotfix-login-timeout' did not match any file(s) known to git",
+			expectedFix: "git fetch && git checkout hotfix-login-timeout",
+		},
+		{
+			name:        "remote typo",
+			command:     "git push orign main",
+			output:      "fatal: 'orign' does not appear to be a git repository",
+			expectedFix: "git push origin main",
+		},
+		{
+			name:        "only remote",
+			command:     "git push upstream main",
+			output:      "fatal: 'upstream' does not appear to be a git repository",
+			expectedFix: "git push origin main",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+}
+
+// TestGitAutoCorrect tests that GIT_AUTO_CORRECT only marks unambiguous
+// subcommand typos to run without asking
+func TestGitAutoCorrect(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+
+	notACommand := "git: 'stauts' is not a git command. See 'git --help'."
+	tests := []struct {
+		name        string
+		autoCorrect bool
+		command     string
+		output      string
+		want        bool
+	}{
+		{"typo", true, "git stauts", notACommand, true},
+		{"disabled", false, "git stauts", notACommand, false},
+		{"not rejected by git", true, "git stauts", "fatal: not a git repository", false},
+		{"other fix", true, "git checkout mian", "error: pathspec 'mian' did not match", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.SetCurrent(&config.Config{GitAutoCorrect: tt.autoCorrect})
+			eng := engine.New()
+			eng.SetPlugins([]plugins.Plugin{&plugins.GitPlugin{}})
+			suggestion, err := eng.Suggest(context.Background(), tt.command, tt.output)
+			if err != nil || suggestion == nil {
+				if tt.want {
+					t.Fatalf("Suggest() = %v, %v", suggestion, err)
+				}
+				return
+			}
+			if suggestion.AutoApply != tt.want {
+				t.Errorf("Suggest(%q).AutoApply = %v, want %v", tt.command, suggestion.AutoApply, tt.want)
+			}
+		})
+	}
+}
+
+// TestGitSuggestAliases tests offering an alias for a repeated typo
+func TestGitSuggestAliases(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{GitSuggestAliases: true, HistoryFile: filepath.Join(t.TempDir(), "history.json")})
+	store := history.NewFromConfig()
+
+	plugin := &plugins.GitPlugin{}
+	output := "git: 'stauts' is not a git command. See 'git --help'."
+	for seen := 0; seen < 3; seen++ {
+		edit := plugin.SuggestConfig("git stauts -s", output)
+		if seen < 2 && edit != nil {
+			t.Fatalf("SuggestConfig() after %d typos = %+v, want no alias yet", seen, edit)
+		}
+		if seen == 2 && (edit == nil || edit.Section != "alias" || edit.Key != "stauts" || edit.Value != "status") {
+			t.Fatalf("SuggestConfig() after %d typos = %+v, want alias stauts = status", seen, edit)
+		}
+		if _, err := store.Add(history.Entry{Command: "git stauts -s", Suggestion: "git status -s", Source: "git"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	updated := *config.Current()
+	updated.GitSuggestAliases = false
+	config.SetCurrent(&updated)
+	if edit := plugin.SuggestConfig("git stauts -s", output); edit != nil {
+		t.Errorf("SuggestConfig() with GIT_SUGGEST_ALIASES off = %+v", edit)
+	}
+}
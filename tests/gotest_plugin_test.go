@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestGoTestPlugin tests the go test plugin
+func TestGoTestPlugin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "e2e"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	source := "//go:build integration\n\npackage e2e\n"
+	if err := os.WriteFile(filepath.Join(dir, "e2e", "api_test.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	plugin := &plugins.GoTestPlugin{Dir: dir}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "race without cgo",
+			command:     "go test -race ./...",
+			output:      "go: -race requires cgo; enable cgo by setting CGO_ENABLED=1",
+			shouldMatch: true,
+			expectedFix: "env CGO_ENABLED=1 go test -race ./...",
+		},
+		{
+			name:        "no module",
+			command:     "go test ./...",
+			output:      "go: go.mod file not found in current directory or any parent directory; see 'go help modules'",
+			shouldMatch: true,
+			expectedFix: "go mod init " + filepath.Base(dir),
+		},
+		{
+			name:        "missing dependency",
+			command:     "go test ./...",
+			output:      "internal/api/api_test.go:8:2: no required module provides package github.com/stretchr/testify/assert; to add it:\n\tgo get github.com/stretchr/testify/assert\nFAIL\texample.com/app/internal/api [setup failed]",
+			shouldMatch: true,
+			expectedFix: "go get github.com/stretchr/testify/assert",
+		},
+		{
+			name:        "missing go.sum entry",
+			command:     "go test ./pkg/...",
+			output:      "pkg/db/db.go:6:2: missing go.sum entry for module providing package github.com/lib/pq (imported by example.com/app/pkg/db); to add:\n\tgo get example.com/app/pkg/db",
+			shouldMatch: true,
+			expectedFix: "go mod tidy",
+		},
+		{
+			name:        "build tag required",
+			command:     "go test ./e2e",
+			output:      "package example.com/app/e2e: build constraints exclude all Go files in " + filepath.Join(dir, "e2e"),
+			shouldMatch: true,
+			expectedFix: "go test -tags=integration ./e2e",
+		},
+		{
+			name:        "no tests in current directory",
+			command:     "go test",
+			output:      "?   \texample.com/app\t[no test files]",
+			shouldMatch: true,
+			expectedFix: "go test ./...",
+		},
+		{
+			name:        "newer go required",
+			command:     "go test ./...",
+			output:      "go: go.mod requires go >= 1.23.0 (running go 1.22.4; GOTOOLCHAIN=local)",
+			shouldMatch: true,
+			expectedFix: "env GOTOOLCHAIN=auto go test ./...",
+		},
+		{
+			name:        "failing test",
+			command:     "go test ./...",
+			output:      "--- FAIL: TestAdd (0.00s)\n    math_test.go:9: got 3, want 4\nFAIL\texample.com/app\t0.002s",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+
+	// Fixes run without a shell, so assignments move behind env
+	command := "CGO_ENABLED=0 GOFLAGS=-count=1 go test -race ./..."
+	if got, want := plugin.Suggest(command, "go: -race requires cgo; enable cgo by setting CGO_ENABLED=1"), "env CGO_ENABLED=1 GOFLAGS=-count=1 go test -race ./..."; got != want {
+		t.Errorf("Suggest(%q) = %q, want %q", command, got, want)
+	}
+}
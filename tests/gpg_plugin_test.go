@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestGPGPlugin tests the GPG plugin
+func TestGPGPlugin(t *testing.T) {
+	plugin := &plugins.GPGPlugin{}
+	// An empty keyring, so no secret key is found
+	t.Setenv("GNUPGHOME", t.TempDir())
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "apt repository key missing",
+			command:     "sudo apt update",
+			output:      "W: GPG error: https://packages.example.com stable InRelease: The following signatures couldn't be verified because the public key is not available: NO_PUBKEY 7EA0A9C3F273FCD8",
+			shouldMatch: true,
+			expectedFix: "sudo gpg --no-default-keyring --keyring /etc/apt/trusted.gpg.d/7EA0A9C3F273FCD8.gpg --keyserver hkps://keyserver.ubuntu.com --recv-keys 7EA0A9C3F273FCD8",
+		},
+		{
+			name:        "apt repository key expired",
+			command:     "sudo apt-get update",
+			output:      "The following signatures were invalid: EXPKEYSIG 23E7166788B63E1E Yarn Packaging <yarn@dan.cx>",
+			shouldMatch: true,
+			expectedFix: "sudo gpg --no-default-keyring --keyring /etc/apt/trusted.gpg.d/23E7166788B63E1E.gpg --keyserver hkps://keyserver.ubuntu.com --recv-keys 23E7166788B63E1E",
+		},
+		{
+			name:        "pinentry without tty",
+			command:     "git commit -S -m release",
+			output:      "error: gpg failed to sign the data\ngpg: signing failed: Inappropriate ioctl for device",
+			shouldMatch: true,
+			expectedFix: "gpg-connect-agent updatestartuptty /bye",
+		},
+		{
+			name:        "git signing key missing, no secret keys",
+			command:     "git tag -s v1.0.0 -m v1.0.0",
+			output:      "gpg: skipped \"ABCDEF0123456789\": No secret key\ngpg: signing failed: No secret key\nerror: gpg failed to sign the data",
+			shouldMatch: true,
+			expectedFix: "gpg --list-secret-keys --keyid-format=long",
+		},
+		{
+			name:        "verify without signer key",
+			command:     "gpg --verify release.tar.gz.asc",
+			output:      "gpg: Signature made Tue 01 Oct 2024 10:00:00 UTC\ngpg:                using RSA key 4AEE18F83AFDEB23\ngpg: Can't check signature: No public key",
+			shouldMatch: true,
+			expectedFix: "gpg --keyserver hkps://keyserver.ubuntu.com --recv-keys 4AEE18F83AFDEB23",
+		},
+		{
+			name:        "unrelated output",
+			command:     "git push",
+			output:      "Everything up-to-date",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
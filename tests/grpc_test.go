@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	logaidv1 "github.com/ayushsharma-1/LogAid/api/logaid/v1"
+	"github.com/ayushsharma-1/LogAid/internal/api"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// TestGRPC tests the gRPC service of 'logaid serve'
+func TestGRPC(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{})
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "sudo apt install nginx"}})
+	store := history.New(filepath.Join(t.TempDir(), "history.json"), 10)
+	if _, err := store.Add(history.Entry{Command: "gti status", Suggestion: "git status", Source: "git", Status: history.StatusApplied}); err != nil {
+		t.Fatal(err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := api.NewServer(eng, store, "secret").NewGRPC()
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := logaidv1.NewLogAidClient(conn)
+	authorized := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	req := &logaidv1.SuggestRequest{Command: "apt install ngnix", Output: "E: Unable to locate package ngnix"}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := client.Suggest(context.Background(), req)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Suggest() error = %v, want Unauthenticated", err)
+		}
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := client.Suggest(authorized, &logaidv1.SuggestRequest{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Suggest() error = %v, want InvalidArgument", err)
+		}
+	})
+
+	t.Run("suggest", func(t *testing.T) {
+		resp, err := client.Suggest(authorized, req)
+		if err != nil {
+			t.Fatalf("Suggest() error = %v", err)
+		}
+		if resp.Source != "stub" || len(resp.Suggestions) != 1 || resp.Suggestions[0].Command != "sudo apt install nginx" || resp.Suggestions[0].Rank != 1 {
+			t.Errorf("Suggest() = %v, want the stub plugin's fix", resp)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		stream, err := client.StreamSuggest(authorized, req)
+		if err != nil {
+			t.Fatalf("StreamSuggest() error = %v", err)
+		}
+		var events []*logaidv1.SuggestEvent
+		for {
+			event, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Recv() error = %v", err)
+			}
+			events = append(events, event)
+		}
+		if len(events) != 3 || events[0].GetStage() != "analyzing" ||
+			events[1].GetCandidate().GetCommand() != "sudo apt install nginx" ||
+			events[2].GetDone().GetSource() != "stub" {
+			t.Errorf("events = %v, want a stage, the candidate and the response", events)
+		}
+	})
+
+	t.Run("history", func(t *testing.T) {
+		resp, err := client.History(authorized, &logaidv1.HistoryRequest{Limit: 1})
+		if err != nil {
+			t.Fatalf("History() error = %v", err)
+		}
+		if len(resp.Entries) != 1 || resp.Entries[0].Suggestion != "git status" || resp.Entries[0].Time.AsTime().IsZero() {
+			t.Errorf("History() = %v, want the stored entry", resp)
+		}
+	})
+}
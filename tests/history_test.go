@@ -0,0 +1,272 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/history"
+)
+
+// TestHistoryStore tests recording, updating and trimming suggestion history
+func TestHistoryStore(t *testing.T) {
+	store := history.New(filepath.Join(t.TempDir(), "history.json"), 2)
+
+	var ids []int64
+	for _, command := range []string{"gti status", "git pul", "npm isntall"} {
+		entry, err := store.Add(history.Entry{Command: command, Suggestion: "fixed", Status: history.StatusProposed})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		ids = append(ids, entry.ID)
+	}
+
+	if err := store.Update(ids[2], func(e *history.Entry) {
+		e.Status = history.StatusApplied
+		e.Explanation = "typo in install"
+	}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := store.Update(ids[0], func(e *history.Entry) {}); err == nil {
+		t.Error("Update() expected error for trimmed entry")
+	}
+
+	entries, err := store.List(0)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Command != "git pul" {
+		t.Fatalf("List() = %+v, want the 2 most recent entries", entries)
+	}
+
+	last, err := store.Last()
+	if err != nil || last == nil {
+		t.Fatalf("Last() = %v, %v", last, err)
+	}
+	if last.Command != "npm isntall" || last.Status != history.StatusApplied || last.Explanation != "typo in install" {
+		t.Errorf("Last() = %+v", last)
+	}
+}
+
+// TestHistorySearch tests slicing the history by text, outcome and age
+func TestHistorySearch(t *testing.T) {
+	store := history.New(filepath.Join(t.TempDir(), "history.json"), 10)
+	now := time.Now()
+	for _, entry := range []history.Entry{
+		{Time: now.Add(-10 * 24 * time.Hour), Command: "apt install foo", Suggestion: "apt install fio", Source: "apt", Status: history.StatusFailed},
+		{Time: now.Add(-2 * time.Hour), Command: "sudo apt-get instal vim", Suggestion: "sudo apt-get install vim", Source: "apt", Status: history.StatusApplied},
+		{Time: now.Add(-time.Hour), Command: "apt upgrade", Output: "E: Could not get lock", Suggestion: "sudo apt upgrade", Source: "AI", Status: history.StatusFailed,
+			Dir: "/srv", ExitCode: 100, Provider: "openai", Model: "gpt-4o-mini", LatencyMS: 850},
+		{Time: now, Command: "gti status", Suggestion: "git status", Source: "git", Status: history.StatusRejected},
+	} {
+		if _, err := store.Add(entry); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter history.Filter
+		want   []string
+	}{
+		{"all", history.Filter{}, []string{"apt install foo", "sudo apt-get instal vim", "apt upgrade", "gti status"}},
+		{"search", history.Filter{Search: "APT"}, []string{"apt install foo", "sudo apt-get instal vim", "apt upgrade"}},
+		{"search output", history.Filter{Search: "could not get lock"}, []string{"apt upgrade"}},
+		{"failed only", history.Filter{FailedOnly: true}, []string{"apt install foo", "apt upgrade"}},
+		{"since", history.Filter{Since: now.Add(-7 * 24 * time.Hour)}, []string{"sudo apt-get instal vim", "apt upgrade", "gti status"}},
+		{"combined", history.Filter{Search: "apt", FailedOnly: true, Since: now.Add(-7 * 24 * time.Hour)}, []string{"apt upgrade"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := store.Search(tt.filter)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			var got []string
+			for _, entry := range entries {
+				got = append(got, entry.Command)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+
+	entries, _ := store.Search(history.Filter{Search: "could not get lock"})
+	if len(entries) == 1 {
+		e := entries[0]
+		if e.Dir != "/srv" || e.ExitCode != 100 || e.Provider != "openai" || e.Model != "gpt-4o-mini" || e.LatencyMS != 850 {
+			t.Errorf("metadata not kept: %+v", e)
+		}
+	}
+}
+
+// TestHistoryDeduplication tests collapsing repeated failures and pruning
+func TestHistoryDeduplication(t *testing.T) {
+	store := history.New(filepath.Join(t.TempDir(), "history.json"), 3)
+	now := time.Now()
+	repeat := history.Entry{Command: "apt install foo", Output: "E: Unable to locate package foo", Suggestion: "apt install fio", Status: history.StatusProposed}
+
+	first := repeat
+	first.Time = now.Add(-40 * 24 * time.Hour)
+	if _, err := store.Add(first); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := store.Add(history.Entry{Time: now.Add(-35 * 24 * time.Hour), Command: "gti status", Suggestion: "git status"}); err != nil {
+		t.Fatal(err)
+	}
+	second := repeat
+	second.Time = now.Add(-time.Hour)
+	entry, err := store.Add(second)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if entry.Occurrences() != 2 || !entry.First().Equal(first.Time) {
+		t.Errorf("Add() = count %d first %v, want 2 and %v", entry.Occurrences(), entry.First(), first.Time)
+	}
+
+	entries, _ := store.List(0)
+	if len(entries) != 2 || entries[0].Command != "gti status" || entries[1].ID != entry.ID {
+		t.Fatalf("List() = %+v, want the repeat collapsed into the latest entry", entries)
+	}
+
+	removed, err := store.Prune(now.Add(-30 * 24 * time.Hour))
+	if err != nil || removed != 1 {
+		t.Fatalf("Prune() = %d, %v, want the old entry removed", removed, err)
+	}
+	entries, _ = store.List(0)
+	if len(entries) != 1 || entries[0].Occurrences() != 2 {
+		t.Errorf("List() after Prune() = %+v", entries)
+	}
+
+	// Histories written before de-duplication are collapsed and capped
+	path := filepath.Join(t.TempDir(), "history.json")
+	legacy := `{"entries": [
+		{"id": 1, "command": "make", "output": "no rule", "suggestion": "make all"},
+		{"id": 2, "command": "gti", "suggestion": "git"},
+		{"id": 3, "command": "make", "output": "no rule", "suggestion": "make all", "feedback": "helpful"},
+		{"id": 4, "command": "npm tset", "suggestion": "npm test"},
+		{"id": 5, "command": "cargo biuld", "suggestion": "cargo build"},
+		{"id": 6, "command": "make", "output": "no rule", "suggestion": "make all"}
+	]}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store = history.New(path, 3)
+	if removed, err := store.Prune(time.Time{}); err != nil || removed != 3 {
+		t.Fatalf("Prune() = %d, %v, want 3 removed", removed, err)
+	}
+	entries, _ = store.List(0)
+	if len(entries) != 3 || entries[2].ID != 6 || entries[2].Occurrences() != 3 || entries[2].Feedback != "helpful" {
+		t.Errorf("List() after Prune() = %+v, want make collapsed into the last entry", entries)
+	}
+}
+
+// TestHistorySessions tests grouping history entries by shell session
+func TestHistorySessions(t *testing.T) {
+	store := history.New(filepath.Join(t.TempDir(), "history.json"), 0)
+	now := time.Now()
+	for i, entry := range []history.Entry{
+		{Session: "101", Command: "npm test", Suggestion: "npm run test", Status: history.StatusApplied},
+		{Session: "202", Command: "gti status", Suggestion: "git status", Status: history.StatusApplied},
+		{Session: "101", Command: "npm start", Suggestion: "npm run start", Status: history.StatusFailed},
+		{Session: "202", Command: "gti status", Suggestion: "git status", Status: history.StatusApplied},
+		{Session: "101", Command: "gti status", Suggestion: "git status", Status: history.StatusProposed},
+		{Command: "make", Suggestion: "make all"},
+	} {
+		entry.Time = now.Add(time.Duration(i-10) * time.Minute)
+		if _, err := store.Add(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sessions, err := store.Sessions()
+	if err != nil {
+		t.Fatalf("Sessions() error = %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != "202" || sessions[1].ID != "101" {
+		t.Fatalf("Sessions() = %+v, want 202 then the most recent 101", sessions)
+	}
+	if s := sessions[0]; s.Entries != 1 || s.Failures != 2 || s.Fixed != 1 {
+		t.Errorf("session 202 = %+v, want the repeat counted once with 2 failures", s)
+	}
+	if s := sessions[1]; s.Entries != 3 || s.Fixed != 1 || !s.Start.Equal(now.Add(-10*time.Minute)) {
+		t.Errorf("session 101 = %+v", s)
+	}
+	if last, _ := store.LastSession(); last != "101" {
+		t.Errorf("LastSession() = %q, want 101", last)
+	}
+
+	entries, err := store.Search(history.Filter{Session: "101"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var commands []string
+	for _, entry := range entries {
+		commands = append(commands, entry.Command)
+	}
+	if got := strings.Join(commands, ", "); got != "npm test, npm start, gti status" {
+		t.Errorf("Search(session 101) = %s, want the session in order", got)
+	}
+
+	t.Setenv(history.SessionEnv, "tmux-3")
+	if id := history.CurrentSession(); id != "tmux-3" {
+		t.Errorf("CurrentSession() = %q, want LOGAID_SESSION", id)
+	}
+}
+
+// TestHistoryExport tests writing the history as CSV, JSON and Markdown
+func TestHistoryExport(t *testing.T) {
+	code := 0
+	entries := history.Redact([]history.Entry{
+		{Time: time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC), Command: "apt install foo", ExitCode: 100, Suggestion: "apt install fio",
+			Source: "apt", Plugin: "apt", Status: history.StatusApplied, FixExitCode: &code, LatencyMS: 12, Count: 2,
+			FirstTime: time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)},
+		{Time: time.Date(2026, 10, 13, 9, 0, 0, 0, time.UTC), Command: "curl -H 'Authorization: Bearer abcdef123456789' a|b", Output: "401",
+			Suggestion: "curl -v", Source: "AI", Provider: "openai", Model: "gpt-4o-mini", Status: history.StatusRejected},
+	})
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"csv", []string{
+			"time,first_time,count,session,dir,command,exit_code,output,suggestion,source,plugin,provider,model,latency_ms,status,fix_exit_code,feedback,note\n",
+			"2026-10-12T09:00:00Z,2026-10-10T09:00:00Z,2,,,apt install foo,100,,apt install fio,apt,apt,,,12,applied,0,,\n",
+		}},
+		{"json", []string{`"command": "apt install foo"`, `"model": "gpt-4o-mini"`, `"count": 2`}},
+		{"md", []string{
+			"3 failure(s) from 2026-10-10 09:00 to 2026-10-13 09:00 UTC.",
+			"| applied | 2 |", "| rejected | 1 |", "| apt | 2 |",
+			"| 2026-10-12 09:00 | `apt install foo` | 100 | `apt install fio` | apt | applied | 2 |",
+			`a\|b`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b strings.Builder
+			if err := history.Export(&b, entries, tt.format); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("Export(%s) = %q, want it to contain %q", tt.format, b.String(), want)
+				}
+			}
+			if strings.Contains(b.String(), "abcdef123456789") {
+				t.Errorf("Export(%s) = %q, want the token redacted", tt.format, b.String())
+			}
+		})
+	}
+
+	if err := history.Export(&strings.Builder{}, entries, "xml"); err == nil {
+		t.Error("Export(xml) expected an error")
+	}
+}
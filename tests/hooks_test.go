@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/hooks"
+)
+
+// TestGitHooks tests wrapping git hooks and restoring the originals
+func TestGitHooks(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+	original := "#!/bin/sh\necho \"lint failed: $1\" >&2\nexit 3\n"
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pre-commit"), []byte(original), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		hook string
+		want hooks.Status
+	}{
+		{"wraps an existing hook", "pre-commit", hooks.Status{Name: "pre-commit", Installed: true, Original: true}},
+		{"installs a missing hook", "pre-push", hooks.Status{Name: "pre-push", Installed: true}},
+		{"installs again", "pre-commit", hooks.Status{Name: "pre-commit", Installed: true, Original: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := hooks.Install(dir, tt.hook)
+			if err != nil {
+				t.Fatalf("Install() error = %v", err)
+			}
+			if status != tt.want || hooks.Get(dir, tt.hook) != tt.want {
+				t.Errorf("Install() = %+v, Get() = %+v, want %+v", status, hooks.Get(dir, tt.hook), tt.want)
+			}
+		})
+	}
+	if data, _ := os.ReadFile(hooks.Original(dir, "pre-commit")); string(data) != original {
+		t.Errorf("original hook = %q, want it kept unchanged", data)
+	}
+	if _, err := hooks.Install(dir, "post-checkout"); err == nil {
+		t.Error("Install() expected error for a hook that cannot fail")
+	}
+
+	// Without logaid on the PATH the wrapper runs the original hook itself
+	if runtime.GOOS != "windows" {
+		cmd := exec.Command(filepath.Join(dir, "pre-commit"), "a.go")
+		cmd.Env = append(os.Environ(), "PATH=/usr/bin:/bin")
+		out, err := cmd.CombinedOutput()
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok || exitErr.ExitCode() != 3 || !strings.Contains(string(out), "lint failed: a.go") {
+			t.Errorf("wrapper without logaid = %q, %v; want the original hook's output and exit code", out, err)
+		}
+	}
+
+	for _, hook := range []string{"pre-commit", "pre-push"} {
+		if _, err := hooks.Uninstall(dir, hook); err != nil {
+			t.Fatalf("Uninstall(%s) error = %v", hook, err)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "pre-commit")); string(data) != original {
+		t.Errorf("pre-commit after Uninstall() = %q, want the original", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pre-push")); !os.IsNotExist(err) {
+		t.Error("pre-push still exists after Uninstall()")
+	}
+
+	// A hook LogAid did not install is left alone
+	if status, err := hooks.Uninstall(dir, "pre-commit"); err != nil || status.Installed {
+		t.Errorf("Uninstall() of an unwrapped hook = %+v, %v", status, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "pre-commit")); string(data) != original {
+		t.Error("Uninstall() changed a hook LogAid did not install")
+	}
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestJavaPlugin tests the Java/JVM plugin against a sample project
+func TestJavaPlugin(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{"Hello.java", "target/classes/com/example/App.class"}
+	for _, name := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plugin := &plugins.JavaPlugin{Dir: dir}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "class file given",
+			command:     "java Main.class",
+			output:      "Error: Could not find or load main class Main.class\nCaused by: java.lang.ClassNotFoundException: Main.class",
+			shouldMatch: true,
+			expectedFix: "java Main",
+		},
+		{
+			name:        "path to class in output directory",
+			command:     "java out/Main",
+			output:      "Error: Could not find or load main class out/Main",
+			shouldMatch: true,
+			expectedFix: "java -cp out Main",
+		},
+		{
+			name:        "source not compiled",
+			command:     "java Hello",
+			output:      "Error: Could not find or load main class Hello\nCaused by: java.lang.ClassNotFoundException: Hello",
+			shouldMatch: true,
+			expectedFix: "javac Hello.java && java Hello",
+		},
+		{
+			name:        "maven classes directory",
+			command:     "java com.example.App",
+			output:      "Error: Could not find or load main class com.example.App",
+			shouldMatch: true,
+			expectedFix: "java -cp target/classes com.example.App",
+		},
+		{
+			name:        "newer class file version",
+			command:     "java -jar app.jar",
+			output:      "Exception in thread \"main\" java.lang.UnsupportedClassVersionError: com/example/App has been compiled by a more recent version of the Java Runtime (class file version 61.0), this version of the Java Runtime only recognizes class file versions up to 55.0",
+			shouldMatch: true,
+			expectedFix: "sudo apt install openjdk-17-jdk && java -jar app.jar",
+		},
+		{
+			name:        "javac release not supported",
+			command:     "javac --release 21 App.java",
+			output:      "error: release version 21 not supported",
+			shouldMatch: true,
+			expectedFix: "sudo apt install openjdk-21-jdk && javac --release 21 App.java",
+		},
+		{
+			name:        "heap exhausted",
+			command:     "java -jar indexer.jar",
+			output:      "Exception in thread \"main\" java.lang.OutOfMemoryError: Java heap space",
+			shouldMatch: true,
+			expectedFix: "java -Xmx2g -jar indexer.jar",
+		},
+		{
+			name:        "heap limit doubled",
+			command:     "java -Xmx512m -jar indexer.jar",
+			output:      "java.lang.OutOfMemoryError: GC overhead limit exceeded",
+			shouldMatch: true,
+			expectedFix: "java -Xmx1024m -jar indexer.jar",
+		},
+		{
+			name:        "javac not installed",
+			command:     "javac App.java",
+			output:      "Command 'javac' not found, but can be installed with:\nsudo apt install openjdk-17-jdk-headless",
+			shouldMatch: true,
+			expectedFix: "sudo apt install openjdk-17-jdk-headless && javac App.java",
+		},
+		{
+			name:        "program exception",
+			command:     "java -jar app.jar",
+			output:      "Exception in thread \"main\" java.lang.NullPointerException",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
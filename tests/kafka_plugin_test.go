@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestKafkaPlugin tests the Kafka CLI plugin
+func TestKafkaPlugin(t *testing.T) {
+	plugin := &plugins.KafkaPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "removed zookeeper flag",
+			command:     "kafka-topics.sh --zookeeper localhost:2181 --list",
+			output:      "Exception in thread \"main\" joptsimple.UnrecognizedOptionException: zookeeper is not a recognized option",
+			shouldMatch: true,
+			expectedFix: "kafka-topics.sh --bootstrap-server localhost:9092 --list",
+		},
+		{
+			name:        "zookeeper port as bootstrap server",
+			command:     "kafka-topics --bootstrap-server kafka1:2181 --describe",
+			output:      "[AdminClient clientId=adminclient-1] Connection to node -1 (kafka1/10.0.0.5:2181) could not be established. Broker may not be available.",
+			shouldMatch: true,
+			expectedFix: "kafka-topics --bootstrap-server kafka1:9092 --describe",
+		},
+		{
+			name:        "broker down",
+			command:     "kafka-console-producer.sh --broker-list localhost:9092 --topic events",
+			output:      "Connection to node -1 (localhost/127.0.0.1:9092) could not be established. Broker may not be available.",
+			shouldMatch: true,
+			expectedFix: "nc -zv localhost 9092",
+		},
+		{
+			name:        "unknown topic",
+			command:     "/opt/kafka/bin/kafka-console-consumer.sh --bootstrap-server broker:9092 --topic ordrs",
+			output:      "WARN [Consumer clientId=console-consumer] Error while fetching metadata with correlation id 2 : {ordrs=UNKNOWN_TOPIC_OR_PARTITION}",
+			shouldMatch: true,
+			expectedFix: "/opt/kafka/bin/kafka-topics.sh --bootstrap-server broker:9092 --list",
+		},
+		{
+			name:        "source checkout",
+			command:     "bin/kafka-topics.sh --bootstrap-server localhost:9092 --list",
+			output:      "Classpath is empty. Please build the project first e.g. by running './gradlew jar -PscalaVersion=2.13.12'",
+			shouldMatch: true,
+			expectedFix: "./gradlew jar -PscalaVersion=2.13.12",
+		},
+		{
+			name:        "not kafka",
+			command:     "curl localhost:9092",
+			output:      "Connection to node -1 could not be established",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
+
+// TestKafkaPluginJavaHome tests that JAVA_HOME is set for the command, as
+// fixes run without a shell
+func TestKafkaPluginJavaHome(t *testing.T) {
+	dir := t.TempDir()
+	jdk := filepath.Join(dir, "jdk-17")
+	if err := os.MkdirAll(filepath.Join(jdk, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(jdk, "bin", "java"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	bin := filepath.Join(dir, "bin")
+	if err := os.Mkdir(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(jdk, "bin", "java"), filepath.Join(bin, "java")); err != nil {
+		t.Fatal(err)
+	}
+
+	plugin := &plugins.KafkaPlugin{}
+	command := "kafka-topics.sh --bootstrap-server localhost:9092 --list"
+	output := "Error: JAVA_HOME is not defined correctly."
+
+	t.Setenv("PATH", bin)
+	if got, want := plugin.Suggest(command, output), "env JAVA_HOME="+jdk+" "+command; got != want {
+		t.Errorf("Suggest() = %q, want %q", got, want)
+	}
+	t.Setenv("PATH", t.TempDir())
+	if got, want := plugin.Suggest(command, output), "sudo apt install default-jre"; got != want {
+		t.Errorf("Suggest() without java = %q, want %q", got, want)
+	}
+}
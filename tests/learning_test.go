@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/learning"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestLearningStore tests learning fixes and rejections per error signature
+func TestLearningStore(t *testing.T) {
+	store := learning.New(filepath.Join(t.TempDir(), "learning.json"))
+
+	if err := store.Succeeded("apt install foo", "E: Unable to locate package foo", "apt install foo-utils"); err != nil {
+		t.Fatalf("Succeeded() error = %v", err)
+	}
+	if fix, ok := store.Fix("apt install bar", "E: Unable to locate package bar"); !ok || fix != "apt install bar-utils" {
+		t.Errorf("Fix() = %q, %v, want the learned fix for the new package", fix, ok)
+	}
+	if _, ok := store.Fix("apt install bar", "E: Package bar has no installation candidate"); ok {
+		t.Error("Fix() found a fix for a different error")
+	}
+
+	for i := 0; i < learning.MaxRejections; i++ {
+		if blocked := store.Blocked("apt install foo", "E: Unable to locate package foo"); blocked["apt install foo-utils"] {
+			t.Fatalf("Blocked() after %d rejections = %v", i, blocked)
+		}
+		if err := store.Rejected("apt install foo", "E: Unable to locate package foo", "apt install foo-utils"); err != nil {
+			t.Fatalf("Rejected() error = %v", err)
+		}
+	}
+	if _, ok := store.Fix("apt install foo", "E: Unable to locate package foo"); ok {
+		t.Error("Fix() offered a fix rejected twice")
+	}
+	if blocked := store.Blocked("apt install baz", "E: Unable to locate package baz"); !blocked["apt install baz-utils"] {
+		t.Errorf("Blocked() = %v, want the rejected fix", blocked)
+	}
+
+	if err := store.Succeeded("npm test", "npm ERR! missing script: test", "npm run build"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Failed("npm test", "npm ERR! missing script: test", "npm run build"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Fix("npm test", "npm ERR! missing script: test"); ok {
+		t.Error("Fix() offered a fix that failed as often as it worked")
+	}
+}
+
+// TestLearningEngine tests that the engine offers fixes that worked first and
+// stops proposing suggestions rejected twice
+func TestLearningEngine(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	t.Setenv("HOME", t.TempDir())
+	config.SetCurrent(&config.Config{LearnFixes: true})
+	ctx := context.Background()
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{
+		&stubPlugin{name: "first", fix: "git push --force"},
+		&stubPlugin{name: "second", fix: "git pull --rebase"},
+	})
+	const command, output = "git push", "error: failed to push some refs"
+	for i := 0; i < learning.MaxRejections; i++ {
+		suggestion, _ := eng.Suggest(ctx, command, output)
+		if suggestion == nil || suggestion.Command != "git push --force" {
+			t.Fatalf("Suggest() after %d rejections = %+v", i, suggestion)
+		}
+		eng.Propose(command, output, suggestion)
+		eng.Dismiss(suggestion)
+	}
+	if suggestion, _ := eng.Suggest(ctx, command, output); suggestion == nil || suggestion.Command != "git pull --rebase" {
+		t.Errorf("Suggest() = %+v, want the suggestion rejected twice skipped", suggestion)
+	}
+
+	var out bytes.Buffer
+	eng.SetIO(nil, &out, &out)
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "echo", fix: "echo fixed"}})
+	suggestion, _ := eng.Suggest(ctx, "make build", "make: *** No rule to make target 'build'")
+	eng.Propose("make build", "make: *** No rule to make target 'build'", suggestion)
+	if !eng.Apply(suggestion) {
+		t.Fatalf("Apply() failed: %s", out.String())
+	}
+
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "other", fix: "make all"}})
+	suggestion, _ = eng.Suggest(ctx, "make build", "make: *** No rule to make target 'build'")
+	if suggestion == nil || suggestion.Command != "echo fixed" || suggestion.Source != "learned" {
+		t.Errorf("Suggest() = %+v, want the fix that worked before", suggestion)
+	}
+}
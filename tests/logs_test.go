@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// TestLogsQuery tests reading back and filtering LogAid's log file
+func TestLogsQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logaid.log")
+	content := `2026/10/14 09:00:00 [INFO] Loaded 30 plugins
+2026/10/15 10:00:00 [DEBUG] Suggestion cache hit: git status
+2026/10/15 10:00:01 [WARN] Suggestion from git:
+2026/10/15 10:00:02 [ERROR] Plugin npm timed out after 2s in Suggest, skipping it
+2026/10/15 10:00:03 [SUCCESS] Applied configuration change
+--- ~/.gitconfig
++++ ~/.gitconfig
+2026/10/15 10:00:04 [INFO] gitlab token refreshed
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter logger.Filter
+		want   []string
+	}{
+		{"all", logger.Filter{}, []string{
+			"Loaded 30 plugins", "Suggestion cache hit: git status", "Suggestion from git:",
+			"Plugin npm timed out after 2s in Suggest, skipping it",
+			"Applied configuration change\n--- ~/.gitconfig\n+++ ~/.gitconfig", "gitlab token refreshed",
+		}},
+		{"level", logger.Filter{Level: "warn"}, []string{"Suggestion from git:", "Plugin npm timed out after 2s in Suggest, skipping it"}},
+		{"since", logger.Filter{Since: time.Date(2026, 10, 15, 10, 0, 3, 0, time.Local)}, []string{
+			"Applied configuration change\n--- ~/.gitconfig\n+++ ~/.gitconfig", "gitlab token refreshed",
+		}},
+		{"plugin as a word", logger.Filter{Plugin: "git"}, []string{"Suggestion cache hit: git status", "Suggestion from git:"}},
+		{"grep", logger.Filter{Grep: regexp.MustCompile(`timed out|gitconfig`)}, []string{
+			"Plugin npm timed out after 2s in Suggest, skipping it", "Applied configuration change\n--- ~/.gitconfig\n+++ ~/.gitconfig",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := logger.Query(path, tt.filter)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			var got []string
+			for _, entry := range entries {
+				got = append(got, entry.Message)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Query() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Query()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	entries, _ := logger.Query(path, logger.Filter{Level: "error"})
+	if len(entries) != 1 || entries[0].Level != "error" || !entries[0].Time.Equal(time.Date(2026, 10, 15, 10, 0, 2, 0, time.Local)) {
+		t.Errorf("Query(error) = %+v, want the npm timeout at 10:00:02", entries)
+	}
+}
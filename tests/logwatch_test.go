@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/logwatch"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestLogWatch tests following a log file and suggesting fixes for new errors
+func TestLogWatch(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{})
+
+	path := filepath.Join(t.TempDir(), "service.log")
+	if err := os.WriteFile(path, []byte("12:00:01 starting\n12:00:02 ERROR: connection refused on port 5432\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "systemctl start postgresql"}})
+	watcher := logwatch.New(eng, "service")
+	watcher.After = 1
+	watcher.Settle = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	findings := make(chan logwatch.Finding, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Run(ctx, logwatch.File(path, true), func(f logwatch.Finding) { findings <- f })
+	}()
+
+	next := func() logwatch.Finding {
+		select {
+		case f := <-findings:
+			return f
+		case <-time.After(5 * time.Second):
+			t.Fatal("no finding reported")
+		}
+		return logwatch.Finding{}
+	}
+
+	first := next()
+	if first.Line != "12:00:02 ERROR: connection refused on port 5432" {
+		t.Errorf("Line = %q", first.Line)
+	}
+	if first.Output != "12:00:01 starting\n12:00:02 ERROR: connection refused on port 5432" {
+		t.Errorf("Output = %q, want the line before the error included", first.Output)
+	}
+	if first.Suggestion == nil || first.Suggestion.Command != "systemctl start postgresql" {
+		t.Errorf("Suggestion = %+v, want the stub plugin's fix", first.Suggestion)
+	}
+
+	// The repeat differs only in numbers and is ignored; the new error is
+	// reported with the line after it
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("12:05:09 ERROR: connection refused on port 5433\n")
+	f.WriteString("12:05:10 fatal: disk full\n")
+	f.WriteString("  while writing /var/lib/data\n")
+	f.Close()
+
+	second := next()
+	if second.Line != "12:05:10 fatal: disk full" {
+		t.Errorf("Line = %q, want the repeated error skipped", second.Line)
+	}
+	if want := "  while writing /var/lib/data"; second.Output[len(second.Output)-len(want):] != want {
+		t.Errorf("Output = %q, want the line after the error included", second.Output)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher did not stop")
+	}
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/lsp"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestLSP tests the language server of 'logaid lsp' on a terminal buffer
+func TestLSP(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{})
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "sudo apt install nginx"}})
+
+	buffer := strings.Join([]string{
+		"user@host:~$ ls",
+		"notes.txt",
+		"user@host:~$ apt install ngnix",
+		"Reading package lists... Done",
+		"\x1b[31mE: Unable to locate package ngnix\x1b[0m",
+		"user@host:~$ ",
+	}, "\n")
+	uri := "terminal://1"
+	text, _ := json.Marshal(buffer)
+	requests := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/codeAction","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"capabilities":{}}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":%q,"languageId":"terminal","version":1,"text":%s}}}`, uri, text),
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":3,"method":"textDocument/codeAction","params":{"textDocument":{"uri":%q},"range":{"start":{"line":4,"character":0},"end":{"line":4,"character":0}},"context":{"diagnostics":[]}}}`, uri),
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":4,"method":"workspace/executeCommand","params":{"command":"logaid.applyFix","arguments":[{"uri":%q,"line":4,"fix":"sudo apt install nginx"}]}}`, uri),
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":5,"method":"workspace/executeCommand","params":{"command":"logaid.applyFix","arguments":[{"uri":%q,"line":1}]}}`, uri),
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":6,"method":"workspace/executeCommand","params":{"command":"logaid.dismissFix","arguments":[{"uri":%q,"line":4}]}}`, uri),
+		`{"jsonrpc":"2.0","id":7,"method":"textDocument/hover","params":{}}`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":8,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+		`{"jsonrpc":"2.0","id":9,"method":"shutdown"}`,
+	}
+	var in bytes.Buffer
+	for _, request := range requests {
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(request), request)
+	}
+	var out bytes.Buffer
+	if err := lsp.NewServer(eng, "1.0.0").Serve(context.Background(), &in, &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	messages := readLSPMessages(t, &out)
+	tests := []struct {
+		name string
+		want []string // expected in the message, in the order they are sent
+	}{
+		{"before initialize", []string{`"id":1`, `"code":-32002`}},
+		{"initialize", []string{`"id":2`, `"codeActionProvider"`, `"logaid.applyFix"`, `"name":"logaid"`}},
+		{"diagnostics", []string{`"method":"textDocument/publishDiagnostics"`, `"line":4`, `"character":33`, `apt install ngnix failed: E: Unable to locate package ngnix`}},
+		{"code actions", []string{`"id":3`, `"title":"Run: sudo apt install nginx (LogAid, from stub)"`, `"isPreferred":true`, `"command":"logaid.dismissFix"`}},
+		{"apply", []string{`"id":4`, `"result":{"command":"sudo apt install nginx"}`}},
+		{"no failure", []string{`"id":5`, `no failure at line 1`}},
+		{"dismissed", []string{`"method":"textDocument/publishDiagnostics"`, `"diagnostics":[]`}},
+		{"dismiss", []string{`"id":6`, `"result":null`}},
+		{"unknown method", []string{`"id":7`, `"code":-32601`}},
+		{"parse error", []string{`"id":null`, `"code":-32700`}},
+		{"shutdown", []string{`"id":8`, `"result":null`}},
+	}
+	if len(messages) != len(tests) {
+		t.Fatalf("got %d messages, want %d (nothing after exit):\n%s", len(messages), len(tests), strings.Join(messages, "\n"))
+	}
+	for i, tt := range tests {
+		for _, want := range tt.want {
+			if !strings.Contains(messages[i], want) {
+				t.Errorf("%s: message %s does not contain %s", tt.name, messages[i], want)
+			}
+		}
+	}
+}
+
+// readLSPMessages splits the server's output into the JSON messages
+func readLSPMessages(t *testing.T, r io.Reader) []string {
+	t.Helper()
+	reader := textproto.NewReader(bufio.NewReader(r))
+	var messages []string
+	for {
+		header, err := reader.ReadMIMEHeader()
+		if err == io.EOF {
+			return messages
+		}
+		if err != nil {
+			t.Fatalf("invalid header: %v", err)
+		}
+		length, err := strconv.Atoi(header.Get("Content-Length"))
+		if err != nil {
+			t.Fatalf("invalid Content-Length %q", header.Get("Content-Length"))
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader.R, body); err != nil {
+			t.Fatal(err)
+		}
+		if !json.Valid(body) {
+			t.Errorf("invalid JSON %s", body)
+		}
+		messages = append(messages, string(body))
+	}
+}
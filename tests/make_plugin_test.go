@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestMakePlugin tests the make plugin against a sample Makefile
+func TestMakePlugin(t *testing.T) {
+	dir := t.TempDir()
+	makefile := "BIN := app\nVERSION ?= dev\n\n.PHONY: build test clean\n\nbuild: main.o\n\tcc -o $(BIN) main.o\n\ntest:\n\t./run-tests.sh\n\nclean:\n\trm -f *.o $(BIN)\n\n%.o: %.c\n\tcc -c $<\n"
+	if err := os.WriteFile(filepath.Join(dir, "Makefile"), []byte(makefile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	plugin := &plugins.MakePlugin{Dir: dir}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "target typo",
+			command:     "make biuld",
+			output:      "make: *** No rule to make target 'biuld'.  Stop.",
+			shouldMatch: true,
+			expectedFix: "make build",
+		},
+		{
+			name:        "target typo with flags",
+			command:     "make -j8 tset",
+			output:      "make: *** No rule to make target 'tset'.  Stop.",
+			shouldMatch: true,
+			expectedFix: "make -j8 test",
+		},
+		{
+			name:        "missing separator",
+			command:     "make build",
+			output:      "Makefile:7: *** missing separator.  Stop.",
+			shouldMatch: true,
+			expectedFix: `sed -i 7s/^\s\+/\t/ Makefile`,
+		},
+		{
+			name:        "make not installed",
+			command:     "make",
+			output:      "bash: make: command not found",
+			shouldMatch: true,
+			expectedFix: "sudo apt install build-essential",
+		},
+		{
+			name:        "tool missing in recipe",
+			command:     "make",
+			output:      "cmake -B out\n/bin/sh: 1: cmake: not found\nmake: *** [Makefile:3: configure] Error 127",
+			shouldMatch: true,
+			expectedFix: "sudo apt install cmake",
+		},
+		{
+			name:        "compiler missing without shell",
+			command:     "make build",
+			output:      "cc -c main.c\nmake: cc: No such file or directory\nmake: *** [Makefile:16: main.o] Error 127",
+			shouldMatch: true,
+			expectedFix: "sudo apt install build-essential",
+		},
+		{
+			name:        "successful build",
+			command:     "make",
+			output:      "cc -o app main.o",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+
+	// A CMake project is configured, then built
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, "CMakeLists.txt"), []byte("project(app)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmake := &plugins.MakePlugin{Dir: project}
+	noMakefile := "make: *** No targets specified and no makefile found.  Stop."
+	if got, want := cmake.Suggest("make", noMakefile), "cmake -B build"; got != want {
+		t.Errorf("Suggest() before configuring = %q, want %q", got, want)
+	}
+	if err := os.MkdirAll(filepath.Join(project, "build"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "build", "CMakeCache.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cmake.Suggest("make", noMakefile), "cmake --build build"; got != want {
+		t.Errorf("Suggest() after configuring = %q, want %q", got, want)
+	}
+}
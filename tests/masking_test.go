@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+)
+
+// TestMaskSecrets tests that secrets are masked before they reach the log
+// file and the history, unless MASK_SECRETS is off
+func TestMaskSecrets(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		name := "masked"
+		if !enabled {
+			name = "unmasked"
+		}
+		t.Run(name, func(t *testing.T) {
+			saved := config.Current()
+			defer func() { config.SetCurrent(saved) }()
+			config.SetCurrent(&config.Config{MaskSecrets: enabled})
+
+			dir := t.TempDir()
+			logFile := filepath.Join(dir, "logaid.log")
+			savedLogger := logger.AppLogger
+			t.Setenv("LOG_FILE", logFile)
+			if err := logger.Init(); err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				logger.AppLogger.Close()
+				logger.AppLogger = savedLogger
+			}()
+			logger.SetConsole(&strings.Builder{})
+			logger.SetMasking(enabled)
+			logger.Info("Running: mysql -u app -p's3cr3t' shop")
+
+			store := history.New(filepath.Join(dir, "history.json"), 10)
+			entry, err := store.Add(history.Entry{
+				Command:    "curl -H 'Authorization: Bearer abcdef123456789' https://api.example.com",
+				Output:     "401 Unauthorized",
+				Suggestion: "mysql -u app -ps3cr3t shop",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Update(entry.ID, func(e *history.Entry) { e.Note = "DB_PASSWORD=s3cr3t works" }); err != nil {
+				t.Fatal(err)
+			}
+
+			logger.Flush()
+			logged, err := os.ReadFile(logFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored, err := os.ReadFile(filepath.Join(dir, "history.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for file, content := range map[string]string{"log": string(logged), "history": string(stored)} {
+				leaked := strings.Contains(content, "s3cr3t")
+				if enabled && leaked {
+					t.Errorf("%s = %q, want the secrets masked", file, content)
+				}
+				if !enabled && !leaked {
+					t.Errorf("%s = %q, want the text unchanged with MASK_SECRETS off", file, content)
+				}
+			}
+			if enabled && strings.Contains(string(stored), "abcdef123456789") {
+				t.Errorf("history = %q, want the bearer token masked", stored)
+			}
+		})
+	}
+}
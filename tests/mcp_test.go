@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/mcp"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestMCP tests the Model Context Protocol server of 'logaid mcp'
+func TestMCP(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{})
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "sudo apt install nginx"}})
+
+	requests := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"suggest_fix","arguments":{"command":"apt install ngnix","output":"E: Unable to locate package ngnix"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"analyze_error","arguments":{"output":"starting\nE: Unable to locate package ngnix"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"suggest_fix","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"nope"}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"resources/list"}`,
+		`not json`,
+	}
+	var out bytes.Buffer
+	err := mcp.NewServer(eng, "1.0.0").Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out)
+	if err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	responses := strings.Split(strings.TrimSpace(out.String()), "\n")
+	tests := []struct {
+		name string
+		want []string // expected in the response, in order of the requests that get one
+	}{
+		{"initialize", []string{`"id":1`, `"protocolVersion":"2024-11-05"`, `"name":"logaid"`}},
+		{"tools/list", []string{`"id":2`, `"name":"suggest_fix"`, `"name":"analyze_error"`, `"name":"list_plugins"`}},
+		{"suggest_fix", []string{`"id":3`, `Suggested by stub`, `1. sudo apt install nginx`}},
+		{"analyze_error", []string{`"id":4`, `- E: Unable to locate package ngnix`, `- stub`}},
+		{"invalid arguments", []string{`"id":5`, `"isError":true`, `command is required`}},
+		{"unknown tool", []string{`"id":6`, `"code":-32602`}},
+		{"unknown method", []string{`"id":7`, `"code":-32601`}},
+		{"parse error", []string{`"id":null`, `"code":-32700`}},
+	}
+	if len(responses) != len(tests) {
+		t.Fatalf("got %d responses, want %d (the notification gets none):\n%s", len(responses), len(tests), out.String())
+	}
+	for i, tt := range tests {
+		if !json.Valid([]byte(responses[i])) {
+			t.Errorf("%s: invalid JSON %s", tt.name, responses[i])
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(responses[i], want) {
+				t.Errorf("%s: response %s does not contain %s", tt.name, responses[i], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/metrics"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestMetricsStore tests that batches are summed into per-plugin stats
+func TestMetricsStore(t *testing.T) {
+	store := metrics.New(filepath.Join(t.TempDir(), "metrics.json"))
+
+	first := metrics.Batch{}
+	first.Call("npm", 2*time.Millisecond, false)
+	first.Match("npm")
+	first.Suggestion("npm")
+	first.Call("git", time.Millisecond, true)
+	second := metrics.Batch{}
+	second.Call("npm", 4*time.Millisecond, false)
+	second.Match("npm")
+	second.Suggestion("npm")
+	second.Outcome("npm", true, true)
+	second.Outcome("npm", false, false)
+
+	for _, batch := range []metrics.Batch{first, second} {
+		if err := store.Add(batch); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	all, since, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if since.IsZero() || len(all) != 2 || all[0].Plugin != "npm" {
+		t.Fatalf("Load() = %+v since %v, want npm first of 2", all, since)
+	}
+	npm := all[0]
+	if npm.Matches != 2 || npm.Suggestions != 2 || npm.Accepted != 1 || npm.Rejected != 1 {
+		t.Errorf("npm stats = %+v", npm.Stats)
+	}
+	if npm.AcceptanceRate() != 0.5 || npm.SuccessRate() != 1 {
+		t.Errorf("rates = %v, %v, want 0.5, 1", npm.AcceptanceRate(), npm.SuccessRate())
+	}
+	if npm.AverageLatency() != 3*time.Millisecond || npm.MaxLatency != 4*time.Millisecond {
+		t.Errorf("latency avg %s max %s, want 3ms and 4ms", npm.AverageLatency(), npm.MaxLatency)
+	}
+	if all[1].Timeouts != 1 {
+		t.Errorf("git timeouts = %d, want 1", all[1].Timeouts)
+	}
+
+	if err := store.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if all, _, _ := store.Load(); len(all) != 0 {
+		t.Errorf("Load() after Reset() = %+v", all)
+	}
+}
+
+// TestEngineRecordsMetrics tests that suggesting a fix records plugin metrics
+func TestEngineRecordsMetrics(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{PluginMetrics: true})
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{
+		&stubPlugin{name: "quiet"},
+		&stubPlugin{name: "quick", fix: "terraform plan"},
+	})
+	if _, err := eng.Suggest(context.Background(), "terrafrom plan", "terrafrom: command not found"); err != nil {
+		t.Fatal(err)
+	}
+
+	all, _, err := metrics.NewFromConfig().Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := map[string]metrics.Stats{}
+	for _, p := range all {
+		stats[p.Plugin] = p.Stats
+	}
+	if s := stats["quick"]; s.Matches != 1 || s.Suggestions != 1 || s.Calls == 0 {
+		t.Errorf("quick stats = %+v, want one match and suggestion", s)
+	}
+	if s := stats["quiet"]; s.Matches != 1 || s.Suggestions != 0 {
+		t.Errorf("quiet stats = %+v, want a match without suggestion", s)
+	}
+}
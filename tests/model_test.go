@@ -0,0 +1,289 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/fuzzy"
+	"github.com/ayushsharma-1/LogAid/internal/model"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestPersonalModel tests corrections learned from shell history and accepted fixes
+func TestPersonalModel(t *testing.T) {
+	dir := t.TempDir()
+	bashHistory := filepath.Join(dir, ".bash_history")
+	zshHistory := filepath.Join(dir, ".zsh_history")
+
+	bash := "git co feature-login\ngit co feature-login\n#1700000000\ngit co main\ngit status\nsudo apt install ripgrep\n"
+	zsh := ": 1700000000:0;git co feature-login\n: 1700000001:0;sudo apt install ripgrep\n"
+	if err := os.WriteFile(bashHistory, []byte(bash), 0644); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+	if err := os.WriteFile(zshHistory, []byte(zsh), 0644); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+
+	store := model.New(filepath.Join(dir, "model.json"), []string{bashHistory, zshHistory})
+	if err := store.RecordFix("gti status", "git status"); err != nil {
+		t.Fatalf("RecordFix() error = %v", err)
+	}
+
+	m, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		command  string
+		expected string
+		changed  bool
+	}{
+		{
+			name:     "frequent branch for alias",
+			command:  "git co featre-login",
+			expected: "git co feature-login",
+			changed:  true,
+		},
+		{
+			name:     "package used with sudo",
+			command:  "sudo apt install ripgrp",
+			expected: "sudo apt install ripgrep",
+			changed:  true,
+		},
+		{
+			name:     "accepted fix",
+			command:  "gti status",
+			expected: "git status",
+			changed:  true,
+		},
+		{
+			name:     "rarely used word is not suggested",
+			command:  "git co mian",
+			expected: "git co mian",
+			changed:  false,
+		},
+		{
+			name:     "known command unchanged",
+			command:  "git co main",
+			expected: "git co main",
+			changed:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, changed := m.Correct(tc.command)
+			if got != tc.expected || changed != tc.changed {
+				t.Errorf("Correct(%q) = %q, %v; want %q, %v", tc.command, got, changed, tc.expected, tc.changed)
+			}
+		})
+	}
+}
+
+// TestPersonalModelOnlyFixesTypos tests that the personal model corrects a
+// command only when its output shows a mistyped word, so a failure for
+// another reason keeps its arguments and never reaches the personal model
+func TestPersonalModelOnlyFixesTypos(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HISTFILE", "")
+	history := "git push origin feature-y\ngit push origin feature-y\n"
+	if err := os.WriteFile(filepath.Join(home, ".bash_history"), []byte(history), 0644); err != nil {
+		t.Fatal(err)
+	}
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{PersonalModel: true})
+
+	testCases := []struct {
+		name     string
+		output   string
+		expected string
+		source   string
+	}{
+		{
+			name:     "rejected push keeps the new branch",
+			output:   " ! [rejected]        feature-x -> feature-x (non-fast-forward)",
+			expected: "git pull --rebase origin feature-x",
+			source:   "git",
+		},
+		{
+			name:     "mistyped branch corrected",
+			output:   "error: src refspec feature-x does not match any\nerror: pathspec 'feature-x' did not match any file(s) known to git",
+			expected: "git push origin feature-y",
+			source:   "personal model",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eng := engine.New()
+			eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "git", fix: "git pull --rebase origin feature-x"}})
+			suggestion, err := eng.Suggest(context.Background(), "git push origin feature-x", tc.output)
+			if err != nil || suggestion == nil {
+				t.Fatalf("Suggest() = %v, %v", suggestion, err)
+			}
+			if suggestion.Command != tc.expected || suggestion.Source != tc.source {
+				t.Errorf("Suggest() = %q from %s, want %q from %s", suggestion.Command, suggestion.Source, tc.expected, tc.source)
+			}
+		})
+	}
+
+	// Without a plugin fix, a non-typo failure still never gets the history
+	// correction, which would change the branch the user asked for
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "git"}})
+	suggestion, _ := eng.Suggest(context.Background(), "git push origin feature-x", testCases[0].output)
+	if suggestion != nil && suggestion.Source == "personal model" {
+		t.Errorf("Suggest() = %q from the personal model for a non-typo failure", suggestion.Command)
+	}
+}
+
+// TestTypoDictionary tests listing and removing accepted corrections
+func TestTypoDictionary(t *testing.T) {
+	store := model.New(filepath.Join(t.TempDir(), "model.json"), nil)
+	for _, fix := range [][2]string{
+		{"git checout main", "git checkout main"},
+		{"git checout main", "git checkout main"},
+		{"docker checout", "docker checkout"},
+		{"gti status", "git status"},
+	} {
+		if err := store.RecordFix(fix[0], fix[1]); err != nil {
+			t.Fatalf("RecordFix() error = %v", err)
+		}
+	}
+
+	fixes, err := store.Fixes()
+	if err != nil {
+		t.Fatalf("Fixes() error = %v", err)
+	}
+	want := []model.Fix{
+		{Context: "", From: "gti", To: "git", Count: 1},
+		{Context: "docker", From: "checout", To: "checkout", Count: 1},
+		{Context: "git", From: "checout", To: "checkout", Count: 2},
+	}
+	if len(fixes) != len(want) {
+		t.Fatalf("Fixes() = %+v, want %+v", fixes, want)
+	}
+	for i := range want {
+		if fixes[i] != want[i] {
+			t.Errorf("Fixes()[%d] = %+v, want %+v", i, fixes[i], want[i])
+		}
+	}
+
+	m := &model.Model{Fixes: fixes}
+	if got, _ := m.Correct("git checout main"); got != "git checkout main" {
+		t.Errorf("Correct() = %q, want the learned correction", got)
+	}
+
+	if removed, err := store.RemoveFix("checout", "git"); err != nil || removed != 1 {
+		t.Errorf("RemoveFix(checout, git) = %d, %v, want 1", removed, err)
+	}
+	if removed, err := store.RemoveFix("checout"); err != nil || removed != 1 {
+		t.Errorf("RemoveFix(checout) = %d, %v, want the docker correction removed", removed, err)
+	}
+	if removed, _ := store.RemoveFix("nope"); removed != 0 {
+		t.Errorf("RemoveFix(nope) = %d, want 0", removed)
+	}
+	if fixes, _ := store.Fixes(); len(fixes) != 1 || fixes[0].From != "gti" {
+		t.Errorf("Fixes() after removal = %+v", fixes)
+	}
+}
+
+// TestImportHistory tests reading bash, zsh and fish history with timestamps
+// and learning from an imported file
+func TestImportHistory(t *testing.T) {
+	tests := []struct {
+		format  string
+		history string
+	}{
+		{"bash", "#1700000000\ngit status\n#1700000600\ngit log\n"},
+		{"zsh", ": 1700000000:0;git status\n: 1700000600:0;git log\n"},
+		{"fish", "- cmd: git status\n  when: 1700000000\n- cmd: git log\n  when: 1700000600\n  paths:\n    - .\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			commands, format, err := model.ReadHistory(strings.NewReader(tt.history))
+			if err != nil {
+				t.Fatalf("ReadHistory() error = %v", err)
+			}
+			if format != tt.format {
+				t.Errorf("format = %q, want %q", format, tt.format)
+			}
+			if len(commands) != 2 || commands[0].Text != "git status" || commands[1].Text != "git log" {
+				t.Fatalf("ReadHistory() = %+v", commands)
+			}
+			if !commands[0].Time.Equal(time.Unix(1700000000, 0)) || !commands[1].Time.Equal(time.Unix(1700000600, 0)) {
+				t.Errorf("timestamps = %v, %v", commands[0].Time, commands[1].Time)
+			}
+		})
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "work_history")
+	history := ": 1700000000:0;terraform plan\n" +
+		": 1700000010:0;terraform plan\n" +
+		": 1700000020:0;terrafrom apply\n" +
+		": 1700000030:0;terraform apply\n" +
+		": 1700009000:0;terrafrom init\n" + // not retyped soon after
+		": 1700009900:0;terraform init\n"
+	if err := os.WriteFile(path, []byte(history), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := model.New(filepath.Join(dir, "model.json"), nil)
+	for i := 0; i < 2; i++ {
+		imported, err := store.Import(path)
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+		if imported.Commands != 6 || imported.Format != "zsh" || imported.Tools[0].Word != "terraform" || imported.Tools[0].Count != 4 {
+			t.Errorf("Import() = %+v", imported)
+		}
+		if len(imported.Fixes) != 1 || imported.Fixes[0].From != "terrafrom" || imported.Fixes[0].To != "terraform" {
+			t.Errorf("Import() fixes = %+v, want the retyped command only", imported.Fixes)
+		}
+	}
+
+	fixes, _ := store.Fixes()
+	if len(fixes) != 1 || fixes[0].Count != 1 {
+		t.Errorf("Fixes() after importing twice = %+v, want the correction counted once", fixes)
+	}
+	m, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := m.Count("terraform", "plan"); count != 2 {
+		t.Errorf("Count(terraform plan) = %d, want the imported uses counted once", count)
+	}
+	if fixed, ok := m.Correct("terafrm validate"); !ok || fixed != "terraform validate" {
+		t.Errorf("Correct() = %q, %v, want the imported command", fixed, ok)
+	}
+}
+
+// TestFuzzyDistance tests edit distances including transpositions
+func TestFuzzyDistance(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"git", "git", 0},
+		{"gti", "git", 1},
+		{"checout", "checkout", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+
+	for _, tc := range testCases {
+		if got := fuzzy.Distance(tc.a, tc.b); got != tc.expected {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestNotFoundPlugin tests the command-not-found plugin
+func TestNotFoundPlugin(t *testing.T) {
+	bin := t.TempDir()
+	for _, name := range []string{"git", "kubectl", "top"} {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plugin := &plugins.NotFoundPlugin{Path: bin}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "typo corrected from PATH",
+			command:     "gti status",
+			output:      "bash: gti: command not found",
+			shouldMatch: true,
+			expectedFix: "git status",
+		},
+		{
+			name:        "typo under sudo in zsh",
+			command:     "sudo kubctl get pods",
+			output:      "zsh: command not found: kubctl",
+			shouldMatch: true,
+			expectedFix: "sudo kubectl get pods",
+		},
+		{
+			name:        "ubuntu handler package",
+			command:     "cowsay hello",
+			output:      "Command 'cowsay' not found, but can be installed with:\n\nsudo apt install cowsay",
+			shouldMatch: true,
+			expectedFix: "sudo apt install cowsay && cowsay hello",
+		},
+		{
+			name:        "fedora packagekit",
+			command:     "tmux",
+			output:      "bash: tmux: command not found...\nInstall package 'tmux' to provide command 'tmux'? [N/y]",
+			shouldMatch: true,
+			expectedFix: "sudo dnf install tmux && tmux",
+		},
+		{
+			name:        "known package is not treated as a typo",
+			command:     "htop",
+			output:      "sh: 1: htop: not found",
+			shouldMatch: true,
+			expectedFix: "sudo apt install htop && htop",
+		},
+		{
+			name:        "package name differs from command",
+			command:     "dig example.com",
+			output:      "bash: dig: command not found",
+			shouldMatch: true,
+			expectedFix: "sudo apt install dnsutils && dig example.com",
+		},
+		{
+			name:        "command run by a script",
+			command:     "./build.sh",
+			output:      "./build.sh: line 3: protoc: command not found",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
+
+// TestNotFoundPackageDatabase tests packages found through the distro's
+// command-not-found data
+func TestNotFoundPackageDatabase(t *testing.T) {
+	bin := t.TempDir()
+	scripts := map[string]string{
+		"apt-file": "#!/bin/sh\n" + `case "$3" in *protoc*) echo "protobuf-compiler: /usr/bin/protoc" ;; *) exit 1 ;; esac` + "\n",
+		"pkgfile":  "#!/bin/sh\n" + `[ "$2" = "rg" ] && echo "extra/ripgrep"` + "\n",
+		"kubectl":  "#!/bin/sh\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plugin := &plugins.NotFoundPlugin{Path: bin}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "contents index",
+			command:     "protoc --go_out=. api.proto",
+			output:      "bash: protoc: command not found",
+			expectedFix: "sudo apt install protobuf-compiler && protoc --go_out=. api.proto",
+		},
+		{
+			name:        "second database answers",
+			command:     "rg TODO",
+			output:      "bash: rg: command not found",
+			expectedFix: "sudo pacman -S ripgrep && rg TODO",
+		},
+		{
+			name:        "unknown to every database",
+			command:     "kubctl get pods",
+			output:      "bash: kubctl: command not found",
+			expectedFix: "kubectl get pods",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+}
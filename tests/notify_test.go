@@ -0,0 +1,228 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/notify"
+)
+
+// recordingChannel counts the events it receives
+type recordingChannel struct {
+	name   string
+	events []notify.Event
+}
+
+func (c *recordingChannel) Name() string {
+	return c.name
+}
+
+func (c *recordingChannel) Send(ctx context.Context, event notify.Event) error {
+	c.events = append(c.events, event)
+	return nil
+}
+
+// TestNotificationRouting tests rule parsing and first-match routing
+func TestNotificationRouting(t *testing.T) {
+	rules, err := notify.ParseRules("fix_proposed:destructive=slack,terminal; fix_failed=desktop; *=terminal")
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+
+	router := notify.NewRouter(rules, nil, 0)
+
+	testCases := []struct {
+		name     string
+		event    notify.Event
+		expected []string
+	}{
+		{
+			name:     "destructive fix goes to slack",
+			event:    notify.Event{Type: notify.EventFixProposed, Risk: notify.RiskDestructive},
+			expected: []string{"slack", "terminal"},
+		},
+		{
+			name:     "safe fix stays local",
+			event:    notify.Event{Type: notify.EventFixProposed, Risk: notify.RiskSafe},
+			expected: []string{"terminal"},
+		},
+		{
+			name:     "failed fix goes to desktop",
+			event:    notify.Event{Type: notify.EventFixFailed},
+			expected: []string{"desktop"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := router.Route(tc.event)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("Route() = %v, want %v", got, tc.expected)
+			}
+			for i := range got {
+				if got[i] != tc.expected[i] {
+					t.Errorf("Route() = %v, want %v", got, tc.expected)
+				}
+			}
+		})
+	}
+
+	if _, err := notify.ParseRules("fix_proposed"); err == nil {
+		t.Error("ParseRules() expected error for rule without channels")
+	}
+}
+
+// TestNotificationQuietHoursAndRateLimit tests delivery suppression
+func TestNotificationQuietHoursAndRateLimit(t *testing.T) {
+	quiet, err := notify.ParseQuietHours("22:00-07:00")
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+
+	night := time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC)
+	day := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if !quiet.Contains(night) || quiet.Contains(day) {
+		t.Fatalf("Contains() wrong result for window wrapping midnight")
+	}
+
+	rules, _ := notify.ParseRules("*=slack,terminal")
+	router := notify.NewRouter(rules, quiet, 2)
+	slack := &recordingChannel{name: "slack"}
+	terminal := &recordingChannel{name: "terminal"}
+	router.Register(slack)
+	router.Register(terminal)
+
+	router.Now = func() time.Time { return night }
+	router.Notify(context.Background(), notify.Event{Type: notify.EventFixProposed})
+	if len(slack.events) != 0 || len(terminal.events) != 1 {
+		t.Fatalf("quiet hours: slack=%d terminal=%d, want 0 and 1", len(slack.events), len(terminal.events))
+	}
+
+	router.Now = func() time.Time { return day }
+	for i := 0; i < 5; i++ {
+		router.Notify(context.Background(), notify.Event{Type: notify.EventFixProposed})
+	}
+	if len(slack.events) != 2 {
+		t.Errorf("rate limit: slack received %d events, want 2", len(slack.events))
+	}
+	if len(terminal.events) != 6 {
+		t.Errorf("terminal should not be rate limited, got %d events", len(terminal.events))
+	}
+}
+
+// TestNotificationTemplates tests the messages posted to Slack, Discord and
+// webhooks for a failure
+func TestNotificationTemplates(t *testing.T) {
+	event := notify.Event{
+		Type:       notify.EventFixProposed,
+		Risk:       notify.RiskSafe,
+		Command:    "./deploy.sh",
+		Output:     "Pulling image\nError: unable to connect to registry\nexiting",
+		Suggestion: "docker login registry.example.com",
+		Source:     "ai",
+		Host:       "ci-runner-1",
+	}
+	if got := event.ErrorSummary(); got != "Error: unable to connect to registry" {
+		t.Errorf("ErrorSummary() = %q", got)
+	}
+	if got := (notify.Event{Output: "done\n"}).ErrorSummary(); got != "done" {
+		t.Errorf("ErrorSummary() without an error line = %q, want the last line", got)
+	}
+
+	bodies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies[r.URL.Path] = string(data)
+	}))
+	defer server.Close()
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{
+		NotifyRules:           "*=slack,discord,webhook",
+		NotifySlackWebhook:    server.URL + "/slack",
+		NotifyDiscordWebhook:  server.URL + "/discord",
+		NotifyWebhookURL:      server.URL + "/hook",
+		NotifyTemplate:        "{{.Host}}: `{{.Command}}` failed, try `{{.Suggestion}}`",
+		NotifyWebhookTemplate: `{"command": {{json .Command}}, "error": {{json .ErrorSummary}}}`,
+		MaskSecrets:           true,
+	})
+	notify.NewFromConfig().Notify(context.Background(), event)
+
+	var slack, discord map[string]string
+	if err := json.Unmarshal([]byte(bodies["/slack"]), &slack); err != nil {
+		t.Fatalf("Slack body %q: %v", bodies["/slack"], err)
+	}
+	want := "ci-runner-1: `./deploy.sh` failed, try `docker login registry.example.com`"
+	if slack["text"] != want {
+		t.Errorf("Slack text = %q, want %q", slack["text"], want)
+	}
+	if err := json.Unmarshal([]byte(bodies["/discord"]), &discord); err != nil || discord["content"] != want {
+		t.Errorf("Discord body = %q, want content %q", bodies["/discord"], want)
+	}
+	if bodies["/hook"] != `{"command": "./deploy.sh", "error": "Error: unable to connect to registry"}` {
+		t.Errorf("webhook body = %q", bodies["/hook"])
+	}
+
+	// The default message, with secrets masked
+	updated := *config.Current()
+	updated.NotifyTemplate = ""
+	config.SetCurrent(&updated)
+	event.Command = "mysql -u root -p'hunter2' app"
+	notify.NewFromConfig().Notify(context.Background(), event)
+	if err := json.Unmarshal([]byte(bodies["/slack"]), &slack); err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{"[safe]", "> Error: unable to connect to registry", "Host: ci-runner-1"} {
+		if !strings.Contains(slack["text"], part) {
+			t.Errorf("default message %q does not contain %q", slack["text"], part)
+		}
+	}
+	if strings.Contains(slack["text"], "hunter2") {
+		t.Errorf("default message %q contains the password", slack["text"])
+	}
+
+	if _, err := notify.ParseTemplate("{{.Command"); err == nil {
+		t.Error("ParseTemplate() expected error for an unclosed action")
+	}
+}
+
+// TestNotificationTruncation tests that long summaries and Discord messages
+// are cut between characters, never inside one
+func TestNotificationTruncation(t *testing.T) {
+	long := strings.Repeat("é", 2500)
+
+	summary := (notify.Event{Output: "Error: " + long}).ErrorSummary()
+	if !utf8.ValidString(summary) || utf8.RuneCountInString(summary) != 200 || !strings.HasSuffix(summary, "...") {
+		t.Errorf("ErrorSummary() = %q, want 200 valid characters ending in ...", summary)
+	}
+
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	tmpl, err := notify.ParseTemplate("{{.Command}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	channel := &notify.DiscordChannel{WebhookURL: server.URL, Template: tmpl, Client: server.Client()}
+	if err := channel.Send(context.Background(), notify.Event{Command: long}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	var discord map[string]string
+	if err := json.Unmarshal(body, &discord); err != nil {
+		t.Fatalf("Discord body %q: %v", body, err)
+	}
+	if content := discord["content"]; !utf8.ValidString(content) || utf8.RuneCountInString(content) != 2000 {
+		t.Errorf("Discord content has %d characters, want 2000 valid ones", utf8.RuneCountInString(content))
+	}
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestNpmRegistryLookup tests package name validation against the registry
+func TestNpmRegistryLookup(t *testing.T) {
+	published := map[string]bool{"lodash": true, "chalk": true, "left-pad": true}
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/-/v1/search" {
+			var objects []string
+			for _, name := range []string{"chalk", "chalk-template", "left-pad"} {
+				if strings.Contains(name, r.URL.Query().Get("text")[:3]) {
+					objects = append(objects, fmt.Sprintf(`{"package":{"name":%q}}`, name))
+				}
+			}
+			fmt.Fprintf(w, `{"objects":[%s]}`, strings.Join(objects, ","))
+			return
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/latest")
+		if !published[name] {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"name":%q}`, name)
+	}))
+	defer server.Close()
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{NPMSuggestAlternatives: true, CacheDir: t.TempDir()})
+
+	plugin := &plugins.NpmPlugin{Registry: server.URL}
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "known typo verified",
+			command:     "npm install lodas",
+			output:      "npm ERR! 404 Not Found - GET https://registry.npmjs.org/lodas - Not found",
+			expectedFix: "npm install lodash",
+		},
+		{
+			name:        "unknown name from search",
+			command:     "npm install chalkk",
+			output:      "npm ERR! 404 Not Found - GET https://registry.npmjs.org/chalkk - Not found",
+			expectedFix: "npm install chalk",
+		},
+		{
+			name:        "second package is the missing one",
+			command:     "npm install chalk leftpad",
+			output:      "npm ERR! 404 Not Found - GET https://registry.npmjs.org/leftpad - Not found",
+			expectedFix: "npm install chalk left-pad",
+		},
+		{
+			name:        "no search hits",
+			command:     "npm install zzzzqq",
+			output:      "npm ERR! 404 Not Found - GET https://registry.npmjs.org/zzzzqq - Not found",
+			expectedFix: "npm install zzzzqq",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+
+	// Answers are cached, so asking again does not reach the registry
+	before := atomic.LoadInt32(&requests)
+	plugin.Suggest(testCases[1].command, testCases[1].output)
+	if after := atomic.LoadInt32(&requests); after != before {
+		t.Errorf("cached lookup made %d requests", after-before)
+	}
+}
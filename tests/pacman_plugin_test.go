@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestPacmanPlugin tests the pacman/yay plugin
+func TestPacmanPlugin(t *testing.T) {
+	plugin := &plugins.PacmanPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "debian package name",
+			command:     "sudo pacman -S build-essential",
+			output:      "error: target not found: build-essential",
+			shouldMatch: true,
+			expectedFix: "sudo pacman -S base-devel",
+		},
+		{
+			name:        "aur package",
+			command:     "sudo pacman -S visual-studio-code-bin",
+			output:      "error: target not found: visual-studio-code-bin",
+			shouldMatch: true,
+			expectedFix: "yay -S visual-studio-code-bin",
+		},
+		{
+			name:        "stale lock",
+			command:     "sudo pacman -S htop",
+			output:      "error: failed to init transaction (unable to lock database)\nerror: could not lock database: File exists",
+			shouldMatch: true,
+			expectedFix: "! pgrep -x pacman && sudo rm -f /var/lib/pacman/db.lck && sudo pacman -S htop",
+		},
+		{
+			name:        "keyring",
+			command:     "sudo pacman -Syu",
+			output:      "error: linux: signature from \"Jan Doe <jan@archlinux.org>\" is unknown trust\n:: File /var/cache/pacman/pkg/linux.pkg.tar.zst is corrupted (invalid or corrupted package (PGP signature)).",
+			shouldMatch: true,
+			expectedFix: "sudo pacman -Sy --needed archlinux-keyring && sudo pacman -Su && sudo pacman -Syu",
+		},
+		{
+			name:        "outdated mirrors",
+			command:     "sudo pacman -S firefox",
+			output:      "error: failed retrieving file 'firefox-120.0-1-x86_64.pkg.tar.zst' from mirror : The requested URL returned error: 404",
+			shouldMatch: true,
+			expectedFix: "sudo pacman -Syu && sudo pacman -S firefox",
+		},
+		{
+			name:        "partial upgrade",
+			command:     "sudo pacman -Sy firefox",
+			output:      "error: failed to prepare transaction (could not satisfy dependencies)\n:: installing nss breaks dependency 'nss=3.94' required by lib32-nss",
+			shouldMatch: true,
+			expectedFix: "sudo pacman -Syu firefox",
+		},
+		{
+			name:        "not root",
+			command:     "pacman -S htop",
+			output:      "error: you cannot perform this operation unless you are root.",
+			shouldMatch: true,
+			expectedFix: "sudo pacman -S htop",
+		},
+		{
+			name:        "yay as root",
+			command:     "sudo yay -S google-chrome",
+			output:      "-> Avoid running yay as root/sudo.",
+			shouldMatch: true,
+			expectedFix: "yay -S google-chrome",
+		},
+		{
+			name:        "apt command",
+			command:     "sudo apt install htop",
+			output:      "E: Unable to locate package htop",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestPermissionPlugin tests the generic filesystem-permission plugin
+func TestPermissionPlugin(t *testing.T) {
+	plugin := &plugins.PermissionPlugin{Home: "/home/dev"}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "script not executable",
+			command:     "./deploy.sh --prod",
+			output:      "bash: ./deploy.sh: Permission denied",
+			shouldMatch: true,
+			expectedFix: "chmod +x ./deploy.sh && ./deploy.sh --prod",
+		},
+		{
+			name:        "root-owned file in home",
+			command:     "go build ./...",
+			output:      "go: creating work dir: mkdir /home/dev/.cache/go-build/3f: permission denied",
+			shouldMatch: true,
+			expectedFix: "sudo chown -R $USER /home/dev/.cache/go-build/3f && go build ./...",
+		},
+		{
+			name:        "python errno 13 on relative path",
+			command:     "python train.py",
+			output:      "PermissionError: [Errno 13] Permission denied: 'checkpoints/model.pt'",
+			shouldMatch: true,
+			expectedFix: "sudo chown -R $USER checkpoints/model.pt && python train.py",
+		},
+		{
+			name:        "system path",
+			command:     "touch /etc/motd",
+			output:      "touch: cannot touch '/etc/motd': Permission denied",
+			shouldMatch: true,
+			expectedFix: "sudo touch /etc/motd",
+		},
+		{
+			name:        "node global install",
+			command:     "npx degit user/repo /opt/app",
+			output:      "Error: EACCES: permission denied, mkdir '/opt/app'",
+			shouldMatch: true,
+			expectedFix: "sudo npx degit user/repo /opt/app",
+		},
+		{
+			name:        "serial device",
+			command:     "screen /dev/ttyUSB0 115200",
+			output:      "Cannot open line '/dev/ttyUSB0' for R/W: Permission denied",
+			shouldMatch: true,
+			expectedFix: "sudo usermod -aG dialout $USER && newgrp dialout",
+		},
+		{
+			name:        "docker socket",
+			command:     "docker compose up",
+			output:      "permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock",
+			shouldMatch: true,
+			expectedFix: "sudo usermod -aG docker $USER && newgrp docker",
+		},
+		{
+			name:        "ssh authentication is not a file permission",
+			command:     "rsync -a dist/ web1:/srv",
+			output:      "git@web1: Permission denied (publickey).",
+			shouldMatch: false,
+		},
+		{
+			name:        "unrelated error",
+			command:     "ls missing",
+			output:      "ls: cannot access 'missing': No such file or directory",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
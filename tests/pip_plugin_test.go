@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestPipIndexLookup tests package and version checks against PyPI
+func TestPipIndexLookup(t *testing.T) {
+	projects := map[string]string{
+		"requests": `{"releases": {"2.30.0": [{"yanked": false}], "2.31.0": [{"yanked": false}], "3.0.0b1": [{"yanked": false}], "2.32.0": [{"yanked": true}]}}`,
+		"django":   `{"releases": {"4.2.1": [{"yanked": false}], "5.0.0": [{"yanked": false}], "5.1.2": [{"yanked": false}]}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/pypi/"), "/json")
+		body, ok := projects[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{PipSuggestVersions: true, CacheDir: t.TempDir()})
+
+	plugin := &plugins.PipPlugin{Index: server.URL}
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "corrected name confirmed",
+			command:     "pip3 install reqeusts",
+			output:      "ERROR: No matching distribution found for reqeusts",
+			expectedFix: "pip3 install requests",
+		},
+		{
+			name:        "version too new",
+			command:     "pip3 install requests==2.99",
+			output:      "ERROR: Could not find a version that satisfies the requirement requests==2.99",
+			expectedFix: "pip3 install requests==2.31.0",
+		},
+		{
+			name:        "version too old",
+			command:     "pip3 install django==1.11",
+			output:      "ERROR: Could not find a version that satisfies the requirement django==1.11",
+			expectedFix: "pip3 install django==4.2.1",
+		},
+		{
+			name:        "lower bound above every release",
+			command:     "pip3 install djnago>=6",
+			output:      "ERROR: Could not find a version that satisfies the requirement djnago>=6",
+			expectedFix: "pip3 install django==5.1.2",
+		},
+		{
+			name:        "unknown project left alone",
+			command:     "pip3 install notapackage",
+			output:      "ERROR: No matching distribution found for notapackage",
+			expectedFix: "pip3 install notapackage",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// scoredPlugin is a stubPlugin that reports a fixed score
+type scoredPlugin struct {
+	stubPlugin
+	score float64
+}
+
+func (p *scoredPlugin) Score(cmd string, output string) float64 { return p.score }
+
+// TestPluginScores tests that the engine prefers the most confident plugin
+func TestPluginScores(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{})
+
+	testCases := []struct {
+		name           string
+		plugins        []plugins.Plugin
+		expectedSource string
+	}{
+		{
+			name: "higher score wins over load order",
+			plugins: []plugins.Plugin{
+				&scoredPlugin{stubPlugin{name: "keyword", fix: "sudo apt install rediscli"}, 0.4},
+				&scoredPlugin{stubPlugin{name: "typo-map", fix: "sudo apt install redis-tools"}, 0.95},
+			},
+			expectedSource: "typo-map",
+		},
+		{
+			name: "unscored plugins rank at the default score",
+			plugins: []plugins.Plugin{
+				&scoredPlugin{stubPlugin{name: "generic", fix: "sudo !!"}, 0.3},
+				&stubPlugin{name: "tool", fix: "git push"},
+			},
+			expectedSource: "tool",
+		},
+		{
+			name: "ties keep load order",
+			plugins: []plugins.Plugin{
+				&stubPlugin{name: "first", fix: "a"},
+				&scoredPlugin{stubPlugin{name: "second", fix: "b"}, plugins.DefaultScore},
+			},
+			expectedSource: "first",
+		},
+		{
+			name: "empty suggestion falls through to the next plugin",
+			plugins: []plugins.Plugin{
+				&scoredPlugin{stubPlugin{name: "confident", fix: ""}, 1},
+				&stubPlugin{name: "fallback", fix: "make build"},
+			},
+			expectedSource: "fallback",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eng := engine.New()
+			eng.SetPlugins(tc.plugins)
+
+			suggestion, err := eng.Suggest(context.Background(), "cmd", "error")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if suggestion == nil || suggestion.Source != tc.expectedSource {
+				t.Errorf("Suggest() = %+v, want a suggestion from %s", suggestion, tc.expectedSource)
+			}
+		})
+	}
+}
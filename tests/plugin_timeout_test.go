@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// stubPlugin matches every command after delay and suggests fix
+type stubPlugin struct {
+	name  string
+	delay time.Duration
+	fix   string
+}
+
+func (p *stubPlugin) Name() string { return p.name }
+
+func (p *stubPlugin) Match(cmd string, output string) bool {
+	time.Sleep(p.delay)
+	return true
+}
+
+func (p *stubPlugin) Suggest(cmd string, output string) string { return p.fix }
+
+// TestPluginTimeout tests that a hanging plugin is skipped after PLUGIN_TIMEOUT
+func TestPluginTimeout(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{PluginTimeout: 1})
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{
+		&stubPlugin{name: "hanging", delay: time.Minute, fix: "wrong"},
+		&stubPlugin{name: "quick", fix: "terraform plan"},
+	})
+
+	start := time.Now()
+	suggestion, err := eng.Suggest(context.Background(), "terrafrom plan", "terrafrom: command not found")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Suggest() took %s, want about PLUGIN_TIMEOUT", elapsed)
+	}
+	if suggestion == nil || suggestion.Command != "terraform plan" || suggestion.Source != "quick" {
+		t.Errorf("Suggest() = %+v, want terraform plan from quick", suggestion)
+	}
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestPoetryPlugin tests the Poetry/Pipenv plugin
+func TestPoetryPlugin(t *testing.T) {
+	plugin := &plugins.PoetryPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "lock file out of date",
+			command:     "poetry install",
+			output:      "pyproject.toml changed significantly since poetry.lock was last generated. Run `poetry lock [--no-update]` to fix the lock file.",
+			shouldMatch: true,
+			expectedFix: "poetry lock && poetry install",
+		},
+		{
+			name:        "unsupported python",
+			command:     "poetry install",
+			output:      "The currently activated Python version 3.8.10 is not supported by the project (>=3.10,<4.0).\nTrying to find and use a compatible version.",
+			shouldMatch: true,
+			expectedFix: "poetry env use python3.10 && poetry install",
+		},
+		{
+			name:        "package typo",
+			command:     "poetry add requets",
+			output:      "Could not find a matching version of package requets",
+			shouldMatch: true,
+			expectedFix: "poetry add requests",
+		},
+		{
+			name:        "unsatisfiable version",
+			command:     "poetry add django@^9.0",
+			output:      "Could not find a matching version of package django",
+			shouldMatch: true,
+			expectedFix: "poetry add django",
+		},
+		{
+			name:        "missing venv module",
+			command:     "pipenv install",
+			output:      "The virtual environment was not created successfully because ensurepip is not\navailable.",
+			shouldMatch: true,
+			expectedFix: "sudo apt install python3-venv && pipenv install",
+		},
+		{
+			name:        "pipenv lock out of date",
+			command:     "pipenv sync",
+			output:      "Pipfile.lock (a1b2c3) out of date, updating to (d4e5f6)...",
+			shouldMatch: true,
+			expectedFix: "pipenv lock && pipenv sync",
+		},
+		{
+			name:        "pipenv python missing",
+			command:     "pipenv install",
+			output:      "Warning: Python 3.11 was not found on your system...",
+			shouldMatch: true,
+			expectedFix: "pipenv --python 3.11",
+		},
+		{
+			name:        "pip command",
+			command:     "pip install requets",
+			output:      "ERROR: Could not find a version that satisfies the requirement requets",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
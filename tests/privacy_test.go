@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/history"
+	"github.com/ayushsharma-1/LogAid/internal/logger"
+	"github.com/ayushsharma-1/LogAid/internal/privacy"
+)
+
+// TestPrivacyRetentionAndPurge tests deleting stored data older than the
+// retention period and purging all of it
+func TestPrivacyRetentionAndPurge(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	savedConfig, savedLogger := config.Current(), logger.AppLogger
+	defer func() { config.SetCurrent(savedConfig); logger.AppLogger = savedLogger }()
+	logger.AppLogger = nil
+	config.SetCurrent(&config.Config{
+		HistoryFile:      filepath.Join(dir, "history.json"),
+		CacheDir:         filepath.Join(dir, "cache"),
+		SessionsDir:      filepath.Join(dir, "sessions"),
+		LogFile:          filepath.Join(dir, "logaid.log"),
+		ExecAuditLogFile: filepath.Join(dir, "exec.jsonl"),
+	})
+	now := time.Now()
+	old := now.AddDate(0, 0, -40)
+
+	store := history.New(config.Current().HistoryFile, 0)
+	for _, entry := range []history.Entry{
+		{Time: old, Command: "apt install foo", Suggestion: "apt install fio"},
+		{Time: now, Command: "git pul", Suggestion: "git pull"},
+	} {
+		if _, err := store.Add(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(config.Current().SessionsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	oldSession := old.Format("20060102-150405") + "-apt.json"
+	newSession := now.Format("20060102-150405") + "-git.json"
+	for name, modified := range map[string]time.Time{oldSession: old, newSession: now, "notes.json": old} {
+		path := filepath.Join(config.Current().SessionsDir, name)
+		if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+	logLines := old.Format("2006/01/02 15:04:05") + " [INFO] old message\n" +
+		now.Format("2006/01/02 15:04:05") + " [INFO] new message\n"
+	for _, path := range []string{config.Current().LogFile, config.Current().ExecAuditLogFile} {
+		if err := os.WriteFile(path, []byte(logLines), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := privacy.Retain(now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("Retain() error = %v", err)
+	}
+	if removed[privacy.History] != 1 || removed[privacy.Sessions] != 1 {
+		t.Errorf("Retain() removed %v, want one history entry and one session", removed)
+	}
+	if entries, _ := store.List(0); len(entries) != 1 || entries[0].Command != "git pul" {
+		t.Errorf("history after Retain() = %+v", entries)
+	}
+	if _, err := os.Stat(filepath.Join(config.Current().SessionsDir, newSession)); err != nil {
+		t.Errorf("recent session removed: %v", err)
+	}
+	data, _ := os.ReadFile(config.Current().LogFile)
+	if strings.Contains(string(data), "old message") || !strings.Contains(string(data), "new message") {
+		t.Errorf("log after Retain() = %q", data)
+	}
+	if data, _ := os.ReadFile(config.Current().ExecAuditLogFile); string(data) != logLines {
+		t.Error("Retain() changed the exec audit log")
+	}
+
+	targets, err := privacy.Purge(false)
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	for _, target := range targets {
+		if target.Name == privacy.ExecAudit {
+			t.Error("Purge(false) included the exec audit log")
+		}
+	}
+	for _, path := range []string{config.Current().HistoryFile, filepath.Join(config.Current().SessionsDir, newSession)} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after Purge()", path)
+		}
+	}
+	// Files LogAid did not write are kept, even in SESSIONS_DIR
+	if _, err := os.Stat(filepath.Join(config.Current().SessionsDir, "notes.json")); err != nil {
+		t.Errorf("Purge() removed a file it did not write: %v", err)
+	}
+	if data, _ := os.ReadFile(config.Current().LogFile); len(data) != 0 {
+		t.Errorf("log after Purge() = %q, want it emptied", data)
+	}
+	if _, err := os.Stat(config.Current().ExecAuditLogFile); err != nil {
+		t.Errorf("Purge(false) removed the exec audit log: %v", err)
+	}
+
+	if _, err := privacy.Purge(true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(config.Current().ExecAuditLogFile); !os.IsNotExist(err) {
+		t.Error("Purge(true) kept the exec audit log")
+	}
+}
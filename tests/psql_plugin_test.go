@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestPsqlPlugin tests the PostgreSQL client plugin
+func TestPsqlPlugin(t *testing.T) {
+	plugin := &plugins.PsqlPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "client not installed",
+			command:     "psql -h db.internal -U app",
+			output:      "bash: psql: command not found",
+			shouldMatch: true,
+			expectedFix: "sudo apt install postgresql-client && psql -h db.internal -U app",
+		},
+		{
+			name:        "missing role",
+			command:     "psql",
+			output:      `psql: error: connection to server on socket "/var/run/postgresql/.s.PGSQL.5432" failed: FATAL:  role "alice" does not exist`,
+			shouldMatch: true,
+			expectedFix: "sudo -u postgres createuser --createdb alice && psql",
+		},
+		{
+			name:        "missing database",
+			command:     "psql -h localhost -U app shop",
+			output:      `psql: error: connection to server at "localhost" (127.0.0.1), port 5432 failed: FATAL:  database "shop" does not exist`,
+			shouldMatch: true,
+			expectedFix: "createdb -h localhost -U app shop && psql -h localhost -U app shop",
+		},
+		{
+			name:        "peer authentication",
+			command:     "psql -U app appdb",
+			output:      `psql: error: connection to server on socket "/var/run/postgresql/.s.PGSQL.5432" failed: FATAL:  Peer authentication failed for user "app"`,
+			shouldMatch: true,
+			expectedFix: "psql -h localhost -U app appdb",
+		},
+		{
+			name:        "password authentication",
+			command:     "psql -h localhost -U app appdb",
+			output:      `psql: error: connection to server at "localhost" (127.0.0.1), port 5432 failed: FATAL:  password authentication failed for user "app"`,
+			shouldMatch: true,
+			expectedFix: `sudo -u postgres psql -c "\password app"`,
+		},
+		{
+			name:        "local server not running",
+			command:     "pg_dump shop",
+			output:      "pg_dump: error: connection to server on socket \"/var/run/postgresql/.s.PGSQL.5432\" failed: No such file or directory\n\tIs the server running locally and accepting connections on that socket?",
+			shouldMatch: true,
+			expectedFix: "sudo systemctl start postgresql && pg_dump shop",
+		},
+		{
+			name:        "remote server refused",
+			command:     "psql -h 10.0.0.7 -p 5433 shop",
+			output:      `psql: error: connection to server at "10.0.0.7", port 5433 failed: Connection refused`,
+			shouldMatch: true,
+			expectedFix: "pg_isready -h 10.0.0.7 -p 5433",
+		},
+		{
+			name:        "query error",
+			command:     "psql -c 'select 1'",
+			output:      "ERROR:  syntax error at or near \"selec\"",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
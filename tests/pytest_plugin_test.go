@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestPytestPlugin tests the pytest plugin against a sample project
+func TestPytestPlugin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pytest.ini"), []byte("[pytest]\nmarkers =\n    integration\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	plugin := &plugins.PytestPlugin{Dir: dir}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "plugin fixture",
+			command:     "pytest tests/",
+			output:      "file /src/tests/test_api.py, line 8\n  def test_call(mocker):\nE       fixture 'mocker' not found\n>       available fixtures: cache, capfd, capsys, monkeypatch, tmp_path",
+			shouldMatch: true,
+			expectedFix: "pip install pytest-mock && pytest tests/",
+		},
+		{
+			name:        "fixture typo",
+			command:     "pytest",
+			output:      "file /src/tests/test_io.py, line 12\n  def test_write(tmp_pth):\nE       fixture 'tmp_pth' not found\n>       available fixtures: cache, capfd, capsys, monkeypatch, tmp_path, tmp_path_factory",
+			shouldMatch: true,
+			expectedFix: `sed -i '12s/\btmp_pth\b/tmp_path/' /src/tests/test_io.py && pytest`,
+		},
+		{
+			name:        "local package not importable",
+			command:     "pytest -x",
+			output:      "ImportError while loading conftest '/src/tests/conftest.py'.\ntests/conftest.py:3: in <module>\n    from app.main import create_app\nE   ModuleNotFoundError: No module named 'app'",
+			shouldMatch: true,
+			expectedFix: "python -m pytest -x",
+		},
+		{
+			name:        "third-party module missing",
+			command:     "pytest",
+			output:      "ImportError while importing test module '/src/tests/test_cfg.py'.\nE   ModuleNotFoundError: No module named 'yaml'",
+			shouldMatch: true,
+			expectedFix: "pip install pyyaml && pytest",
+		},
+		{
+			name:        "unregistered mark",
+			command:     "pytest --strict-markers",
+			output:      "'slow' not found in `markers` configuration option",
+			shouldMatch: true,
+			expectedFix: `sed -i '/^markers\s*=/a\    slow' pytest.ini && pytest --strict-markers`,
+		},
+		{
+			name:        "mark typo",
+			command:     "pytest",
+			output:      "tests/test_math.py:10: PytestUnknownMarkWarning: Unknown pytest.mark.parametrise - is this a typo?",
+			shouldMatch: true,
+			expectedFix: `sed -i '10s/mark\.parametrise/mark.parametrize/' tests/test_math.py && pytest`,
+		},
+		{
+			name:        "pytest not installed",
+			command:     "python3 -m pytest",
+			output:      "/usr/bin/python3: No module named pytest",
+			shouldMatch: true,
+			expectedFix: "pip install pytest && python3 -m pytest",
+		},
+		{
+			name:        "assertion failure",
+			command:     "pytest",
+			output:      "E       assert 2 == 3\nFAILED tests/test_math.py::test_add - assert 2 == 3",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
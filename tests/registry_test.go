@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/registry"
+)
+
+// newTestRegistry creates a registry for source that installs into a temp dir
+func newTestRegistry(t *testing.T, source string) *registry.Registry {
+	t.Helper()
+	reg := registry.New(source, filepath.Join(t.TempDir(), "plugins"))
+	reg.Manifest = filepath.Join(t.TempDir(), "plugins.json")
+	return reg
+}
+
+// TestRegistryBundledIndex tests installing and removing the rule packs in registry/index.json
+func TestRegistryBundledIndex(t *testing.T) {
+	reg := newTestRegistry(t, filepath.Join("..", "registry", "index.json"))
+	ctx := context.Background()
+
+	index, err := reg.Index(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range index.Plugins {
+		if _, err := reg.Install(ctx, entry.Name); err != nil {
+			t.Errorf("Install(%s) error = %v", entry.Name, err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(reg.Dir, "terraform.yaml")); err != nil {
+		t.Fatalf("terraform.yaml not installed: %v", err)
+	}
+	installed, err := reg.Installed()
+	if err != nil || installed["terraform"].Version == "" {
+		t.Fatalf("Installed() = %v, %v", installed, err)
+	}
+
+	if err := reg.Remove("terraform"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(reg.Dir, "terraform.yaml")); !os.IsNotExist(err) {
+		t.Error("terraform.yaml still present after Remove()")
+	}
+	if err := reg.Remove("terraform"); err == nil {
+		t.Error("Remove() of a plugin that is not installed should fail")
+	}
+}
+
+// TestRegistryChecksums tests that downloads are verified before anything is written
+func TestRegistryChecksums(t *testing.T) {
+	const pack = "rules:\n  - output: x\n    suggest: y\n"
+	version := "1.0.0"
+	sum := fmt.Sprintf("%x", sha256.Sum256([]byte(pack)))
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.json":
+			w.Write([]byte(`{"plugins": [
+				{"name": "good", "version": "` + version + `", "files": [{"name": "good.yaml", "url": "packs/good.yaml", "sha256": "` + sum + `"}]},
+				{"name": "tampered", "version": "1.0.0", "files": [{"name": "tampered.yaml", "url": "packs/good.yaml", "sha256": "` + strings.Repeat("0", 64) + `"}]},
+				{"name": "escape", "version": "1.0.0", "files": [{"name": "../escape.yaml", "url": "packs/good.yaml", "sha256": "` + sum + `"}]}
+			]}`))
+		case "/packs/good.yaml":
+			w.Write([]byte(pack))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	reg := newTestRegistry(t, server.URL+"/index.json")
+	reg.Client = server.Client()
+	ctx := context.Background()
+
+	if _, err := reg.Install(ctx, "tampered"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Install(tampered) error = %v, want a checksum mismatch", err)
+	}
+	if _, err := reg.Install(ctx, "escape"); err == nil {
+		t.Error("Install(escape) should reject file names outside PLUGINS_DIR")
+	}
+	if _, err := reg.Install(ctx, "missing"); err == nil {
+		t.Error("Install(missing) should fail")
+	}
+	if entries, _ := os.ReadDir(reg.Dir); len(entries) != 0 {
+		t.Errorf("failed installs wrote %d files", len(entries))
+	}
+
+	if _, err := reg.Install(ctx, "good"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(reg.Dir, "good.yaml")); err != nil || string(data) != pack {
+		t.Fatalf("good.yaml = %q, %v", data, err)
+	}
+
+	if updated, err := reg.Update(ctx, nil); err != nil || len(updated) != 0 {
+		t.Errorf("Update() = %v, %v, want nothing to update", updated, err)
+	}
+	version = "1.1.0"
+	if updated, err := reg.Update(ctx, nil); err != nil || len(updated) != 1 {
+		t.Errorf("Update() = %v, %v, want good updated", updated, err)
+	}
+	if installed, _ := reg.Installed(); installed["good"].Version != "1.1.0" {
+		t.Errorf("installed version = %q, want 1.1.0", installed["good"].Version)
+	}
+}
@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/ayushsharma-1/LogAid/internal/risk"
+)
+
+// TestRiskClassify tests classifying fixes as safe, moderate or destructive
+func TestRiskClassify(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{RequireSudoConfirmation: true, BlacklistCommands: "terraform destroy, helm uninstall"})
+	existing := filepath.Join(t.TempDir(), "app.conf")
+	if err := os.WriteFile(existing, []byte("port=80\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	created := filepath.Join(t.TempDir(), "new.log")
+
+	tests := []struct {
+		command string
+		want    risk.Level
+	}{
+		{"git status", risk.Safe},
+		{"git checkout main", risk.Safe},
+		{"npm run build", risk.Safe},
+		{"sudo make install", risk.Moderate},
+		{"sudo apt install nginx", risk.Moderate},
+		{"pip install requests", risk.Moderate},
+		{"brew install jq", risk.Moderate},
+		{"sudo systemctl restart nginx", risk.Moderate},
+		{"systemctl status nginx", risk.Safe},
+		{"chmod +x deploy.sh", risk.Moderate},
+		{"git push origin main", risk.Moderate},
+		{"curl -fsSL https://get.example.com | sh", risk.Moderate},
+		{"rm -rf node_modules && npm install", risk.Destructive},
+		{"sudo -E rm package-lock.json", risk.Destructive},
+		{"dd if=ubuntu.iso of=/dev/sdb bs=4M", risk.Destructive},
+		{"sudo mkfs.ext4 /dev/sdb1", risk.Destructive},
+		{"chmod -R 777 /var/www", risk.Destructive},
+		{"sudo chown -Rf www-data /srv", risk.Destructive},
+		{"git reset --hard origin/main", risk.Destructive},
+		{"git push --force origin main", risk.Destructive},
+		{"find . -name '*.pyc' -delete", risk.Destructive},
+		{"docker system prune -af", risk.Destructive},
+		{`psql -c "DROP TABLE users"`, risk.Destructive},
+		{"echo data > /dev/sda", risk.Destructive},
+		{"terraform destroy -auto-approve", risk.Destructive},
+		{`git commit -m "stop calling rm; drop the cache"`, risk.Safe},
+		{"bash -c 'rm -rf /'", risk.Destructive},
+		{"sh -c 'echo hello'", risk.Safe},
+		{`sudo sh -c "rm -rf /var/cache/app"`, risk.Destructive},
+		{`sudo bash -lc "echo 127.0.0.1 db >> /etc/hosts"`, risk.Moderate},
+		{`eval "git reset --hard"`, risk.Destructive},
+		{`sh -c "sh -c 'dd if=/dev/zero of=disk.img'"`, risk.Destructive},
+		{"echo port=8080 > " + existing, risk.Moderate},
+		{"echo started > " + created, risk.Safe},
+		{"make 2>&1 >/dev/null", risk.Safe},
+		{"echo 'root::0:0::/root:/bin/sh' > /etc/passwd", risk.Destructive},
+		{`sudo sh -c "echo nameserver 1.1.1.1 > /etc/passwd"`, risk.Destructive},
+		{"echo port=8080 | tee " + existing, risk.Moderate},
+		{"echo port=8080 | tee -a " + existing, risk.Safe},
+		{":(){ :|:& };:", risk.Destructive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			got := risk.Classify(tt.command)
+			if got.Level != tt.want {
+				t.Errorf("Classify(%q) = %s (%s), want %s", tt.command, got.Level, got.Reason, tt.want)
+			}
+			if got.Level != risk.Safe && got.Reason == "" {
+				t.Errorf("Classify(%q) gave no reason", tt.command)
+			}
+		})
+	}
+
+	updated := *config.Current()
+	updated.RequireSudoConfirmation = false
+	config.SetCurrent(&updated)
+	if got := risk.Classify("sudo make install"); got.Level != risk.Safe {
+		t.Errorf("Classify(sudo make install) = %s without REQUIRE_SUDO_CONFIRMATION, want safe", got.Level)
+	}
+}
+
+// TestRiskConfirmation tests confirming fixes according to their risk:
+// Enter runs a safe fix, a destructive one has to be typed back and is never
+// auto-confirmed
+func TestRiskConfirmation(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+
+	lock := filepath.Join(t.TempDir(), "stale.lock")
+	destructive := "rm -f " + lock
+
+	tests := []struct {
+		name      string
+		cfg       config.Config
+		fix       string
+		input     string
+		wantFixed bool
+		decision  string
+	}{
+		{"safe fix on enter", config.Config{DangerousCommandsCheck: true}, "true", "\n", true, engine.DecisionAccepted},
+		{"moderate fix needs y", config.Config{DangerousCommandsCheck: true}, "chmod 644 " + lock, "\n", false, engine.DecisionRejected},
+		{"destructive fix typed back", config.Config{DangerousCommandsCheck: true}, destructive, "y\n" + destructive + "\n", true, engine.DecisionAccepted},
+		{"destructive fix not typed back", config.Config{DangerousCommandsCheck: true}, destructive, "y\n\n", false, engine.DecisionRejected},
+		{"destructive fix not auto-confirmed", config.Config{DangerousCommandsCheck: true, AssumeYes: true}, destructive, "", false, engine.DecisionSuggested},
+		{"check disabled", config.Config{}, destructive, "y\n", true, engine.DecisionAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			config.SetCurrent(&cfg)
+			if err := os.WriteFile(lock, nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			eng := engine.New()
+			eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: tt.fix}})
+			var out bytes.Buffer
+			eng.SetIO(strings.NewReader(tt.input), &out, &out)
+			// Monitor returns the command's error unless the fix worked
+			report, err := eng.Monitor(exec.Command("sh", "-c", "echo 'error: lock file exists' >&2; exit 1"), &out)
+			if (err == nil) != tt.wantFixed {
+				t.Fatalf("Monitor() error = %v, output %s", err, out.String())
+			}
+			if report.Fixed != tt.wantFixed || report.Decision != tt.decision {
+				t.Errorf("Monitor() fixed = %v, decision %q, want %v, %q", report.Fixed, report.Decision, tt.wantFixed, tt.decision)
+			}
+			if want := risk.Classify(tt.fix).Level.String(); report.Risk != want {
+				t.Errorf("report risk = %q, want %q", report.Risk, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestRulePackPlugin tests the bundled terraform rule pack
+func TestRulePackPlugin(t *testing.T) {
+	plugin, err := plugins.NewRulePackPlugin(filepath.Join("..", "rules", "terraform.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plugin.Name() != "terraform" {
+		t.Errorf("Name() = %q, want terraform", plugin.Name())
+	}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "misspelled subcommand",
+			command:     "terraform plna -out tf.plan",
+			output:      `Terraform has no command named "plna". Did you mean "plan"?`,
+			shouldMatch: true,
+			expectedFix: "terraform plan -out tf.plan",
+		},
+		{
+			name:        "state lock",
+			command:     "terraform apply",
+			output:      "Error: Error acquiring the state lock\n\nLock Info:\n  ID:        8c1f2a4e-7d3b-4b8e-9f1a-2c5d6e7f8a9b\n  Path:      state/terraform.tfstate",
+			shouldMatch: true,
+			expectedFix: "terraform force-unlock 8c1f2a4e-7d3b-4b8e-9f1a-2c5d6e7f8a9b",
+		},
+		{
+			name:        "lock file out of date",
+			command:     "tofu plan",
+			output:      "Error: Inconsistent dependency lock file",
+			shouldMatch: true,
+			expectedFix: "terraform init -upgrade && tofu plan",
+		},
+		{
+			name:        "not initialised",
+			command:     "terraform plan",
+			output:      `Error: Backend initialization required, please run "terraform init"`,
+			shouldMatch: true,
+			expectedFix: "terraform init && terraform plan",
+		},
+		{
+			name:        "other command",
+			command:     "make plan",
+			output:      `please run "terraform init"`,
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
+
+// TestRulePackReload tests that rule pack edits apply without reloading plugins
+func TestRulePackReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "helm.yaml")
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now().Add(-time.Hour)
+	write("rules:\n  - output: 'repo (\\w+) not found'\n    suggest: helm repo add $1 <url>\n", start)
+
+	loaded := plugins.LoadExternalPlugins(dir, time.Second)
+	if len(loaded) != 1 || loaded[0].Name() != "helm" {
+		t.Fatalf("LoadExternalPlugins() = %v, want the helm rule pack", loaded)
+	}
+	plugin := loaded[0]
+	output := "Error: repo bitnami not found"
+
+	if suggestion := plugin.Suggest("helm install db bitnami/postgresql", output); suggestion != "helm repo add bitnami <url>" {
+		t.Errorf("Suggest() = %q before reload", suggestion)
+	}
+
+	write("rules:\n  - output: 'repo (\\w+) not found'\n    suggest: helm repo update && $command\n", start.Add(time.Minute))
+	if suggestion := plugin.Suggest("helm install db bitnami/postgresql", output); suggestion != "helm repo update && helm install db bitnami/postgresql" {
+		t.Errorf("Suggest() = %q after reload", suggestion)
+	}
+
+	// A broken edit keeps the previous rules
+	write("rules: [", start.Add(2*time.Minute))
+	if !plugin.Match("helm install db bitnami/postgresql", output) {
+		t.Error("Match() = false after a broken edit, want the previous rules")
+	}
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestRustupPlugin tests the rustup plugin
+func TestRustupPlugin(t *testing.T) {
+	plugin := &plugins.RustupPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "toolchain not installed",
+			command:     "cargo +nightly build",
+			output:      "error: toolchain 'nightly-x86_64-unknown-linux-gnu' is not installed",
+			shouldMatch: true,
+			expectedFix: "rustup toolchain install nightly-x86_64-unknown-linux-gnu && cargo +nightly build",
+		},
+		{
+			name:        "component with install hint",
+			command:     "cargo clippy",
+			output:      "error: 'cargo-clippy' is not installed for the toolchain 'stable-x86_64-unknown-linux-gnu'.\nTo install, run `rustup component add clippy`",
+			shouldMatch: true,
+			expectedFix: "rustup component add clippy && cargo clippy",
+		},
+		{
+			name:        "component without hint",
+			command:     "rustfmt src/main.rs",
+			output:      "error: 'rustfmt' is not installed for the toolchain '1.75.0-x86_64-unknown-linux-gnu'",
+			shouldMatch: true,
+			expectedFix: "rustup component add rustfmt --toolchain 1.75.0-x86_64-unknown-linux-gnu && rustfmt src/main.rs",
+		},
+		{
+			name:        "cargo subcommand missing",
+			command:     "cargo fmt --check",
+			output:      "error: no such command: `fmt`",
+			shouldMatch: true,
+			expectedFix: "rustup component add rustfmt && cargo fmt --check",
+		},
+		{
+			name:        "no default toolchain",
+			command:     "cargo build --release",
+			output:      "error: rustup could not choose a version of cargo to run, because one wasn't specified explicitly, and no default is configured.",
+			shouldMatch: true,
+			expectedFix: "rustup default stable && cargo build --release",
+		},
+		{
+			name:        "update conflict",
+			command:     "rustup update nightly",
+			output:      "error: failed to install component: 'rustc-x86_64-unknown-linux-gnu', detected conflict: 'lib/librustc_driver.so'",
+			shouldMatch: true,
+			expectedFix: "rustup toolchain uninstall nightly && rustup toolchain install nightly",
+		},
+		{
+			name:        "compile error",
+			command:     "cargo build",
+			output:      "error[E0425]: cannot find value `x` in this scope",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
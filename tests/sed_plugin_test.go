@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestSedPlugin tests the sed/awk plugin
+func TestSedPlugin(t *testing.T) {
+	plugin := &plugins.SedPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "unterminated s command",
+			command:     "sed 's/foo/bar' input.txt",
+			output:      "sed: -e expression #1, char 9: unterminated `s' command",
+			shouldMatch: true,
+			expectedFix: "sed 's/foo/bar/' input.txt",
+		},
+		{
+			name:        "unescaped slashes in paths",
+			command:     "sed -i 's//usr/local//opt/g' config",
+			output:      "sed: -e expression #1, char 19: unknown option to `s'",
+			shouldMatch: true,
+			expectedFix: "sed -i 's|/usr/local|/opt|g' config",
+		},
+		{
+			name:        "GNU sed given BSD in-place flag",
+			command:     "sed -i '' 's/a/b/' file.txt",
+			output:      "sed: can't read s/a/b/: No such file or directory",
+			shouldMatch: true,
+			expectedFix: "sed -i 's/a/b/' file.txt",
+		},
+		{
+			name:        "BSD sed given GNU in-place flag",
+			command:     "sed -i 's/a/b/' notes.txt",
+			output:      `sed: 1: "notes.txt": invalid command code n`,
+			shouldMatch: true,
+			expectedFix: "sed -i '' 's/a/b/' notes.txt",
+		},
+		{
+			name:        "awk action without braces",
+			command:     "awk -F':' 'print $1' /etc/passwd",
+			output:      "awk: cmd. line:1: print $1\nawk: cmd. line:1: ^ syntax error",
+			shouldMatch: true,
+			expectedFix: "awk -F':' '{print $1}' /etc/passwd",
+		},
+		{
+			name:        "awk program in double quotes",
+			command:     `awk "{sum+=$3} END {print sum}" sales.csv`,
+			output:      "awk: cmd. line:1: {sum+=} END {print sum}\nawk: cmd. line:1:        ^ syntax error",
+			shouldMatch: true,
+			expectedFix: "awk '{sum+=$3} END {print sum}' sales.csv",
+		},
+		{
+			name:        "not sed",
+			command:     "grep foo bar.txt",
+			output:      "grep: bar.txt: syntax error",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
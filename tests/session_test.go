@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/ayushsharma-1/LogAid/internal/session"
+)
+
+// TestSessionRecording tests recording a monitored command and replaying it
+func TestSessionRecording(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{AssumeYes: true})
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "true"}})
+	recorder := session.NewRecorder("sh -c fail")
+	eng.SetRecorder(recorder)
+	if _, err := eng.Monitor(exec.Command("sh", "-c", "echo 'error: boom' >&2; exit 3"), io.Discard); err != nil {
+		t.Fatalf("Monitor() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	path, err := recorder.Save(dir)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	s, err := session.Load(dir, recorder.Session().ID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if s.Command != "sh -c fail" || !strings.HasSuffix(path, s.ID+".json") {
+		t.Errorf("session %q saved to %s", s.ID, path)
+	}
+
+	// The steps LogAid took, in order, between the output
+	var steps []string
+	var stderr string
+	for _, event := range s.Events {
+		if event.Type == session.EventOutput {
+			if event.Stream == session.StreamStderr {
+				stderr += event.Data
+			}
+			continue
+		}
+		steps = append(steps, event.Describe())
+	}
+	want := []string{
+		"command exited with status 3",
+		"error detected",
+		"suggestion from stub: true",
+		"decision: auto-confirmed",
+		"fix exited with status 0",
+	}
+	if strings.Join(steps, "\n") != strings.Join(want, "\n") {
+		t.Errorf("steps = %q, want %q", steps, want)
+	}
+	if stderr != "error: boom\n" {
+		t.Errorf("recorded stderr = %q", stderr)
+	}
+	for i := 1; i < len(s.Events); i++ {
+		if s.Events[i].Time < s.Events[i-1].Time {
+			t.Errorf("event %d at %.3fs is before the previous one", i, s.Events[i].Time)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		stopAt  int // quit at this step, 0 to play everything
+		want    string
+		notWant string
+	}{
+		{"play", 0, "fix exited with status 0", ""},
+		{"quit at the second step", 2, "error detected", "suggestion from stub"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			player := &session.Player{Out: &out}
+			stop := errors.New("stop")
+			if tt.stopAt > 0 {
+				n := 0
+				player.Step = func(session.Event) error {
+					if n++; n == tt.stopAt {
+						return stop
+					}
+					return nil
+				}
+			}
+			err := player.Play(context.Background(), s)
+			if tt.stopAt > 0 && !errors.Is(err, stop) || tt.stopAt == 0 && err != nil {
+				t.Fatalf("Play() error = %v", err)
+			}
+			if !strings.Contains(out.String(), "error: boom") || !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output = %q, want the command's output and %q", out.String(), tt.want)
+			}
+			if tt.notWant != "" && strings.Contains(out.String(), tt.notWant) {
+				t.Errorf("output = %q, want it to stop before %q", out.String(), tt.notWant)
+			}
+		})
+	}
+
+	sessions, err := session.List(dir)
+	if err != nil || len(sessions) != 1 || sessions[0].ID != s.ID {
+		t.Errorf("List() = %v, %v, want the saved session", sessions, err)
+	}
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestShellPlugin tests the shell syntax and builtin error plugin
+func TestShellPlugin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "setup.sh"), []byte("echo setting up\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	plugin := &plugins.ShellPlugin{Dir: dir}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "unquoted parentheses",
+			command:     "git commit -m fix(parser): handle tabs",
+			output:      "bash: syntax error near unexpected token `('",
+			shouldMatch: true,
+			expectedFix: "git commit -m 'fix(parser): handle tabs'",
+		},
+		{
+			name:        "placeholder in angle brackets",
+			command:     "ssh-keygen -f <file>",
+			output:      "bash: syntax error near unexpected token `newline'",
+			shouldMatch: true,
+			expectedFix: "ssh-keygen -f '<file>'",
+		},
+		{
+			name:        "bash syntax under sh",
+			command:     "sh build.sh",
+			output:      "build.sh: 4: Bad substitution",
+			shouldMatch: true,
+			expectedFix: "bash build.sh",
+		},
+		{
+			name:        "windows line endings",
+			command:     "./deploy.sh",
+			output:      "bash: ./deploy.sh: /bin/bash^M: bad interpreter: No such file or directory",
+			shouldMatch: true,
+			expectedFix: `sed -i 's/\r$//' ./deploy.sh && ./deploy.sh`,
+		},
+		{
+			name:        "missing interpreter",
+			command:     "./manage.py migrate",
+			output:      "bash: ./manage.py: /usr/bin/python: bad interpreter: No such file or directory",
+			shouldMatch: true,
+			expectedFix: "sed -i '1s|^#!.*|#!/usr/bin/env python3|' ./manage.py && ./manage.py migrate",
+		},
+		{
+			name:        "script without shebang",
+			command:     "./setup.sh",
+			output:      "exec: Failed to execute process './setup.sh': Exec format error",
+			shouldMatch: true,
+			expectedFix: "sed -i '1i #!/usr/bin/env bash' ./setup.sh && ./setup.sh",
+		},
+		{
+			name:        "too many files for rm",
+			command:     "rm -f logs/*.log",
+			output:      "bash: /usr/bin/rm: Argument list too long",
+			shouldMatch: true,
+			expectedFix: "find logs -maxdepth 1 -name '*.log' -print0 | xargs -0 rm -f",
+		},
+		{
+			name:        "too many files for cp",
+			command:     "cp *.jpg backup/",
+			output:      "bash: /usr/bin/cp: Argument list too long",
+			shouldMatch: true,
+			expectedFix: "find . -maxdepth 1 -name '*.jpg' -print0 | xargs -0 cp -t backup/",
+		},
+		{
+			name:        "unrelated error",
+			command:     "ls /nope",
+			output:      "ls: cannot access '/nope': No such file or directory",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
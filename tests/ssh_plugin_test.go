@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestSSHPlugin tests the SSH plugin
+func TestSSHPlugin(t *testing.T) {
+	sshConfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(sshConfig, []byte("Host staging-web\n  HostName 10.0.0.7\n  User deploy\n\nHost *\n  AddKeysToAgent yes\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	plugin := &plugins.SSHPlugin{ConfigPath: sshConfig}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "password auth available",
+			command:     "ssh deploy@10.0.0.5",
+			output:      "deploy@10.0.0.5: Permission denied (publickey,password).",
+			shouldMatch: true,
+			expectedFix: "ssh-copy-id deploy@10.0.0.5 && ssh deploy@10.0.0.5",
+		},
+		{
+			name:        "password auth on custom port",
+			command:     "ssh -p 2222 -i ~/.ssh/work deploy@10.0.0.5",
+			output:      "deploy@10.0.0.5: Permission denied (publickey,password).",
+			shouldMatch: true,
+			expectedFix: "ssh-copy-id -p 2222 deploy@10.0.0.5 && ssh -p 2222 -i ~/.ssh/work deploy@10.0.0.5",
+		},
+		{
+			name:        "changed host key",
+			command:     "ssh web1",
+			output:      "@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@\n@    WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED!     @\n@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@\nOffending ECDSA key in /home/user/.ssh/known_hosts:12\nHost key for web1 has changed and you have requested strict checking.\nHost key verification failed.",
+			shouldMatch: true,
+			expectedFix: "ssh-keygen -R web1 && ssh web1",
+		},
+		{
+			name:        "changed host key on custom port",
+			command:     "scp -P 2222 build.tar.gz deploy@10.0.0.5:/tmp/",
+			output:      "WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED!\nHost key verification failed.\nlost connection",
+			shouldMatch: true,
+			expectedFix: `ssh-keygen -R "[10.0.0.5]:2222" && scp -P 2222 build.tar.gz deploy@10.0.0.5:/tmp/`,
+		},
+		{
+			name:        "unknown host in batch mode",
+			command:     "ssh -o BatchMode=yes git@example.com",
+			output:      "No ED25519 host key is known for example.com and you have requested strict checking.\nHost key verification failed.",
+			shouldMatch: true,
+			expectedFix: "ssh -o StrictHostKeyChecking=accept-new -o BatchMode=yes git@example.com",
+		},
+		{
+			name:        "key too open",
+			command:     "ssh -i ~/.ssh/aws.pem ec2-user@1.2.3.4",
+			output:      "@         WARNING: UNPROTECTED PRIVATE KEY FILE!          @\nPermissions 0644 for '/home/user/.ssh/aws.pem' are too open.\nec2-user@1.2.3.4: Permission denied (publickey).",
+			shouldMatch: true,
+			expectedFix: "chmod 600 /home/user/.ssh/aws.pem && ssh -i ~/.ssh/aws.pem ec2-user@1.2.3.4",
+		},
+		{
+			name:        "config typo",
+			command:     "ssh staging-web",
+			output:      "/home/user/.ssh/config: line 4: Bad configuration option: identifyfile\n/home/user/.ssh/config: terminating, 1 bad configuration options",
+			shouldMatch: true,
+			expectedFix: "sed -i '4s/identifyfile/IdentityFile/I' /home/user/.ssh/config && ssh staging-web",
+		},
+		{
+			name:        "host alias typo",
+			command:     "ssh stagingweb",
+			output:      "ssh: Could not resolve hostname stagingweb: Name or service not known",
+			shouldMatch: true,
+			expectedFix: "ssh staging-web",
+		},
+		{
+			name:        "connection refused",
+			command:     "ssh -p 2200 admin@db.internal",
+			output:      "ssh: connect to host db.internal port 2200: Connection refused",
+			shouldMatch: true,
+			expectedFix: "nc -zv db.internal 2200 # check whether sshd is running and listening on this port",
+		},
+		{
+			name:        "connection timed out",
+			command:     "ssh admin@db.internal",
+			output:      "ssh: connect to host db.internal port 22: Connection timed out",
+			shouldMatch: true,
+			expectedFix: "ping -c 3 db.internal && nc -zv -w 5 db.internal 22",
+		},
+		{
+			name:        "remote permission error",
+			command:     "ls /root",
+			output:      "ls: cannot open directory '/root': Permission denied",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
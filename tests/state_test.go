@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/state"
+)
+
+// TestStateConcurrentUpdates tests that locked updates from concurrent writers are not lost
+func TestStateConcurrentUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counter.json")
+
+	const workers = 10
+	const iterations = 10
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				var counter struct{ Count int }
+				err := state.UpdateJSON(path, &counter, func() error {
+					counter.Count++
+					return nil
+				})
+				if err != nil {
+					t.Errorf("UpdateJSON() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var counter struct{ Count int }
+	if err := state.ReadJSON(path, &counter); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if counter.Count != workers*iterations {
+		t.Errorf("Count = %d, want %d", counter.Count, workers*iterations)
+	}
+}
+
+// TestStateAtomicWriteAndAppend tests atomic replacement and append-only writes
+func TestStateAtomicWriteAndAppend(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "data.txt")
+	if err := state.WriteFileAtomic(path, []byte("first"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+	if err := state.WriteFileAtomic(path, []byte("second"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "second" {
+		t.Errorf("content = %q, want %q", content, "second")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("temporary file left behind: %s", entry.Name())
+		}
+	}
+
+	audit := filepath.Join(dir, "audit.log")
+	for _, line := range []string{"one", "two\n", "three"} {
+		if err := state.AppendLine(audit, []byte(line)); err != nil {
+			t.Fatalf("AppendLine() error = %v", err)
+		}
+	}
+	content, _ = os.ReadFile(audit)
+	if string(content) != "one\ntwo\nthree\n" {
+		t.Errorf("audit content = %q", content)
+	}
+}
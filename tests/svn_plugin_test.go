@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestSvnPlugin tests the Subversion plugin
+func TestSvnPlugin(t *testing.T) {
+	plugin := &plugins.SvnPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "locked working copy",
+			command:     "svn update",
+			output:      "svn: E155037: Previous operation has not finished; run 'svn cleanup' if it was interrupted",
+			shouldMatch: true,
+			expectedFix: "svn cleanup && svn update",
+		},
+		{
+			name:        "not a working copy",
+			command:     "svn status",
+			output:      "svn: warning: W155007: '/home/dev/project' is not a working copy",
+			shouldMatch: true,
+			expectedFix: "svn checkout <repository-url> /home/dev/project",
+		},
+		{
+			name:        "commit with conflict",
+			command:     "svn commit -m 'fix build'",
+			output:      "svn: E155015: Commit failed (details follow):\nsvn: E155015: Aborting commit: '/home/dev/project/src/app.c' remains in conflict",
+			shouldMatch: true,
+			expectedFix: "svn resolve --accept working /home/dev/project/src/app.c && svn commit -m 'fix build'",
+		},
+		{
+			name:        "tree conflict on update",
+			command:     "svn update",
+			output:      "Updating '.':\n   C docs/old.md\nAt revision 1042.\nSummary of conflicts:\n  Tree conflicts: 1",
+			shouldMatch: true,
+			expectedFix: "svn resolve --accept working docs/old.md",
+		},
+		{
+			name:        "stale credentials",
+			command:     "svn checkout https://svn.example.com/repos/app/trunk app",
+			output:      "svn: E170001: Authentication failed",
+			shouldMatch: true,
+			expectedFix: "svn auth --remove svn.example.com && svn checkout https://svn.example.com/repos/app/trunk app",
+		},
+		{
+			name:        "old working copy format",
+			command:     "svn log",
+			output:      "svn: E155036: Please see the 'svn upgrade' command\nsvn: E155036: The working copy at '/srv/app' is too old (format 29) to work with client version '1.14.2'",
+			shouldMatch: true,
+			expectedFix: "svn upgrade && svn log",
+		},
+		{
+			name:        "svn not installed",
+			command:     "svn info",
+			output:      "zsh: command not found: svn",
+			shouldMatch: true,
+			expectedFix: "sudo apt install subversion && svn info",
+		},
+		{
+			name:        "clean update",
+			command:     "svn update",
+			output:      "Updating '.':\nAt revision 1042.",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+	"github.com/ayushsharma-1/LogAid/internal/config"
+)
+
+// TestSystemPrompt tests the global system prompt and per-plugin overrides
+func TestSystemPrompt(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SYSTEM_PROMPT", "Respond in German.")
+	t.Setenv("SYSTEM_PROMPT_APT", "Prefer dnf over apt.")
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		plugin   string
+		expected string
+	}{
+		{"", "Respond in German.\n\nBase."},
+		{"npm", "Respond in German.\n\nBase."},
+		{"apt", "Prefer dnf over apt.\n\nBase."},
+	}
+
+	for _, tc := range testCases {
+		if got := ai.SystemPrompt("Base.", tc.plugin); got != tc.expected {
+			t.Errorf("SystemPrompt(%q) = %q, want %q", tc.plugin, got, tc.expected)
+		}
+	}
+
+	config.SetCurrent(&config.Config{})
+	if got := ai.SystemPrompt("Base.", "npm"); got != "Base." {
+		t.Errorf("SystemPrompt() without config = %q, want %q", got, "Base.")
+	}
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestSystemctlInstalledUnits tests service names matched against the units
+// systemctl lists
+func TestSystemctlInstalledUnits(t *testing.T) {
+	bin := t.TempDir()
+	script := `#!/bin/sh
+case "$2" in
+list-unit-files) printf 'httpd.service enabled -\nredis-server.service enabled enabled\nnginx.service disabled enabled\ngetty@.service enabled enabled\n' ;;
+list-units) printf 'postgresql@16-main.service loaded active running PostgreSQL Cluster 16-main\n' ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(bin, "systemctl"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	plugin := &plugins.SystemctlPlugin{}
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		expectedFix string
+	}{
+		{
+			name:        "distribution alias",
+			command:     "sudo systemctl restart apache2",
+			output:      "Failed to restart apache2.service: Unit apache2.service not found.",
+			expectedFix: "sudo systemctl restart httpd.service",
+		},
+		{
+			name:        "prefix of installed unit",
+			command:     "sudo systemctl start redis",
+			output:      "Failed to start redis.service: Unit redis.service not found.",
+			expectedFix: "sudo systemctl start redis-server.service",
+		},
+		{
+			name:        "typo",
+			command:     "sudo systemctl reload ngimx",
+			output:      "Failed to reload ngimx.service: Unit ngimx.service not found.",
+			expectedFix: "sudo systemctl reload nginx.service",
+		},
+		{
+			name:        "loaded instance unit",
+			command:     "systemctl status postgresql@16-mian",
+			output:      "Unit postgresql@16-mian.service could not be found.",
+			expectedFix: "systemctl status postgresql@16-main.service",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+				t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/ayushsharma-1/LogAid/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTelemetrySpans tests the spans recorded while finding a fix
+func TestTelemetrySpans(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{})
+
+	recorder := tracetest.NewSpanRecorder()
+	savedProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(savedProvider)
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "git status"}})
+	suggestion, err := eng.ProcessError(context.Background(), "git stauts", "git: 'stauts' is not a git command")
+	if err != nil || suggestion != "git status" {
+		t.Fatalf("ProcessError() = %q, %v, want git status", suggestion, err)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	for _, name := range []string{"engine.ProcessError", "engine.Suggest", "plugin.Match", "plugin.Suggest"} {
+		if spans[name] == nil {
+			t.Fatalf("no %s span among %d spans", name, len(spans))
+		}
+	}
+	root := spans["engine.ProcessError"].SpanContext()
+	if parent := spans["plugin.Suggest"].Parent(); parent.TraceID() != root.TraceID() || parent.SpanID() != spans["engine.Suggest"].SpanContext().SpanID() {
+		t.Errorf("plugin.Suggest is not a child of engine.Suggest in the engine.ProcessError trace")
+	}
+	attrs := make(map[string]string)
+	for _, kv := range spans["engine.Suggest"].Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["logaid.tool"] != "git" || attrs["logaid.source"] != "stub" {
+		t.Errorf("engine.Suggest attributes = %v, want tool git and source stub", attrs)
+	}
+}
+
+// TestTelemetryExport tests exporting spans to an OTLP/HTTP collector
+func TestTelemetryExport(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path+" "+r.Header.Get("X-Team"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{OTelEndpoint: collector.URL + "/", OTelHeaders: "X-Team=sre", OTelServiceName: "logaid"})
+	savedProvider, savedMeters := otel.GetTracerProvider(), otel.GetMeterProvider()
+	defer otel.SetTracerProvider(savedProvider)
+	defer otel.SetMeterProvider(savedMeters)
+
+	if err := telemetry.Init(context.Background(), "test"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	_, span := telemetry.Start(context.Background(), "engine.ProcessError")
+	span.End()
+	if err := telemetry.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	found := false
+	for _, p := range paths {
+		found = found || p == "/v1/traces sre"
+	}
+	if !found {
+		t.Errorf("collector received %v, want the span at /v1/traces with the X-Team header", paths)
+	}
+}
@@ -0,0 +1,67 @@
+//go:build wasip1
+
+// Command wasmplugin is the WASM plugin used by TestWasmPlugin. Build it with
+//
+//	GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o terraform.wasm
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"unsafe"
+)
+
+//go:wasmimport logaid config
+func config(keyPtr, keyLen, bufPtr, bufCap uint32) int32
+
+// buffers keeps memory handed to the host alive
+var buffers = map[uint32][]byte{}
+
+func keep(buf []byte) uint32 {
+	ptr := uint32(uintptr(unsafe.Pointer(unsafe.SliceData(buf))))
+	buffers[ptr] = buf
+	return ptr
+}
+
+//go:wasmexport logaid_alloc
+func alloc(size uint32) uint32 {
+	return keep(make([]byte, size+1))
+}
+
+func command(ptr, length uint32) string {
+	var request struct {
+		Command string `json:"command"`
+	}
+	json.Unmarshal(buffers[ptr][:length], &request)
+	return request.Command
+}
+
+func setting(key string) string {
+	buf := make([]byte, 256)
+	n := config(uint32(uintptr(unsafe.Pointer(unsafe.StringData(key)))), uint32(len(key)),
+		uint32(uintptr(unsafe.Pointer(&buf[0]))), uint32(len(buf)))
+	if n < 0 || int(n) > len(buf) {
+		return ""
+	}
+	return string(buf[:n])
+}
+
+//go:wasmexport logaid_match
+func match(ptr, length uint32) uint32 {
+	if strings.HasPrefix(command(ptr, length), "terrafrom ") {
+		return 1
+	}
+	return 0
+}
+
+//go:wasmexport logaid_suggest
+func suggest(ptr, length uint32) uint64 {
+	suggestion := "terraform " + strings.TrimPrefix(command(ptr, length), "terrafrom ")
+	if flags := setting("FLAGS"); flags != "" {
+		suggestion += " " + flags
+	}
+	buf := []byte(suggestion)
+	return uint64(keep(buf))<<32 | uint64(len(buf))
+}
+
+func main() {}
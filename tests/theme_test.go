@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/theme"
+	"github.com/fatih/color"
+)
+
+// TestThemeParse tests turning color specs into terminal escape codes
+func TestThemeParse(t *testing.T) {
+	saved := color.NoColor
+	defer func() { color.NoColor = saved }()
+	color.NoColor = false
+
+	tests := []struct {
+		name      string
+		spec      string
+		colorterm string
+		want      string // the escape code the text starts with
+		wantErr   bool
+	}{
+		{"name", "red", "", "\x1b[31m", false},
+		{"attribute and bright name", "bold bright-cyan", "", "\x1b[1;96m", false},
+		{"hi alias", "hi_green", "", "\x1b[92m", false},
+		{"hex on truecolor terminal", "#ff8800", "truecolor", "\x1b[38;2;255;136;0m", false},
+		{"hex on 256-color terminal", "#ff8800", "", "\x1b[38;5;208m", false},
+		{"short hex", "#fff", "", "\x1b[38;5;231m", false},
+		{"none", "none", "", "", false},
+		{"unknown name", "rainbow", "", "", true},
+		{"invalid hex", "#ggg", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLORTERM", tt.colorterm)
+			c, err := theme.Parse(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if err == nil && !strings.HasPrefix(c.Sprint("x"), tt.want) {
+				t.Errorf("Parse(%q) renders %q, want it to start with %q", tt.spec, c.Sprint("x"), tt.want)
+			}
+		})
+	}
+}
+
+// TestColorTheme tests built-in themes and COLOR_* overrides
+func TestColorTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		want    theme.Theme
+		wantErr bool
+	}{
+		{
+			name: "default",
+			cfg:  config.Config{},
+			want: theme.Theme{Error: "red", Warning: "yellow", Success: "green", Suggestion: "cyan", Info: "cyan", Debug: "magenta"},
+		},
+		{
+			name: "override",
+			cfg:  config.Config{Theme: "mono", ColorSuggestion: "bold #5fafff"},
+			want: theme.Theme{Error: "bold", Warning: "bold", Success: "none", Suggestion: "bold #5fafff", Info: "none", Debug: "dim"},
+		},
+		{name: "unknown theme", cfg: config.Config{Theme: "neon"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cfg.ColorTheme()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ColorTheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ColorTheme() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	for _, name := range theme.Names() {
+		colors, err := theme.Get(name, theme.Theme{})
+		if err != nil {
+			t.Fatalf("Get(%q) error = %v", name, err)
+		}
+		for _, spec := range []string{colors.Error, colors.Warning, colors.Success, colors.Suggestion, colors.Info, colors.Debug} {
+			if _, err := theme.Parse(spec); err != nil {
+				t.Errorf("theme %s: %v", name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/ai"
+)
+
+// TestTruncateOutput tests that huge output keeps the first errors and the tail within budget
+func TestTruncateOutput(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("src/main.c:10: error: expected ';' before '}' token\n")
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&b, "compiling module %d of 5000\n", i)
+	}
+	b.WriteString("make: *** [Makefile:12: all] Error 2\n")
+	output := b.String()
+
+	const limit = 2048
+	got := ai.Truncate(output, limit)
+
+	if len(got) > limit {
+		t.Errorf("Truncate() returned %d bytes, want at most %d", len(got), limit)
+	}
+	for _, want := range []string{"[output truncated: 5002 lines", "expected ';' before '}' token", "make: *** [Makefile:12: all] Error 2", "compiling module 4999 of 5000"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Truncate() result missing %q", want)
+		}
+	}
+
+	short := "E: Unable to locate package rediscli"
+	if got := ai.Truncate(short, limit); got != short {
+		t.Errorf("Truncate() changed short output to %q", got)
+	}
+}
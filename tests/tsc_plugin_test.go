@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestTscPlugin tests the TypeScript compiler plugin
+func TestTscPlugin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "node_modules", "lodash"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	plugin := &plugins.TscPlugin{Dir: dir}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "installed package without types",
+			command:     "npx tsc",
+			output:      "src/index.ts(1,15): error TS2307: Cannot find module 'lodash/fp' or its corresponding type declarations.",
+			shouldMatch: true,
+			expectedFix: "npm install --save-dev @types/lodash && npx tsc",
+		},
+		{
+			name:        "declaration file missing",
+			command:     "tsc --noEmit",
+			output:      "error TS7016: Could not find a declaration file for module '@babel/core'.",
+			shouldMatch: true,
+			expectedFix: "npm install --save-dev @types/babel__core && tsc --noEmit",
+		},
+		{
+			name:        "package not installed",
+			command:     "tsc",
+			output:      "src/api.ts(2,20): error TS2307: Cannot find module 'zod' or its corresponding type declarations.",
+			shouldMatch: true,
+			expectedFix: "npm install zod && tsc",
+		},
+		{
+			name:        "tsconfig option typo",
+			command:     "tsc",
+			output:      "tsconfig.json(4,5): error TS5023: Unknown compiler option 'strictNullCheck'.",
+			shouldMatch: true,
+			expectedFix: `sed -i 's/"strictNullCheck"/"strictNullChecks"/' tsconfig.json && tsc`,
+		},
+		{
+			name:        "command line option typo",
+			command:     "tsc --noEmitt",
+			output:      "error TS5023: Unknown compiler option '--noEmitt'.",
+			shouldMatch: true,
+			expectedFix: "tsc --noEmit",
+		},
+		{
+			name:        "compiler older than option",
+			command:     "npx tsc",
+			output:      "tsconfig.json(6,5): error TS5023: Unknown compiler option 'verbatimModuleSyntax'.",
+			shouldMatch: true,
+			expectedFix: "npm install --save-dev typescript@latest && npx tsc",
+		},
+		{
+			name:        "wrong npx package",
+			command:     "npx tsc -p .",
+			output:      "                This is not the tsc command you are looking for",
+			shouldMatch: true,
+			expectedFix: "npm install --save-dev typescript && npx tsc -p .",
+		},
+		{
+			name:        "type error in code",
+			command:     "tsc",
+			output:      "src/a.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'.",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}
+
+// TestTscPluginWorkspace tests that installs target the workspace root
+func TestTscPluginWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":        `{"name": "mono", "private": true}`,
+		"pnpm-lock.yaml":      "lockfileVersion: '9.0'\n",
+		"pnpm-workspace.yaml": "packages:\n  - apps/*\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plugin := &plugins.TscPlugin{Dir: dir}
+
+	command := "pnpm exec tsc"
+	output := "error TS7016: Could not find a declaration file for module 'express'."
+	if !plugin.Match(command, output) {
+		t.Fatalf("Match() = false for %q", command)
+	}
+
+	expected := "pnpm add -D -w @types/express && pnpm exec tsc"
+	if suggestion := plugin.Suggest(command, output); suggestion != expected {
+		t.Errorf("Suggest() = %q, want %q", suggestion, expected)
+	}
+}
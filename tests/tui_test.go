@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+	"github.com/ayushsharma-1/LogAid/internal/tui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tuiDriver runs a TUI model without a terminal, executing its commands in
+// the background and feeding their messages back
+type tuiDriver struct {
+	t     *testing.T
+	model *tui.Model
+	msgs  chan tea.Msg
+}
+
+func (d *tuiDriver) schedule(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	go func() { d.msgs <- cmd() }()
+}
+
+// send delivers msg to the model
+func (d *tuiDriver) send(msg tea.Msg) {
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, cmd := range batch {
+			d.schedule(cmd)
+		}
+		return
+	}
+	_, cmd := d.model.Update(msg)
+	d.schedule(cmd)
+}
+
+// waitFor processes messages until the view contains text
+func (d *tuiDriver) waitFor(text string) {
+	d.t.Helper()
+	timeout := time.After(5 * time.Second)
+	for !strings.Contains(d.model.View(), text) {
+		select {
+		case msg := <-d.msgs:
+			d.send(msg)
+		case <-timeout:
+			d.t.Fatalf("view never showed %q:\n%s", text, d.model.View())
+		}
+	}
+}
+
+// TestTUI tests running a failing command in the TUI and applying the fix
+func TestTUI(t *testing.T) {
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	config.SetCurrent(&config.Config{DangerousCommandsCheck: true})
+	edit := []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("e")}, {Type: tea.KeyBackspace}, {Type: tea.KeyBackspace}, {Type: tea.KeyBackspace}, {Type: tea.KeyBackspace}}
+	// Edits the fix into a destructive one, applies it and presses last
+	destructive := func(last tea.KeyType) []tea.KeyMsg {
+		keys := append([]tea.KeyMsg(nil), edit...)
+		return append(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("rm -f logaid-tui-missing")}, tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: last})
+	}
+
+	testCases := []struct {
+		name       string
+		keys       []tea.KeyMsg
+		expectView string
+		expectFail bool
+	}{
+		{
+			name:       "apply",
+			keys:       []tea.KeyMsg{{Type: tea.KeyEnter}},
+			expectView: "[fixed]",
+		},
+		{
+			name:       "edit then apply",
+			keys:       append(edit, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("false")}, tea.KeyMsg{Type: tea.KeyEnter}),
+			expectView: "Fix failed",
+			expectFail: true,
+		},
+		{
+			name:       "destructive fix cancelled",
+			keys:       destructive(tea.KeyEsc),
+			expectView: "Cancelled",
+			expectFail: true,
+		},
+		{
+			name:       "destructive fix confirmed",
+			keys:       destructive(tea.KeyEnter),
+			expectView: "[fixed]",
+		},
+		{
+			name:       "dismiss",
+			keys:       []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("d")}},
+			expectView: "Suggestion dismissed",
+			expectFail: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eng := engine.New()
+			eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "true"}})
+			model := tui.New(eng, nil, []string{"sh", "-c", "echo 'error: boom' >&2; exit 3"})
+			eng.SetIO(nil, model.Output(), model.Output())
+
+			d := &tuiDriver{t: t, model: model, msgs: make(chan tea.Msg, 16)}
+			d.schedule(model.Init())
+			d.waitFor("1. true")
+			if !strings.Contains(model.View(), "✗ error: boom") {
+				t.Errorf("view does not list the detected error:\n%s", model.View())
+			}
+
+			for _, key := range tc.keys {
+				d.send(key)
+			}
+			d.waitFor(tc.expectView)
+			if model.Failed() != tc.expectFail {
+				t.Errorf("Failed() = %v, want %v", model.Failed(), tc.expectFail)
+			}
+		})
+	}
+}
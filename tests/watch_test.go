@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ayushsharma-1/LogAid/internal/config"
+	"github.com/ayushsharma-1/LogAid/internal/engine"
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// waitForPlugin polls until the engine has a plugin called name
+func waitForPlugin(eng *engine.Engine, name string) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, plugin := range eng.Plugins() {
+			if plugin.Name() == name {
+				return true
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return false
+}
+
+// TestEngineWatch tests that new plugins and config changes apply without a restart
+func TestEngineWatch(t *testing.T) {
+	if _, ok := os.LookupEnv("ENABLE_PLUGINS"); ok {
+		t.Skip("ENABLE_PLUGINS is set in the environment and takes precedence over .env")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Cleanup(func() { os.Unsetenv("ENABLE_PLUGINS") })
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	updated := *config.Current()
+	updated.EnablePlugins = ""
+	config.SetCurrent(&updated)
+
+	eng := engine.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := eng.Watch(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	pack := "rules:\n  - output: 'repo (\\w+) not found'\n    suggest: helm repo update\n"
+	if err := os.WriteFile(filepath.Join(config.Current().PluginsDir, "helm.yaml"), []byte(pack), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !waitForPlugin(eng, "helm") {
+		t.Fatal("rule pack added to PLUGINS_DIR was not loaded")
+	}
+
+	if err := os.WriteFile(filepath.Join(config.Dir(), ".env"), []byte("ENABLE_PLUGINS=git\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !waitForPlugin(eng, "git") {
+		t.Fatal("plugin enabled in .env was not loaded")
+	}
+}
+
+// TestEngineWatchReloadDuringSuggest tests that reloading the configuration
+// while suggestions are made does not race; run it with -race
+func TestEngineWatchReloadDuringSuggest(t *testing.T) {
+	if _, ok := os.LookupEnv("PLUGIN_TIMEOUT"); ok {
+		t.Skip("PLUGIN_TIMEOUT is set in the environment and takes precedence over .env")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { os.Unsetenv("PLUGIN_TIMEOUT") })
+	saved := config.Current()
+	defer func() { config.SetCurrent(saved) }()
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	eng := engine.New()
+	eng.SetPlugins([]plugins.Plugin{&stubPlugin{name: "stub", fix: "echo fixed"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := eng.Watch(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				eng.Suggest(ctx, "make build", "make: *** No rule to make target 'build'")
+			}
+		}()
+	}
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	envFile := filepath.Join(config.Dir(), ".env")
+	for timeout := 2; timeout <= 4; timeout++ {
+		if err := os.WriteFile(envFile, []byte(fmt.Sprintf("PLUGIN_TIMEOUT=%d\n", timeout)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for config.Current().PluginTimeout != timeout {
+			if time.Now().After(deadline) {
+				t.Fatalf("PLUGIN_TIMEOUT=%d written to .env was not reloaded", timeout)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+}
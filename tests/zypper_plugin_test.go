@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ayushsharma-1/LogAid/internal/plugins"
+)
+
+// TestZypperPlugin tests the zypper plugin
+func TestZypperPlugin(t *testing.T) {
+	plugin := &plugins.ZypperPlugin{}
+
+	testCases := []struct {
+		name        string
+		command     string
+		output      string
+		shouldMatch bool
+		expectedFix string
+	}{
+		{
+			name:        "debian package name",
+			command:     "sudo zypper install libssl-dev",
+			output:      "Loading repository data...\nReading installed packages...\n'libssl-dev' not found in package names. Trying capabilities.\nNo provider of 'libssl-dev' found.",
+			shouldMatch: true,
+			expectedFix: "sudo zypper install libopenssl-devel",
+		},
+		{
+			name:        "generic dev package",
+			command:     "sudo zypper in libcurl-dev",
+			output:      "No provider of 'libcurl-dev' found.",
+			shouldMatch: true,
+			expectedFix: "sudo zypper in libcurl-devel",
+		},
+		{
+			name:        "unknown package",
+			command:     "sudo zypper in frobnicator",
+			output:      "No provider of 'frobnicator' found.",
+			shouldMatch: true,
+			expectedFix: "zypper search frobnicator",
+		},
+		{
+			name:        "locked by packagekit",
+			command:     "sudo zypper up",
+			output:      "System management is locked by the application with pid 1234 (/usr/libexec/packagekitd).\nClose this application before trying again.",
+			shouldMatch: true,
+			expectedFix: "sudo systemctl stop packagekit && sudo zypper up",
+		},
+		{
+			name:        "locked by zypper",
+			command:     "sudo zypper up",
+			output:      "System management is locked by the application with pid 4321 (zypper).",
+			shouldMatch: true,
+			expectedFix: "while [ -e /proc/4321 ]; do sleep 1; done; sudo zypper up",
+		},
+		{
+			name:        "repository refresh failure",
+			command:     "sudo zypper in htop",
+			output:      "Download (curl) error for 'http://download.opensuse.org/update/leap/15.5/oss/repodata/repomd.xml':\nError code: Curl error 6",
+			shouldMatch: true,
+			expectedFix: "sudo zypper refresh && sudo zypper in htop",
+		},
+		{
+			name:        "vendor change",
+			command:     "sudo zypper dup",
+			output:      "Problem: problem with installed package ffmpeg-4-4.4-1.1.x86_64\n Solution 1: install ffmpeg-4-4.4-2.1.x86_64 from vendor openSUSE\n  replacing ffmpeg-4-4.4-1.1.x86_64 from vendor http://packman.links2linux.de (with vendor change)",
+			shouldMatch: true,
+			expectedFix: "sudo zypper dup --allow-vendor-change",
+		},
+		{
+			name:        "not root",
+			command:     "zypper in htop",
+			output:      "Root privileges are required for installing or uninstalling packages.",
+			shouldMatch: true,
+			expectedFix: "sudo zypper in htop",
+		},
+		{
+			name:        "dnf command",
+			command:     "sudo dnf install htop",
+			output:      "No match for argument: htop",
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := plugin.Match(tc.command, tc.output); matches != tc.shouldMatch {
+				t.Errorf("Match() = %v, want %v", matches, tc.shouldMatch)
+			}
+
+			if tc.shouldMatch && tc.expectedFix != "" {
+				if suggestion := plugin.Suggest(tc.command, tc.output); suggestion != tc.expectedFix {
+					t.Errorf("Suggest() = %q, want %q", suggestion, tc.expectedFix)
+				}
+			}
+		})
+	}
+}